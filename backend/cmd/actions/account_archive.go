@@ -0,0 +1,80 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package actions
+
+import (
+	"fmt"
+	"os"
+
+	"app.skyclerk.com/backend/library/archive"
+	"app.skyclerk.com/backend/models"
+)
+
+//
+// Write an account and all of its files to a zip archive.
+//
+// go run main.go -cmd=account-export -account_id=4992 -file=/tmp/account-4992.zip
+//
+func AccountExport(db models.Datastore, accountId uint, file string) {
+
+	f, err := os.Create(file)
+
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	defer f.Close()
+
+	if err := archive.Export(db, accountId, f); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println("Account", accountId, "exported to", file)
+
+}
+
+//
+// Load an account archive into an account. Safe to run more than once, only
+// what is missing gets added.
+//
+// go run main.go -cmd=account-import -account_id=4992 -file=/tmp/account-4992.zip
+//
+func AccountImport(db models.Datastore, accountId uint, file string) {
+
+	f, err := os.Open(file)
+
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	defer f.Close()
+
+	info, err := f.Stat()
+
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	result, err := archive.Import(db, accountId, f, info.Size())
+
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	for _, name := range []string{"user", "contact", "category", "label", "file", "ledger", "snapclerk", "activity"} {
+		fmt.Printf("%-10s %6d created %6d existing %6d missing\n", name, result.Created[name], result.Existing[name], result.Missing[name])
+	}
+
+}
+
+/* End File */
@@ -0,0 +1,36 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package actions
+
+import (
+	"fmt"
+
+	"app.skyclerk.com/backend/library/ofx"
+	"app.skyclerk.com/backend/models"
+)
+
+//
+// Take an OFX / QFX bank statement and import it. Transactions we already have are skipped.
+//
+// go run main.go -cmd=ofx-import -file=/Users/spicer/Downloads/statement.qfx -account_id=4992
+//
+func OfxImport(db models.Datastore, accountId uint, file string) {
+
+	stmt, err := ofx.ParseFile(file)
+
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	importCount := ofx.Import(db, accountId, 0, stmt, []string{})
+	fmt.Println(importCount, "New Ledger Entries Successfully Imported")
+
+}
+
+/* End File */
@@ -43,6 +43,21 @@ func Run(db models.Datastore) bool {
 		actions.AirBnbImport(db, uint(*accountId), *file)
 		return true
 
+	// Import an OFX / QFX bank statement
+	case "ofx-import":
+		actions.OfxImport(db, uint(*accountId), *file)
+		return true
+
+	// Export an account to a zip archive
+	case "account-export":
+		actions.AccountExport(db, uint(*accountId), *file)
+		return true
+
+	// Import an account zip archive into an account
+	case "account-import":
+		actions.AccountImport(db, uint(*accountId), *file)
+		return true
+
 	// Create a new application from the CLI
 	case "create-application":
 		actions.CreateApplication(db, *name)
@@ -71,6 +86,16 @@ func Run(db models.Datastore) bool {
 		actions.PurgeOldAccounts(db)
 		return true
 
+	// Rebuild the search index for one account (or all of them with no account_id)
+	case "search-reindex":
+		fmt.Println(db.ReindexSearch(uint(*accountId)), "Documents Indexed")
+		return true
+
+	// Post the journal lines again for one account (or all of them with no account_id)
+	case "journal-rebuild":
+		fmt.Println(db.RebuildJournal(uint(*accountId)), "Entries Posted")
+		return true
+
 	}
 
 	return false
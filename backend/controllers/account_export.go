@@ -0,0 +1,65 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"app.skyclerk.com/backend/library/archive"
+	"app.skyclerk.com/backend/services"
+)
+
+//
+// ExportAccount - Download the whole account as a zip archive. Only the
+// account owner can do this. See library/archive for the format.
+//
+func (t *Controller) ExportAccount(c *gin.Context) {
+	// Make sure the UserId is correct.
+	userId := c.MustGet("userId").(int)
+
+	// Get account id
+	accountId := uint(c.MustGet("accountId").(int))
+
+	// Get account.
+	account, err := t.db.GetAccountById(accountId)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Account not found."})
+		return
+	}
+
+	// We must be the account owner to proceed
+	if account.OwnerId != uint(userId) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "You must be the account owner."})
+		return
+	}
+
+	c.Header("Content-Type", "application/zip")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"skyclerk-account-%d-%s.zip\"", account.Id, time.Now().Format("2006-01-02")))
+
+	// The zip is written straight to the response.
+	if err := archive.Export(t.db, accountId, c.Writer); err != nil {
+		services.Info(err)
+
+		// Nothing has gone out yet so we can still say what went wrong.
+		if !c.Writer.Written() {
+			c.Header("Content-Disposition", "")
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
+
+		return
+	}
+
+	c.Writer.Flush()
+}
+
+/* End File */
@@ -0,0 +1,91 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package controllers
+
+import (
+	"archive/zip"
+	"bytes"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nbio/st"
+
+	"app.skyclerk.com/backend/library/test"
+	"app.skyclerk.com/backend/models"
+)
+
+//
+// TestExportAccount01 - Only the owner can download the account archive.
+//
+func TestExportAccount01(t *testing.T) {
+	// Start the db connection.
+	db, dbName, _ := models.NewTestDB("")
+	defer models.TestingTearDown(db, dbName)
+
+	// Create controller
+	c := &Controller{}
+	c.SetDB(db)
+
+	// Setup test data
+	owner := test.GetRandomUser(33)
+	db.Save(&owner)
+
+	user := test.GetRandomUser(33)
+	db.Save(&user)
+
+	account := test.GetRandomAccount(33)
+	account.OwnerId = owner.Id
+	db.Save(&account)
+	db.Save(&models.AcctToUsers{AccountId: account.Id, UserId: owner.Id})
+	db.Save(&models.AcctToUsers{AccountId: account.Id, UserId: user.Id})
+
+	l := test.GetRandomLedger(33)
+	db.LedgerCreate(&l)
+
+	// Setup router
+	gin.SetMode("release")
+	gin.DisableConsoleColor()
+
+	userId := int(owner.Id)
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("accountId", 33)
+		c.Set("userId", userId)
+	})
+	r.GET("/api/v3/:account/account/export", c.ExportAccount)
+
+	// Owner
+	w := doJSONRequest(r, "GET", "/api/v3/33/account/export", ``)
+	st.Expect(t, w.Code, 200)
+	st.Expect(t, w.HeaderMap["Content-Type"][0], "application/zip")
+	st.Expect(t, strings.HasPrefix(w.HeaderMap["Content-Disposition"][0], "attachment; filename=\"skyclerk-account-33-"), true)
+
+	z, err := zip.NewReader(bytes.NewReader(w.Body.Bytes()), int64(w.Body.Len()))
+	st.Expect(t, err, nil)
+
+	names := []string{}
+
+	for _, row := range z.File {
+		names = append(names, row.Name)
+	}
+
+	sort.Strings(names)
+	st.Expect(t, names, []string{"account.json", "activities.json", "categories.json", "chart_accounts.json", "contacts.json", "files.json", "labels.json", "ledger.json", "manifest.json", "snapclerks.json", "users.json"})
+
+	// Not the owner
+	userId = int(user.Id)
+
+	w = doJSONRequest(r, "GET", "/api/v3/33/account/export", ``)
+	st.Expect(t, w.Code, 400)
+	st.Expect(t, w.Body.String(), `{"error":"You must be the account owner."}`)
+}
+
+/* End File */
@@ -35,12 +35,16 @@ func (t *Controller) GetActivities(c *gin.Context) {
 		Limit:            limit,
 		Page:             page,
 		AllowedOrderCols: []string{},
+		CursorCol:        "id",
 		PreLoads:         []string{"User", "Ledger"},
 		Wheres: []models.KeyValue{
 			{Key: "account_id", Compare: "=", ValueInt: c.MustGet("accountId").(int)},
 		},
 	}
 
+	// Keyset paging - ?cursor=
+	params.UseCursor, params.Cursor, params.NoCount = request.GetCursorParms(c)
+
 	// Did we pass in a leder_id so we filter by a ledger.
 	if c.DefaultQuery("ledger_id", "") != "" {
 		// Set id
@@ -61,6 +65,11 @@ func (t *Controller) GetActivities(c *gin.Context) {
 	// Run the query
 	meta, err := t.db.QueryMeta(&results, params)
 
+	if err == models.ErrInvalidCursor {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": gin.H{"cursor": err.Error()}})
+		return
+	}
+
 	// Add in the message
 	for key, row := range results {
 		// Add in ledger contact
@@ -439,4 +439,56 @@ func TestGetActivities05(t *testing.T) {
 	st.Expect(t, results[0].LedgerId, uint(3))
 }
 
+//
+// TestGetActivitiesCursor01 - Page activities with keyset cursors.
+//
+func TestGetActivitiesCursor01(t *testing.T) {
+	// Start the db connection.
+	db, dbName, _ := models.NewTestDB("")
+	defer models.TestingTearDown(db, dbName)
+
+	// Create controller
+	c := &Controller{}
+	c.SetDB(db)
+
+	// Create test user
+	user := test.GetRandomUser(33)
+	db.Save(&user)
+
+	for i := 0; i < 120; i++ {
+		db.Create(&models.Activity{AccountId: uint(33), UserId: user.Id, Action: "expense", SubAction: "create", Name: fmt.Sprintf("Contact %d", i)})
+	}
+
+	// Setup router
+	gin.SetMode("release")
+	gin.DisableConsoleColor()
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("accountId", 33)
+		c.Set("userId", 1)
+	})
+	r.GET("/api/v3/:account/activities", c.GetActivities)
+
+	// First page, newest first.
+	w := doJSONRequest(r, "GET", "/api/v3/33/activities?cursor=", ``)
+	st.Expect(t, w.Code, 200)
+	st.Expect(t, w.HeaderMap["X-Last-Page"][0], "false")
+
+	results := []models.Activity{}
+	json.Unmarshal([]byte(w.Body.String()), &results)
+	st.Expect(t, len(results), 100)
+	st.Expect(t, results[0].Id, uint(120))
+	st.Expect(t, results[99].Id, uint(21))
+
+	// Second page
+	w = doJSONRequest(r, "GET", "/api/v3/33/activities?cursor="+w.HeaderMap["X-Next-Cursor"][0], ``)
+	st.Expect(t, w.HeaderMap["X-Last-Page"][0], "true")
+
+	json.Unmarshal([]byte(w.Body.String()), &results)
+	st.Expect(t, len(results), 20)
+	st.Expect(t, results[0].Id, uint(20))
+	st.Expect(t, results[19].Id, uint(1))
+}
+
 /* End File */
@@ -35,6 +35,7 @@ func (t *Controller) DoRoutes(r *gin.Engine) {
 
 		// Snapclerk
 		adminAPI.GET("/snapclerk", t.GetSnapClerks)
+		adminAPI.GET("/snapclerk/suggest", t.GetSnapClerkSuggestions)
 		adminAPI.POST("/snapclerk/reject/:id", t.RejectSnapClerk)
 		adminAPI.POST("/snapclerk/convert/:id", t.ConvertSnapClerk)
 
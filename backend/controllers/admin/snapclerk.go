@@ -10,6 +10,7 @@ import (
 	"errors"
 	"flag"
 	"io/ioutil"
+	"math"
 	"net/http"
 	"strconv"
 	"strings"
@@ -46,6 +47,30 @@ func (t *Controller) GetSnapClerks(c *gin.Context) {
 	c.JSON(200, results)
 }
 
+//
+// GetSnapClerkSuggestions - Category and label suggestions for the receipt we are
+// reviewing based on the account's history with this contact. Receipts are always
+// expenses so the amount is treated as one.
+//
+func (t *Controller) GetSnapClerkSuggestions(c *gin.Context) {
+	// Get the account id
+	accountID := helpers.StringToInt(c.DefaultQuery("account_id", "0"))
+
+	// Amount is optional
+	amount, err := strconv.ParseFloat(c.DefaultQuery("amount", "0"), 64)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": "The amount field must be a number."})
+		return
+	}
+
+	// Get the suggestions
+	results := t.db.GetLedgerSuggestions(uint(accountID), c.Query("contact"), math.Abs(amount)*-1)
+
+	// Return happy JSON
+	c.JSON(200, results)
+}
+
 //
 // ConvertSnapClerk - convert a snapclerk to a ledger entry
 //
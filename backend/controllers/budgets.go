@@ -0,0 +1,174 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"app.skyclerk.com/backend/library/response"
+	"app.skyclerk.com/backend/models"
+)
+
+//
+// GetBudgets - Return the budgets in the account by name.
+//
+func (t *Controller) GetBudgets(c *gin.Context) {
+	// Return happy.
+	response.Results(c, t.db.GetBudgetsByAccount(uint(c.MustGet("accountId").(int))), nil)
+}
+
+//
+// GetBudget by id
+//
+func (t *Controller) GetBudget(c *gin.Context) {
+	// Set id
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": err})
+		return
+	}
+
+	// Get budget and make sure we have perms to it
+	b, err := t.db.GetBudgetByAccountAndId(uint(c.MustGet("accountId").(int)), uint(id))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Budget not found."})
+		return
+	}
+
+	// Return happy.
+	response.Results(c, b, nil)
+}
+
+//
+// CreateBudget - Create a budget within the account.
+//
+func (t *Controller) CreateBudget(c *gin.Context) {
+	// Setup Budget obj
+	o := models.Budget{}
+
+	// Here we parse the JSON sent in, assign it to a struct, set validation errors if any.
+	if t.ValidateRequest(c, &o, "create") != nil {
+		return
+	}
+
+	// Make sure the AccountId is correct.
+	o.Id = 0
+	o.AccountId = uint(c.MustGet("accountId").(int))
+	o.AlertStart = ""
+	o.AlertLevel = 0
+
+	// Create budget
+	t.db.BudgetCreate(&o)
+
+	// Fresh pull
+	b, err := t.db.GetBudgetByAccountAndId(o.AccountId, o.Id)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "System error. Please contact help@skyclerk.com."})
+		return
+	}
+
+	// Return happy.
+	response.RespondCreated(c, b, nil)
+}
+
+//
+// UpdateBudget - Update a budget within the account.
+//
+func (t *Controller) UpdateBudget(c *gin.Context) {
+	// Get ID from URL
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": err})
+		return
+	}
+
+	// Get budget and make sure we have perms to it
+	org, err := t.db.GetBudgetByAccountAndId(uint(c.MustGet("accountId").(int)), uint(id))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Budget not found."})
+		return
+	}
+
+	// Setup Budget obj
+	o := models.Budget{}
+
+	// Here we parse the JSON sent in, assign it to a struct, set validation errors if any.
+	if t.ValidateRequest(c, &o, "update") != nil {
+		return
+	}
+
+	// Make sure the Id, AccountId and CreatedAt are correct.
+	o.Id = org.Id
+	o.AccountId = org.AccountId
+	o.CreatedAt = org.CreatedAt
+
+	// Keep track of the alerts we sent unless the budget changed under them.
+	if (o.Amount == org.Amount) && (o.Period == org.Period) && (o.CategoryId == org.CategoryId) && (o.LabelId == org.LabelId) && (o.Rollover == org.Rollover) {
+		o.AlertStart = org.AlertStart
+		o.AlertLevel = org.AlertLevel
+	}
+
+	// Update budget
+	t.db.BudgetUpdate(&o)
+
+	// Fresh pull
+	b, err := t.db.GetBudgetByAccountAndId(org.AccountId, org.Id)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "System error. Please contact help@skyclerk.com."})
+		return
+	}
+
+	// Return happy.
+	response.RespondUpdated(c, b, nil)
+}
+
+//
+// DeleteBudget a budget within the account.
+//
+func (t *Controller) DeleteBudget(c *gin.Context) {
+	// Get Id
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": err})
+		return
+	}
+
+	// AccountId.
+	accountId := uint(c.MustGet("accountId").(int))
+
+	// First we make sure this is a budget we have access to.
+	_, err = t.db.GetBudgetByAccountAndId(accountId, uint(id))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Budget not found."})
+		return
+	}
+
+	// Delete budget
+	err = t.db.DeleteBudgetByAccountAndId(accountId, uint(id))
+
+	if err != nil {
+		response.RespondError(c, err)
+		return
+	}
+
+	// Return happy.
+	response.RespondDeleted(c, nil)
+}
+
+/* End File */
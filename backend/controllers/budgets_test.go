@@ -0,0 +1,173 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nbio/st"
+	"github.com/tidwall/gjson"
+
+	"app.skyclerk.com/backend/library/reports"
+	"app.skyclerk.com/backend/models"
+)
+
+//
+// TestBudgets01 - Create, update, list and delete a budget.
+//
+func TestBudgets01(t *testing.T) {
+	// Start the db connection.
+	db, dbName, _ := models.NewTestDB("")
+	defer models.TestingTearDown(db, dbName)
+
+	// Create controller
+	c := &Controller{}
+	c.SetDB(db)
+
+	cat := db.GetOrCreateCategory(33, "Travel", "1")
+	other := db.GetOrCreateCategory(34, "Travel", "1")
+
+	// Setup router
+	gin.SetMode("release")
+	gin.DisableConsoleColor()
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("accountId", 33)
+		c.Set("userId", 1)
+	})
+	r.GET("/api/v3/:account/budgets", c.GetBudgets)
+	r.GET("/api/v3/:account/budgets/:id", c.GetBudget)
+	r.POST("/api/v3/:account/budgets", c.CreateBudget)
+	r.PUT("/api/v3/:account/budgets/:id", c.UpdateBudget)
+	r.DELETE("/api/v3/:account/budgets/:id", c.DeleteBudget)
+
+	// Create
+	w := doJSONRequest(r, "POST", "/api/v3/33/budgets", `{ "name": " Travel ", "category_id": 1, "amount": 500, "rollover": true }`)
+	st.Expect(t, w.Code, 201)
+
+	b := models.Budget{}
+	json.Unmarshal(w.Body.Bytes(), &b)
+	st.Expect(t, b.Id, uint(1))
+	st.Expect(t, b.AccountId, uint(33))
+	st.Expect(t, b.Name, "Travel")
+	st.Expect(t, b.Period, "month")
+	st.Expect(t, b.Rollover, true)
+	st.Expect(t, b.Category.Id, cat.Id)
+
+	// Validation
+	w = doJSONRequest(r, "POST", "/api/v3/33/budgets", `{ "name": "Bad", "amount": -5, "period": "week" }`)
+	st.Expect(t, w.Code, 400)
+	st.Expect(t, gjson.Get(w.Body.String(), "errors.amount").String(), "The amount field must be more than zero.")
+	st.Expect(t, gjson.Get(w.Body.String(), "errors.period").String(), "The period field must be month, quarter or year.")
+	st.Expect(t, gjson.Get(w.Body.String(), "errors.category_id").String(), "A budget needs a category or a label.")
+
+	w = doJSONRequest(r, "POST", "/api/v3/33/budgets", `{ "name": "Both", "amount": 5, "category_id": 1, "label_id": 1 }`)
+	st.Expect(t, gjson.Get(w.Body.String(), "errors.category_id").String(), "A budget can have a category or a label but not both.")
+
+	w = doJSONRequest(r, "POST", "/api/v3/33/budgets", fmt.Sprintf(`{ "name": "Not Ours", "amount": 5, "category_id": %d }`, other.Id))
+	st.Expect(t, w.Code, 400)
+	st.Expect(t, gjson.Get(w.Body.String(), "errors.category_id").String(), "Category not found.")
+
+	w = doJSONRequest(r, "POST", "/api/v3/33/budgets", `{ "name": "No Label", "amount": 5, "label_id": 99 }`)
+	st.Expect(t, gjson.Get(w.Body.String(), "errors.label_id").String(), "Label not found.")
+
+	// Alerts we already sent are kept unless the budget changes.
+	db.BudgetAlertSent(&b, "2026-10-01", 80)
+
+	w = doJSONRequest(r, "PUT", "/api/v3/33/budgets/1", `{ "name": "Trips", "category_id": 1, "amount": 500, "period": "month", "rollover": true }`)
+	st.Expect(t, w.Code, 200)
+	b, _ = db.GetBudgetByAccountAndId(33, 1)
+	st.Expect(t, b.Name, "Trips")
+	st.Expect(t, b.AlertLevel, 80)
+
+	w = doJSONRequest(r, "PUT", "/api/v3/33/budgets/1", `{ "name": "Trips", "category_id": 1, "amount": 750, "period": "quarter" }`)
+	st.Expect(t, w.Code, 200)
+	b, _ = db.GetBudgetByAccountAndId(33, 1)
+	st.Expect(t, b.Amount, 750.00)
+	st.Expect(t, b.Period, "quarter")
+	st.Expect(t, b.Rollover, false)
+	st.Expect(t, b.AlertLevel, 0)
+	st.Expect(t, b.AlertStart, "")
+
+	// List
+	w = doJSONRequest(r, "GET", "/api/v3/33/budgets", ``)
+	st.Expect(t, w.Code, 200)
+	st.Expect(t, gjson.Get(w.Body.String(), "#").Int(), int64(1))
+	st.Expect(t, gjson.Get(w.Body.String(), "0.category.name").String(), "Travel")
+
+	// Other accounts can not see it.
+	db.BudgetCreate(&models.Budget{AccountId: 34, Name: "Theirs", CategoryId: other.Id, Amount: 10})
+
+	w = doJSONRequest(r, "GET", "/api/v3/33/budgets/2", ``)
+	st.Expect(t, w.Code, 400)
+	st.Expect(t, w.Body.String(), `{"error":"Budget not found."}`)
+
+	w = doJSONRequest(r, "DELETE", "/api/v3/33/budgets/2", ``)
+	st.Expect(t, w.Code, 400)
+
+	// Delete
+	w = doJSONRequest(r, "DELETE", "/api/v3/33/budgets/1", ``)
+	st.Expect(t, w.Code, 204)
+	st.Expect(t, len(db.GetBudgetsByAccount(33)), 0)
+	st.Expect(t, len(db.GetBudgetsByAccount(34)), 1)
+}
+
+//
+// TestReportsBudgetVsActual01 - Budgets against what was spent this period.
+//
+func TestReportsBudgetVsActual01(t *testing.T) {
+	// Start the db connection.
+	db, dbName, _ := models.NewTestDB("")
+	defer models.TestingTearDown(db, dbName)
+
+	// Create controller
+	c := &Controller{}
+	c.SetDB(db)
+
+	cat := db.GetOrCreateCategory(33, "Travel", "1")
+	db.BudgetCreate(&models.Budget{AccountId: 33, Name: "Travel", CategoryId: cat.Id, Amount: 200, Period: "month"})
+
+	db.LedgerCreate(&models.Ledger{AccountId: 33, Date: time.Now(), Amount: -170, Contact: models.Contact{Name: "Airline"}, Category: cat})
+	db.LedgerCreate(&models.Ledger{AccountId: 33, Date: time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC), Amount: -50, Contact: models.Contact{Name: "Airline"}, Category: cat})
+
+	// Setup router
+	gin.SetMode("release")
+	gin.DisableConsoleColor()
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("accountId", 33)
+		c.Set("userId", 1)
+	})
+	r.GET("/api/v3/:account/reports/budget-vs-actual", c.ReportsBudgetVsActual)
+
+	// Defaults to today.
+	w := doJSONRequest(r, "GET", "/api/v3/33/reports/budget-vs-actual", ``)
+	st.Expect(t, w.Code, 200)
+
+	result := reports.BudgetVsActual{}
+	json.Unmarshal(w.Body.Bytes(), &result)
+	st.Expect(t, result.Date, time.Now().Format("2006-01-02"))
+	st.Expect(t, result.Budgets[0].Actual, 170.00)
+	st.Expect(t, result.Budgets[0].Variance, 30.00)
+	st.Expect(t, result.Budgets[0].PercentUsed, 85.00)
+
+	w = doJSONRequest(r, "GET", "/api/v3/33/reports/budget-vs-actual?date=2024-05-31", ``)
+	json.Unmarshal(w.Body.Bytes(), &result)
+	st.Expect(t, result.Budgets[0].Start, "2024-05-01")
+	st.Expect(t, result.Budgets[0].End, "2024-05-31")
+	st.Expect(t, result.Budgets[0].Actual, 50.00)
+	st.Expect(t, result.Budgets[0].PercentUsed, 25.00)
+}
+
+/* End File */
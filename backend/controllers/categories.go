@@ -135,9 +135,11 @@ func (t *Controller) CreateCategory(c *gin.Context) {
 	// Clean up some vars
 	o.Type = strings.Trim(o.Type, " ")
 	o.Name = strings.Trim(o.Name, " ")
+	o.Irs = strings.Trim(o.Irs, " ")
 
 	// Create category
 	t.db.New().Create(&o)
+	t.db.IndexCategory(o.AccountId, o.Id)
 
 	// Make the API more clear TODO: get rid of the numbering in the db in the future once we kill PHP
 	if o.Type == "1" {
@@ -170,8 +172,8 @@ func (t *Controller) UpdateCategory(c *gin.Context) {
 		return
 	}
 
-	// Setup Category obj
-	o := models.Category{}
+	// Setup Category obj. Not every client sends the tax form line, keep it if not sent.
+	o := models.Category{Irs: orgCat.Irs}
 
 	// Here we parse the JSON sent in, assign it to a struct, set validation errors if any.
 	if t.ValidateRequest(c, &o, "update") != nil {
@@ -181,10 +183,14 @@ func (t *Controller) UpdateCategory(c *gin.Context) {
 	// We just allow updating of a few fields
 	orgCat.Type = strings.Trim(o.Type, " ")
 	orgCat.Name = strings.Trim(o.Name, " ")
+	orgCat.Irs = strings.Trim(o.Irs, " ")
 
 	// Update category
 	t.db.New().Save(&orgCat)
 
+	// Update search, the name shows up on ledger entries too.
+	t.db.IndexCategory(orgCat.AccountId, orgCat.Id)
+
 	// Make the API more clear TODO: get rid of the numbering in the db in the future once we kill PHP
 	if orgCat.Type == "1" {
 		orgCat.Type = "expense"
@@ -228,4 +234,11 @@ func (t *Controller) DeleteCategory(c *gin.Context) {
 	response.RespondDeleted(c, nil)
 }
 
+//
+// GetTaxForms - Return the tax forms and lines a category can be mapped to.
+//
+func (t *Controller) GetTaxForms(c *gin.Context) {
+	response.Results(c, models.GetTaxForms(), nil)
+}
+
 /* End File */
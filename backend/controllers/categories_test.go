@@ -1161,4 +1161,77 @@ func TestDeleteCategory02(t *testing.T) {
 	st.Expect(t, gjson.Get(w.Body.String(), "error").String(), "Can not delete category. It is in use by a ledger entry.")
 }
 
+//
+// TestCategoryTaxLine01 - Map categories to tax form lines.
+//
+func TestCategoryTaxLine01(t *testing.T) {
+	// Start the db connection.
+	db, dbName, _ := models.NewTestDB("")
+	defer models.TestingTearDown(db, dbName)
+
+	// Create controller
+	c := &Controller{}
+	c.SetDB(db)
+
+	// Setup router
+	gin.SetMode("release")
+	gin.DisableConsoleColor()
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("accountId", 33)
+		c.Set("userId", 109)
+	})
+	r.POST("/api/v3/:account/categories", c.CreateCategory)
+	r.PUT("/api/v3/:account/categories/:id", c.UpdateCategory)
+	r.GET("/api/v3/:account/tax-forms", c.GetTaxForms)
+
+	// The forms we can map to.
+	w := doJSONRequest(r, "GET", "/api/v3/33/tax-forms", ``)
+	st.Expect(t, w.Code, 200)
+	st.Expect(t, gjson.Get(w.Body.String(), "#.id").String(), `["schedule-c","schedule-e"]`)
+	st.Expect(t, gjson.Get(w.Body.String(), "0.lines.4.code").String(), "schedule-c:8")
+	st.Expect(t, gjson.Get(w.Body.String(), "0.lines.4.name").String(), "Advertising")
+
+	// Map on create.
+	w = doJSONRequest(r, "POST", "/api/v3/33/categories", `{"name":"Ads","type":"1","irs":"schedule-c:8"}`)
+	st.Expect(t, w.Code, 201)
+	st.Expect(t, gjson.Get(w.Body.String(), "irs").String(), "schedule-c:8")
+
+	// Lines we do not know, or on the wrong side.
+	w = doJSONRequest(r, "POST", "/api/v3/33/categories", `{"name":"Junk","type":"1","irs":"schedule-c:99"}`)
+	st.Expect(t, w.Code, 400)
+	st.Expect(t, gjson.Get(w.Body.String(), "errors.irs").String(), "The irs field must be a known tax form line.")
+
+	w = doJSONRequest(r, "POST", "/api/v3/33/categories", `{"name":"Rent","type":"2","irs":"schedule-e:14"}`)
+	st.Expect(t, w.Code, 400)
+	st.Expect(t, gjson.Get(w.Body.String(), "errors.irs").String(), "The tax form line does not match the category type.")
+
+	// Move it to another line.
+	w = doJSONRequest(r, "PUT", "/api/v3/33/categories/1", `{"name":"Ads","type":"1","irs":"schedule-e:5"}`)
+	st.Expect(t, w.Code, 200)
+
+	cat := models.Category{}
+	db.First(&cat, 1)
+	st.Expect(t, cat.Irs, "schedule-e:5")
+
+	// A rename that does not send the line keeps it.
+	w = doJSONRequest(r, "PUT", "/api/v3/33/categories/1", `{"name":"Advertising","type":"1"}`)
+	st.Expect(t, w.Code, 200)
+	st.Expect(t, gjson.Get(w.Body.String(), "irs").String(), "schedule-e:5")
+
+	cat = models.Category{}
+	db.First(&cat, 1)
+	st.Expect(t, cat.Name, "Advertising")
+	st.Expect(t, cat.Irs, "schedule-e:5")
+
+	// Sending it empty clears it.
+	w = doJSONRequest(r, "PUT", "/api/v3/33/categories/1", `{"name":"Advertising","type":"1","irs":""}`)
+	st.Expect(t, w.Code, 200)
+
+	cat = models.Category{}
+	db.First(&cat, 1)
+	st.Expect(t, cat.Irs, "")
+}
+
 /* End File */
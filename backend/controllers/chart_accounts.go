@@ -0,0 +1,153 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"app.skyclerk.com/backend/library/response"
+	"app.skyclerk.com/backend/models"
+)
+
+//
+// GetChartAccounts - Return the chart of accounts in code order.
+//
+func (t *Controller) GetChartAccounts(c *gin.Context) {
+	// Return happy.
+	response.Results(c, t.db.GetChartAccountsByAccount(uint(c.MustGet("accountId").(int))), nil)
+}
+
+//
+// GetChartAccount by id
+//
+func (t *Controller) GetChartAccount(c *gin.Context) {
+	// Set id
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": err})
+		return
+	}
+
+	// Get chart account and make sure we have perms to it
+	a, err := t.db.GetChartAccountByAccountAndId(uint(c.MustGet("accountId").(int)), uint(id))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Chart account not found."})
+		return
+	}
+
+	// Return happy.
+	response.Results(c, a, nil)
+}
+
+//
+// CreateChartAccount - Create a chart account within the account.
+//
+func (t *Controller) CreateChartAccount(c *gin.Context) {
+	// Setup ChartAccount obj
+	o := models.ChartAccount{}
+
+	// Here we parse the JSON sent in, assign it to a struct, set validation errors if any.
+	if t.ValidateRequest(c, &o, "create") != nil {
+		return
+	}
+
+	// Make sure the AccountId is correct. System and category accounts are ours to make.
+	o.Id = 0
+	o.AccountId = uint(c.MustGet("accountId").(int))
+	o.System = ""
+	o.CategoryId = 0
+
+	// Create chart account
+	t.db.ChartAccountCreate(&o)
+
+	// Return happy.
+	response.RespondCreated(c, o, nil)
+}
+
+//
+// UpdateChartAccount - Update a chart account within the account.
+//
+func (t *Controller) UpdateChartAccount(c *gin.Context) {
+	// Get ID from URL
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": err})
+		return
+	}
+
+	// Get chart account and make sure we have perms to it
+	org, err := t.db.GetChartAccountByAccountAndId(uint(c.MustGet("accountId").(int)), uint(id))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Chart account not found."})
+		return
+	}
+
+	// Setup ChartAccount obj
+	o := models.ChartAccount{}
+
+	// Here we parse the JSON sent in, assign it to a struct, set validation errors if any.
+	if t.ValidateRequest(c, &o, "update") != nil {
+		return
+	}
+
+	// Only the code, name, type and cash flag can change.
+	org.Code = o.Code
+	org.Name = o.Name
+	org.Type = o.Type
+	org.Cash = o.Cash
+
+	// Update chart account
+	t.db.ChartAccountUpdate(&org)
+
+	// Return happy.
+	response.RespondUpdated(c, org, nil)
+}
+
+//
+// DeleteChartAccount a chart account within the account.
+//
+func (t *Controller) DeleteChartAccount(c *gin.Context) {
+	// Get Id
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": err})
+		return
+	}
+
+	// AccountId.
+	accountId := uint(c.MustGet("accountId").(int))
+
+	// First we make sure this is a chart account we have access to.
+	_, err = t.db.GetChartAccountByAccountAndId(accountId, uint(id))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Chart account not found."})
+		return
+	}
+
+	// Delete chart account
+	err = t.db.DeleteChartAccountByAccountAndId(accountId, uint(id))
+
+	if err != nil {
+		response.RespondError(c, err)
+		return
+	}
+
+	// Return happy.
+	response.RespondDeleted(c, nil)
+}
+
+/* End File */
@@ -0,0 +1,217 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nbio/st"
+
+	"app.skyclerk.com/backend/library/helpers"
+	"app.skyclerk.com/backend/library/reports"
+	"app.skyclerk.com/backend/library/test"
+	"app.skyclerk.com/backend/models"
+)
+
+//
+// TestChartAccounts01 - Set up accounts, post entries and transfers to them,
+// and make sure the trial balance adds up.
+//
+func TestChartAccounts01(t *testing.T) {
+	// Start the db connection.
+	db, dbName, _ := models.NewTestDB("")
+	defer models.TestingTearDown(db, dbName)
+
+	// Create controller
+	c := &Controller{}
+	c.SetDB(db)
+
+	user := test.GetRandomUser(33)
+	db.Save(&user)
+
+	// Setup router
+	gin.SetMode("release")
+	gin.DisableConsoleColor()
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("accountId", 33)
+		c.Set("userId", int(user.Id))
+	})
+	r.GET("/api/v3/:account/chart-accounts", c.GetChartAccounts)
+	r.GET("/api/v3/:account/chart-accounts/:id", c.GetChartAccount)
+	r.POST("/api/v3/:account/chart-accounts", c.CreateChartAccount)
+	r.PUT("/api/v3/:account/chart-accounts/:id", c.UpdateChartAccount)
+	r.DELETE("/api/v3/:account/chart-accounts/:id", c.DeleteChartAccount)
+	r.POST("/api/v3/:account/ledger", c.CreateLedger)
+	r.GET("/api/v3/:account/reports/trial-balance", c.ReportsTrialBalance)
+
+	// Every account starts with the system accounts.
+	w := doJSONRequest(r, "GET", "/api/v3/33/chart-accounts", ``)
+	st.Expect(t, w.Code, 200)
+
+	list := []models.ChartAccount{}
+	json.Unmarshal(w.Body.Bytes(), &list)
+	st.Expect(t, len(list), 4)
+	st.Expect(t, list[0].Name, "Cash")
+	st.Expect(t, list[0].System, "cash")
+	cash := list[0]
+
+	// A bank account and a credit card. System can not be set.
+	w = doJSONRequest(r, "POST", "/api/v3/33/chart-accounts", `{"code":"1010","name":"Checking","type":"asset","system":"cash"}`)
+	st.Expect(t, w.Code, 201)
+
+	checking := models.ChartAccount{}
+	json.Unmarshal(w.Body.Bytes(), &checking)
+	st.Expect(t, checking.AccountId, uint(33))
+	st.Expect(t, checking.System, "")
+
+	w = doJSONRequest(r, "POST", "/api/v3/33/chart-accounts", `{"code":"2000","name":"Visa","type":"liability"}`)
+	st.Expect(t, w.Code, 201)
+
+	visa := models.ChartAccount{}
+	json.Unmarshal(w.Body.Bytes(), &visa)
+
+	// Bad requests
+	w = doJSONRequest(r, "POST", "/api/v3/33/chart-accounts", `{"code":"2000","name":"Amex","type":"bank"}`)
+	st.Expect(t, w.Code, 400)
+	st.Expect(t, w.Body.String(), `{"errors":{"code":"The code field must be unique.","type":"The type field must be asset, liability, equity, income, or expense."}}`)
+
+	// Money in to checking.
+	w = doJSONRequest(r, "POST", "/api/v3/33/ledger", fmt.Sprintf(`{"amount":1000,"date":"2024-01-05T00:00:00Z","chart_account_id":%d,"contact":{"name":"Acme"},"category":{"name":"Sales","type":"2"}}`, checking.Id))
+	st.Expect(t, w.Code, 201)
+
+	income := models.Ledger{}
+	json.Unmarshal(w.Body.Bytes(), &income)
+	st.Expect(t, income.ChartAccountId, checking.Id)
+
+	// A split charge on the card.
+	w = doJSONRequest(r, "POST", "/api/v3/33/ledger", fmt.Sprintf(`{"amount":-300,"date":"2024-02-05T00:00:00Z","chart_account_id":%d,"contact":{"name":"Staples"},"splits":[{"amount":-200,"category":{"name":"Supplies","type":"1"}},{"amount":-100,"category":{"name":"Travel","type":"1"}}]}`, visa.Id))
+	st.Expect(t, w.Code, 201)
+
+	// Pay the card from checking. Transfers do not need a category.
+	w = doJSONRequest(r, "POST", "/api/v3/33/ledger", fmt.Sprintf(`{"amount":-250,"date":"2024-03-05T00:00:00Z","chart_account_id":%d,"transfer_chart_account_id":%d,"contact":{"name":"Chase"}}`, checking.Id, visa.Id))
+	st.Expect(t, w.Code, 201)
+
+	transfer := models.Ledger{}
+	json.Unmarshal(w.Body.Bytes(), &transfer)
+	st.Expect(t, transfer.CategoryId, uint(0))
+	st.Expect(t, transfer.TransferChartAccountId, visa.Id)
+
+	// Bad transfers
+	w = doJSONRequest(r, "POST", "/api/v3/33/ledger", fmt.Sprintf(`{"amount":-250,"date":"2024-03-05T00:00:00Z","transfer_chart_account_id":%d,"contact":{"name":"Chase"}}`, cash.Id))
+	st.Expect(t, w.Code, 400)
+	st.Expect(t, w.Body.String(), `{"errors":{"transfer_chart_account_id":"An entry can not transfer to the account it is in."}}`)
+
+	w = doJSONRequest(r, "POST", "/api/v3/33/ledger", fmt.Sprintf(`{"amount":-250,"date":"2024-03-05T00:00:00Z","chart_account_id":%d,"contact":{"name":"Chase"},"category":{"name":"Sales","type":"2"}}`, list[3].Id))
+	st.Expect(t, w.Code, 400)
+	st.Expect(t, w.Body.String(), `{"errors":{"chart_account_id":"The account must be an asset, liability, or equity account."}}`)
+
+	// Transfers are not in the P&L.
+	pnl := reports.GetCategoriesPnL(db, 33, helpers.ParseDateNoError("2024-01-01"), helpers.ParseDateNoError("2025-01-01"), "asc")
+	st.Expect(t, len(pnl), 3)
+
+	// Debits equal credits.
+	w = doJSONRequest(r, "GET", "/api/v3/33/reports/trial-balance?date=2024-12-31", ``)
+	st.Expect(t, w.Code, 200)
+
+	tb := reports.TrialBalance{}
+	json.Unmarshal(w.Body.Bytes(), &tb)
+	st.Expect(t, tb.Date, "2024-12-31")
+	st.Expect(t, tb.Balanced, true)
+	st.Expect(t, tb.Debit, 1050.00)
+	st.Expect(t, tb.Credit, 1050.00)
+
+	balances := map[string][]float64{}
+
+	for _, row := range tb.Accounts {
+		balances[row.Name] = []float64{row.Debit, row.Credit}
+	}
+
+	st.Expect(t, len(balances), 5)
+	st.Expect(t, balances["Checking"], []float64{750, 0})
+	st.Expect(t, balances["Visa"], []float64{0, 50})
+	st.Expect(t, balances["Sales"], []float64{0, 1000})
+	st.Expect(t, balances["Supplies"], []float64{200, 0})
+	st.Expect(t, balances["Travel"], []float64{100, 0})
+
+	// As of a date
+	w = doJSONRequest(r, "GET", "/api/v3/33/reports/trial-balance?date=2024-01-05", ``)
+	json.Unmarshal(w.Body.Bytes(), &tb)
+	st.Expect(t, tb.Debit, 1000.00)
+	st.Expect(t, len(tb.Accounts), 2)
+
+	// Trashed entries drop out.
+	db.DeleteLedgerByAccountAndId(33, income.Id)
+
+	w = doJSONRequest(r, "GET", "/api/v3/33/reports/trial-balance?date=2024-12-31", ``)
+	json.Unmarshal(w.Body.Bytes(), &tb)
+	st.Expect(t, tb.Balanced, true)
+	st.Expect(t, tb.Debit, 300.00)
+
+	// Category accounts were made for us.
+	w = doJSONRequest(r, "GET", "/api/v3/33/chart-accounts", ``)
+	json.Unmarshal(w.Body.Bytes(), &list)
+	st.Expect(t, len(list), 9)
+
+	// Accounts in use keep their type and can not be deleted.
+	w = doJSONRequest(r, "PUT", "/api/v3/33/chart-accounts/"+strconv.Itoa(int(visa.Id)), `{"code":"2000","name":"Visa","type":"income"}`)
+	st.Expect(t, w.Code, 400)
+	st.Expect(t, w.Body.String(), `{"errors":{"type":"The type of this account can not be changed."}}`)
+
+	w = doJSONRequest(r, "PUT", "/api/v3/33/chart-accounts/"+strconv.Itoa(int(visa.Id)), `{"code":"2010","name":"Visa Card","type":"liability"}`)
+	st.Expect(t, w.Code, 200)
+	json.Unmarshal(w.Body.Bytes(), &visa)
+	st.Expect(t, visa.Name, "Visa Card")
+	st.Expect(t, visa.Code, "2010")
+
+	w = doJSONRequest(r, "DELETE", "/api/v3/33/chart-accounts/"+strconv.Itoa(int(visa.Id)), ``)
+	st.Expect(t, w.Code, 400)
+	st.Expect(t, w.Body.String(), `{"error":"This account is in use."}`)
+
+	w = doJSONRequest(r, "DELETE", "/api/v3/33/chart-accounts/"+strconv.Itoa(int(cash.Id)), ``)
+	st.Expect(t, w.Code, 400)
+	st.Expect(t, w.Body.String(), `{"error":"This account can not be deleted."}`)
+
+	// Unused accounts can go.
+	w = doJSONRequest(r, "POST", "/api/v3/33/chart-accounts", `{"code":"1500","name":"Truck","type":"asset"}`)
+	truck := models.ChartAccount{}
+	json.Unmarshal(w.Body.Bytes(), &truck)
+
+	w = doJSONRequest(r, "DELETE", "/api/v3/33/chart-accounts/"+strconv.Itoa(int(truck.Id)), ``)
+	st.Expect(t, w.Code, 204)
+
+	w = doJSONRequest(r, "GET", "/api/v3/33/chart-accounts/"+strconv.Itoa(int(truck.Id)), ``)
+	st.Expect(t, w.Code, 400)
+	st.Expect(t, w.Body.String(), `{"error":"Chart account not found."}`)
+
+	// Rebuilding the journal gets us back to the same place.
+	st.Expect(t, db.RebuildJournal(33), 3)
+
+	w = doJSONRequest(r, "GET", "/api/v3/33/reports/trial-balance?date=2024-12-31", ``)
+	json.Unmarshal(w.Body.Bytes(), &tb)
+	st.Expect(t, tb.Balanced, true)
+	st.Expect(t, tb.Debit, 300.00)
+	st.Expect(t, len(tb.Accounts), 4)
+
+	// Accounts from before the journal get posted by the migration.
+	db.Exec("DELETE FROM journal_lines WHERE account_id = 33")
+	db.MigrateJournal()
+
+	w = doJSONRequest(r, "GET", "/api/v3/33/reports/trial-balance?date=2024-12-31", ``)
+	json.Unmarshal(w.Body.Bytes(), &tb)
+	st.Expect(t, tb.Balanced, true)
+	st.Expect(t, tb.Debit, 300.00)
+	st.Expect(t, len(tb.Accounts), 4)
+}
+
+/* End File */
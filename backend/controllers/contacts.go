@@ -49,11 +49,15 @@ func (t *Controller) GetContacts(c *gin.Context) {
 		Limit:            limit,
 		Page:             page,
 		AllowedOrderCols: []string{"ContactsId", "ContactsName"},
+		CursorCol:        "ContactsId",
 		Wheres: []models.KeyValue{
 			{Key: "ContactsAccountId", Compare: "=", ValueInt: accountId},
 		},
 	}
 
+	// Keyset paging - ?cursor=
+	params.UseCursor, params.Cursor, params.NoCount = request.GetCursorParms(c)
+
 	// Manage a search query - TODO(spicer): this is hacky but it will do for now.
 	if len(c.DefaultQuery("search", "")) > 0 {
 		// Query term
@@ -92,6 +96,11 @@ func (t *Controller) GetContacts(c *gin.Context) {
 	// Run the query
 	meta, err := t.db.QueryMeta(&results, params)
 
+	if err == models.ErrInvalidCursor {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": gin.H{"cursor": err.Error()}})
+		return
+	}
+
 	// TODO(spicer): Move this into the model maybe.
 	for key, row := range results {
 		// Double check the contact has an avatar. This is just to double check.
@@ -161,6 +170,21 @@ func (t *Controller) CreateContact(c *gin.Context) {
 	o.Linkedin = strings.Trim(o.Linkedin, " ")
 	o.Website = strings.Trim(o.Website, " ")
 
+	// The tax id is only set from what was posted.
+	o.TaxId = ""
+	o.TaxIdLast4 = ""
+
+	if o.TaxIdPost != nil {
+		if err := o.SetTaxId(*o.TaxIdPost); err != nil {
+			services.Critical(err)
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Something went wrong creating your contact. Please contact help@skyclerk.com"})
+			return
+		}
+
+		// Never send it back.
+		o.TaxIdPost = nil
+	}
+
 	// Create category
 	err := t.db.CreateContact(&o)
 
@@ -194,8 +218,8 @@ func (t *Controller) UpdateContact(c *gin.Context) {
 		return
 	}
 
-	// Setup Contact obj
-	o := models.Contact{}
+	// Setup Contact obj. Not every client sends the 1099 fields, keep them if not sent.
+	o := models.Contact{Is1099: orgCon.Is1099, TaxIdType: orgCon.TaxIdType}
 
 	// Here we parse the JSON sent in, assign it to a struct, set validation errors if any.
 	if t.ValidateRequest(c, &o, "update") != nil {
@@ -217,10 +241,24 @@ func (t *Controller) UpdateContact(c *gin.Context) {
 	orgCon.Facebook = strings.Trim(o.Facebook, " ")
 	orgCon.Linkedin = strings.Trim(o.Linkedin, " ")
 	orgCon.Website = strings.Trim(o.Website, " ")
+	orgCon.Is1099 = o.Is1099
+	orgCon.TaxIdType = o.TaxIdType
+
+	// The tax id only changes if one was sent.
+	if o.TaxIdPost != nil {
+		if err := orgCon.SetTaxId(*o.TaxIdPost); err != nil {
+			services.Critical(err)
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Something went wrong updating your contact. Please contact help@skyclerk.com"})
+			return
+		}
+	}
 
 	// Update category
 	t.db.New().Save(&orgCon)
 
+	// Update search, the name shows up on ledger entries too.
+	t.db.IndexContact(orgCon.AccountId, orgCon.Id)
+
 	// Return happy.
 	response.RespondUpdated(c, orgCon, nil)
 }
@@ -882,4 +882,176 @@ func TestDeleteContact03(t *testing.T) {
 	st.Expect(t, gjson.Get(w.Body.String(), "error").String(), "Contact not found.")
 }
 
+//
+// TestGetContactsCursor01 - Page contacts with keyset cursors.
+//
+func TestGetContactsCursor01(t *testing.T) {
+	// Start the db connection.
+	db, dbName, _ := models.NewTestDB("")
+	defer models.TestingTearDown(db, dbName)
+
+	// Create controller
+	c := &Controller{}
+	c.SetDB(db)
+
+	// Names that tie, with and without case.
+	for _, name := range []string{"", "acme", "Bravo", "", "Acme", "bravo", "Charlie", "ACME", "delta"} {
+		db.Save(&models.Contact{AccountId: 33, Name: name, FirstName: "Jane", LastName: "Wells"})
+	}
+
+	db.Save(&models.Contact{AccountId: 34, Name: "Acme"})
+
+	// Setup router
+	gin.SetMode("release")
+	gin.DisableConsoleColor()
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("accountId", 33)
+		c.Set("userId", 1)
+	})
+	r.GET("/api/v3/:account/contacts", c.GetContacts)
+
+	for _, sort := range []string{"ASC", "DESC"} {
+		// What we get in one go.
+		w := doJSONRequest(r, "GET", "/api/v3/33/contacts?sort="+sort+"&count=false", ``)
+		st.Expect(t, w.HeaderMap["X-Last-Page"][0], "true")
+		st.Expect(t, len(w.HeaderMap["X-No-Limit-Count"]), 0)
+
+		all := []models.Contact{}
+		json.Unmarshal([]byte(w.Body.String()), &all)
+		st.Expect(t, len(all), 9)
+
+		// Two at a time.
+		paged := []models.Contact{}
+		cursor := ""
+
+		for {
+			w := doJSONRequest(r, "GET", "/api/v3/33/contacts?limit=2&count=true&sort="+sort+"&cursor="+cursor, ``)
+			st.Expect(t, w.Code, 200)
+			st.Expect(t, w.HeaderMap["X-No-Limit-Count"][0], "9")
+
+			results := []models.Contact{}
+			json.Unmarshal([]byte(w.Body.String()), &results)
+			paged = append(paged, results...)
+
+			if w.HeaderMap["X-Last-Page"][0] == "true" {
+				break
+			}
+
+			cursor = w.HeaderMap["X-Next-Cursor"][0]
+		}
+
+		// Same order, and ties come back by id so nothing is skipped.
+		st.Expect(t, len(paged), len(all))
+		seen := map[uint]bool{}
+
+		for key := range all {
+			st.Expect(t, strings.ToLower(paged[key].Name), strings.ToLower(all[key].Name))
+			seen[paged[key].Id] = true
+
+			if (key > 0) && strings.EqualFold(paged[key].Name, paged[key-1].Name) {
+				st.Expect(t, (paged[key].Id > paged[key-1].Id) == (sort == "ASC"), true)
+			}
+		}
+
+		st.Expect(t, len(seen), 9)
+	}
+
+	// A bad cursor
+	w := doJSONRequest(r, "GET", "/api/v3/33/contacts?cursor=bad", ``)
+	st.Expect(t, w.Code, 400)
+	st.Expect(t, w.Body.String(), `{"errors":{"cursor":"The cursor is not valid."}}`)
+}
+
+//
+// TestContactTaxId01 - Set, keep and clear a 1099 contractor's tax id.
+//
+func TestContactTaxId01(t *testing.T) {
+	// Start the db connection.
+	db, dbName, _ := models.NewTestDB("")
+	defer models.TestingTearDown(db, dbName)
+
+	// Create controller
+	c := &Controller{}
+	c.SetDB(db)
+
+	// Setup router
+	gin.SetMode("release")
+	gin.DisableConsoleColor()
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("accountId", 33)
+		c.Set("userId", 109)
+	})
+	r.POST("/api/v3/:account/contacts", c.CreateContact)
+	r.PUT("/api/v3/:account/contacts/:id", c.UpdateContact)
+
+	// Bad tax ids and types.
+	w := doJSONRequest(r, "POST", "/api/v3/33/contacts", `{"name":"Acme","is_1099":true,"tax_id_type":"itin","tax_id":"12-34"}`)
+	st.Expect(t, w.Code, 400)
+	st.Expect(t, w.Body.String(), `{"errors":{"tax_id":"The tax id must be 9 digits.","tax_id_type":"The tax id type must be ein or ssn."}}`)
+
+	w = doJSONRequest(r, "POST", "/api/v3/33/contacts", `{"name":"Acme","is_1099":true,"tax_id_type":"ein","tax_id":"abcdefghi"}`)
+	st.Expect(t, w.Code, 400)
+	st.Expect(t, w.Body.String(), `{"errors":{"tax_id":"The tax id must be 9 digits."}}`)
+
+	w = doJSONRequest(r, "POST", "/api/v3/33/contacts", `{"name":"Acme","is_1099":true,"tax_id_type":"ssn","tax_id":"123-45-678O"}`)
+	st.Expect(t, w.Code, 400)
+	st.Expect(t, w.Body.String(), `{"errors":{"tax_id":"The tax id must be 9 digits."}}`)
+
+	// The tax id is never sent back, just the last 4.
+	w = doJSONRequest(r, "POST", "/api/v3/33/contacts", `{"name":"Acme","is_1099":true,"tax_id_type":"ein","tax_id":"12-3456789","tax_id_last4":"0000"}`)
+	st.Expect(t, w.Code, 201)
+	st.Expect(t, gjson.Get(w.Body.String(), "is_1099").Bool(), true)
+	st.Expect(t, gjson.Get(w.Body.String(), "tax_id_last4").String(), "6789")
+	st.Expect(t, gjson.Get(w.Body.String(), "tax_id").Exists(), false)
+	st.Expect(t, strings.Contains(w.Body.String(), "3456789"), false)
+
+	// Stored encrypted.
+	contact := models.Contact{}
+	db.First(&contact, 1)
+	st.Expect(t, contact.TaxId != "", true)
+	st.Expect(t, strings.Contains(contact.TaxId, "123456789"), false)
+
+	taxId, err := contact.GetTaxId()
+	st.Expect(t, err, nil)
+	st.Expect(t, taxId, "12-3456789")
+
+	// Leaving it out keeps it.
+	w = doJSONRequest(r, "PUT", "/api/v3/33/contacts/1", `{"name":"Acme Inc","is_1099":true,"tax_id_type":"ssn"}`)
+	st.Expect(t, w.Code, 200)
+
+	contact = models.Contact{}
+	db.First(&contact, 1)
+	taxId, _ = contact.GetTaxId()
+	st.Expect(t, contact.Name, "Acme Inc")
+	st.Expect(t, taxId, "123-45-6789")
+
+	// Clients that do not know about 1099s leave them alone.
+	w = doJSONRequest(r, "PUT", "/api/v3/33/contacts/1", `{"name":"Acme Co","email":"ap@acme.com"}`)
+	st.Expect(t, w.Code, 200)
+	st.Expect(t, gjson.Get(w.Body.String(), "is_1099").Bool(), true)
+	st.Expect(t, gjson.Get(w.Body.String(), "tax_id_type").String(), "ssn")
+
+	contact = models.Contact{}
+	db.First(&contact, 1)
+	taxId, _ = contact.GetTaxId()
+	st.Expect(t, contact.Name, "Acme Co")
+	st.Expect(t, contact.Is1099, true)
+	st.Expect(t, contact.TaxIdType, "ssn")
+	st.Expect(t, taxId, "123-45-6789")
+
+	// Empty clears it.
+	w = doJSONRequest(r, "PUT", "/api/v3/33/contacts/1", `{"name":"Acme Inc","is_1099":false,"tax_id":""}`)
+	st.Expect(t, w.Code, 200)
+	st.Expect(t, gjson.Get(w.Body.String(), "tax_id_last4").String(), "")
+
+	contact = models.Contact{}
+	db.First(&contact, 1)
+	st.Expect(t, contact.Is1099, false)
+	st.Expect(t, contact.TaxId, "")
+}
+
 /* End File */
@@ -0,0 +1,83 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"app.skyclerk.com/backend/library/response"
+	"app.skyclerk.com/backend/models"
+)
+
+//
+// GetExportJobs - Return the exports for this account, newest first.
+//
+func (t *Controller) GetExportJobs(c *gin.Context) {
+	// Run the query
+	results := t.db.GetExportJobsByAccount(uint(c.MustGet("accountId").(int)))
+
+	// Return json based on if this was a good result or not.
+	response.Results(c, results, nil)
+}
+
+//
+// GetExportJob by id. Once the export is done this has the download link.
+//
+func (t *Controller) GetExportJob(c *gin.Context) {
+	// Set id
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": err})
+		return
+	}
+
+	// Get export and make sure we have perms to it
+	j, err := t.db.GetExportJobByAccountAndId(uint(c.MustGet("accountId").(int)), uint(id))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Export not found."})
+		return
+	}
+
+	// Return happy.
+	response.Results(c, j, nil)
+}
+
+//
+// CreateExportJob - Queue up an export. The cron builds it and emails the
+// user a link when it is ready.
+//
+func (t *Controller) CreateExportJob(c *gin.Context) {
+	// Setup ExportJob obj
+	o := models.ExportJob{}
+
+	// Here we parse the JSON sent in, assign it to a struct, set validation errors if any.
+	if t.ValidateRequest(c, &o, "create") != nil {
+		return
+	}
+
+	// Only the type and year come from the request.
+	o = models.ExportJob{
+		AccountId: uint(c.MustGet("accountId").(int)),
+		UserId:    uint(c.MustGet("userId").(int)),
+		Type:      o.Type,
+		Year:      o.Year,
+	}
+
+	// Create export job
+	t.db.CreateExportJob(&o)
+
+	// Return happy.
+	response.RespondCreated(c, o, nil)
+}
+
+/* End File */
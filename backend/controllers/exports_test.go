@@ -0,0 +1,131 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package controllers
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nbio/st"
+
+	"app.skyclerk.com/backend/models"
+)
+
+//
+// TestExportJobs01 - Queue a year-end export and check on it.
+//
+func TestExportJobs01(t *testing.T) {
+	// Start the db connection.
+	db, dbName, _ := models.NewTestDB("")
+	defer models.TestingTearDown(db, dbName)
+
+	// Create controller
+	c := &Controller{}
+	c.SetDB(db)
+
+	// Someone else's export
+	other := models.ExportJob{AccountId: 34, UserId: 2, Type: "year-end", Year: 2024}
+	db.CreateExportJob(&other)
+
+	// Setup router
+	gin.SetMode("release")
+	gin.DisableConsoleColor()
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("accountId", 33)
+		c.Set("userId", 1)
+	})
+	r.GET("/api/v3/:account/exports", c.GetExportJobs)
+	r.GET("/api/v3/:account/exports/:id", c.GetExportJob)
+	r.POST("/api/v3/:account/exports", c.CreateExportJob)
+
+	// Queue one. Status and account can not be set.
+	w := doJSONRequest(r, "POST", "/api/v3/33/exports", `{"type":"year-end","year":2024,"status":"done","account_id":34}`)
+	st.Expect(t, w.Code, 201)
+
+	job := models.ExportJob{}
+	json.Unmarshal(w.Body.Bytes(), &job)
+	st.Expect(t, job.AccountId, uint(33))
+	st.Expect(t, job.UserId, uint(1))
+	st.Expect(t, job.Status, "pending")
+	st.Expect(t, job.Year, 2024)
+
+	// Only one at a time for a year.
+	w = doJSONRequest(r, "POST", "/api/v3/33/exports", `{"type":"year-end","year":2024}`)
+	st.Expect(t, w.Code, 400)
+	st.Expect(t, w.Body.String(), `{"errors":{"year":"This export is already being built."}}`)
+
+	// Bad requests
+	w = doJSONRequest(r, "POST", "/api/v3/33/exports", `{"type":"everything","year":2024}`)
+	st.Expect(t, w.Code, 400)
+	st.Expect(t, w.Body.String(), `{"errors":{"type":"The type field must be year-end."}}`)
+
+	w = doJSONRequest(r, "POST", "/api/v3/33/exports", `{"type":"year-end","year":`+strconv.Itoa(time.Now().Year()+1)+`}`)
+	st.Expect(t, w.Code, 400)
+	st.Expect(t, w.Body.String(), `{"errors":{"year":"The year field can not be in the future."}}`)
+
+	w = doJSONRequest(r, "POST", "/api/v3/33/exports", `{"type":"year-end"}`)
+	st.Expect(t, w.Code, 400)
+	st.Expect(t, w.Body.String(), `{"errors":{"year":"The year field is required."}}`)
+
+	// No link until it is done.
+	w = doJSONRequest(r, "GET", "/api/v3/33/exports/"+strconv.Itoa(int(job.Id)), ``)
+	st.Expect(t, w.Code, 200)
+	json.Unmarshal(w.Body.Bytes(), &job)
+	st.Expect(t, job.DownloadUrl, "")
+
+	// Build it.
+	st.Expect(t, db.ClaimExportJob(&job), true)
+	st.Expect(t, db.ClaimExportJob(&job), false)
+	db.ExportJobDone(&job, "accounts/33/exports/1/skyclerk-2024-year-end.zip", 1024)
+
+	w = doJSONRequest(r, "GET", "/api/v3/33/exports/"+strconv.Itoa(int(job.Id)), ``)
+	st.Expect(t, w.Code, 200)
+	st.Expect(t, strings.Contains(w.Body.String(), `"path"`), false)
+
+	job = models.ExportJob{}
+	json.Unmarshal(w.Body.Bytes(), &job)
+	st.Expect(t, job.Status, "done")
+	st.Expect(t, job.Progress, 100)
+	st.Expect(t, job.Size, int64(1024))
+	st.Expect(t, strings.Contains(job.DownloadUrl, "skyclerk-2024-year-end.zip"), true)
+
+	// Now we can ask again.
+	w = doJSONRequest(r, "POST", "/api/v3/33/exports", `{"type":"year-end","year":2024}`)
+	st.Expect(t, w.Code, 201)
+
+	// List, newest first.
+	w = doJSONRequest(r, "GET", "/api/v3/33/exports", ``)
+	st.Expect(t, w.Code, 200)
+
+	list := []models.ExportJob{}
+	json.Unmarshal(w.Body.Bytes(), &list)
+	st.Expect(t, len(list), 2)
+	st.Expect(t, list[0].Status, "pending")
+	st.Expect(t, list[1].Status, "done")
+
+	// Expired jobs lose their link.
+	db.New().Model(&models.ExportJob{}).Where("id = ?", job.Id).Update("expires_at", time.Now().Add(-time.Hour))
+	st.Expect(t, len(db.GetExpiredExportJobs(time.Now())), 1)
+
+	w = doJSONRequest(r, "GET", "/api/v3/33/exports/"+strconv.Itoa(int(job.Id)), ``)
+	json.Unmarshal(w.Body.Bytes(), &job)
+	st.Expect(t, job.DownloadUrl, "")
+
+	// Not ours
+	w = doJSONRequest(r, "GET", "/api/v3/33/exports/"+strconv.Itoa(int(other.Id)), ``)
+	st.Expect(t, w.Code, 400)
+	st.Expect(t, w.Body.String(), `{"error":"Export not found."}`)
+}
+
+/* End File */
@@ -20,6 +20,7 @@ import (
 	"github.com/adelowo/filer/validator"
 	"github.com/gin-gonic/gin"
 
+	"app.skyclerk.com/backend/library/realip"
 	"app.skyclerk.com/backend/library/response"
 	"app.skyclerk.com/backend/models"
 	"app.skyclerk.com/backend/services"
@@ -51,6 +52,8 @@ func (t *Controller) CreateFile(c *gin.Context) {
 		if err != nil {
 			services.Info(errors.New(fmt.Sprintf("Files.CreateFile() - AccountId: %d LedgerId: %d - %s", accountId, ledgerId, err.Error())))
 			c.JSON(http.StatusBadRequest, gin.H{"errors": gin.H{"file": "Your ledger_id is not found."}})
+		} else {
+			t.db.CreateLedgerRevision(accountId, uint(ledgerId), uint(c.MustGet("userId").(int)), realip.RealIP(c.Request), "update")
 		}
 	}
 
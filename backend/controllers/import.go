@@ -0,0 +1,265 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package controllers
+
+import (
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"app.skyclerk.com/backend/library/csvimport"
+	"app.skyclerk.com/backend/library/ofx"
+	"app.skyclerk.com/backend/library/response"
+	"app.skyclerk.com/backend/models"
+)
+
+// Max size of an import file we accept.
+const maxImportSize = 10 << 20
+
+// OfxImportPreview struct - What we send back before the import is committed.
+type OfxImportPreview struct {
+	BankId string          `json:"bank_id"`
+	AcctId string          `json:"acct_id"`
+	Start  time.Time       `json:"start"`
+	End    time.Time       `json:"end"`
+	Rows   []ofx.ImportRow `json:"rows"`
+}
+
+//
+// PreviewOfxImport - Upload an OFX / QFX file and get back what would be imported.
+// Nothing is saved.
+//
+func (t *Controller) PreviewOfxImport(c *gin.Context) {
+	// Parse the statement. JSON error set in function.
+	stmt, err := t.parseUploadedStatement(c)
+
+	if err != nil {
+		return
+	}
+
+	// Build preview
+	preview := OfxImportPreview{
+		BankId: stmt.BankId,
+		AcctId: stmt.AcctId,
+		Start:  stmt.Start,
+		End:    stmt.End,
+		Rows:   ofx.Preview(t.db, uint(c.MustGet("accountId").(int)), stmt),
+	}
+
+	// Show what we have done with these contacts before.
+	for key, row := range preview.Rows {
+		preview.Rows[key].Suggestions = t.getImportSuggestions(c, row.Ledger, row.Error)
+	}
+
+	// Return happy.
+	response.Results(c, preview, nil)
+}
+
+//
+// CommitOfxImport - Upload an OFX / QFX file and import it. Pass in a comma
+// separated "fit_ids" field to only import some of the rows from the preview.
+//
+func (t *Controller) CommitOfxImport(c *gin.Context) {
+	// Parse the statement. JSON error set in function.
+	stmt, err := t.parseUploadedStatement(c)
+
+	if err != nil {
+		return
+	}
+
+	// Rows we want to import.
+	fitIds := []string{}
+
+	if len(c.PostForm("fit_ids")) > 0 {
+		fitIds = strings.Split(c.PostForm("fit_ids"), ",")
+	}
+
+	// Run the import
+	count := ofx.Import(t.db, uint(c.MustGet("accountId").(int)), uint(c.MustGet("userId").(int)), stmt, fitIds)
+
+	// Return happy.
+	response.RespondCreated(c, gin.H{"imported": count}, nil)
+}
+
+//
+// PreviewCsvImport - Upload a CSV file and get back what would be imported with
+// the import profile. Nothing is saved.
+//
+func (t *Controller) PreviewCsvImport(c *gin.Context) {
+	// Get the profile. JSON error set in function.
+	profile, err := t.getImportProfile(c)
+
+	if err != nil {
+		return
+	}
+
+	// Open the file. JSON error set in function.
+	fh, err := t.openUploadedImport(c)
+
+	if err != nil {
+		return
+	}
+
+	defer fh.Close()
+
+	rows, err := csvimport.Preview(t.db, uint(c.MustGet("accountId").(int)), profile, fh)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": gin.H{"file": "Unable to read the CSV file."}})
+		return
+	}
+
+	// Show what we have done with these contacts before.
+	for key, row := range rows {
+		rows[key].Suggestions = t.getImportSuggestions(c, row.Ledger, row.Error)
+	}
+
+	// Return happy.
+	response.Results(c, rows, nil)
+}
+
+//
+// CommitCsvImport - Upload a CSV file and import it with the import profile. Pass
+// in a comma separated "lines" field to only import some of the lines from the preview.
+//
+func (t *Controller) CommitCsvImport(c *gin.Context) {
+	// Get the profile. JSON error set in function.
+	profile, err := t.getImportProfile(c)
+
+	if err != nil {
+		return
+	}
+
+	// Open the file. JSON error set in function.
+	fh, err := t.openUploadedImport(c)
+
+	if err != nil {
+		return
+	}
+
+	defer fh.Close()
+
+	// Lines we want to import.
+	lines := []int{}
+
+	for _, row := range strings.Split(c.PostForm("lines"), ",") {
+		if line, err := strconv.Atoi(strings.TrimSpace(row)); err == nil {
+			lines = append(lines, line)
+		}
+	}
+
+	// Run the import
+	count, rows, err := csvimport.Import(t.db, uint(c.MustGet("accountId").(int)), uint(c.MustGet("userId").(int)), profile, fh, lines)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": gin.H{"file": "Unable to read the CSV file."}})
+		return
+	}
+
+	// Return happy.
+	response.RespondCreated(c, gin.H{"imported": count, "rows": rows}, nil)
+}
+
+// -------------- Private Helper Functions ------------------ //
+
+//
+// getImportSuggestions - Suggestions for an import row. New contacts have no
+// history so we do not bother looking.
+//
+func (t *Controller) getImportSuggestions(c *gin.Context, ledger models.Ledger, rowError string) models.LedgerSuggestions {
+	if (len(rowError) > 0) || (ledger.Contact.Id == 0) {
+		return models.LedgerSuggestions{Categories: []models.CategorySuggestion{}, Labels: []models.LabelSuggestion{}}
+	}
+
+	return t.db.GetLedgerSuggestions(uint(c.MustGet("accountId").(int)), strconv.Itoa(int(ledger.Contact.Id)), ledger.Amount)
+}
+
+//
+// getImportProfile - Get the profile from either the "profile_id" field (saved
+// profiles) or the "profile" field (built in profiles).
+//
+func (t *Controller) getImportProfile(c *gin.Context) (models.ImportProfile, error) {
+	if len(c.PostForm("profile")) > 0 {
+		profile, err := csvimport.GetBuiltinProfile(c.PostForm("profile"))
+
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"errors": gin.H{"profile": err.Error()}})
+		}
+
+		return profile, err
+	}
+
+	id, _ := strconv.Atoi(c.PostForm("profile_id"))
+
+	profile, err := t.db.GetImportProfileByAccountAndId(uint(c.MustGet("accountId").(int)), uint(id))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": gin.H{"profile_id": err.Error()}})
+	}
+
+	return profile, err
+}
+
+//
+// openUploadedImport - We assume a multi-part upload where "file" is the variable.
+//
+func (t *Controller) openUploadedImport(c *gin.Context) (multipart.File, error) {
+	// This is the file we are uploading.
+	file, err := c.FormFile("file")
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": gin.H{"file": "A file is required."}})
+		return nil, err
+	}
+
+	if file.Size > maxImportSize {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": gin.H{"file": "We have a 10MB limit on import uploads."}})
+		return nil, errors.New("Import file too big.")
+	}
+
+	fh, err := file.Open()
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": gin.H{"file": "An error happend when uploading file (#001). Please contact help@skyclerk.com."}})
+		return nil, err
+	}
+
+	// Return happy
+	return fh, nil
+}
+
+//
+// parseUploadedStatement - We assume a multi-part upload where "file" is the variable.
+//
+func (t *Controller) parseUploadedStatement(c *gin.Context) (ofx.Statement, error) {
+	// Open the file. JSON error set in function.
+	fh, err := t.openUploadedImport(c)
+
+	if err != nil {
+		return ofx.Statement{}, err
+	}
+
+	defer fh.Close()
+
+	stmt, err := ofx.Parse(fh)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": gin.H{"file": err.Error()}})
+		return ofx.Statement{}, err
+	}
+
+	// Return happy
+	return stmt, nil
+}
+
+/* End File */
@@ -0,0 +1,158 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"app.skyclerk.com/backend/library/csvimport"
+	"app.skyclerk.com/backend/library/response"
+	"app.skyclerk.com/backend/models"
+)
+
+//
+// GetImportProfiles - Return the built in import profiles followed by the ones
+// saved to this account.
+//
+func (t *Controller) GetImportProfiles(c *gin.Context) {
+	// Place to store the results.
+	var results = []models.ImportProfile{}
+
+	// Run the query
+	t.db.New().Where("account_id = ?", c.MustGet("accountId").(int)).Order("name ASC").Find(&results)
+
+	// Return json based on if this was a good result or not.
+	response.Results(c, append(csvimport.BuiltinProfiles(), results...), nil)
+}
+
+//
+// GetImportProfile by id
+//
+func (t *Controller) GetImportProfile(c *gin.Context) {
+	// Set id
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": err})
+		return
+	}
+
+	// Get profile and make sure we have perms to it
+	p, err := t.db.GetImportProfileByAccountAndId(uint(c.MustGet("accountId").(int)), uint(id))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Import profile not found."})
+		return
+	}
+
+	// Return happy.
+	response.Results(c, p, nil)
+}
+
+//
+// CreateImportProfile - Create an import profile within the account.
+//
+func (t *Controller) CreateImportProfile(c *gin.Context) {
+	// Setup ImportProfile obj
+	o := models.ImportProfile{}
+
+	// Here we parse the JSON sent in, assign it to a struct, set validation errors if any.
+	if t.ValidateRequest(c, &o, "create") != nil {
+		return
+	}
+
+	// Make sure the AccountId is correct.
+	o.Id = 0
+	o.AccountId = uint(c.MustGet("accountId").(int))
+
+	// Create profile
+	t.db.ImportProfileCreate(&o)
+
+	// Return happy.
+	response.RespondCreated(c, o, nil)
+}
+
+//
+// UpdateImportProfile - Update an import profile within the account.
+//
+func (t *Controller) UpdateImportProfile(c *gin.Context) {
+	// Get ID from URL
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": err})
+		return
+	}
+
+	// Get profile and make sure we have perms to it
+	org, err := t.db.GetImportProfileByAccountAndId(uint(c.MustGet("accountId").(int)), uint(id))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Import profile not found."})
+		return
+	}
+
+	// Setup ImportProfile obj
+	o := models.ImportProfile{}
+
+	// Here we parse the JSON sent in, assign it to a struct, set validation errors if any.
+	if t.ValidateRequest(c, &o, "update") != nil {
+		return
+	}
+
+	// Make sure the Id, AccountId and CreatedAt are correct.
+	o.Id = org.Id
+	o.AccountId = org.AccountId
+	o.CreatedAt = org.CreatedAt
+
+	// Update profile
+	t.db.ImportProfileUpdate(&o)
+
+	// Return happy.
+	response.RespondUpdated(c, o, nil)
+}
+
+//
+// DeleteImportProfile an import profile within the account.
+//
+func (t *Controller) DeleteImportProfile(c *gin.Context) {
+	// Get Id
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": err})
+		return
+	}
+
+	// AccountId.
+	accountId := uint(c.MustGet("accountId").(int))
+
+	// First we make sure this is a profile we have access to.
+	_, err = t.db.GetImportProfileByAccountAndId(accountId, uint(id))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Import profile not found."})
+		return
+	}
+
+	// Delete profile
+	err = t.db.DeleteImportProfileByAccountAndId(accountId, uint(id))
+
+	if err != nil {
+		response.RespondError(c, err)
+		return
+	}
+
+	// Return happy.
+	response.RespondDeleted(c, nil)
+}
+
+/* End File */
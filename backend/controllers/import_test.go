@@ -0,0 +1,242 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nbio/st"
+
+	"app.skyclerk.com/backend/library/csvimport"
+	"app.skyclerk.com/backend/models"
+)
+
+//
+// TestPreviewOfxImport01 - Preview a statement then commit it.
+//
+func TestPreviewOfxImport01(t *testing.T) {
+	// Start the db connection.
+	db, dbName, _ := models.NewTestDB("")
+	defer models.TestingTearDown(db, dbName)
+
+	// Create controller
+	c := &Controller{}
+	c.SetDB(db)
+
+	// Setup request
+	body, writer := buildImportFileform(t, "../library/ofx/statement_.qfx", map[string]string{})
+	req, _ := http.NewRequest("POST", "/api/v3/33/import/ofx/preview", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	// Setup writer.
+	w := httptest.NewRecorder()
+	gin.SetMode("release")
+	gin.DisableConsoleColor()
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("accountId", 33)
+		c.Set("userId", 1)
+	})
+	r.POST("/api/v3/33/import/ofx/preview", c.PreviewOfxImport)
+	r.POST("/api/v3/33/import/ofx", c.CommitOfxImport)
+	r.ServeHTTP(w, req)
+
+	// Grab result and convert to strut
+	result := OfxImportPreview{}
+	err := json.Unmarshal([]byte(w.Body.String()), &result)
+
+	// Test results
+	st.Expect(t, err, nil)
+	st.Expect(t, w.Code, 200)
+	st.Expect(t, result.AcctId, "987654321")
+	st.Expect(t, len(result.Rows), 3)
+	st.Expect(t, result.Rows[0].FitId, "202402030001")
+	st.Expect(t, result.Rows[0].Ledger.Amount, -54.23)
+	st.Expect(t, result.Rows[0].Duplicate, false)
+
+	// Nothing was saved.
+	var count int
+	db.New().Model(&models.Ledger{}).Count(&count)
+	st.Expect(t, count, 0)
+
+	// ----------- Commit two of the rows ---------- //
+
+	body, writer = buildImportFileform(t, "../library/ofx/statement_.qfx", map[string]string{"fit_ids": "202402030001,202402150003"})
+	w2 := httptest.NewRecorder()
+	req2, _ := http.NewRequest("POST", "/api/v3/33/import/ofx", body)
+	req2.Header.Set("Content-Type", writer.FormDataContentType())
+	r.ServeHTTP(w2, req2)
+
+	st.Expect(t, w2.Code, 201)
+	st.Expect(t, w2.Body.String(), `{"imported":2}`)
+
+	db.New().Model(&models.Ledger{}).Where("LedgerAccountId = ? AND LedgerAddedById = ?", 33, 1).Count(&count)
+	st.Expect(t, count, 2)
+
+	// ----------- Bad file ---------- //
+
+	body, writer = buildImportFileform(t, "../library/airbnb/airbnb_.csv", map[string]string{})
+	w3 := httptest.NewRecorder()
+	req3, _ := http.NewRequest("POST", "/api/v3/33/import/ofx/preview", body)
+	req3.Header.Set("Content-Type", writer.FormDataContentType())
+	r.ServeHTTP(w3, req3)
+
+	st.Expect(t, w3.Code, 400)
+	st.Expect(t, w3.Body.String(), `{"errors":{"file":"The file does not look like an OFX or QFX statement."}}`)
+}
+
+//
+// TestCreateImportProfile01 - Save a profile and use it to import a CSV.
+//
+func TestCreateImportProfile01(t *testing.T) {
+	// Start the db connection.
+	db, dbName, _ := models.NewTestDB("")
+	defer models.TestingTearDown(db, dbName)
+
+	// Create controller
+	c := &Controller{}
+	c.SetDB(db)
+
+	// Get JSON
+	postStr := []byte(`{ "name": "My Bank", "skip_rows": 1, "date_column": 1, "date_format": "MM/DD/YYYY", "debit_column": 4, "credit_column": 5, "contact_column": 2, "category_column": 3 }`)
+
+	// Setup request
+	req, _ := http.NewRequest("POST", "/api/v3/33/import/profiles", bytes.NewBuffer(postStr))
+
+	// Setup writer.
+	w := httptest.NewRecorder()
+	gin.SetMode("release")
+	gin.DisableConsoleColor()
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("accountId", 33)
+		c.Set("userId", 1)
+	})
+	r.POST("/api/v3/33/import/profiles", c.CreateImportProfile)
+	r.GET("/api/v3/33/import/profiles", c.GetImportProfiles)
+	r.POST("/api/v3/33/import/csv/preview", c.PreviewCsvImport)
+	r.POST("/api/v3/33/import/csv", c.CommitCsvImport)
+	r.ServeHTTP(w, req)
+
+	// Grab result and convert to strut
+	result := models.ImportProfile{}
+	err := json.Unmarshal([]byte(w.Body.String()), &result)
+
+	// Test results
+	st.Expect(t, err, nil)
+	st.Expect(t, w.Code, 201)
+	st.Expect(t, result.Id, uint(1))
+	st.Expect(t, result.AccountId, uint(33))
+	st.Expect(t, result.Delimiter, ",")
+	st.Expect(t, result.SignConvention, "normal")
+
+	// ----------- List includes the built in profiles ---------- //
+
+	req2, _ := http.NewRequest("GET", "/api/v3/33/import/profiles", nil)
+	w2 := httptest.NewRecorder()
+	r.ServeHTTP(w2, req2)
+
+	list := []models.ImportProfile{}
+	json.Unmarshal([]byte(w2.Body.String()), &list)
+	st.Expect(t, len(list), 2)
+	st.Expect(t, list[0].Slug, "airbnb")
+	st.Expect(t, list[1].Name, "My Bank")
+
+	// ----------- Preview ---------- //
+
+	body, writer := buildImportFileform(t, "../library/csvimport/bank_.csv", map[string]string{"profile_id": "1"})
+	req3, _ := http.NewRequest("POST", "/api/v3/33/import/csv/preview", body)
+	req3.Header.Set("Content-Type", writer.FormDataContentType())
+	w3 := httptest.NewRecorder()
+	r.ServeHTTP(w3, req3)
+
+	rows := []csvimport.ImportRow{}
+	err = json.Unmarshal([]byte(w3.Body.String()), &rows)
+	st.Expect(t, err, nil)
+	st.Expect(t, w3.Code, 200)
+	st.Expect(t, len(rows), 7)
+	st.Expect(t, rows[4].Error, "Unable to read the date \"13/45/2024\".")
+
+	// ----------- Commit ---------- //
+
+	body, writer = buildImportFileform(t, "../library/csvimport/bank_.csv", map[string]string{"profile_id": "1", "lines": "2,3"})
+	req4, _ := http.NewRequest("POST", "/api/v3/33/import/csv", body)
+	req4.Header.Set("Content-Type", writer.FormDataContentType())
+	w4 := httptest.NewRecorder()
+	r.ServeHTTP(w4, req4)
+
+	st.Expect(t, w4.Code, 201)
+
+	var count int
+	db.New().Model(&models.Ledger{}).Where("LedgerAccountId = ?", 33).Count(&count)
+	st.Expect(t, count, 2)
+
+	// ----------- Bad profile ---------- //
+
+	body, writer = buildImportFileform(t, "../library/csvimport/bank_.csv", map[string]string{"profile_id": "99"})
+	req5, _ := http.NewRequest("POST", "/api/v3/33/import/csv/preview", body)
+	req5.Header.Set("Content-Type", writer.FormDataContentType())
+	w5 := httptest.NewRecorder()
+	r.ServeHTTP(w5, req5)
+
+	st.Expect(t, w5.Code, 400)
+	st.Expect(t, w5.Body.String(), `{"errors":{"profile_id":"Import profile not found."}}`)
+
+	// ----------- Bad tax form line ---------- //
+
+	w6 := doJSONRequest(r, "POST", "/api/v3/33/import/profiles", `{ "name": "Rentals", "date_column": 1, "amount_column": 2, "contact_column": 3, "category_name": "Rent", "category_irs": "1" }`)
+	st.Expect(t, w6.Code, 400)
+	st.Expect(t, w6.Body.String(), `{"errors":{"category_irs":"The category_irs field must be a known tax form line."}}`)
+}
+
+//
+// buildImportFileform so we can post a file with some extra fields.
+//
+func buildImportFileform(t *testing.T, filePath string, fields map[string]string) (*bytes.Buffer, *multipart.Writer) {
+	// Build buffer for file to upload.
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	// Create form file body
+	part, err := writer.CreateFormFile("file", filepath.Base(filePath))
+	st.Expect(t, err, nil)
+
+	// Open file handle
+	fh, err := os.Open(filePath)
+	st.Expect(t, err, nil)
+	defer fh.Close()
+
+	// Copy file data to form body.
+	_, err = io.Copy(part, fh)
+	st.Expect(t, err, nil)
+
+	// Add in the extra fields
+	for key, row := range fields {
+		err = writer.WriteField(key, row)
+		st.Expect(t, err, nil)
+	}
+
+	// Close writer
+	err = writer.Close()
+	st.Expect(t, err, nil)
+
+	return body, writer
+}
+
+/* End File */
@@ -109,6 +109,7 @@ func (t *Controller) CreateLabel(c *gin.Context) {
 
 	// Create label
 	t.db.New().Create(&o)
+	t.db.IndexLabel(o.AccountId, o.Id)
 
 	// Return happy.
 	response.RespondCreated(c, o, nil)
@@ -148,6 +149,9 @@ func (t *Controller) UpdateLabel(c *gin.Context) {
 	// Update category
 	t.db.New().Save(&orgLb)
 
+	// Update search, the name shows up on ledger entries too.
+	t.db.IndexLabel(orgLb.AccountId, orgLb.Id)
+
 	// Return happy.
 	response.RespondUpdated(c, orgLb, nil)
 }
@@ -17,6 +17,7 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"app.skyclerk.com/backend/library/helpers"
+	"app.skyclerk.com/backend/library/realip"
 	"app.skyclerk.com/backend/library/request"
 	"app.skyclerk.com/backend/library/response"
 	"app.skyclerk.com/backend/library/slack"
@@ -52,7 +53,7 @@ func (t *Controller) GetLedgers(c *gin.Context) {
 	//fmt.Println(c.Request.URL)
 
 	// Query database based on url parms.
-	results, meta, err := t.QueryLedgers(c, 25, []string{"Category", "Contact", "Labels", "Files"})
+	results, meta, err := t.QueryLedgers(c, 25, []string{"Category", "Contact", "Labels", "Files", "Splits", "Splits.Category", "Splits.Labels"})
 
 	// Error responses were already set in QueryLedgers
 	if err != nil {
@@ -84,6 +85,18 @@ func (t *Controller) GetLedgers(c *gin.Context) {
 // GetLedger by id
 //
 func (t *Controller) GetLedger(c *gin.Context) {
+	// The router will not let /ledger/suggest live next to /ledger/:id
+	if c.Param("id") == "suggest" {
+		t.GetLedgerSuggestions(c)
+		return
+	}
+
+	// Same for /ledger/export
+	if c.Param("id") == "export" {
+		t.ExportLedger(c)
+		return
+	}
+
 	// Set id
 	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
 
@@ -110,6 +123,23 @@ func (t *Controller) GetLedger(c *gin.Context) {
 	response.Results(c, l, nil)
 }
 
+//
+// GetLedgerSuggestions - Ranked category and label suggestions for a contact
+// (id or name) and amount based on the account's history.
+//
+func (t *Controller) GetLedgerSuggestions(c *gin.Context) {
+	// Amount is optional
+	amount, err := strconv.ParseFloat(c.DefaultQuery("amount", "0"), 64)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": gin.H{"amount": "The amount field must be a number."}})
+		return
+	}
+
+	// Return happy.
+	response.Results(c, t.db.GetLedgerSuggestions(uint(c.MustGet("accountId").(int)), c.Query("contact"), amount), nil)
+}
+
 //
 // CreateLedger - Create a ledger within the account.
 //
@@ -138,6 +168,7 @@ func (t *Controller) CreateLedger(c *gin.Context) {
 
 	// Create ledger
 	t.db.LedgerCreate(&o)
+	t.db.CreateLedgerRevision(o.AccountId, o.Id, o.AddedById, realip.RealIP(c.Request), "create")
 
 	// Fresh pull
 	j, err := t.db.GetLedgerByAccountAndId(o.AccountId, o.Id)
@@ -202,6 +233,12 @@ func (t *Controller) UpdateLedger(c *gin.Context) {
 		return
 	}
 
+	// Reconciled entries are locked.
+	if err := t.db.ValidateLedgerUnlocked(org); err != nil {
+		response.RespondError(c, err)
+		return
+	}
+
 	// Setup Ledger obj
 	o := models.Ledger{}
 
@@ -218,8 +255,19 @@ func (t *Controller) UpdateLedger(c *gin.Context) {
 	o.Contact.CreatedAt = org.Contact.CreatedAt
 	o.Category.CreatedAt = org.Category.CreatedAt
 
-	// Create category
+	// Keep the ids from imports so we do not import the entry again (and where it came from).
+	o.FitId = org.FitId
+	o.FitAcct = org.FitAcct
+	o.ImportKey = org.ImportKey
+	o.Source = org.Source
+
+	// Status only changes through a reconciliation.
+	o.Status = org.Status
+	o.ReconciliationId = org.ReconciliationId
+
+	// Update ledger
 	t.db.LedgerUpdate(&o)
+	t.db.CreateLedgerRevision(o.AccountId, o.Id, uint(c.MustGet("userId").(int)), realip.RealIP(c.Request), "update")
 
 	// Set the ledger type
 	ledgerType := "expense"
@@ -270,6 +318,15 @@ func (t *Controller) DeleteLedger(c *gin.Context) {
 		return
 	}
 
+	// Reconciled entries are locked.
+	if err := t.db.ValidateLedgerUnlocked(entry); err != nil {
+		response.RespondError(c, err)
+		return
+	}
+
+	// Keep what it looked like when it was deleted.
+	t.db.CreateLedgerRevision(entry.AccountId, entry.Id, uint(c.MustGet("userId").(int)), realip.RealIP(c.Request), "delete")
+
 	// Delete ledger
 	err = t.db.DeleteLedgerByAccountAndId(uint(c.MustGet("accountId").(int)), uint(id))
 
@@ -349,15 +406,25 @@ func (t *Controller) GetLedgerSummary(c *gin.Context) {
 	// Setup return object
 	ls := LedgerSummary{}
 
+	// Query args
+	args := []interface{}{accountId}
+
+	// A saved view fills in any filters not in the url.
+	viewApplied, err := t.applyLedgerView(c)
+
+	if err != nil {
+		return
+	}
+
 	// Build SQL for category (Notice: lower case column names)
-	catSql := "SELECT CategoriesId AS id, CategoriesName AS name, COUNT(CategoriesId) AS count FROM Ledger INNER JOIN Categories ON Categories.CategoriesId = Ledger.LedgerCategoryId WHERE (LedgerAccountId = ?)"
+	catSql := "SELECT CategoriesId AS id, CategoriesName AS name, COUNT(CategoriesId) AS count FROM " + models.LedgerLinesTable + " INNER JOIN Categories ON Categories.CategoriesId = Ledger.LedgerCategoryId WHERE (LedgerAccountId = ?)"
 
 	// Build SQL for labels (Notice: lower case column names)
-	lbsSql := "SELECT LabelsToLedgerLabelId AS id, LabelsName AS name, COUNT(LabelsToLedgerLabelId) AS count FROM `LabelsToLedger` INNER JOIN `Ledger` ON `LabelsToLedger`.`LabelsToLedgerLedgerId` = `Ledger`.`LedgerId` INNER JOIN `Labels` ON `LabelsToLedger`.`LabelsToLedgerLabelId` = `Labels`.`LabelsId` WHERE (LedgerAccountId = ?)"
+	lbsSql := "SELECT LabelsToLedgerLabelId AS id, LabelsName AS name, COUNT(LabelsToLedgerLabelId) AS count FROM `LabelsToLedger` INNER JOIN `Ledger` ON `LabelsToLedger`.`LabelsToLedgerLedgerId` = `Ledger`.`LedgerId` INNER JOIN `Labels` ON `LabelsToLedger`.`LabelsToLedgerLabelId` = `Labels`.`LabelsId` WHERE (LedgerAccountId = ?) AND (LedgerDeletedAt IS NULL) AND (LabelsDeletedAt IS NULL)"
 
 	// Build SQL for years (Notice: lower case column names)
 	// SQLite uses strftime for date functions
-	yrsSql := "SELECT strftime('%Y', LedgerDate) as year, COUNT(LedgerId) as count FROM Ledger WHERE (LedgerAccountId = ?)"
+	yrsSql := "SELECT strftime('%Y', LedgerDate) as year, COUNT(LedgerId) as count FROM Ledger WHERE (LedgerAccountId = ?) AND (LedgerDeletedAt IS NULL)"
 
 	// Add type filter - income
 	if c.DefaultQuery("type", "") == "income" {
@@ -373,6 +440,28 @@ func (t *Controller) GetLedgerSummary(c *gin.Context) {
 		yrsSql = yrsSql + " AND (LedgerAmount < 0.00)"
 	}
 
+	// A saved view narrows the summary to the entries it lists.
+	if viewApplied {
+		params, err := t.ledgerQueryParams(c, 0, []string{})
+
+		// Error responses were already set in ledgerQueryParams
+		if err != nil {
+			return
+		}
+
+		sub, err := t.db.QuerySubQuery(&models.Ledger{}, "LedgerId", params)
+
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		catSql = catSql + " AND (LedgerId IN (?))"
+		lbsSql = lbsSql + " AND (LedgerId IN (?))"
+		yrsSql = yrsSql + " AND (LedgerId IN (?))"
+		args = append(args, sub)
+	}
+
 	// Add Group
 	catSql = catSql + " GROUP BY CategoriesName ORDER BY CategoriesName ASC"
 	lbsSql = lbsSql + " GROUP BY LabelsToLedgerLabelId ORDER BY LabelsName ASC"
@@ -381,9 +470,9 @@ func (t *Controller) GetLedgerSummary(c *gin.Context) {
 	yrsSql = yrsSql + " GROUP BY strftime('%Y', LedgerDate) ORDER BY LedgerDate DESC"
 
 	// Run query.
-	t.db.New().Raw(yrsSql, accountId).Scan(&ls.Years)
-	t.db.New().Raw(lbsSql, accountId).Scan(&ls.Labels)
-	t.db.New().Raw(catSql, accountId).Scan(&ls.Categories)
+	t.db.New().Raw(yrsSql, args...).Scan(&ls.Years)
+	t.db.New().Raw(lbsSql, args...).Scan(&ls.Labels)
+	t.db.New().Raw(catSql, args...).Scan(&ls.Categories)
 
 	// Return happy.
 	response.Results(c, ls, nil)
@@ -400,9 +489,44 @@ func (t *Controller) QueryLedgers(c *gin.Context, limit int, preloads []string)
 	// Place to store the results.
 	var results = []models.Ledger{}
 
+	// Build the query from the url.
+	params, err := t.ledgerQueryParams(c, limit, preloads)
+
+	// Error responses were already set in ledgerQueryParams
+	if err != nil {
+		return results, models.QueryMetaData{}, err
+	}
+
+	// Run the query
+	meta, err := t.db.QueryMeta(&results, params)
+
+	if err == models.ErrInvalidCursor {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": gin.H{"cursor": err.Error()}})
+		return results, meta, err
+	}
+
+	if err != nil {
+		services.Info(err)
+		return results, meta, err
+	}
+
+	// Return happy
+	return results, meta, nil
+}
+
+//
+// ledgerQueryParams - Turn the ledger filters in the url into query params.
+// Shared by QueryLedgers and the export. Sets the error response on failure.
+//
+func (t *Controller) ledgerQueryParams(c *gin.Context, limit int, preloads []string) (models.QueryParam, error) {
 	// Get account
 	accountId := c.MustGet("accountId").(int)
 
+	// A saved view fills in any filters not in the url.
+	if _, err := t.applyLedgerView(c); err != nil {
+		return models.QueryParam{}, err
+	}
+
 	// Get limits and pages
 	page, _, _ := request.GetSetPagingParms(c)
 
@@ -422,6 +546,10 @@ func (t *Controller) QueryLedgers(c *gin.Context, limit int, preloads []string)
 	// Set limit
 	if limit > 0 {
 		params.Limit = limit
+
+		// Keyset paging - ?cursor=
+		params.UseCursor, params.Cursor, params.NoCount = request.GetCursorParms(c)
+		params.CursorCol = "LedgerId"
 	}
 
 	// Add type filter - income
@@ -450,14 +578,13 @@ func (t *Controller) QueryLedgers(c *gin.Context, limit int, preloads []string)
 		if err != nil {
 			services.Info(err)
 			c.JSON(http.StatusBadRequest, gin.H{"error": "Error with category_id"})
-			return results, models.QueryMetaData{}, err
+			return params, err
 		}
 
-		// Update query.
+		// Split entries match on any of their lines.
 		params.Wheres = append(params.Wheres, models.KeyValue{
-			Key:      "LedgerCategoryId",
-			Compare:  "=",
-			ValueInt: cat_id,
+			Sql:  "(LedgerCategoryId = ? OR EXISTS (SELECT 1 FROM ledger_splits WHERE ledger_splits.ledger_id = Ledger.LedgerId AND ledger_splits.category_id = ?))",
+			Args: []interface{}{cat_id, cat_id},
 		})
 	}
 
@@ -494,6 +621,15 @@ func (t *Controller) QueryLedgers(c *gin.Context, limit int, preloads []string)
 		})
 	}
 
+	// Add type filter - status
+	if len(c.DefaultQuery("status", "")) > 0 {
+		params.Wheres = append(params.Wheres, models.KeyValue{
+			Key:     "LedgerStatus",
+			Compare: "=",
+			Value:   c.DefaultQuery("status", ""),
+		})
+	}
+
 	// Get ledger ids from lables we want to filter from.
 	if len(c.DefaultQuery("label_ids", "")) > 0 {
 		// Get Ids from url
@@ -519,41 +655,32 @@ func (t *Controller) QueryLedgers(c *gin.Context, limit int, preloads []string)
 		})
 	}
 
-	// Manage a search query
-	if len(c.DefaultQuery("search", "")) > 0 {
-		// Query term
-		search := c.DefaultQuery("search", "")
+	// Filter query - q=amount<-100 AND label:"client a" AND NOT category:Travel
+	if len(c.DefaultQuery("q", "")) > 0 {
+		filter, err := models.ParseLedgerFilter(c.DefaultQuery("q", ""))
 
-		// Array of contact ids
-		contactWhereIn := []int{}
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"errors": gin.H{"q": err.Error()}})
+			return params, err
+		}
 
-		// Get contacts
-		c := []models.Contact{}
-		t.db.New().Where("(ContactsName LIKE ? OR ContactsFirstName LIKE ? OR ContactsLastName LIKE ?) AND ContactsAccountId = ?", "%"+search+"%", "%"+search+"%", "%"+search+"%", accountId).Find(&c)
+		where, err := t.db.GetLedgerFilterWhere(uint(accountId), uint(c.GetInt("userId")), filter)
 
-		// Build id array.
-		for _, row := range c {
-			contactWhereIn = append(contactWhereIn, int(row.Id))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"errors": gin.H{"q": err.Error()}})
+			return params, err
 		}
 
-		// Update query.
-		params.Wheres = append(params.Wheres, models.KeyValue{
-			Key:          "LedgerContactId",
-			Compare:      "IN",
-			ValueIntList: contactWhereIn,
-		})
+		params.Wheres = append(params.Wheres, where)
 	}
 
-	// Run the query
-	meta, err := t.db.QueryMeta(&results, params)
-
-	if err != nil {
-		services.Info(err)
-		return results, meta, err
+	// Manage a search query. Matches notes, contacts, categories, labels and amounts.
+	if len(c.DefaultQuery("search", "")) > 0 {
+		params.Wheres = append(params.Wheres, t.db.SearchLedgerWhere(uint(accountId), c.DefaultQuery("search", "")))
 	}
 
 	// Return happy
-	return results, meta, nil
+	return params, nil
 }
 
 /* End File */
@@ -0,0 +1,92 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"app.skyclerk.com/backend/library/realip"
+	"app.skyclerk.com/backend/library/response"
+	"app.skyclerk.com/backend/models"
+)
+
+//
+// BulkLedger - Change many ledger entries at once. Post in a list of ids or
+// leave them out and pass the same filters GET /ledger takes in the query
+// string (?year=2024&category_id=3). Returns a result for every entry.
+//
+func (t *Controller) BulkLedger(c *gin.Context) {
+	// The router will not let /ledger/bulk live next to /ledger/:id/restore/:revision
+	if c.Param("id") != "bulk" {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Page not found."})
+		return
+	}
+
+	// Setup LedgerBulk obj
+	o := models.LedgerBulk{}
+
+	// Here we parse the JSON sent in, assign it to a struct, set validation errors if any.
+	if t.ValidateRequest(c, &o, "create") != nil {
+		return
+	}
+
+	// No ids so we use the filters. We do not want an empty request, or one
+	// with just paging or ordering, to hit every entry.
+	if len(o.Ids) == 0 {
+		if !hasLedgerFilter(c) {
+			c.JSON(http.StatusBadRequest, gin.H{"errors": gin.H{"ids": "Pass a list of ids or filter parameters."}})
+			return
+		}
+
+		ledgers, _, err := t.QueryLedgers(c, 0, []string{})
+
+		// Error responses were already set in QueryLedgers
+		if err != nil {
+			return
+		}
+
+		for _, row := range ledgers {
+			o.Ids = append(o.Ids, row.Id)
+		}
+	}
+
+	// Run the change
+	results, err := t.db.LedgerBulkUpdate(uint(c.MustGet("accountId").(int)), uint(c.MustGet("userId").(int)), realip.RealIP(c.Request), o)
+
+	if err != nil {
+		response.RespondError(c, err)
+		return
+	}
+
+	// Return happy.
+	response.Results(c, results, nil)
+}
+
+// ----------------- Private Helper Funcs -------------- //
+
+//
+// hasLedgerFilter - True if the query string has a saved view or at least one
+// filter that narrows the ledger.
+//
+func hasLedgerFilter(c *gin.Context) bool {
+	if len(c.DefaultQuery("view_id", "")) > 0 {
+		return true
+	}
+
+	for _, row := range models.LedgerViewFilterKeys {
+		if len(c.DefaultQuery(row, "")) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+/* End File */
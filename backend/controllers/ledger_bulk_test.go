@@ -0,0 +1,171 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nbio/st"
+
+	"app.skyclerk.com/backend/library/test"
+	"app.skyclerk.com/backend/models"
+)
+
+//
+// TestBulkLedger01 - Recategorize, relabel, shift and delete entries in bulk.
+//
+func TestBulkLedger01(t *testing.T) {
+	// Start the db connection.
+	db, dbName, _ := models.NewTestDB("")
+	defer models.TestingTearDown(db, dbName)
+
+	// Create controller
+	c := &Controller{}
+	c.SetDB(db)
+
+	account := test.GetRandomAccount(33)
+	db.Save(&account)
+
+	// Three entries in 2024 (the last one reconciled) and one in 2023.
+	dates := []time.Time{
+		time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 2, 2, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 2, 3, 0, 0, 0, 0, time.UTC),
+		time.Date(2023, 2, 3, 0, 0, 0, 0, time.UTC),
+	}
+
+	for _, row := range dates {
+		l := test.GetRandomLedger(33)
+		l.Date = row
+		l.Amount = -20.00
+		l.Labels = []models.Label{}
+		db.LedgerCreate(&l)
+	}
+
+	db.New().Model(&models.Ledger{}).Where("LedgerId = ?", 3).UpdateColumn("LedgerStatus", "reconciled")
+
+	// Someone else's entry
+	other := test.GetRandomLedger(34)
+	db.LedgerCreate(&other)
+
+	// What we will set
+	cat := models.Category{AccountId: 33, Name: "Bulk Category", Type: "1"}
+	db.Save(&cat)
+
+	label := models.Label{AccountId: 33, Name: "Bulk Label"}
+	db.Save(&label)
+
+	// Setup router
+	gin.SetMode("release")
+	gin.DisableConsoleColor()
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("accountId", 33)
+		c.Set("userId", 1)
+	})
+	r.POST("/api/v3/:account/ledger/:id", c.BulkLedger)
+
+	// Validation
+	w := doJSONRequest(r, "POST", "/api/v3/33/ledger/bulk", `{ "ids": [1], "operation": "explode" }`)
+	st.Expect(t, w.Code, 400)
+	st.Expect(t, w.Body.String(), `{"errors":{"operation":"The operation field must be set_category, add_labels, remove_labels, set_contact, shift_date or delete."}}`)
+
+	w = doJSONRequest(r, "POST", "/api/v3/33/ledger/bulk", `{ "operation": "delete" }`)
+	st.Expect(t, w.Code, 400)
+	st.Expect(t, w.Body.String(), `{"errors":{"ids":"Pass a list of ids or filter parameters."}}`)
+
+	// Paging and ordering are not filters.
+	for _, row := range []string{"?order=LedgerId", "?page=1&sort=ASC", "?year=", "?color=red"} {
+		w = doJSONRequest(r, "POST", "/api/v3/33/ledger/bulk"+row, `{ "operation": "delete" }`)
+		st.Expect(t, w.Code, 400)
+		st.Expect(t, w.Body.String(), `{"errors":{"ids":"Pass a list of ids or filter parameters."}}`)
+	}
+
+	st.Expect(t, len(db.GetTrashByAccount(33, "")), 0)
+
+	w = doJSONRequest(r, "POST", "/api/v3/33/ledger/bulk", fmt.Sprintf(`{ "ids": [1], "operation": "set_category", "category_id": %d }`, other.Category.Id))
+	st.Expect(t, w.Code, 400)
+
+	w = doJSONRequest(r, "POST", "/api/v3/33/ledger/other", `{ "ids": [1], "operation": "delete" }`)
+	st.Expect(t, w.Code, 404)
+
+	// Recategorize by id. Locked and missing entries are reported.
+	w = doJSONRequest(r, "POST", "/api/v3/33/ledger/bulk", fmt.Sprintf(`{ "ids": [1, 2, 3, %d], "operation": "set_category", "category_id": %d }`, other.Id, cat.Id))
+	results := []models.LedgerBulkResult{}
+	err := json.Unmarshal([]byte(w.Body.String()), &results)
+
+	st.Expect(t, err, nil)
+	st.Expect(t, w.Code, 200)
+	st.Expect(t, results, []models.LedgerBulkResult{
+		{Id: 1, Success: true},
+		{Id: 2, Success: true},
+		{Id: 3, Success: false, Error: "This entry has been reconciled. Undo the reconciliation to change it."},
+		{Id: other.Id, Success: false, Error: "Ledger entry not found."},
+	})
+
+	l1, _ := db.GetLedgerByAccountAndId(33, 1)
+	st.Expect(t, l1.Category.Id, cat.Id)
+
+	// One activity and one revision per changed entry.
+	count := 0
+	db.New().Model(&models.Activity{}).Where("account_id = ? AND sub_action = ?", 33, "update").Count(&count)
+	st.Expect(t, count, 2)
+	st.Expect(t, db.GetLedgerRevisions(33, 1)[0].Snapshot.Category, "Bulk Category")
+
+	// Add a label using the GET /ledger filters.
+	w = doJSONRequest(r, "POST", "/api/v3/33/ledger/bulk?year=2024", fmt.Sprintf(`{ "operation": "add_labels", "label_ids": [%d] }`, label.Id))
+	json.Unmarshal([]byte(w.Body.String()), &results)
+
+	st.Expect(t, w.Code, 200)
+	st.Expect(t, len(results), 3)
+
+	l1, _ = db.GetLedgerByAccountAndId(33, 1)
+	st.Expect(t, len(l1.Labels), 1)
+	st.Expect(t, l1.Labels[0].Name, "Bulk Label")
+
+	l4, _ := db.GetLedgerByAccountAndId(33, 4)
+	st.Expect(t, len(l4.Labels), 0)
+
+	// And take it back off.
+	w = doJSONRequest(r, "POST", "/api/v3/33/ledger/bulk", fmt.Sprintf(`{ "ids": [1, 2], "operation": "remove_labels", "label_ids": [%d] }`, label.Id))
+	st.Expect(t, w.Code, 200)
+
+	l1, _ = db.GetLedgerByAccountAndId(33, 1)
+	st.Expect(t, len(l1.Labels), 0)
+
+	// Shift dates. The new date has to be open too.
+	db.New().Model(&models.Account{}).Where("id = ?", 33).UpdateColumn("books_closed_through", time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC))
+
+	w = doJSONRequest(r, "POST", "/api/v3/33/ledger/bulk", `{ "ids": [1, 2], "operation": "shift_date", "days": -32 }`)
+	json.Unmarshal([]byte(w.Body.String()), &results)
+
+	st.Expect(t, results[0].Success, false)
+	st.Expect(t, results[0].Error, "The books are closed through December 31, 2023. Entries dated on or before then can not be added, changed or deleted.")
+	st.Expect(t, results[1].Success, true)
+
+	l2, _ := db.GetLedgerByAccountAndId(33, 2)
+	st.Expect(t, l2.Date.Format("2006-01-02"), "2024-01-01")
+
+	// Delete goes to the trash.
+	w = doJSONRequest(r, "POST", "/api/v3/33/ledger/bulk", `{ "ids": [1, 2], "operation": "delete" }`)
+	json.Unmarshal([]byte(w.Body.String()), &results)
+
+	st.Expect(t, results[0].Success, true)
+	st.Expect(t, results[1].Success, true)
+	st.Expect(t, len(db.GetTrashByAccount(33, "ledger")), 2)
+
+	db.New().Model(&models.Activity{}).Where("account_id = ? AND sub_action = ?", 33, "delete").Count(&count)
+	st.Expect(t, count, 2)
+}
+
+/* End File */
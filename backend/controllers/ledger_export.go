@@ -0,0 +1,90 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package controllers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"app.skyclerk.com/backend/library/export"
+	"app.skyclerk.com/backend/library/response"
+	"app.skyclerk.com/backend/models"
+	"app.skyclerk.com/backend/services"
+)
+
+// How many ledger entries we load at a time when exporting.
+const ledgerExportBatchSize = 500
+
+//
+// ExportLedger - Download the ledger as csv or xlsx. Takes the same filters as
+// GetLedgers. Rows are written as we read them so big accounts are never held
+// in memory.
+//
+func (t *Controller) ExportLedger(c *gin.Context) {
+	format := c.DefaultQuery("format", "csv")
+
+	if (format != "csv") && (format != "xlsx") {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": gin.H{"format": "The format must be csv or xlsx."}})
+		return
+	}
+
+	// Build the query from the url.
+	params, err := t.ledgerQueryParams(c, 0, export.LedgerPreloads)
+
+	// Error responses were already set in ledgerQueryParams
+	if err != nil {
+		return
+	}
+
+	// Start the file.
+	w, err := export.NewLedgerWriter(t.db, c.Writer, format)
+
+	if err != nil {
+		response.RespondError(c, err)
+		return
+	}
+
+	c.Header("Content-Type", export.ContentType(format))
+	c.Header("Content-Disposition", "attachment; filename=\"ledger-"+time.Now().Format("2006-01-02")+"."+format+"\"")
+
+	// Write each batch and send it on its way.
+	err = t.db.EachLedgerBatch(params, ledgerExportBatchSize, func(rows []models.Ledger) error {
+		if err := w.Write(rows); err != nil {
+			return err
+		}
+
+		if err := w.Flush(); err != nil {
+			return err
+		}
+
+		c.Writer.Flush()
+		return nil
+	})
+
+	if err != nil {
+		services.Info(err)
+
+		// Nothing has gone out yet so we can still say what went wrong.
+		if !c.Writer.Written() {
+			c.Header("Content-Disposition", "")
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
+
+		return
+	}
+
+	if err := w.Close(); err != nil {
+		services.Info(err)
+	}
+
+	c.Writer.Flush()
+}
+
+/* End File */
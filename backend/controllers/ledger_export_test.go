@@ -0,0 +1,144 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package controllers
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"io/ioutil"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nbio/st"
+
+	"app.skyclerk.com/backend/library/test"
+	"app.skyclerk.com/backend/models"
+)
+
+//
+// TestExportLedger01 - Export the ledger as csv and xlsx with filters.
+//
+func TestExportLedger01(t *testing.T) {
+	// Start the db connection.
+	db, dbName, _ := models.NewTestDB("")
+	defer models.TestingTearDown(db, dbName)
+
+	// Create controller
+	c := &Controller{}
+	c.SetDB(db)
+
+	file := test.GetRandomFile(33)
+	file.Path = "accounts/33/receipt.pdf"
+	db.Save(&file)
+
+	l1 := test.GetRandomLedger(33)
+	l1.Amount = -150.25
+	l1.Date = time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	l1.Note = "=SUM(A1:A2)"
+	l1.Contact = models.Contact{Name: "Home Depot"}
+	l1.Category = models.Category{Name: "Supplies", Type: "1"}
+	l1.Labels = []models.Label{{Name: "Client A"}, {Name: "Client B"}}
+	l1.Files = []models.File{file}
+	db.LedgerCreate(&l1)
+
+	l2 := test.GetRandomLedger(33)
+	l2.Amount = 500.00
+	l2.Date = time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	l2.Note = "Invoice 1042, paid"
+	l2.Contact = models.Contact{FirstName: "Jane", LastName: "Wells"}
+	l2.Category = models.Category{Name: "Sales", Type: "2"}
+	l2.Labels = []models.Label{}
+	db.LedgerCreate(&l2)
+
+	// Enough plain entries to need more than one batch.
+	for i := 0; i < ledgerExportBatchSize+50; i++ {
+		db.New().Create(&models.Ledger{AccountId: 33, Amount: -1.00, Date: time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC), Note: "Coffee"})
+	}
+
+	// Someone else's entry
+	other := test.GetRandomLedger(34)
+	db.LedgerCreate(&other)
+
+	// Setup router
+	gin.SetMode("release")
+	gin.DisableConsoleColor()
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("accountId", 33)
+		c.Set("userId", 1)
+	})
+	r.GET("/api/v3/:account/ledger/:id", c.GetLedger)
+
+	// CSV, everything.
+	w := doJSONRequest(r, "GET", "/api/v3/33/ledger/export", ``)
+	st.Expect(t, w.Code, 200)
+	st.Expect(t, w.HeaderMap["Content-Type"][0], "text/csv")
+	st.Expect(t, strings.HasPrefix(w.HeaderMap["Content-Disposition"][0], "attachment; filename=\"ledger-"), true)
+
+	rows, err := csv.NewReader(strings.NewReader(w.Body.String())).ReadAll()
+	st.Expect(t, err, nil)
+	st.Expect(t, len(rows), ledgerExportBatchSize+53)
+	st.Expect(t, rows[0], []string{"Date", "Contact", "Category", "Labels", "Amount", "Note", "Attachments"})
+
+	// Newest first
+	st.Expect(t, rows[1], []string{"2024-02-01", "Jane Wells", "Sales", "", "500.00", "Invoice 1042, paid", ""})
+	st.Expect(t, rows[2][:6], []string{"2024-01-15", "Home Depot", "Supplies", "Client A, Client B", "-150.25", "'=SUM(A1:A2)"})
+	st.Expect(t, strings.Contains(rows[2][6], "accounts/33/receipt.pdf"), true)
+
+	// Filters work like the ledger listing.
+	w = doJSONRequest(r, "GET", "/api/v3/33/ledger/export?type=expense&year=2024", ``)
+	rows, _ = csv.NewReader(strings.NewReader(w.Body.String())).ReadAll()
+	st.Expect(t, len(rows), 2)
+	st.Expect(t, rows[1][1], "Home Depot")
+
+	w = doJSONRequest(r, "GET", "/api/v3/33/ledger/export?order=LedgerId&sort=ASC&q="+url.QueryEscape(`label:"client b"`), ``)
+	rows, _ = csv.NewReader(strings.NewReader(w.Body.String())).ReadAll()
+	st.Expect(t, len(rows), 2)
+
+	// XLSX
+	w = doJSONRequest(r, "GET", "/api/v3/33/ledger/export?format=xlsx&year=2024", ``)
+	st.Expect(t, w.Code, 200)
+	st.Expect(t, w.HeaderMap["Content-Type"][0], "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+
+	z, err := zip.NewReader(bytes.NewReader(w.Body.Bytes()), int64(w.Body.Len()))
+	st.Expect(t, err, nil)
+
+	sheet := ""
+
+	for _, f := range z.File {
+		if f.Name == "xl/worksheets/sheet1.xml" {
+			rc, _ := f.Open()
+			b, _ := ioutil.ReadAll(rc)
+			rc.Close()
+			sheet = string(b)
+		}
+	}
+
+	st.Expect(t, strings.Count(sheet, "<row "), 3)
+	st.Expect(t, strings.Contains(sheet, `<c r="B2" t="inlineStr"><is><t xml:space="preserve">Jane Wells</t></is></c>`), true)
+	st.Expect(t, strings.Contains(sheet, `<c r="E3" s="2"><v>-150.25</v></c>`), true)
+	st.Expect(t, strings.Contains(sheet, `<c r="A3" s="1"><v>45306</v></c>`), true)
+
+	// Bad requests
+	w = doJSONRequest(r, "GET", "/api/v3/33/ledger/export?format=pdf", ``)
+	st.Expect(t, w.Code, 400)
+	st.Expect(t, w.Body.String(), `{"errors":{"format":"The format must be csv or xlsx."}}`)
+
+	w = doJSONRequest(r, "GET", "/api/v3/33/ledger/export?q="+url.QueryEscape(`amount<abc`), ``)
+	st.Expect(t, w.Code, 400)
+
+	w = doJSONRequest(r, "GET", "/api/v3/33/ledger/export?order=LedgerAmount", ``)
+	st.Expect(t, w.Code, 400)
+}
+
+/* End File */
@@ -0,0 +1,156 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package controllers
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nbio/st"
+
+	"app.skyclerk.com/backend/library/test"
+	"app.skyclerk.com/backend/models"
+)
+
+//
+// TestGetLedgersFilter01 - Filter the ledger with the q= query language.
+//
+func TestGetLedgersFilter01(t *testing.T) {
+	// Start the db connection.
+	db, dbName, _ := models.NewTestDB("")
+	defer models.TestingTearDown(db, dbName)
+
+	// Create controller
+	c := &Controller{}
+	c.SetDB(db)
+
+	file := test.GetRandomFile(33)
+	db.Save(&file)
+
+	rows := []struct {
+		amount   float64
+		contact  string
+		category string
+		catType  string
+		labels   []string
+		date     time.Time
+		addedBy  uint
+	}{
+		{-150.00, "Home Depot", "Travel", "1", []string{"Client A"}, time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC), 1},
+		{-50.00, "Apple Inc.", "Office", "1", []string{"Client A", "Client B"}, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), 2},
+		{500.00, "Acme Corp", "Sales", "2", []string{}, time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC), 1},
+		{-200.00, "Home Depot", "Supplies", "1", []string{"Client B"}, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), 1},
+	}
+
+	for key, row := range rows {
+		l := test.GetRandomLedger(33)
+		l.Amount = row.amount
+		l.Contact = models.Contact{Name: row.contact}
+		l.Category = models.Category{Name: row.category, Type: row.catType}
+		l.Date = row.date
+		l.AddedById = row.addedBy
+		l.Labels = []models.Label{}
+
+		for _, lb := range row.labels {
+			l.Labels = append(l.Labels, models.Label{Name: lb})
+		}
+
+		if key == 0 {
+			l.Files = []models.File{file}
+		}
+
+		db.LedgerCreate(&l)
+	}
+
+	// Someone else's entry with the same label
+	other := test.GetRandomLedger(34)
+	other.Labels = []models.Label{{Name: "Client A"}}
+	db.LedgerCreate(&other)
+
+	// Setup router
+	gin.SetMode("release")
+	gin.DisableConsoleColor()
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("accountId", 33)
+		c.Set("userId", 2)
+	})
+	r.GET("/api/v3/:account/ledger", c.GetLedgers)
+
+	filter := func(q string) []uint {
+		w := doJSONRequest(r, "GET", "/api/v3/33/ledger?order=LedgerId&sort=asc&q="+url.QueryEscape(q), ``)
+		st.Expect(t, w.Code, 200)
+
+		results := []models.Ledger{}
+		json.Unmarshal([]byte(w.Body.String()), &results)
+
+		ids := []uint{}
+
+		for _, row := range results {
+			ids = append(ids, row.Id)
+		}
+
+		return ids
+	}
+
+	// The example from the docs. Entry 1 is Travel.
+	st.Expect(t, filter(`amount<-100 AND label:"client a" AND NOT category:Travel AND date>=2024-01-01`), []uint{})
+	st.Expect(t, filter(`amount<-100 label:"client a"`), []uint{1})
+
+	// Labels with AND, OR and NOT
+	st.Expect(t, filter(`label:"client a" OR label:"Client B"`), []uint{1, 2, 4})
+	st.Expect(t, filter(`label:"client a" AND label:"client b"`), []uint{2})
+	st.Expect(t, filter(`label:"client a" AND NOT label:"client b"`), []uint{1})
+	st.Expect(t, filter(`NOT has:label`), []uint{3})
+	st.Expect(t, filter(`NOT category:Travel`), []uint{2, 3, 4})
+
+	// Amount ranges and dates. A day includes the whole day.
+	st.Expect(t, filter(`amount>=-200 AND amount<=-100`), []uint{1, 4})
+	st.Expect(t, filter(`date<=2024-01-15`), []uint{1, 3})
+	st.Expect(t, filter(`date:2024-02-01`), []uint{2})
+	st.Expect(t, filter(`(type:income OR amount<=-200) AND date>2023-12-31`), []uint{4})
+
+	// Contacts, attachments and who added it
+	st.Expect(t, filter(`contact:home*`), []uint{1, 4})
+	st.Expect(t, filter(`contact!="home depot"`), []uint{2, 3})
+	st.Expect(t, filter(`has:attachment`), []uint{1})
+	st.Expect(t, filter(`created_by:me`), []uint{2})
+	st.Expect(t, filter(`created_by:1 type:expense`), []uint{1, 4})
+
+	// Works with the other filters
+	w := doJSONRequest(r, "GET", "/api/v3/33/ledger?year=2024&q="+url.QueryEscape(`created_by:1`), ``)
+	results := []models.Ledger{}
+	json.Unmarshal([]byte(w.Body.String()), &results)
+	st.Expect(t, len(results), 2)
+
+	// Bad filters
+	errs := map[string]string{
+		`amount<abc`:        `{"errors":{"q":"abc is not an amount."}}`,
+		`date>=2024-13-01`:  `{"errors":{"q":"2024-13-01 is not a date. Use YYYY-MM-DD."}}`,
+		`color:red`:         `{"errors":{"q":"color is not a filter field. Use amount, date, label, category, contact, has, created_by, type or status."}}`,
+		`label<"client a"`:  `{"errors":{"q":"label can only use :, = or !=."}}`,
+		`label:"client a`:   `{"errors":{"q":"The filter has a quote that is not closed."}}`,
+		`(label:x OR has:y`: `{"errors":{"q":"has must be attachment, label, note or split."}}`,
+		`(label:x`:          `{"errors":{"q":"The filter is missing a closing parenthesis."}}`,
+		`label:x AND`:       `{"errors":{"q":"The filter ends too soon."}}`,
+		`amount`:            `{"errors":{"q":"Filter terms look like field:value, amount is missing its value."}}`,
+		`label:x)`:          `{"errors":{"q":"Unexpected ) in the filter."}}`,
+	}
+
+	for q, want := range errs {
+		w := doJSONRequest(r, "GET", "/api/v3/33/ledger?q="+url.QueryEscape(q), ``)
+		st.Expect(t, w.Code, 400)
+		st.Expect(t, w.Body.String(), want)
+	}
+}
+
+/* End File */
@@ -0,0 +1,132 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"app.skyclerk.com/backend/library/realip"
+	"app.skyclerk.com/backend/library/response"
+	"app.skyclerk.com/backend/models"
+)
+
+//
+// GetLedgerHistory - Every revision of a ledger entry, newest first, with the
+// fields that changed in each one. Deleted entries still have history.
+//
+func (t *Controller) GetLedgerHistory(c *gin.Context) {
+	// Set id
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": err})
+		return
+	}
+
+	// Get the revisions
+	revs := t.db.GetLedgerRevisions(uint(c.MustGet("accountId").(int)), uint(id))
+
+	if len(revs) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Ledger entry not found."})
+		return
+	}
+
+	// Return happy.
+	response.Results(c, revs, nil)
+}
+
+//
+// RestoreLedgerRevision - Put a ledger entry back the way it was at an earlier
+// revision. The restore is a new revision so it can be undone too.
+//
+func (t *Controller) RestoreLedgerRevision(c *gin.Context) {
+	// Get user and account
+	userId := uint(c.MustGet("userId").(int))
+	accountId := uint(c.MustGet("accountId").(int))
+
+	// Set ids
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": err})
+		return
+	}
+
+	revId, err := strconv.ParseInt(c.Param("revision"), 10, 32)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": err})
+		return
+	}
+
+	// Get ledger and make sure we have perms to it
+	l, err := t.db.GetLedgerByAccountAndId(accountId, uint(id))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Ledger entry not found."})
+		return
+	}
+
+	// Reconciled and closed entries are locked.
+	if err := t.db.ValidateLedgerUnlocked(l); err != nil {
+		response.RespondError(c, err)
+		return
+	}
+
+	// Get the revision
+	rev, err := t.db.GetLedgerRevisionByAccountAndId(accountId, uint(revId))
+
+	if (err != nil) || (rev.LedgerId != l.Id) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Revision not found."})
+		return
+	}
+
+	// Restore
+	if err := t.db.RestoreLedgerRevision(&l, rev); err != nil {
+		response.RespondError(c, err)
+		return
+	}
+
+	t.db.CreateLedgerRevision(accountId, l.Id, userId, realip.RealIP(c.Request), "restore")
+
+	// Set the ledger type
+	ledgerType := "expense"
+
+	if l.Amount > 0 {
+		ledgerType = "income"
+	}
+
+	// Get the contact name.
+	contactName := l.Contact.Name
+
+	if len(contactName) == 0 {
+		contactName = l.Contact.FirstName + " " + l.Contact.LastName
+	}
+
+	// Add to the activity log
+	t.db.New().Create(&models.Activity{
+		AccountId: accountId,
+		UserId:    userId,
+		Action:    ledgerType,
+		SubAction: "update",
+		Name:      contactName,
+		Amount:    l.Amount,
+		LedgerId:  l.Id,
+	})
+
+	// Fresh pull
+	j, _ := t.db.GetLedgerByAccountAndId(accountId, l.Id)
+
+	// Return happy.
+	response.RespondUpdated(c, j, nil)
+}
+
+/* End File */
@@ -0,0 +1,117 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package controllers
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nbio/st"
+
+	"app.skyclerk.com/backend/library/test"
+	"app.skyclerk.com/backend/models"
+)
+
+//
+// TestLedgerHistory01 - Edit an entry, check the diffs and restore an old revision.
+//
+func TestLedgerHistory01(t *testing.T) {
+	// Start the db connection.
+	db, dbName, _ := models.NewTestDB("")
+	defer models.TestingTearDown(db, dbName)
+
+	// Create controller
+	c := &Controller{}
+	c.SetDB(db)
+
+	// User making the changes
+	user := test.GetRandomUser(33)
+	db.Save(&user)
+
+	// Setup router
+	gin.SetMode("release")
+	gin.DisableConsoleColor()
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("accountId", 33)
+		c.Set("userId", int(user.Id))
+	})
+	r.POST("/api/v3/:account/ledger", c.CreateLedger)
+	r.PUT("/api/v3/:account/ledger/:id", c.UpdateLedger)
+	r.DELETE("/api/v3/:account/ledger/:id", c.DeleteLedger)
+	r.GET("/api/v3/:account/ledger/:id/history", c.GetLedgerHistory)
+	r.POST("/api/v3/:account/ledger/:id/restore/:revision", c.RestoreLedgerRevision)
+
+	// Create and then change the entry.
+	w := doJSONRequest(r, "POST", "/api/v3/33/ledger", `{ "amount": -50.00, "date": "2024-01-05T00:00:00Z", "note": "first", "contact": { "name": "Bob" }, "category": { "name": "Travel", "type": "expense" }, "labels": [{ "name": "trip" }] }`)
+	st.Expect(t, w.Code, 201)
+
+	w = doJSONRequest(r, "PUT", "/api/v3/33/ledger/1", `{ "id": 1, "amount": -75.00, "date": "2024-01-05T00:00:00Z", "note": "second", "contact": { "name": "Sue" }, "category": { "name": "Travel", "type": "expense" } }`)
+	st.Expect(t, w.Code, 200)
+
+	// History is newest first with the changes from the revision before.
+	w = doJSONRequest(r, "GET", "/api/v3/33/ledger/1/history", ``)
+	revs := []models.LedgerRevision{}
+	err := json.Unmarshal([]byte(w.Body.String()), &revs)
+
+	st.Expect(t, err, nil)
+	st.Expect(t, w.Code, 200)
+	st.Expect(t, len(revs), 2)
+	st.Expect(t, revs[0].Action, "update")
+	st.Expect(t, revs[0].UserId, user.Id)
+	st.Expect(t, revs[0].Changes, []models.FieldChange{
+		{Field: "amount", From: "-50.00", To: "-75.00"},
+		{Field: "contact", From: "Bob", To: "Sue"},
+		{Field: "labels", From: "trip", To: ""},
+		{Field: "note", From: "first", To: "second"},
+	})
+	st.Expect(t, revs[1].Action, "create")
+	st.Expect(t, revs[1].Snapshot.Amount, -50.00)
+	st.Expect(t, revs[1].Snapshot.Labels, []string{"trip"})
+
+	// Restore the first revision.
+	w = doJSONRequest(r, "POST", "/api/v3/33/ledger/1/restore/1", ``)
+	st.Expect(t, w.Code, 200)
+
+	l, _ := db.GetLedgerByAccountAndId(33, 1)
+	st.Expect(t, l.Amount, -50.00)
+	st.Expect(t, l.Note, "first")
+	st.Expect(t, l.Contact.Name, "Bob")
+	st.Expect(t, len(l.Labels), 1)
+	st.Expect(t, l.Labels[0].Name, "trip")
+
+	revs = db.GetLedgerRevisions(33, 1)
+	st.Expect(t, len(revs), 3)
+	st.Expect(t, revs[0].Action, "restore")
+	st.Expect(t, len(revs[0].Changes), 4)
+
+	// A revision from another entry.
+	l2 := test.GetRandomLedger(33)
+	db.LedgerCreate(&l2)
+	db.CreateLedgerRevision(33, l2.Id, 0, "", "create")
+
+	w = doJSONRequest(r, "POST", "/api/v3/33/ledger/1/restore/4", ``)
+	st.Expect(t, w.Code, 400)
+	st.Expect(t, w.Body.String(), `{"error":"Revision not found."}`)
+
+	// Deleted entries keep their history.
+	w = doJSONRequest(r, "DELETE", "/api/v3/33/ledger/1", ``)
+	st.Expect(t, w.Code, 204)
+
+	revs = db.GetLedgerRevisions(33, 1)
+	st.Expect(t, len(revs), 4)
+	st.Expect(t, revs[0].Action, "delete")
+	st.Expect(t, len(revs[0].Changes), 0)
+
+	// Other accounts can not see it.
+	st.Expect(t, len(db.GetLedgerRevisions(34, 1)), 0)
+}
+
+/* End File */
@@ -19,6 +19,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/nbio/st"
+	"github.com/tidwall/gjson"
 
 	"app.skyclerk.com/backend/library/helpers"
 	"app.skyclerk.com/backend/library/test"
@@ -604,7 +605,14 @@ func TestGetLedgers07(t *testing.T) {
 	st.Expect(t, w.HeaderMap["X-Limit"][0], "25")
 
 	for key, row := range results {
-		st.Expect(t, row.Contact.Name, "Options Cafe")
+		// Search matches labels too. The random labels include "Options Cafe".
+		found := (row.Contact.Name == "Options Cafe")
+
+		for _, lb := range row.Labels {
+			found = found || (lb.Name == "Options Cafe")
+		}
+
+		st.Expect(t, found, true)
 
 		// Verfiy default Order
 		if key > 0 {
@@ -737,6 +745,120 @@ func TestGetLedger02(t *testing.T) {
 	st.Expect(t, w.Body.String(), `{"error":"Ledger entry not found."}`)
 }
 
+//
+// TestGetLedgerSuggestions01 - Suggestions are ranked by use, recency and amount.
+//
+func TestGetLedgerSuggestions01(t *testing.T) {
+	// Start the db connection.
+	db, dbName, _ := models.NewTestDB("")
+	defer models.TestingTearDown(db, dbName)
+
+	// Create controller
+	c := &Controller{}
+	c.SetDB(db)
+
+	// History for this contact.
+	history := []struct {
+		category string
+		catType  string
+		amount   float64
+		months   int
+		label    string
+	}{
+		{"Car & Truck Expenses", "1", -40.00, 1, "fuel"},
+		{"Car & Truck Expenses", "1", -42.50, 2, "fuel"},
+		{"Car & Truck Expenses", "1", -38.00, 3, ""},
+		{"Meals & Entertainment", "1", -40.00, 24, "road trip"},
+		{"Meals & Entertainment", "1", -40.00, 25, "road trip"},
+		{"Travel", "1", -900.00, 1, "road trip"},
+		{"Refunds", "2", 40.00, 1, ""},
+	}
+
+	for _, row := range history {
+		l := test.GetRandomLedger(33)
+		l.Date = time.Now().AddDate(0, row.months*-1, 0)
+		l.Amount = row.amount
+		l.Contact = models.Contact{Name: "Shell Gas"}
+		l.Category = models.Category{Name: row.category, Type: row.catType}
+		l.Labels = []models.Label{}
+
+		if len(row.label) > 0 {
+			l.Labels = append(l.Labels, models.Label{Name: row.label})
+		}
+
+		db.LedgerCreate(&l)
+	}
+
+	// Same contact name in another account.
+	other := test.GetRandomLedger(34)
+	other.Amount = -40.00
+	other.Contact = models.Contact{Name: "Shell Gas"}
+	db.LedgerCreate(&other)
+
+	// Setup request
+	req, _ := http.NewRequest("GET", "/api/v3/33/ledger/suggest?contact=shell%20gas&amount=-40", nil)
+
+	// Setup writer.
+	w := httptest.NewRecorder()
+	gin.SetMode("release")
+	gin.DisableConsoleColor()
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("accountId", 33)
+		c.Set("userId", 1)
+	})
+	r.GET("/api/v3/:account/ledger/:id", c.GetLedger)
+	r.ServeHTTP(w, req)
+
+	// Grab result and convert to strut
+	result := models.LedgerSuggestions{}
+	err := json.Unmarshal([]byte(w.Body.String()), &result)
+
+	// Test results
+	st.Expect(t, err, nil)
+	st.Expect(t, w.Code, 200)
+	st.Expect(t, len(result.Categories), 3)
+	st.Expect(t, result.Categories[0].Category.Name, "Car & Truck Expenses")
+	st.Expect(t, result.Categories[0].Count, 3)
+	st.Expect(t, result.Categories[0].Score > 0.8, true)
+	st.Expect(t, result.Categories[1].Category.Name, "Meals & Entertainment")
+	st.Expect(t, result.Categories[2].Category.Name, "Travel")
+	st.Expect(t, result.Labels[0].Label.Name, "fuel")
+	st.Expect(t, result.Labels[0].Count, 2)
+	st.Expect(t, result.Labels[1].Label.Name, "road trip")
+
+	// ----------- Test income ---------- //
+
+	req2, _ := http.NewRequest("GET", "/api/v3/33/ledger/suggest?contact=Shell%20Gas&amount=40", nil)
+	w2 := httptest.NewRecorder()
+	r.ServeHTTP(w2, req2)
+
+	result = models.LedgerSuggestions{}
+	json.Unmarshal([]byte(w2.Body.String()), &result)
+	st.Expect(t, len(result.Categories), 1)
+	st.Expect(t, result.Categories[0].Category.Name, "Refunds")
+	st.Expect(t, result.Categories[0].Score, 1.00)
+
+	// ----------- Test unknown contact ---------- //
+
+	req3, _ := http.NewRequest("GET", "/api/v3/33/ledger/suggest?contact=Nobody", nil)
+	w3 := httptest.NewRecorder()
+	r.ServeHTTP(w3, req3)
+
+	st.Expect(t, w3.Code, 200)
+	st.Expect(t, w3.Body.String(), `{"categories":[],"labels":[]}`)
+
+	// ----------- Test bad amount ---------- //
+
+	req4, _ := http.NewRequest("GET", "/api/v3/33/ledger/suggest?contact=Nobody&amount=abc", nil)
+	w4 := httptest.NewRecorder()
+	r.ServeHTTP(w4, req4)
+
+	st.Expect(t, w4.Code, 400)
+	st.Expect(t, w4.Body.String(), `{"errors":{"amount":"The amount field must be a number."}}`)
+}
+
 // -------------- Create Ledger ---------------------- //
 
 //
@@ -1040,6 +1162,92 @@ func TestCreateLedger04(t *testing.T) {
 	st.Expect(t, result1.Category.Name, cat.Name)
 }
 
+//
+// TestCreateLedger05 - Create a ledger entry split across categories.
+//
+func TestCreateLedger05(t *testing.T) {
+	// Start the db connection.
+	db, dbName, _ := models.NewTestDB("")
+	defer models.TestingTearDown(db, dbName)
+
+	// Create controller
+	c := &Controller{}
+	c.SetDB(db)
+
+	// Setup test data
+	user := test.GetRandomUser(109)
+	db.Save(&user)
+
+	// Save random contact
+	con := test.GetRandomContact(33)
+	db.Save(&con)
+
+	// Get JSON
+	postStr := []byte(`{ "amount": -150.25, "date": "2018-08-02T08:18:20Z", "contact": { "name": "` + con.Name + `" }, "splits": [ { "amount": -100.00, "category": { "name": "Office Supplies", "type": "1" }, "labels": [ { "name": "Costco" } ] }, { "amount": -50.25, "note": "Lunch", "category": { "name": "Meals", "type": "1" } } ] }`)
+
+	// Setup request
+	req, _ := http.NewRequest("POST", "/api/v3/33/ledger", bytes.NewBuffer(postStr))
+
+	// Setup writer.
+	w := httptest.NewRecorder()
+	gin.SetMode("release")
+	gin.DisableConsoleColor()
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("accountId", 33)
+		c.Set("userId", 1)
+	})
+	r.POST("/api/v3/33/ledger", c.CreateLedger)
+	r.ServeHTTP(w, req)
+
+	// Check result
+	st.Expect(t, w.Code, 201)
+
+	// Double check the db.
+	result1, err := db.GetLedgerByAccountAndId(uint(33), uint(1))
+	st.Expect(t, err, nil)
+	st.Expect(t, result1.Amount, -150.25)
+	st.Expect(t, result1.Contact.Id, uint(1))
+	st.Expect(t, result1.Category.Name, "Office Supplies")
+	st.Expect(t, len(result1.Splits), 2)
+	st.Expect(t, result1.Splits[0].AccountId, uint(33))
+	st.Expect(t, result1.Splits[0].Amount, -100.00)
+	st.Expect(t, result1.Splits[0].Category.Name, "Office Supplies")
+	st.Expect(t, result1.Splits[0].Category.AccountId, uint(33))
+	st.Expect(t, result1.Splits[0].Labels[0].Name, "Costco")
+	st.Expect(t, result1.Splits[1].Amount, -50.25)
+	st.Expect(t, result1.Splits[1].Note, "Lunch")
+	st.Expect(t, result1.Splits[1].Category.Name, "Meals")
+
+	// Filtering on a category finds entries with a split line in it.
+	r.GET("/api/v3/33/ledger", c.GetLedgers)
+
+	w3 := doJSONRequest(r, "GET", fmt.Sprintf("/api/v3/33/ledger?category_id=%d", result1.Splits[1].Category.Id), ``)
+	st.Expect(t, w3.Code, 200)
+	st.Expect(t, gjson.Get(w3.Body.String(), "#").Int(), int64(1))
+	st.Expect(t, gjson.Get(w3.Body.String(), "0.id").Int(), int64(1))
+
+	w3 = doJSONRequest(r, "GET", "/api/v3/33/ledger?category_id=999", ``)
+	st.Expect(t, w3.Code, 200)
+	st.Expect(t, gjson.Get(w3.Body.String(), "#").Int(), int64(0))
+
+	// Category in use by a split line can not be deleted.
+	err = db.DeleteCategoryByAccountAndId(33, result1.Splits[1].Category.Id)
+	st.Expect(t, err.Error(), "Can not delete category. It is in use by a ledger entry.")
+
+	// ----------- Test bad split total ---------- //
+
+	postStr = []byte(`{ "amount": -150.25, "date": "2018-08-02T08:18:20Z", "contact": { "name": "` + con.Name + `" }, "splits": [ { "amount": -100.00, "category": { "name": "Office Supplies", "type": "1" } }, { "amount": -50.00, "category": { "name": "Meals", "type": "1" } } ] }`)
+
+	req2, _ := http.NewRequest("POST", "/api/v3/33/ledger", bytes.NewBuffer(postStr))
+	w2 := httptest.NewRecorder()
+	r.ServeHTTP(w2, req2)
+
+	st.Expect(t, w2.Code, 400)
+	st.Expect(t, w2.Body.String(), `{"errors":{"splits":"The split amounts must add up to the entry amount."}}`)
+}
+
 // -------------- Update Ledger ---------------------- //
 
 //
@@ -1505,4 +1713,133 @@ func TestGetLedgerPlSummary01(t *testing.T) {
 	// TODO(spicer): better testing. Like add in more filters.
 }
 
+//
+// TestGetLedgersCursor01 - Page the ledger with keyset cursors.
+//
+func TestGetLedgersCursor01(t *testing.T) {
+	// Start the db connection.
+	db, dbName, _ := models.NewTestDB("")
+	defer models.TestingTearDown(db, dbName)
+
+	// Create controller
+	c := &Controller{}
+	c.SetDB(db)
+
+	// 60 entries on three dates so the order has lots of ties.
+	dates := []time.Time{
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	for i := 0; i < 60; i++ {
+		l := test.GetRandomLedger(33)
+		l.Date = dates[i%3]
+		db.LedgerCreate(&l)
+	}
+
+	// Different account
+	other := test.GetRandomLedger(34)
+	db.LedgerCreate(&other)
+
+	// Setup router
+	gin.SetMode("release")
+	gin.DisableConsoleColor()
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("accountId", 33)
+		c.Set("userId", 1)
+	})
+	r.GET("/api/v3/:account/ledger", c.GetLedgers)
+
+	// Walk every page, adding a new entry to the top after the first page.
+	seen := map[uint]bool{}
+	pages := 0
+	cursor := ""
+	last := models.Ledger{}
+
+	for {
+		w := doJSONRequest(r, "GET", "/api/v3/33/ledger?cursor="+cursor, ``)
+		st.Expect(t, w.Code, 200)
+
+		results := []models.Ledger{}
+		json.Unmarshal([]byte(w.Body.String()), &results)
+
+		// Counts are skipped unless asked for.
+		st.Expect(t, len(w.HeaderMap["X-No-Limit-Count"]), 0)
+
+		for _, row := range results {
+			st.Expect(t, seen[row.Id], false)
+			st.Expect(t, row.AccountId, uint(33))
+			seen[row.Id] = true
+
+			// Newest first, ties by id.
+			if last.Id > 0 {
+				st.Expect(t, row.Date.After(last.Date), false)
+
+				if row.Date.Equal(last.Date) {
+					st.Expect(t, row.Id < last.Id, true)
+				}
+			}
+
+			last = row
+		}
+
+		pages++
+
+		if pages == 1 {
+			l := test.GetRandomLedger(33)
+			l.Date = time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+			db.LedgerCreate(&l)
+		}
+
+		if w.HeaderMap["X-Last-Page"][0] == "true" {
+			st.Expect(t, len(w.HeaderMap["X-Next-Cursor"]), 0)
+			break
+		}
+
+		st.Expect(t, len(results), 25)
+		cursor = w.HeaderMap["X-Next-Cursor"][0]
+	}
+
+	// Nothing skipped or repeated. The entry added mid scroll is above the cursor.
+	st.Expect(t, pages, 3)
+	st.Expect(t, len(seen), 60)
+
+	// Oldest first with a count.
+	w := doJSONRequest(r, "GET", "/api/v3/33/ledger?cursor=&sort=ASC&order=LedgerId&count=true", ``)
+	results := []models.Ledger{}
+	json.Unmarshal([]byte(w.Body.String()), &results)
+	st.Expect(t, w.HeaderMap["X-No-Limit-Count"][0], "61")
+	st.Expect(t, w.HeaderMap["X-Last-Page"][0], "false")
+	st.Expect(t, results[0].Id, uint(1))
+
+	w = doJSONRequest(r, "GET", "/api/v3/33/ledger?sort=ASC&order=LedgerId&cursor="+w.HeaderMap["X-Next-Cursor"][0], ``)
+	json.Unmarshal([]byte(w.Body.String()), &results)
+	st.Expect(t, results[0].Id, uint(26))
+
+	// A cursor only works with its order.
+	w = doJSONRequest(r, "GET", "/api/v3/33/ledger?cursor="+cursor, ``)
+	st.Expect(t, w.Code, 200)
+
+	w = doJSONRequest(r, "GET", "/api/v3/33/ledger?sort=ASC&cursor="+cursor, ``)
+	st.Expect(t, w.Code, 400)
+	st.Expect(t, w.Body.String(), `{"errors":{"cursor":"The cursor is not valid."}}`)
+
+	w = doJSONRequest(r, "GET", "/api/v3/33/ledger?cursor=abc", ``)
+	st.Expect(t, w.Code, 400)
+
+	// Paging by page can skip the count too.
+	w = doJSONRequest(r, "GET", "/api/v3/33/ledger?page=3&count=false", ``)
+	json.Unmarshal([]byte(w.Body.String()), &results)
+	st.Expect(t, len(results), 11)
+	st.Expect(t, w.HeaderMap["X-Offset"][0], "50")
+	st.Expect(t, w.HeaderMap["X-Last-Page"][0], "true")
+	st.Expect(t, len(w.HeaderMap["X-No-Limit-Count"]), 0)
+
+	w = doJSONRequest(r, "GET", "/api/v3/33/ledger?page=2&count=false", ``)
+	st.Expect(t, w.HeaderMap["X-Last-Page"][0], "false")
+}
+
 /* End File */
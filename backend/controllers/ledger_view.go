@@ -0,0 +1,199 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"app.skyclerk.com/backend/library/response"
+	"app.skyclerk.com/backend/models"
+)
+
+//
+// GetLedgerViews - Return the shared views and the ones this user made.
+//
+func (t *Controller) GetLedgerViews(c *gin.Context) {
+	response.Results(c, t.db.GetLedgerViewsByAccountAndUser(uint(c.MustGet("accountId").(int)), uint(c.MustGet("userId").(int))), nil)
+}
+
+//
+// GetLedgerView by id
+//
+func (t *Controller) GetLedgerView(c *gin.Context) {
+	// Set id
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": err})
+		return
+	}
+
+	// Get view and make sure we have perms to it
+	v, err := t.db.GetLedgerViewByAccountAndId(uint(c.MustGet("accountId").(int)), uint(c.MustGet("userId").(int)), uint(id))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "View not found."})
+		return
+	}
+
+	// Return happy.
+	response.Results(c, v, nil)
+}
+
+//
+// CreateLedgerView - Save a set of ledger filters.
+//
+func (t *Controller) CreateLedgerView(c *gin.Context) {
+	// Setup LedgerView obj
+	o := models.LedgerView{}
+
+	// Here we parse the JSON sent in, assign it to a struct, set validation errors if any.
+	if t.ValidateRequest(c, &o, "create") != nil {
+		return
+	}
+
+	// Make sure the AccountId and UserId are correct.
+	o.Id = 0
+	o.AccountId = uint(c.MustGet("accountId").(int))
+	o.UserId = uint(c.MustGet("userId").(int))
+
+	// Create view
+	if err := t.db.LedgerViewCreate(&o); err != nil {
+		response.RespondError(c, err)
+		return
+	}
+
+	// Return happy.
+	response.RespondCreated(c, o, nil)
+}
+
+//
+// UpdateLedgerView - Update a view. Shared views can be changed by anyone in
+// the account.
+//
+func (t *Controller) UpdateLedgerView(c *gin.Context) {
+	// Get ID from URL
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": err})
+		return
+	}
+
+	// Get view and make sure we have perms to it
+	org, err := t.db.GetLedgerViewByAccountAndId(uint(c.MustGet("accountId").(int)), uint(c.MustGet("userId").(int)), uint(id))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "View not found."})
+		return
+	}
+
+	// Setup LedgerView obj
+	o := models.LedgerView{}
+
+	// Here we parse the JSON sent in, assign it to a struct, set validation errors if any.
+	if t.ValidateRequest(c, &o, "update") != nil {
+		return
+	}
+
+	// Make sure the Id, AccountId, UserId and CreatedAt are correct.
+	o.Id = org.Id
+	o.AccountId = org.AccountId
+	o.UserId = org.UserId
+	o.CreatedAt = org.CreatedAt
+
+	// Update view
+	if err := t.db.LedgerViewUpdate(&o); err != nil {
+		response.RespondError(c, err)
+		return
+	}
+
+	// Return happy.
+	response.RespondUpdated(c, o, nil)
+}
+
+//
+// DeleteLedgerView - Delete a view.
+//
+func (t *Controller) DeleteLedgerView(c *gin.Context) {
+	// Get Id
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": err})
+		return
+	}
+
+	// AccountId.
+	accountId := uint(c.MustGet("accountId").(int))
+
+	// First we make sure this is a view we have access to.
+	_, err = t.db.GetLedgerViewByAccountAndId(accountId, uint(c.MustGet("userId").(int)), uint(id))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "View not found."})
+		return
+	}
+
+	// Delete view
+	if err := t.db.DeleteLedgerViewByAccountAndId(accountId, uint(id)); err != nil {
+		response.RespondError(c, err)
+		return
+	}
+
+	// Return happy.
+	response.RespondDeleted(c, nil)
+}
+
+// -------------- Private Helper Functions ------------------ //
+
+//
+// applyLedgerView - When ?view_id= is passed copy the view's filters and sort
+// into the query string so everything that reads the ledger filters sees
+// them. Anything set in the URL wins over the view.
+//
+func (t *Controller) applyLedgerView(c *gin.Context) (bool, error) {
+	if len(c.DefaultQuery("view_id", "")) == 0 {
+		return false, nil
+	}
+
+	id, err := strconv.Atoi(c.DefaultQuery("view_id", ""))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "View not found."})
+		return false, errors.New("View not found.")
+	}
+
+	v, err := t.db.GetLedgerViewByAccountAndId(uint(c.MustGet("accountId").(int)), uint(c.GetInt("userId")), uint(id))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "View not found."})
+		return false, err
+	}
+
+	query := c.Request.URL.Query()
+	filters := v.Filters
+	filters["order"] = v.Order
+	filters["sort"] = v.Sort
+
+	for key, row := range filters {
+		if len(query.Get(key)) == 0 {
+			query.Set(key, row)
+		}
+	}
+
+	c.Request.URL.RawQuery = query.Encode()
+
+	return true, nil
+}
+
+/* End File */
@@ -0,0 +1,259 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nbio/st"
+
+	"app.skyclerk.com/backend/library/test"
+	"app.skyclerk.com/backend/models"
+)
+
+//
+// TestLedgerViews01 - Create, share, update and delete saved views.
+//
+func TestLedgerViews01(t *testing.T) {
+	// Start the db connection.
+	db, dbName, _ := models.NewTestDB("")
+	defer models.TestingTearDown(db, dbName)
+
+	// Create controller
+	c := &Controller{}
+	c.SetDB(db)
+
+	// Setup router, one per user.
+	gin.SetMode("release")
+	gin.DisableConsoleColor()
+
+	routers := map[int]*gin.Engine{}
+
+	for _, userId := range []int{1, 2} {
+		id := userId
+		r := gin.New()
+		r.Use(func(c *gin.Context) {
+			c.Set("accountId", 33)
+			c.Set("userId", id)
+		})
+		r.GET("/api/v3/:account/views", c.GetLedgerViews)
+		r.GET("/api/v3/:account/views/:id", c.GetLedgerView)
+		r.POST("/api/v3/:account/views", c.CreateLedgerView)
+		r.PUT("/api/v3/:account/views/:id", c.UpdateLedgerView)
+		r.DELETE("/api/v3/:account/views/:id", c.DeleteLedgerView)
+		routers[id] = r
+	}
+
+	// Validation
+	errs := map[string]string{
+		`{ "name": "" }`: `{"errors":{"name":"The name field is required."}}`,
+		`{ "name": "Bad", "filters": { "color": "red" } }`:    `{"errors":{"filters":"color is not a ledger filter. Use type, category_id, year, start_date, end_date, status, label_ids, search, q."}}`,
+		`{ "name": "Bad", "filters": { "q": "amount<abc" } }`: `{"errors":{"filters":"abc is not an amount."}}`,
+		`{ "name": "Bad", "order": "LedgerAmount" }`:          `{"errors":{"order":"The order field must be LedgerDate or LedgerId."}}`,
+		`{ "name": "Bad", "sort": "UP" }`:                     `{"errors":{"sort":"The sort field must be ASC or DESC."}}`,
+		`{ "name": "Bad", "columns": "date,amount,color" }`:   `{"errors":{"columns":"color is not a ledger column. Use date, contact, category, labels, note, amount, status, files, source."}}`,
+	}
+
+	for body, want := range errs {
+		w := doJSONRequest(routers[1], "POST", "/api/v3/33/views", body)
+		st.Expect(t, w.Code, 400)
+		st.Expect(t, w.Body.String(), want)
+	}
+
+	// A private view and a shared view
+	w := doJSONRequest(routers[1], "POST", "/api/v3/33/views", `{ "name": "Big Expenses", "filters": { "type": "expense", "q": "amount<-100", "search": "" }, "sort": "asc", "columns": "date, contact, amount" }`)
+	st.Expect(t, w.Code, 201)
+
+	private := models.LedgerView{}
+	json.Unmarshal([]byte(w.Body.String()), &private)
+	st.Expect(t, private.UserId, uint(1))
+	st.Expect(t, private.Shared, false)
+	st.Expect(t, private.Order, "LedgerDate")
+	st.Expect(t, private.Sort, "ASC")
+	st.Expect(t, private.Columns, "date,contact,amount")
+	st.Expect(t, private.Filters, map[string]string{"type": "expense", "q": "amount<-100"})
+
+	w = doJSONRequest(routers[1], "POST", "/api/v3/33/views", `{ "name": "Income", "shared": true, "filters": { "type": "income" } }`)
+	st.Expect(t, w.Code, 201)
+
+	shared := models.LedgerView{}
+	json.Unmarshal([]byte(w.Body.String()), &shared)
+
+	// Names are unique within what a user can see.
+	w = doJSONRequest(routers[1], "POST", "/api/v3/33/views", `{ "name": "big expenses" }`)
+	st.Expect(t, w.Code, 400)
+	st.Expect(t, w.Body.String(), `{"errors":{"name":"A view with this name already exists."}}`)
+
+	w = doJSONRequest(routers[2], "POST", "/api/v3/33/views", `{ "name": "Big Expenses" }`)
+	st.Expect(t, w.Code, 201)
+
+	// User 1 sees both of theirs, shared first. User 2 sees the shared one and their own.
+	views := []models.LedgerView{}
+	w = doJSONRequest(routers[1], "GET", "/api/v3/33/views", ``)
+	json.Unmarshal([]byte(w.Body.String()), &views)
+	st.Expect(t, len(views), 2)
+	st.Expect(t, views[0].Name, "Income")
+	st.Expect(t, views[1].Name, "Big Expenses")
+	st.Expect(t, views[1].Filters["q"], "amount<-100")
+
+	w = doJSONRequest(routers[2], "GET", "/api/v3/33/views", ``)
+	json.Unmarshal([]byte(w.Body.String()), &views)
+	st.Expect(t, len(views), 2)
+	st.Expect(t, views[0].Name, "Income")
+	st.Expect(t, views[1].UserId, uint(2))
+
+	// Private views are hidden from everyone else.
+	w = doJSONRequest(routers[2], "GET", fmt.Sprintf("/api/v3/33/views/%d", private.Id), ``)
+	st.Expect(t, w.Code, 400)
+	st.Expect(t, w.Body.String(), `{"error":"View not found."}`)
+
+	w = doJSONRequest(routers[2], "PUT", fmt.Sprintf("/api/v3/33/views/%d", private.Id), `{ "name": "Mine Now" }`)
+	st.Expect(t, w.Code, 400)
+
+	w = doJSONRequest(routers[2], "DELETE", fmt.Sprintf("/api/v3/33/views/%d", private.Id), ``)
+	st.Expect(t, w.Code, 400)
+
+	// Shared views can be changed by anyone, but the owner stays the same.
+	w = doJSONRequest(routers[2], "PUT", fmt.Sprintf("/api/v3/33/views/%d", shared.Id), `{ "name": "All Income", "shared": true, "filters": { "type": "income", "year": "2024" }, "order": "LedgerId" }`)
+	st.Expect(t, w.Code, 200)
+
+	w = doJSONRequest(routers[1], "GET", fmt.Sprintf("/api/v3/33/views/%d", shared.Id), ``)
+	st.Expect(t, w.Code, 200)
+
+	got := models.LedgerView{}
+	json.Unmarshal([]byte(w.Body.String()), &got)
+	st.Expect(t, got.Name, "All Income")
+	st.Expect(t, got.UserId, uint(1))
+	st.Expect(t, got.Order, "LedgerId")
+	st.Expect(t, got.Filters, map[string]string{"type": "income", "year": "2024"})
+
+	// Delete
+	w = doJSONRequest(routers[1], "DELETE", fmt.Sprintf("/api/v3/33/views/%d", private.Id), ``)
+	st.Expect(t, w.Code, 204)
+
+	w = doJSONRequest(routers[1], "GET", fmt.Sprintf("/api/v3/33/views/%d", private.Id), ``)
+	st.Expect(t, w.Code, 400)
+}
+
+//
+// TestLedgerViews02 - Apply a saved view to the ledger listing and summaries.
+//
+func TestLedgerViews02(t *testing.T) {
+	// Start the db connection.
+	db, dbName, _ := models.NewTestDB("")
+	defer models.TestingTearDown(db, dbName)
+
+	// Create controller
+	c := &Controller{}
+	c.SetDB(db)
+
+	rows := []struct {
+		amount   float64
+		category string
+		catType  string
+		label    string
+		date     time.Time
+	}{
+		{-150.00, "Travel", "1", "Client A", time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)},
+		{-50.00, "Office", "1", "Client A", time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{-300.00, "Travel", "1", "Client B", time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)},
+		{900.00, "Sales", "2", "Client A", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	ids := []uint{}
+
+	for _, row := range rows {
+		l := test.GetRandomLedger(33)
+		l.Amount = row.amount
+		l.Contact = models.Contact{Name: "Home Depot"}
+		l.Category = models.Category{Name: row.category, Type: row.catType}
+		l.Labels = []models.Label{{Name: row.label}}
+		l.Date = row.date
+		db.LedgerCreate(&l)
+		ids = append(ids, l.Id)
+	}
+
+	// Big expenses, oldest first.
+	v := models.LedgerView{AccountId: 33, UserId: 1, Name: "Big Expenses", Filters: map[string]string{"type": "expense", "q": "amount<-100"}, Sort: "ASC"}
+	db.LedgerViewCreate(&v)
+
+	// Someone else's private view
+	p := models.LedgerView{AccountId: 33, UserId: 2, Name: "Private", Filters: map[string]string{"type": "income"}}
+	db.LedgerViewCreate(&p)
+
+	// Setup router
+	gin.SetMode("release")
+	gin.DisableConsoleColor()
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("accountId", 33)
+		c.Set("userId", 1)
+	})
+	r.GET("/api/v3/:account/ledger", c.GetLedgers)
+	r.GET("/api/v3/:account/ledger-summary", c.GetLedgerSummary)
+	r.GET("/api/v3/:account/ledger-pl-summary", c.GetLedgerPlSummary)
+
+	// Listing
+	w := doJSONRequest(r, "GET", fmt.Sprintf("/api/v3/33/ledger?view_id=%d", v.Id), ``)
+	st.Expect(t, w.Code, 200)
+
+	results := []models.Ledger{}
+	json.Unmarshal([]byte(w.Body.String()), &results)
+	st.Expect(t, len(results), 2)
+	st.Expect(t, results[0].Id, ids[2])
+	st.Expect(t, results[1].Id, ids[0])
+
+	// The url wins over the view.
+	w = doJSONRequest(r, "GET", fmt.Sprintf("/api/v3/33/ledger?view_id=%d&sort=DESC&year=2024", v.Id), ``)
+	json.Unmarshal([]byte(w.Body.String()), &results)
+	st.Expect(t, len(results), 1)
+	st.Expect(t, results[0].Id, ids[0])
+
+	// P&L summary
+	w = doJSONRequest(r, "GET", fmt.Sprintf("/api/v3/33/ledger-pl-summary?view_id=%d", v.Id), ``)
+	st.Expect(t, w.Code, 200)
+
+	pl := map[string]float64{}
+	json.Unmarshal([]byte(w.Body.String()), &pl)
+	st.Expect(t, pl["income"], 0.00)
+	st.Expect(t, math.Abs(pl["expense"]+450.00) < 0.02, true)
+
+	// Summary counts match the listing.
+	w = doJSONRequest(r, "GET", fmt.Sprintf("/api/v3/33/ledger-summary?view_id=%d", v.Id), ``)
+	st.Expect(t, w.Code, 200)
+
+	ls := LedgerSummary{}
+	json.Unmarshal([]byte(w.Body.String()), &ls)
+	st.Expect(t, len(ls.Categories), 1)
+	st.Expect(t, ls.Categories[0].Name, "Travel")
+	st.Expect(t, ls.Categories[0].Count, 2)
+	st.Expect(t, len(ls.Labels), 2)
+	st.Expect(t, len(ls.Years), 2)
+
+	// Without a view the summary covers everything.
+	w = doJSONRequest(r, "GET", "/api/v3/33/ledger-summary", ``)
+	json.Unmarshal([]byte(w.Body.String()), &ls)
+	st.Expect(t, len(ls.Categories), 3)
+
+	// Unknown and private views
+	for _, id := range []string{"abc", "999", fmt.Sprintf("%d", p.Id)} {
+		for _, path := range []string{"ledger", "ledger-summary", "ledger-pl-summary"} {
+			w = doJSONRequest(r, "GET", "/api/v3/33/"+path+"?view_id="+id, ``)
+			st.Expect(t, w.Code, 400)
+			st.Expect(t, w.Body.String(), `{"error":"View not found."}`)
+		}
+	}
+}
+
+/* End File */
@@ -0,0 +1,161 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package controllers
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tidwall/gjson"
+
+	"app.skyclerk.com/backend/library/response"
+	"app.skyclerk.com/backend/models"
+)
+
+// ClosedPeriods struct - The closed through date and the period locks.
+type ClosedPeriods struct {
+	BooksClosedThrough time.Time           `json:"books_closed_through"`
+	Locks              []models.PeriodLock `json:"locks"`
+}
+
+//
+// GetClosedPeriods - Return the closed through date and the period locks.
+//
+func (t *Controller) GetClosedPeriods(c *gin.Context) {
+	// Get account
+	account, err := t.db.GetAccountById(uint(c.MustGet("accountId").(int)))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Account not found."})
+		return
+	}
+
+	// Get the locks
+	locks := []models.PeriodLock{}
+	t.db.New().Where("account_id = ?", account.Id).Order("start_date DESC").Find(&locks)
+
+	// Return happy.
+	response.Results(c, ClosedPeriods{BooksClosedThrough: account.BooksClosedThrough, Locks: locks}, nil)
+}
+
+//
+// UpdateBooksClosedThrough - Close the books through a date. Post in
+// {"date": "2024-03-31"}. Moving the date back (or posting an empty date)
+// reopens the books which only the account owner can do.
+//
+func (t *Controller) UpdateBooksClosedThrough(c *gin.Context) {
+	// Get user
+	userId := c.MustGet("userId").(int)
+
+	// Get account
+	account, err := t.db.GetAccountById(uint(c.MustGet("accountId").(int)))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Account not found."})
+		return
+	}
+
+	// Read the JSON POSTed in.
+	body, _ := ioutil.ReadAll(c.Request.Body)
+	date := gjson.GetBytes(body, "date").String()
+	through := time.Time{}
+
+	if len(date) > 0 {
+		through, err = time.Parse("2006-01-02", date)
+
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"errors": gin.H{"date": "The date field must be YYYY-MM-DD."}})
+			return
+		}
+	}
+
+	// Only the owner can reopen.
+	if through.Before(account.BooksClosedThrough) && (account.OwnerId != uint(userId)) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "You must be the account owner to reopen the books."})
+		return
+	}
+
+	// Close the books
+	t.db.SetBooksClosedThrough(&account, uint(userId), through)
+
+	// Return happy.
+	response.RespondUpdated(c, gin.H{"books_closed_through": account.BooksClosedThrough}, nil)
+}
+
+//
+// CreatePeriodLock - Close a range of dates.
+//
+func (t *Controller) CreatePeriodLock(c *gin.Context) {
+	// Setup PeriodLock obj
+	o := models.PeriodLock{}
+
+	// Here we parse the JSON sent in, assign it to a struct, set validation errors if any.
+	if t.ValidateRequest(c, &o, "create") != nil {
+		return
+	}
+
+	// Make sure the AccountId is correct.
+	o.Id = 0
+	o.AccountId = uint(c.MustGet("accountId").(int))
+	o.UserId = uint(c.MustGet("userId").(int))
+
+	// Create lock
+	t.db.PeriodLockCreate(&o)
+
+	// Return happy.
+	response.RespondCreated(c, o, nil)
+}
+
+//
+// ReopenPeriodLock - Remove a period lock. Only the account owner can do this.
+//
+func (t *Controller) ReopenPeriodLock(c *gin.Context) {
+	// Get user
+	userId := c.MustGet("userId").(int)
+
+	// Set id
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": err})
+		return
+	}
+
+	// Get account
+	account, err := t.db.GetAccountById(uint(c.MustGet("accountId").(int)))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Account not found."})
+		return
+	}
+
+	// We must be the account owner to proceed
+	if account.OwnerId != uint(userId) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "You must be the account owner to reopen a period."})
+		return
+	}
+
+	// Get the lock and make sure we have perms to it
+	p, err := t.db.GetPeriodLockByAccountAndId(account.Id, uint(id))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Period not found."})
+		return
+	}
+
+	// Reopen
+	err = t.db.ReopenPeriodLock(p, uint(userId))
+
+	// Return happy.
+	response.RespondDeleted(c, err)
+}
+
+/* End File */
@@ -0,0 +1,134 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nbio/st"
+
+	"app.skyclerk.com/backend/library/test"
+	"app.skyclerk.com/backend/models"
+)
+
+//
+// TestClosedPeriods01 - Close the books and make sure entries in closed periods are locked.
+//
+func TestClosedPeriods01(t *testing.T) {
+	// Start the db connection.
+	db, dbName, _ := models.NewTestDB("")
+	defer models.TestingTearDown(db, dbName)
+
+	// Create controller
+	c := &Controller{}
+	c.SetDB(db)
+
+	// Account owned by user 1
+	account := test.GetRandomAccount(33)
+	account.OwnerId = 1
+	db.Save(&account)
+
+	// An entry from February
+	l := test.GetRandomLedger(33)
+	l.Date = time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	db.LedgerCreate(&l)
+
+	// Setup router. User 2 is not the owner.
+	userId := 2
+	gin.SetMode("release")
+	gin.DisableConsoleColor()
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("accountId", 33)
+		c.Set("userId", userId)
+	})
+	r.PUT("/api/v3/:account/periods/closed-through", c.UpdateBooksClosedThrough)
+	r.POST("/api/v3/:account/periods", c.CreatePeriodLock)
+	r.DELETE("/api/v3/:account/periods/:id", c.ReopenPeriodLock)
+	r.POST("/api/v3/:account/ledger", c.CreateLedger)
+	r.PUT("/api/v3/:account/ledger/:id", c.UpdateLedger)
+	r.DELETE("/api/v3/:account/ledger/:id", c.DeleteLedger)
+
+	// Anyone can close the books.
+	w := doJSONRequest(r, "PUT", "/api/v3/33/periods/closed-through", `{ "date": "2024-03-31" }`)
+	st.Expect(t, w.Code, 200)
+	st.Expect(t, w.Body.String(), `{"books_closed_through":"2024-03-31T00:00:00Z"}`)
+
+	// Lock June too.
+	w = doJSONRequest(r, "POST", "/api/v3/33/periods", `{ "start_date": "2024-06-01T00:00:00Z", "end_date": "2024-06-30T00:00:00Z", "note": "Q2 filed" }`)
+	st.Expect(t, w.Code, 201)
+
+	// Closed entries can not be changed.
+	w = doJSONRequest(r, "PUT", "/api/v3/33/ledger/1", `{ "id": 1, "amount": -55.00, "date": "2024-05-05T00:00:00Z", "contact": { "name": "Bob" }, "category": { "name": "Travel", "type": "expense" } }`)
+	st.Expect(t, w.Code, 400)
+	st.Expect(t, w.Body.String(), `{"error":"The books are closed through March 31, 2024. Entries dated on or before then can not be added, changed or deleted."}`)
+
+	w = doJSONRequest(r, "DELETE", "/api/v3/33/ledger/1", ``)
+	st.Expect(t, w.Code, 400)
+
+	// New entries can not be added to a closed period.
+	w = doJSONRequest(r, "POST", "/api/v3/33/ledger", `{ "amount": -55.00, "date": "2024-03-15T00:00:00Z", "contact": { "name": "Bob" }, "category": { "name": "Travel", "type": "expense" } }`)
+	st.Expect(t, w.Code, 400)
+	st.Expect(t, w.Body.String(), `{"errors":{"date":"The books are closed through March 31, 2024. Entries dated on or before then can not be added, changed or deleted."}}`)
+
+	w = doJSONRequest(r, "POST", "/api/v3/33/ledger", `{ "amount": -55.00, "date": "2024-06-15T00:00:00Z", "contact": { "name": "Bob" }, "category": { "name": "Travel", "type": "expense" } }`)
+	st.Expect(t, w.Code, 400)
+	st.Expect(t, w.Body.String(), `{"errors":{"date":"The period June 1, 2024 to June 30, 2024 is closed. Entries in it can not be added, changed or deleted."}}`)
+
+	// Entries we can not reject are moved to the first open day.
+	sl := models.Ledger{AccountId: 33, Date: time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC), Note: "Receipt"}
+	st.Expect(t, db.MoveLedgerToOpenPeriod(&sl), true)
+	st.Expect(t, sl.Date.Format("2006-01-02"), "2024-04-01")
+	st.Expect(t, sl.Note, "Receipt\n\nOriginally dated March 10, 2024. Moved because the books are closed.")
+
+	sl = models.Ledger{AccountId: 33, Date: time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC)}
+	st.Expect(t, db.MoveLedgerToOpenPeriod(&sl), true)
+	st.Expect(t, sl.Date.Format("2006-01-02"), "2024-07-01")
+
+	sl = models.Ledger{AccountId: 33, Date: time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)}
+	st.Expect(t, db.MoveLedgerToOpenPeriod(&sl), false)
+
+	// Only the owner can reopen.
+	w = doJSONRequest(r, "PUT", "/api/v3/33/periods/closed-through", `{ "date": "2024-01-31" }`)
+	st.Expect(t, w.Code, 400)
+	st.Expect(t, w.Body.String(), `{"error":"You must be the account owner to reopen the books."}`)
+
+	w = doJSONRequest(r, "DELETE", "/api/v3/33/periods/1", ``)
+	st.Expect(t, w.Code, 400)
+	st.Expect(t, w.Body.String(), `{"error":"You must be the account owner to reopen a period."}`)
+
+	userId = 1
+
+	w = doJSONRequest(r, "PUT", "/api/v3/33/periods/closed-through", `{ "date": "2024-01-31" }`)
+	st.Expect(t, w.Code, 200)
+
+	w = doJSONRequest(r, "DELETE", "/api/v3/33/periods/1", ``)
+	st.Expect(t, w.Code, 204)
+
+	// Open again
+	w = doJSONRequest(r, "DELETE", "/api/v3/33/ledger/1", ``)
+	st.Expect(t, w.Code, 204)
+
+	// Reopening is logged.
+	activities := []models.Activity{}
+	db.New().Where("action = ?", "period").Order("id ASC").Find(&activities)
+
+	st.Expect(t, len(activities), 4)
+	st.Expect(t, activities[0].SubAction, "create")
+	st.Expect(t, activities[0].UserId, uint(2))
+	st.Expect(t, activities[2].SubAction, "reopen")
+	st.Expect(t, activities[2].UserId, uint(1))
+	st.Expect(t, activities[2].Name, "dates after 2024-01-31")
+	st.Expect(t, activities[3].SubAction, "reopen")
+	st.Expect(t, activities[3].Name, "2024-06-01 to 2024-06-30")
+}
+
+/* End File */
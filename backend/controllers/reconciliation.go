@@ -0,0 +1,252 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package controllers
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tidwall/gjson"
+
+	"app.skyclerk.com/backend/library/request"
+	"app.skyclerk.com/backend/library/response"
+	"app.skyclerk.com/backend/models"
+)
+
+//
+// GetReconciliations - Return a list of reconciliations. Newest statement first.
+//
+func (t *Controller) GetReconciliations(c *gin.Context) {
+	// Place to store the results.
+	var results = []models.Reconciliation{}
+
+	// Get account
+	accountId := c.MustGet("accountId").(int)
+
+	// Get limits and pages
+	page, limit, _ := request.GetSetPagingParms(c)
+
+	// Set the query parms
+	params := models.QueryParam{
+		Order:            c.DefaultQuery("order", "statement_date"),
+		Sort:             c.DefaultQuery("sort", "DESC"),
+		Limit:            limit,
+		Page:             page,
+		AllowedOrderCols: []string{"id", "statement_date"},
+		Wheres: []models.KeyValue{
+			{Key: "account_id", Compare: "=", ValueInt: accountId},
+		},
+	}
+
+	// Run the query
+	meta, err := t.db.QueryMeta(&results, params)
+
+	// Add in the totals
+	for key, row := range results {
+		results[key], _ = t.db.GetReconciliationByAccountAndId(uint(accountId), row.Id)
+	}
+
+	// Return json based on if this was a good result or not.
+	response.ResultsMeta(c, results, err, meta)
+}
+
+//
+// GetReconciliation by id
+//
+func (t *Controller) GetReconciliation(c *gin.Context) {
+	// Get reconciliation. JSON error set in function.
+	r, err := t.getReconciliation(c)
+
+	if err != nil {
+		return
+	}
+
+	// Return happy.
+	response.Results(c, r, nil)
+}
+
+//
+// CreateReconciliation - Start reconciling a bank statement.
+//
+func (t *Controller) CreateReconciliation(c *gin.Context) {
+	// Setup Reconciliation obj
+	o := models.Reconciliation{}
+
+	// Here we parse the JSON sent in, assign it to a struct, set validation errors if any.
+	if t.ValidateRequest(c, &o, "create") != nil {
+		return
+	}
+
+	// Make sure the AccountId is correct.
+	o.Id = 0
+	o.AccountId = uint(c.MustGet("accountId").(int))
+	o.UserId = uint(c.MustGet("userId").(int))
+
+	// Create reconciliation
+	t.db.ReconciliationCreate(&o)
+
+	// Fresh pull
+	r, err := t.db.GetReconciliationByAccountAndId(o.AccountId, o.Id)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "System error. Please contact help@skyclerk.com."})
+		return
+	}
+
+	// Return happy.
+	response.RespondCreated(c, r, nil)
+}
+
+//
+// UpdateReconciliation - Change the statement date or balances on an open reconciliation.
+//
+func (t *Controller) UpdateReconciliation(c *gin.Context) {
+	// Get reconciliation. JSON error set in function.
+	org, err := t.getReconciliation(c)
+
+	if err != nil {
+		return
+	}
+
+	// Setup Reconciliation obj
+	o := models.Reconciliation{}
+
+	// Here we parse the JSON sent in, assign it to a struct, set validation errors if any.
+	if t.ValidateRequest(c, &o, "update") != nil {
+		return
+	}
+
+	// Only the statement can change.
+	org.StatementDate = o.StatementDate
+	org.StartingBalance = o.StartingBalance
+	org.EndingBalance = o.EndingBalance
+
+	// Update reconciliation
+	err = t.db.ReconciliationUpdate(&org)
+
+	// Return happy.
+	response.RespondUpdated(c, org, err)
+}
+
+//
+// DeleteReconciliation - Delete an open reconciliation.
+//
+func (t *Controller) DeleteReconciliation(c *gin.Context) {
+	// Get reconciliation. JSON error set in function.
+	r, err := t.getReconciliation(c)
+
+	if err != nil {
+		return
+	}
+
+	// Delete reconciliation
+	err = t.db.DeleteReconciliationByAccountAndId(r.AccountId, r.Id)
+
+	// Return happy.
+	response.RespondDeleted(c, err)
+}
+
+//
+// ClearReconciliationEntries - Tick entries on the statement. Post in
+// {"ledger_ids": [1, 2], "cleared": true}. Set cleared to false to untick. We
+// send back the reconciliation with the new difference.
+//
+func (t *Controller) ClearReconciliationEntries(c *gin.Context) {
+	// Get reconciliation. JSON error set in function.
+	r, err := t.getReconciliation(c)
+
+	if err != nil {
+		return
+	}
+
+	// Read the JSON POSTed in.
+	body, _ := ioutil.ReadAll(c.Request.Body)
+
+	ids := []uint{}
+
+	for _, row := range gjson.GetBytes(body, "ledger_ids").Array() {
+		ids = append(ids, uint(row.Int()))
+	}
+
+	cleared := true
+
+	if gjson.GetBytes(body, "cleared").Exists() {
+		cleared = gjson.GetBytes(body, "cleared").Bool()
+	}
+
+	// Tick the entries
+	r, err = t.db.SetLedgersCleared(r, ids, cleared)
+
+	// Return happy.
+	response.RespondUpdated(c, r, err)
+}
+
+//
+// FinishReconciliation - Reconcile and lock the cleared entries.
+//
+func (t *Controller) FinishReconciliation(c *gin.Context) {
+	// Get reconciliation. JSON error set in function.
+	r, err := t.getReconciliation(c)
+
+	if err != nil {
+		return
+	}
+
+	// Finish up
+	err = t.db.FinishReconciliation(&r)
+
+	// Return happy.
+	response.RespondUpdated(c, r, err)
+}
+
+//
+// UndoReconciliation - Unlock the entries of the last reconciliation and open it back up.
+//
+func (t *Controller) UndoReconciliation(c *gin.Context) {
+	// Get reconciliation. JSON error set in function.
+	r, err := t.getReconciliation(c)
+
+	if err != nil {
+		return
+	}
+
+	// Undo
+	err = t.db.UndoReconciliation(&r)
+
+	// Return happy.
+	response.RespondUpdated(c, r, err)
+}
+
+// -------------- Private Helper Functions ------------------ //
+
+//
+// getReconciliation - Get the reconciliation from the id in the URL.
+//
+func (t *Controller) getReconciliation(c *gin.Context) (models.Reconciliation, error) {
+	// Set id
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": err})
+		return models.Reconciliation{}, err
+	}
+
+	// Get reconciliation and make sure we have perms to it
+	r, err := t.db.GetReconciliationByAccountAndId(uint(c.MustGet("accountId").(int)), uint(id))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Reconciliation not found."})
+		return models.Reconciliation{}, err
+	}
+
+	return r, nil
+}
+
+/* End File */
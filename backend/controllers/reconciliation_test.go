@@ -0,0 +1,184 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nbio/st"
+
+	"app.skyclerk.com/backend/library/test"
+	"app.skyclerk.com/backend/models"
+)
+
+//
+// TestReconciliation01 - Tick, finish, lock and undo a reconciliation.
+//
+func TestReconciliation01(t *testing.T) {
+	// Start the db connection.
+	db, dbName, _ := models.NewTestDB("")
+	defer models.TestingTearDown(db, dbName)
+
+	// Create controller
+	c := &Controller{}
+	c.SetDB(db)
+
+	// Entries on the statement (and one after it).
+	entries := []struct {
+		date   string
+		amount float64
+	}{
+		{"2024-01-05", -50.00},
+		{"2024-01-10", 200.00},
+		{"2024-01-20", -25.00},
+		{"2024-02-03", -10.00},
+	}
+
+	for _, row := range entries {
+		l := test.GetRandomLedger(33)
+		l.Date, _ = time.Parse("2006-01-02", row.date)
+		l.Amount = row.amount
+		db.LedgerCreate(&l)
+	}
+
+	// Setup router
+	gin.SetMode("release")
+	gin.DisableConsoleColor()
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("accountId", 33)
+		c.Set("userId", 1)
+	})
+	r.POST("/api/v3/:account/reconciliations", c.CreateReconciliation)
+	r.POST("/api/v3/:account/reconciliations/:id/clear", c.ClearReconciliationEntries)
+	r.POST("/api/v3/:account/reconciliations/:id/finish", c.FinishReconciliation)
+	r.POST("/api/v3/:account/reconciliations/:id/undo", c.UndoReconciliation)
+	r.DELETE("/api/v3/:account/reconciliations/:id", c.DeleteReconciliation)
+	r.PUT("/api/v3/:account/ledger/:id", c.UpdateLedger)
+	r.DELETE("/api/v3/:account/ledger/:id", c.DeleteLedger)
+
+	// Start the reconciliation
+	w := doJSONRequest(r, "POST", "/api/v3/33/reconciliations", `{ "statement_date": "2024-01-31T00:00:00Z", "starting_balance": 1000.00, "ending_balance": 1150.00 }`)
+
+	rec := models.Reconciliation{}
+	err := json.Unmarshal([]byte(w.Body.String()), &rec)
+
+	st.Expect(t, err, nil)
+	st.Expect(t, w.Code, 201)
+	st.Expect(t, rec.Status, "open")
+	st.Expect(t, rec.ClearedBalance, 1000.00)
+	st.Expect(t, rec.Difference, 150.00)
+
+	// Only one open at a time.
+	w = doJSONRequest(r, "POST", "/api/v3/33/reconciliations", `{ "statement_date": "2024-02-28T00:00:00Z", "ending_balance": 1.00 }`)
+	st.Expect(t, w.Code, 400)
+	st.Expect(t, w.Body.String(), `{"errors":{"statement_date":"Finish or delete the open reconciliation first."}}`)
+
+	// Tick the first entry and the one after the statement date.
+	w = doJSONRequest(r, "POST", "/api/v3/33/reconciliations/1/clear", `{ "ledger_ids": [1, 4] }`)
+	json.Unmarshal([]byte(w.Body.String()), &rec)
+
+	st.Expect(t, w.Code, 200)
+	st.Expect(t, rec.ClearedCount, 1)
+	st.Expect(t, rec.ClearedBalance, 950.00)
+	st.Expect(t, rec.Difference, 200.00)
+
+	// Not done yet.
+	w = doJSONRequest(r, "POST", "/api/v3/33/reconciliations/1/finish", ``)
+	st.Expect(t, w.Code, 400)
+	st.Expect(t, w.Body.String(), `{"error":"The cleared balance does not match the statement ending balance."}`)
+
+	// Tick the rest and untick one.
+	doJSONRequest(r, "POST", "/api/v3/33/reconciliations/1/clear", `{ "ledger_ids": [2, 3] }`)
+	w = doJSONRequest(r, "POST", "/api/v3/33/reconciliations/1/clear", `{ "ledger_ids": [3], "cleared": false }`)
+	json.Unmarshal([]byte(w.Body.String()), &rec)
+
+	st.Expect(t, rec.ClearedCount, 2)
+	st.Expect(t, rec.Difference, 0.00)
+
+	// Finish
+	w = doJSONRequest(r, "POST", "/api/v3/33/reconciliations/1/finish", ``)
+	json.Unmarshal([]byte(w.Body.String()), &rec)
+
+	st.Expect(t, w.Code, 200)
+	st.Expect(t, rec.Status, "finished")
+	st.Expect(t, rec.ClearedCount, 2)
+
+	l1, _ := db.GetLedgerByAccountAndId(33, 1)
+	st.Expect(t, l1.Status, "reconciled")
+	st.Expect(t, l1.ReconciliationId, uint(1))
+
+	l3, _ := db.GetLedgerByAccountAndId(33, 3)
+	st.Expect(t, l3.Status, "uncleared")
+
+	l4, _ := db.GetLedgerByAccountAndId(33, 4)
+	st.Expect(t, l4.Status, "uncleared")
+
+	// Reconciled entries are locked.
+	w = doJSONRequest(r, "PUT", "/api/v3/33/ledger/1", `{ "id": 1, "amount": -55.00, "date": "2024-01-05T00:00:00Z", "contact": { "name": "Bob" }, "category": { "name": "Travel", "type": "expense" } }`)
+	st.Expect(t, w.Code, 400)
+	st.Expect(t, w.Body.String(), `{"error":"This entry has been reconciled. Undo the reconciliation to change it."}`)
+
+	w = doJSONRequest(r, "DELETE", "/api/v3/33/ledger/2", ``)
+	st.Expect(t, w.Code, 400)
+
+	// The next statement starts where this one ended.
+	w = doJSONRequest(r, "POST", "/api/v3/33/reconciliations", `{ "statement_date": "2024-02-29T00:00:00Z", "starting_balance": 5.00, "ending_balance": 1115.00 }`)
+	rec2 := models.Reconciliation{}
+	json.Unmarshal([]byte(w.Body.String()), &rec2)
+
+	st.Expect(t, w.Code, 201)
+	st.Expect(t, rec2.StartingBalance, 1150.00)
+	st.Expect(t, rec2.Difference, -35.00)
+
+	// Can not undo while another is open.
+	w = doJSONRequest(r, "POST", "/api/v3/33/reconciliations/1/undo", ``)
+	st.Expect(t, w.Code, 400)
+	st.Expect(t, w.Body.String(), `{"error":"Finish or delete the open reconciliation first."}`)
+
+	w = doJSONRequest(r, "DELETE", "/api/v3/33/reconciliations/2", ``)
+	st.Expect(t, w.Code, 204)
+
+	// Undo
+	w = doJSONRequest(r, "POST", "/api/v3/33/reconciliations/1/undo", ``)
+	json.Unmarshal([]byte(w.Body.String()), &rec)
+
+	st.Expect(t, w.Code, 200)
+	st.Expect(t, rec.Status, "open")
+
+	l1, _ = db.GetLedgerByAccountAndId(33, 1)
+	st.Expect(t, l1.Status, "cleared")
+	st.Expect(t, l1.ReconciliationId, uint(0))
+
+	// Unlocked again.
+	w = doJSONRequest(r, "PUT", "/api/v3/33/ledger/1", `{ "id": 1, "amount": -55.00, "date": "2024-01-05T00:00:00Z", "contact": { "name": "Bob" }, "category": { "name": "Travel", "type": "expense" } }`)
+	st.Expect(t, w.Code, 200)
+
+	l1, _ = db.GetLedgerByAccountAndId(33, 1)
+	st.Expect(t, l1.Amount, -55.00)
+	st.Expect(t, l1.Status, "cleared")
+}
+
+//
+// doJSONRequest - Run a request with a JSON body through the router.
+//
+func doJSONRequest(r *gin.Engine, method string, url string, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, url, bytes.NewBuffer([]byte(body)))
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+/* End File */
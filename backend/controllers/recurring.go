@@ -0,0 +1,198 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"app.skyclerk.com/backend/library/request"
+	"app.skyclerk.com/backend/library/response"
+	"app.skyclerk.com/backend/models"
+)
+
+//
+// GetRecurrings - Return a list of recurring ledger templates.
+//
+func (t *Controller) GetRecurrings(c *gin.Context) {
+	// Place to store the results.
+	var results = []models.Recurring{}
+
+	// Get limits and pages
+	page, limit, _ := request.GetSetPagingParms(c)
+
+	// Set the query parms
+	params := models.QueryParam{
+		Order:            c.DefaultQuery("order", "next_run_date"),
+		Sort:             c.DefaultQuery("sort", "ASC"),
+		Limit:            limit,
+		Page:             page,
+		PreLoads:         []string{"Contact", "Category", "Labels"},
+		AllowedOrderCols: []string{"id", "next_run_date", "start_date", "amount"},
+		Wheres: []models.KeyValue{
+			{Key: "account_id", Compare: "=", ValueInt: c.MustGet("accountId").(int)},
+		},
+	}
+
+	// Filter by status
+	if len(c.DefaultQuery("status", "")) > 0 {
+		params.Wheres = append(params.Wheres, models.KeyValue{
+			Key:     "status",
+			Compare: "=",
+			Value:   c.DefaultQuery("status", ""),
+		})
+	}
+
+	// Run the query
+	meta, err := t.db.QueryMeta(&results, params)
+
+	// Return json based on if this was a good result or not.
+	response.ResultsMeta(c, results, err, meta)
+}
+
+//
+// GetRecurring by id
+//
+func (t *Controller) GetRecurring(c *gin.Context) {
+	// Set id
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": err})
+		return
+	}
+
+	// Get template and make sure we have perms to it
+	r, err := t.db.GetRecurringByAccountAndId(uint(c.MustGet("accountId").(int)), uint(id))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Recurring entry not found."})
+		return
+	}
+
+	// Return happy.
+	response.Results(c, r, nil)
+}
+
+//
+// CreateRecurring - Create a recurring template within the account.
+//
+func (t *Controller) CreateRecurring(c *gin.Context) {
+	// Setup Recurring obj
+	o := models.Recurring{}
+
+	// Here we parse the JSON sent in, assign it to a struct, set validation errors if any.
+	if t.ValidateRequest(c, &o, "create") != nil {
+		return
+	}
+
+	// Make sure the AccountId is correct.
+	o.AccountId = uint(c.MustGet("accountId").(int))
+
+	// Add in auto fields
+	o.AddedById = uint(c.MustGet("userId").(int))
+
+	// Create template
+	t.db.RecurringCreate(&o)
+
+	// Fresh pull
+	r, err := t.db.GetRecurringByAccountAndId(o.AccountId, o.Id)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "System error. Please contact help@skyclerk.com."})
+		return
+	}
+
+	// Return happy.
+	response.RespondCreated(c, r, nil)
+}
+
+//
+// UpdateRecurring - Update a recurring template within the account.
+//
+func (t *Controller) UpdateRecurring(c *gin.Context) {
+	// Get ID from URL
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": err})
+		return
+	}
+
+	// Get template and make sure we have perms to it
+	org, err := t.db.GetRecurringByAccountAndId(uint(c.MustGet("accountId").(int)), uint(id))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Recurring entry not found."})
+		return
+	}
+
+	// Setup Recurring obj
+	o := models.Recurring{}
+
+	// Here we parse the JSON sent in, assign it to a struct, set validation errors if any.
+	if t.ValidateRequest(c, &o, "update") != nil {
+		return
+	}
+
+	// Make sure the AccountId is correct.
+	o.AccountId = org.AccountId
+
+	// Update template
+	t.db.RecurringUpdate(org, &o)
+
+	// Fresh pull
+	r, err := t.db.GetRecurringByAccountAndId(org.AccountId, org.Id)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "System error. Please contact help@skyclerk.com."})
+		return
+	}
+
+	// Return happy.
+	response.RespondUpdated(c, r, nil)
+}
+
+//
+// DeleteRecurring a recurring template within the account.
+//
+func (t *Controller) DeleteRecurring(c *gin.Context) {
+	// Get Id
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": err})
+		return
+	}
+
+	// AccountId.
+	accountId := uint(c.MustGet("accountId").(int))
+
+	// First we make sure this is an entry we have access to.
+	_, err = t.db.GetRecurringByAccountAndId(accountId, uint(id))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Recurring entry not found."})
+		return
+	}
+
+	// Delete template
+	err = t.db.DeleteRecurringByAccountAndId(accountId, uint(id))
+
+	if err != nil {
+		response.RespondError(c, err)
+		return
+	}
+
+	// Return happy.
+	response.RespondDeleted(c, nil)
+}
+
+/* End File */
@@ -0,0 +1,453 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nbio/st"
+
+	"app.skyclerk.com/backend/library/test"
+	"app.skyclerk.com/backend/models"
+)
+
+//
+// TestGetRecurrings01 - Get recurring templates by account.
+//
+func TestGetRecurrings01(t *testing.T) {
+	// Start the db connection.
+	db, dbName, _ := models.NewTestDB("")
+	defer models.TestingTearDown(db, dbName)
+
+	// Create controller
+	c := &Controller{}
+	c.SetDB(db)
+
+	// Create a few templates. Different account.
+	for i := 0; i < 3; i++ {
+		r := test.GetRandomRecurring(23)
+		db.RecurringCreate(&r)
+	}
+
+	// Create a few templates.
+	for i := 0; i < 5; i++ {
+		r := test.GetRandomRecurring(33)
+		db.RecurringCreate(&r)
+	}
+
+	// Setup request
+	req, _ := http.NewRequest("GET", "/api/v3/33/recurring", nil)
+
+	// Setup writer.
+	w := httptest.NewRecorder()
+	gin.SetMode("release")
+	gin.DisableConsoleColor()
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("accountId", 33)
+		c.Set("userId", 1)
+	})
+	r.GET("/api/v3/:account/recurring", c.GetRecurrings)
+	r.ServeHTTP(w, req)
+
+	// Grab result and convert to strut
+	results := []models.Recurring{}
+	err := json.Unmarshal([]byte(w.Body.String()), &results)
+
+	// Test results
+	st.Expect(t, err, nil)
+	st.Expect(t, w.Code, 200)
+	st.Expect(t, len(results), 5)
+	st.Expect(t, w.HeaderMap["X-No-Limit-Count"][0], "5")
+
+	for _, row := range results {
+		st.Expect(t, row.AccountId, uint(33))
+		st.Expect(t, row.Contact.AccountId, uint(33))
+		st.Expect(t, row.Category.AccountId, uint(33))
+		st.Expect(t, len(row.Labels), 1)
+		st.Expect(t, row.Status, "Active")
+		st.Expect(t, row.NextRunDate.Format("2006-01-02"), "2024-01-31")
+	}
+}
+
+//
+// TestCreateRecurring01 - Create a recurring template.
+//
+func TestCreateRecurring01(t *testing.T) {
+	// Start the db connection.
+	db, dbName, _ := models.NewTestDB("")
+	defer models.TestingTearDown(db, dbName)
+
+	// Create controller
+	c := &Controller{}
+	c.SetDB(db)
+
+	// Post data
+	post := test.GetRandomRecurring(33)
+	post.Frequency = "week"
+	post.Interval = 2
+
+	// Get JSON
+	postStr, _ := json.Marshal(post)
+
+	// Setup request
+	req, _ := http.NewRequest("POST", "/api/v3/33/recurring", bytes.NewBuffer(postStr))
+
+	// Setup writer.
+	w := httptest.NewRecorder()
+	gin.SetMode("release")
+	gin.DisableConsoleColor()
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("accountId", 33)
+		c.Set("userId", 5)
+	})
+	r.POST("/api/v3/33/recurring", c.CreateRecurring)
+	r.ServeHTTP(w, req)
+
+	// Grab result and convert to strut
+	result := models.Recurring{}
+	err := json.Unmarshal([]byte(w.Body.String()), &result)
+
+	// Test results
+	st.Expect(t, err, nil)
+	st.Expect(t, w.Code, 201)
+	st.Expect(t, result.Id, uint(1))
+	st.Expect(t, result.AccountId, uint(33))
+	st.Expect(t, result.AddedById, uint(5))
+	st.Expect(t, result.Amount, post.Amount)
+	st.Expect(t, result.Note, post.Note)
+	st.Expect(t, result.Frequency, "week")
+	st.Expect(t, result.Interval, 2)
+	st.Expect(t, result.Status, "Active")
+	st.Expect(t, result.Contact.Name, post.Contact.Name)
+	st.Expect(t, result.Category.Name, post.Category.Name)
+	st.Expect(t, result.Labels[0].Name, post.Labels[0].Name)
+	st.Expect(t, result.NextRunDate.Format("2006-01-02"), "2024-01-31")
+	st.Expect(t, result.EndDate == nil, true)
+
+	// ----------- Test bad frequency ---------- //
+
+	post.Frequency = "hourly"
+	postStr, _ = json.Marshal(post)
+
+	req2, _ := http.NewRequest("POST", "/api/v3/33/recurring", bytes.NewBuffer(postStr))
+	w2 := httptest.NewRecorder()
+	r.ServeHTTP(w2, req2)
+
+	st.Expect(t, w2.Code, 400)
+	st.Expect(t, w2.Body.String(), `{"errors":{"frequency":"The frequency field must be day, week, month, or year."}}`)
+}
+
+//
+// TestUpdateRecurring01 - Pause and update a recurring template.
+//
+func TestUpdateRecurring01(t *testing.T) {
+	// Start the db connection.
+	db, dbName, _ := models.NewTestDB("")
+	defer models.TestingTearDown(db, dbName)
+
+	// Create controller
+	c := &Controller{}
+	c.SetDB(db)
+
+	// Create a template
+	org := test.GetRandomRecurring(33)
+	db.RecurringCreate(&org)
+
+	// Post data
+	post := test.GetRandomRecurring(33)
+	post.Amount = -1500.00
+	post.Note = "Rent"
+	post.Status = "Paused"
+
+	// Get JSON
+	postStr, _ := json.Marshal(post)
+
+	// Setup request
+	req, _ := http.NewRequest("PUT", "/api/v3/33/recurring/1", bytes.NewBuffer(postStr))
+
+	// Setup writer.
+	w := httptest.NewRecorder()
+	gin.SetMode("release")
+	gin.DisableConsoleColor()
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("accountId", 33)
+		c.Set("userId", 1)
+	})
+	r.PUT("/api/v3/33/recurring/:id", c.UpdateRecurring)
+	r.ServeHTTP(w, req)
+
+	// Grab result and convert to strut
+	result := models.Recurring{}
+	err := json.Unmarshal([]byte(w.Body.String()), &result)
+
+	// Test results
+	st.Expect(t, err, nil)
+	st.Expect(t, w.Code, 200)
+	st.Expect(t, result.Id, uint(1))
+	st.Expect(t, result.Amount, -1500.00)
+	st.Expect(t, result.Note, "Rent")
+	st.Expect(t, result.Status, "Paused")
+	st.Expect(t, len(result.Labels), 1)
+
+	// Paused templates do not run.
+	st.Expect(t, len(db.GetRecurringDue(time.Now())), 0)
+
+	// Wrong account
+	req2, _ := http.NewRequest("PUT", "/api/v3/33/recurring/1", bytes.NewBuffer(postStr))
+	w2 := httptest.NewRecorder()
+	r2 := gin.New()
+	r2.Use(func(c *gin.Context) {
+		c.Set("accountId", 34)
+		c.Set("userId", 1)
+	})
+	r2.PUT("/api/v3/33/recurring/:id", c.UpdateRecurring)
+	r2.ServeHTTP(w2, req2)
+
+	st.Expect(t, w2.Code, 400)
+	st.Expect(t, w2.Body.String(), `{"error":"Recurring entry not found."}`)
+}
+
+//
+// TestUpdateRecurring02 - Moving the end date out brings a completed template back.
+//
+func TestUpdateRecurring02(t *testing.T) {
+	// Start the db connection.
+	db, dbName, _ := models.NewTestDB("")
+	defer models.TestingTearDown(db, dbName)
+
+	// Create controller
+	c := &Controller{}
+	c.SetDB(db)
+
+	// Monthly on the 31st, run through the end of March.
+	end := time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)
+	rec := test.GetRandomRecurring(33)
+	rec.EndDate = &end
+	db.RecurringCreate(&rec)
+	db.RecurringRun(&rec, time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC))
+
+	org, _ := db.GetRecurringByAccountAndId(33, rec.Id)
+	st.Expect(t, org.Status, "Completed")
+
+	gin.SetMode("release")
+	gin.DisableConsoleColor()
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("accountId", 33)
+		c.Set("userId", 1)
+	})
+	r.PUT("/api/v3/33/recurring/:id", c.UpdateRecurring)
+
+	// Changing something else leaves it done.
+	post := models.Recurring{Amount: -25.00, Frequency: "month", StartDate: org.StartDate, EndDate: &end, Contact: org.Contact, Category: org.Category}
+	postStr, _ := json.Marshal(post)
+
+	w := doJSONRequest(r, "PUT", "/api/v3/33/recurring/1", string(postStr))
+	st.Expect(t, w.Code, 200)
+
+	result := models.Recurring{}
+	json.Unmarshal(w.Body.Bytes(), &result)
+	st.Expect(t, result.Amount, -25.00)
+	st.Expect(t, result.Status, "Completed")
+
+	// A later end date picks up where we left off.
+	later := time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)
+	post.EndDate = &later
+	postStr, _ = json.Marshal(post)
+
+	w = doJSONRequest(r, "PUT", "/api/v3/33/recurring/1", string(postStr))
+	st.Expect(t, w.Code, 200)
+
+	json.Unmarshal(w.Body.Bytes(), &result)
+	st.Expect(t, result.Status, "Active")
+	st.Expect(t, result.NextRunDate.Format("2006-01-02"), "2024-04-30")
+	st.Expect(t, len(db.GetRecurringDue(time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC))), 1)
+
+	// Pulling it back in finishes it again.
+	post.EndDate = &end
+	postStr, _ = json.Marshal(post)
+
+	w = doJSONRequest(r, "PUT", "/api/v3/33/recurring/1", string(postStr))
+	json.Unmarshal(w.Body.Bytes(), &result)
+	st.Expect(t, result.Status, "Completed")
+}
+
+//
+// TestUpdateRecurring03 - A paused template stays paused if the status is not sent.
+//
+func TestUpdateRecurring03(t *testing.T) {
+	// Start the db connection.
+	db, dbName, _ := models.NewTestDB("")
+	defer models.TestingTearDown(db, dbName)
+
+	// Create controller
+	c := &Controller{}
+	c.SetDB(db)
+
+	rec := test.GetRandomRecurring(33)
+	rec.Status = "Paused"
+	db.RecurringCreate(&rec)
+
+	org, _ := db.GetRecurringByAccountAndId(33, rec.Id)
+	st.Expect(t, org.Status, "Paused")
+
+	gin.SetMode("release")
+	gin.DisableConsoleColor()
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("accountId", 33)
+		c.Set("userId", 1)
+	})
+	r.PUT("/api/v3/33/recurring/:id", c.UpdateRecurring)
+
+	post := models.Recurring{Amount: -25.00, Frequency: "month", StartDate: org.StartDate, Contact: org.Contact, Category: org.Category}
+	postStr, _ := json.Marshal(post)
+
+	w := doJSONRequest(r, "PUT", "/api/v3/33/recurring/1", string(postStr))
+	st.Expect(t, w.Code, 200)
+
+	result := models.Recurring{}
+	json.Unmarshal(w.Body.Bytes(), &result)
+	st.Expect(t, result.Amount, -25.00)
+	st.Expect(t, result.Status, "Paused")
+	st.Expect(t, result.NextRunDate.Format("2006-01-02"), org.NextRunDate.Format("2006-01-02"))
+	st.Expect(t, len(db.GetRecurringDue(time.Now())), 0)
+
+	// Intervals start at one.
+	post.Interval = -1
+	postStr, _ = json.Marshal(post)
+
+	w = doJSONRequest(r, "PUT", "/api/v3/33/recurring/1", string(postStr))
+	st.Expect(t, w.Code, 400)
+	st.Expect(t, w.Body.String(), `{"errors":{"interval":"The interval field must be a positive number."}}`)
+}
+
+//
+// TestDeleteRecurring01 - Delete a recurring template.
+//
+func TestDeleteRecurring01(t *testing.T) {
+	// Start the db connection.
+	db, dbName, _ := models.NewTestDB("")
+	defer models.TestingTearDown(db, dbName)
+
+	// Create controller
+	c := &Controller{}
+	c.SetDB(db)
+
+	// Create a template
+	org := test.GetRandomRecurring(33)
+	db.RecurringCreate(&org)
+
+	// Setup request
+	req, _ := http.NewRequest("DELETE", "/api/v3/33/recurring/1", nil)
+
+	// Setup writer.
+	w := httptest.NewRecorder()
+	gin.SetMode("release")
+	gin.DisableConsoleColor()
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("accountId", 33)
+		c.Set("userId", 1)
+	})
+	r.DELETE("/api/v3/33/recurring/:id", c.DeleteRecurring)
+	r.ServeHTTP(w, req)
+
+	// Test results
+	st.Expect(t, w.Code, 204)
+
+	_, err := db.GetRecurringByAccountAndId(33, 1)
+	st.Expect(t, err.Error(), "Recurring entry not found.")
+
+	var count int
+	db.New().Table("recurring_labels").Where("recurring_id = ?", 1).Count(&count)
+	st.Expect(t, count, 0)
+}
+
+//
+// TestRecurringRun01 - Make sure we catch up on missed runs and do not double up.
+//
+func TestRecurringRun01(t *testing.T) {
+	// Start the db connection.
+	db, dbName, _ := models.NewTestDB("")
+	defer models.TestingTearDown(db, dbName)
+
+	// Monthly on the 31st, ending in June.
+	end := time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC)
+	rec := test.GetRandomRecurring(33)
+	rec.Amount = -1500.00
+	rec.EndDate = &end
+	db.RecurringCreate(&rec)
+
+	// Pretend the server was down until April 2nd.
+	due := db.GetRecurringDue(time.Date(2024, 4, 2, 0, 0, 0, 0, time.UTC))
+	st.Expect(t, len(due), 1)
+
+	ledgers, err := db.RecurringRun(&due[0], time.Date(2024, 4, 2, 0, 0, 0, 0, time.UTC))
+	st.Expect(t, err, nil)
+	st.Expect(t, len(ledgers), 3)
+	st.Expect(t, ledgers[0].Date.Format("2006-01-02"), "2024-01-31")
+	st.Expect(t, ledgers[1].Date.Format("2006-01-02"), "2024-02-29")
+	st.Expect(t, ledgers[2].Date.Format("2006-01-02"), "2024-03-31")
+
+	for _, row := range ledgers {
+		l, err := db.GetLedgerByAccountAndId(33, row.Id)
+		st.Expect(t, err, nil)
+		st.Expect(t, l.Amount, -1500.00)
+		st.Expect(t, l.RecurringId, rec.Id)
+		st.Expect(t, l.Contact.Id, rec.ContactId)
+		st.Expect(t, l.Category.Id, rec.CategoryId)
+		st.Expect(t, len(l.Labels), 1)
+
+		a := models.Activity{}
+		db.New().Where("ledger_id = ?", row.Id).Find(&a)
+		st.Expect(t, a.AccountId, uint(33))
+		st.Expect(t, a.Action, "expense")
+		st.Expect(t, a.SubAction, "create")
+		st.Expect(t, a.Amount, -1500.00)
+	}
+
+	// Run state was saved.
+	r1, _ := db.GetRecurringByAccountAndId(33, rec.Id)
+	st.Expect(t, r1.NextRunDate.Format("2006-01-02"), "2024-04-30")
+	st.Expect(t, r1.LastRunDate.Format("2006-01-02"), "2024-03-31")
+	st.Expect(t, r1.Status, "Active")
+
+	// Running again at the same time does nothing.
+	st.Expect(t, len(db.GetRecurringDue(time.Date(2024, 4, 2, 0, 0, 0, 0, time.UTC))), 0)
+
+	// Run past the end date. April and May only, then we are done.
+	ledgers, err = db.RecurringRun(&r1, time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC))
+	st.Expect(t, err, nil)
+	st.Expect(t, len(ledgers), 2)
+	st.Expect(t, ledgers[1].Date.Format("2006-01-02"), "2024-05-31")
+
+	r2, _ := db.GetRecurringByAccountAndId(33, rec.Id)
+	st.Expect(t, r2.Status, "Completed")
+
+	var count int
+	db.New().Model(&models.Ledger{}).Where("LedgerRecurringId = ?", rec.Id).Count(&count)
+	st.Expect(t, count, 5)
+}
+
+/* End File */
@@ -0,0 +1,141 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package controllers
+
+import (
+	"html"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"app.skyclerk.com/backend/library/response"
+	"app.skyclerk.com/backend/models"
+)
+
+//
+// GetReportSubscriptions - Return the scheduled reports the user gets for this account.
+//
+func (t *Controller) GetReportSubscriptions(c *gin.Context) {
+	// Return happy.
+	response.Results(c, t.db.GetReportSubscriptionsByAccountAndUser(uint(c.MustGet("accountId").(int)), uint(c.MustGet("userId").(int))), nil)
+}
+
+//
+// CreateReportSubscription - Subscribe the user to a scheduled report.
+//
+func (t *Controller) CreateReportSubscription(c *gin.Context) {
+	// Setup ReportSubscription obj
+	o := models.ReportSubscription{}
+
+	// Here we parse the JSON sent in, assign it to a struct, set validation errors if any.
+	if t.ValidateRequest(c, &o, "create") != nil {
+		return
+	}
+
+	// Make sure the AccountId and UserId are correct.
+	o.Id = 0
+	o.AccountId = uint(c.MustGet("accountId").(int))
+	o.UserId = uint(c.MustGet("userId").(int))
+
+	// Create subscription
+	t.db.ReportSubscriptionCreate(&o)
+
+	// Fresh pull
+	s, err := t.db.GetReportSubscriptionById(o.Id)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "System error. Please contact help@skyclerk.com."})
+		return
+	}
+
+	// Return happy.
+	response.RespondCreated(c, s, nil)
+}
+
+//
+// DeleteReportSubscription - Unsubscribe the user from a scheduled report.
+//
+func (t *Controller) DeleteReportSubscription(c *gin.Context) {
+	// Set id
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": err})
+		return
+	}
+
+	// Delete the subscription. You can only remove your own.
+	if err := t.db.DeleteReportSubscriptionByAccountUserAndId(uint(c.MustGet("accountId").(int)), uint(c.MustGet("userId").(int)), uint(id)); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Report subscription not found."})
+		return
+	}
+
+	// Return happy.
+	response.RespondDeleted(c, nil)
+}
+
+//
+// UnsubscribeReport - The unsubscribe link at the bottom of each scheduled
+// report. No login, the token in the link is signed by us. A GET only asks
+// to confirm so link scanners do not unsubscribe people. The POST from that
+// page, or from the mail app's own button (RFC 8058), unsubscribes.
+//
+func (t *Controller) UnsubscribeReport(c *gin.Context) {
+	done := "You are unsubscribed. You will not get this report from Skyclerk again."
+
+	// Set id
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+
+	if err != nil {
+		c.Data(http.StatusBadRequest, "text/html; charset=utf-8", []byte(unsubscribePage("This unsubscribe link is not valid.", "")))
+		return
+	}
+
+	// Already gone, most likely from clicking twice.
+	s, err := t.db.GetReportSubscriptionById(uint(id))
+
+	if err != nil {
+		c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(unsubscribePage(done, "")))
+		return
+	}
+
+	if !s.CheckUnsubscribeToken(c.Query("token")) {
+		c.Data(http.StatusBadRequest, "text/html; charset=utf-8", []byte(unsubscribePage("This unsubscribe link is not valid.", "")))
+		return
+	}
+
+	// Ask first.
+	if c.Request.Method != http.MethodPost {
+		c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(unsubscribePage("Do you want to stop getting this report from Skyclerk?", c.Request.URL.RequestURI())))
+		return
+	}
+
+	t.db.DeleteReportSubscriptionByAccountUserAndId(s.AccountId, s.UserId, s.Id)
+
+	// Return happy.
+	c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(unsubscribePage(done, "")))
+}
+
+// ----------------- Private Helper Funcs -------------- //
+
+//
+// unsubscribePage - A bare page with one message on it. Pass an action to add
+// an unsubscribe button that posts to it.
+//
+func unsubscribePage(msg string, action string) string {
+	form := ""
+
+	if len(action) > 0 {
+		form = `<form method="post" action="` + html.EscapeString(action) + `"><button type="submit" style="font-size: 16px; padding: 10px 20px;">Unsubscribe</button></form>`
+	}
+
+	return `<!DOCTYPE html><html><head><meta charset="utf-8"><title>Skyclerk</title></head><body style="font-family: Roboto, Arial, sans-serif; text-align: center; padding-top: 60px;"><p>` + msg + `</p>` + form + `</body></html>`
+}
+
+/* End File */
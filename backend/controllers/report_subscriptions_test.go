@@ -0,0 +1,168 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package controllers
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nbio/st"
+	"github.com/tidwall/gjson"
+
+	"app.skyclerk.com/backend/models"
+)
+
+//
+// TestReportSubscriptions01 - Subscribe, list and unsubscribe.
+//
+func TestReportSubscriptions01(t *testing.T) {
+	// Start the db connection.
+	db, dbName, _ := models.NewTestDB("")
+	defer models.TestingTearDown(db, dbName)
+
+	// Create controller
+	c := &Controller{}
+	c.SetDB(db)
+
+	// Setup router
+	gin.SetMode("release")
+	gin.DisableConsoleColor()
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("accountId", 33)
+		c.Set("userId", 1)
+	})
+	r.GET("/api/v3/:account/report-subscriptions", c.GetReportSubscriptions)
+	r.POST("/api/v3/:account/report-subscriptions", c.CreateReportSubscription)
+	r.DELETE("/api/v3/:account/report-subscriptions/:id", c.DeleteReportSubscription)
+
+	// Create
+	w := doJSONRequest(r, "POST", "/api/v3/33/report-subscriptions", `{ "report": "monthly-pnl", "account_id": 34, "user_id": 2 }`)
+	st.Expect(t, w.Code, 201)
+
+	s := models.ReportSubscription{}
+	json.Unmarshal(w.Body.Bytes(), &s)
+	st.Expect(t, s.Id, uint(1))
+	st.Expect(t, s.AccountId, uint(33))
+	st.Expect(t, s.UserId, uint(1))
+	st.Expect(t, s.Report, "monthly-pnl")
+
+	// The month that just ended is not sent.
+	s, _ = db.GetReportSubscriptionById(1)
+	start, _ := s.LastPeriodBounds(time.Now())
+	st.Expect(t, s.LastPeriod, start.Format("2006-01-02"))
+
+	// Validation
+	w = doJSONRequest(r, "POST", "/api/v3/33/report-subscriptions", `{ "report": "daily" }`)
+	st.Expect(t, w.Code, 400)
+	st.Expect(t, gjson.Get(w.Body.String(), "errors.report").String(), "The report field must be weekly-activity, monthly-pnl or quarterly-categories.")
+
+	w = doJSONRequest(r, "POST", "/api/v3/33/report-subscriptions", `{ "report": "monthly-pnl" }`)
+	st.Expect(t, w.Code, 400)
+	st.Expect(t, gjson.Get(w.Body.String(), "errors.report").String(), "You are already subscribed to this report.")
+
+	w = doJSONRequest(r, "POST", "/api/v3/33/report-subscriptions", `{ "report": "weekly-activity" }`)
+	st.Expect(t, w.Code, 201)
+
+	// Other users have their own.
+	db.ReportSubscriptionCreate(&models.ReportSubscription{AccountId: 33, UserId: 2, Report: "monthly-pnl"})
+
+	// List
+	w = doJSONRequest(r, "GET", "/api/v3/33/report-subscriptions", ``)
+	st.Expect(t, w.Code, 200)
+	st.Expect(t, gjson.Get(w.Body.String(), "#").Int(), int64(2))
+	st.Expect(t, gjson.Get(w.Body.String(), "1.report").String(), "weekly-activity")
+
+	// Can not remove someone else's.
+	w = doJSONRequest(r, "DELETE", "/api/v3/33/report-subscriptions/3", ``)
+	st.Expect(t, w.Code, 400)
+	st.Expect(t, w.Body.String(), `{"error":"Report subscription not found."}`)
+
+	// Delete
+	w = doJSONRequest(r, "DELETE", "/api/v3/33/report-subscriptions/1", ``)
+	st.Expect(t, w.Code, 204)
+	st.Expect(t, len(db.GetReportSubscriptionsByAccountAndUser(33, 1)), 1)
+	st.Expect(t, len(db.GetReportSubscriptionsByAccountAndUser(33, 2)), 1)
+}
+
+//
+// TestUnsubscribeReport01 - The signed link in the email.
+//
+func TestUnsubscribeReport01(t *testing.T) {
+	// Start the db connection.
+	db, dbName, _ := models.NewTestDB("")
+	defer models.TestingTearDown(db, dbName)
+
+	// Create controller
+	c := &Controller{}
+	c.SetDB(db)
+
+	s1 := models.ReportSubscription{AccountId: 33, UserId: 1, Report: "weekly-activity"}
+	s2 := models.ReportSubscription{AccountId: 33, UserId: 2, Report: "weekly-activity"}
+	db.ReportSubscriptionCreate(&s1)
+	db.ReportSubscriptionCreate(&s2)
+
+	// Only people still on the account get reports.
+	db.Save(&models.AcctToUsers{AccountId: 33, UserId: 1})
+	st.Expect(t, len(db.GetReportSubscriptions()), 1)
+
+	// Setup router. No login.
+	gin.SetMode("release")
+	gin.DisableConsoleColor()
+
+	r := gin.New()
+	r.GET("/reports/unsubscribe/:id", c.UnsubscribeReport)
+	r.POST("/reports/unsubscribe/:id", c.UnsubscribeReport)
+
+	url := s1.UnsubscribeUrl()
+	st.Expect(t, strings.Contains(url, "/reports/unsubscribe/1?token="), true)
+
+	// Mail apps post to the same link.
+	st.Expect(t, s1.UnsubscribeHeaders(), map[string]string{"List-Unsubscribe": "<" + url + ">", "List-Unsubscribe-Post": "List-Unsubscribe=One-Click"})
+
+	// The token for one subscription does not work on another.
+	w := doJSONRequest(r, "GET", "/reports/unsubscribe/2?token="+s1.UnsubscribeToken(), ``)
+	st.Expect(t, w.Code, 400)
+	st.Expect(t, strings.Contains(w.Body.String(), "This unsubscribe link is not valid."), true)
+
+	w = doJSONRequest(r, "GET", "/reports/unsubscribe/1", ``)
+	st.Expect(t, w.Code, 400)
+	st.Expect(t, len(db.GetReportSubscriptionsByAccountAndUser(33, 1)), 1)
+
+	w = doJSONRequest(r, "POST", "/reports/unsubscribe/1?token="+s2.UnsubscribeToken(), ``)
+	st.Expect(t, w.Code, 400)
+	st.Expect(t, len(db.GetReportSubscriptionsByAccountAndUser(33, 1)), 1)
+
+	// Opening the link only asks, link scanners open them too.
+	w = doJSONRequest(r, "GET", "/reports/unsubscribe/1?token="+s1.UnsubscribeToken(), ``)
+	st.Expect(t, w.Code, 200)
+	st.Expect(t, strings.Contains(w.Body.String(), "Do you want to stop getting this report from Skyclerk?"), true)
+	st.Expect(t, strings.Contains(w.Body.String(), `<form method="post" action="/reports/unsubscribe/1?token=`+s1.UnsubscribeToken()+`">`), true)
+	st.Expect(t, strings.Contains(w.Body.String(), "You are unsubscribed."), false)
+	st.Expect(t, len(db.GetReportSubscriptionsByAccountAndUser(33, 1)), 1)
+
+	// The button on that page, or the mail app's one click.
+	w = doJSONRequest(r, "POST", "/reports/unsubscribe/1?token="+s1.UnsubscribeToken(), `List-Unsubscribe=One-Click`)
+	st.Expect(t, w.Code, 200)
+	st.Expect(t, strings.Contains(w.Body.String(), "You are unsubscribed."), true)
+	st.Expect(t, len(db.GetReportSubscriptionsByAccountAndUser(33, 1)), 0)
+
+	// Clicking again is fine.
+	w = doJSONRequest(r, "POST", "/reports/unsubscribe/1?token="+s1.UnsubscribeToken(), ``)
+	st.Expect(t, w.Code, 200)
+
+	w = doJSONRequest(r, "POST", "/reports/unsubscribe/2?token="+s2.UnsubscribeToken(), ``)
+	st.Expect(t, w.Code, 200)
+	st.Expect(t, len(db.GetReportSubscriptionsByAccountAndUser(33, 2)), 0)
+}
+
+/* End File */
@@ -7,87 +7,166 @@
 package controllers
 
 import (
+	"io"
+	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
 
+	"app.skyclerk.com/backend/library/export"
 	"app.skyclerk.com/backend/library/helpers"
 	"app.skyclerk.com/backend/library/reports"
+	"app.skyclerk.com/backend/services"
 )
 
 //
-// ReportsPnlLabel - Return PnL by Label
+// ReportsPnlLabel - Return PnL by Label as json or pdf
 //
 func (t *Controller) ReportsPnlLabel(c *gin.Context) {
 	// Set start / end big range default
 	start := helpers.ParseDateNoError(c.DefaultQuery("start", "1800-01-01"))
 	end := helpers.ParseDateNoError(c.DefaultQuery("end", "3000-01-01"))
 
+	format, ok := reportFormat(c)
+
+	if !ok {
+		return
+	}
+
 	// Run function
 	result := reports.GetLabelsPnL(t.db, uint(c.MustGet("accountId").(int)), start, end, c.DefaultQuery("sort", "desc"))
 
 	// Return happy JSON
-	c.JSON(200, result)
+	if format == "json" {
+		c.JSON(200, result)
+		return
+	}
+
+	t.sendReportPDF(c, "pnl-label", func(w io.Writer, accountName string) error {
+		return export.WritePnLBreakdownPDF(w, "Profit & Loss by Label", "Label", result, start, end, accountName)
+	})
 }
 
 //
-// ReportsPnlCategory - Return PnL by Category
+// ReportsPnlCategory - Return PnL by Category as json or pdf
 //
 func (t *Controller) ReportsPnlCategory(c *gin.Context) {
 	// Set start / end big range default
 	start := helpers.ParseDateNoError(c.DefaultQuery("start", "1800-01-01"))
 	end := helpers.ParseDateNoError(c.DefaultQuery("end", "3000-01-01"))
 
+	format, ok := reportFormat(c)
+
+	if !ok {
+		return
+	}
+
 	// Run function
 	result := reports.GetCategoriesPnL(t.db, uint(c.MustGet("accountId").(int)), start, end, c.DefaultQuery("sort", "desc"))
 
 	// Return happy JSON
-	c.JSON(200, result)
+	if format == "json" {
+		c.JSON(200, result)
+		return
+	}
+
+	t.sendReportPDF(c, "pnl-category", func(w io.Writer, accountName string) error {
+		return export.WritePnLBreakdownPDF(w, "Profit & Loss by Category", "Category", result, start, end, accountName)
+	})
 }
 
 //
-// ReportsIncomeByContact - Get income by contact
+// ReportsIncomeByContact - Get income by contact as json or pdf
 //
 func (t *Controller) ReportsIncomeByContact(c *gin.Context) {
 	// Set start / end big range default
 	start := helpers.ParseDateNoError(c.DefaultQuery("start", "1800-01-01"))
 	end := helpers.ParseDateNoError(c.DefaultQuery("end", "3000-01-01"))
 
+	format, ok := reportFormat(c)
+
+	if !ok {
+		return
+	}
+
 	// Run function
 	result := reports.GetIncomeByContact(t.db, uint(c.MustGet("accountId").(int)), start, end, c.DefaultQuery("sort", "desc"))
 
 	// Return happy JSON
-	c.JSON(200, result)
+	if format == "json" {
+		c.JSON(200, result)
+		return
+	}
+
+	t.sendReportPDF(c, "income-by-contact", func(w io.Writer, accountName string) error {
+		return export.WriteContactTotalsPDF(w, "Income by Contact", result, start, end, accountName)
+	})
 }
 
 //
-// ReportsExpensesByContact - Get expenses by contact
+// ReportsExpensesByContact - Get expenses by contact as json or pdf
 //
 func (t *Controller) ReportsExpensesByContact(c *gin.Context) {
 	// Set start / end big range default
 	start := helpers.ParseDateNoError(c.DefaultQuery("start", "1800-01-01"))
 	end := helpers.ParseDateNoError(c.DefaultQuery("end", "3000-01-01"))
 
+	format, ok := reportFormat(c)
+
+	if !ok {
+		return
+	}
+
 	// Run function
 	result := reports.GetExpenseByContact(t.db, uint(c.MustGet("accountId").(int)), start, end, c.DefaultQuery("sort", "desc"))
 
 	// Return happy JSON
-	c.JSON(200, result)
+	if format == "json" {
+		c.JSON(200, result)
+		return
+	}
+
+	t.sendReportPDF(c, "expenses-by-contact", func(w io.Writer, accountName string) error {
+		return export.WriteContactTotalsPDF(w, "Expenses by Contact", result, start, end, accountName)
+	})
 }
 
 //
-// ReportsPnl returns income, expense, profit by date range grouping
+// ReportsPnl returns income, expense, profit by date range grouping as json
+// or pdf.
 //
 func (t *Controller) ReportsPnl(c *gin.Context) {
 	// Set start / end big range default
 	start := helpers.ParseDateNoError(c.DefaultQuery("start", "1800-01-01"))
 	end := helpers.ParseDateNoError(c.DefaultQuery("end", "3000-01-01"))
 
+	format, ok := reportFormat(c)
+
+	if !ok {
+		return
+	}
+
 	// Run function
-	pl := reports.GetPnL(t.db, uint(c.MustGet("accountId").(int)), start, end, c.DefaultQuery("group", "month"), c.DefaultQuery("sort", "desc"))
+	group := c.DefaultQuery("group", "month")
+	pl := reports.GetPnL(t.db, uint(c.MustGet("accountId").(int)), start, end, group, c.DefaultQuery("sort", "desc"))
 
 	// Return happy JSON
-	c.JSON(200, pl)
+	if format == "json" {
+		c.JSON(200, pl)
+		return
+	}
+
+	// Only known groups make it into the file name.
+	name := "pnl"
+
+	if _, ok := export.PnLTitles[group]; ok {
+		name = "pnl-" + group
+	}
+
+	t.sendReportPDF(c, name, func(w io.Writer, accountName string) error {
+		return export.WritePnLPDF(w, pl, group, start, end, accountName)
+	})
 }
 
 //
@@ -101,4 +180,194 @@ func (t *Controller) ReportsCurrentPnl(c *gin.Context) {
 	c.JSON(200, pl)
 }
 
+//
+// ReportsTrialBalance returns the debit or credit balance of every chart account as of a date.
+//
+func (t *Controller) ReportsTrialBalance(c *gin.Context) {
+	// As of the end of this day
+	date := helpers.ParseDateNoError(c.DefaultQuery("date", time.Now().Format("2006-01-02")))
+
+	// Run function
+	tb := reports.GetTrialBalance(t.db, uint(c.MustGet("accountId").(int)), date)
+
+	// Return happy JSON
+	c.JSON(200, tb)
+}
+
+//
+// ReportsBalanceSheet returns the balance sheet as of a date next to the balance
+// sheet as of another date (a year earlier unless we are told).
+//
+func (t *Controller) ReportsBalanceSheet(c *gin.Context) {
+	// As of the end of these days
+	date := helpers.ParseDateNoError(c.DefaultQuery("date", time.Now().Format("2006-01-02")))
+	compare := helpers.ParseDateNoError(c.DefaultQuery("compare", date.AddDate(-1, 0, 0).Format("2006-01-02")))
+
+	// Run function
+	bs := reports.GetBalanceSheet(t.db, uint(c.MustGet("accountId").(int)), date, compare)
+
+	// Return happy JSON
+	c.JSON(200, bs)
+}
+
+//
+// ReportsCashFlow returns the statement of cash flows for a period next to the
+// period before it (or compare_start to compare_end).
+//
+func (t *Controller) ReportsCashFlow(c *gin.Context) {
+	// Set start and end. Default to the year so far.
+	now := time.Now()
+	start := helpers.ParseDateNoError(c.DefaultQuery("start", time.Date(now.Year(), 1, 1, 0, 0, 0, 0, time.UTC).Format("2006-01-02")))
+	end := helpers.ParseDateNoError(c.DefaultQuery("end", now.Format("2006-01-02")))
+
+	compareStart, compareEnd := reports.PriorPeriod(start, end)
+	compareStart = helpers.ParseDateNoError(c.DefaultQuery("compare_start", compareStart.Format("2006-01-02")))
+	compareEnd = helpers.ParseDateNoError(c.DefaultQuery("compare_end", compareEnd.Format("2006-01-02")))
+
+	// Run function
+	cf := reports.GetCashFlow(t.db, uint(c.MustGet("accountId").(int)), start, end, compareStart, compareEnd)
+
+	// Return happy JSON
+	c.JSON(200, cf)
+}
+
+//
+// ReportsBudgetVsActual returns every budget with what was spent against it in
+// the period that date (today unless we are told) falls in.
+//
+func (t *Controller) ReportsBudgetVsActual(c *gin.Context) {
+	// Budgets are for the period this day is in
+	date := helpers.ParseDateNoError(c.DefaultQuery("date", time.Now().Format("2006-01-02")))
+
+	// Run function
+	bva := reports.GetBudgetVsActual(t.db, uint(c.MustGet("accountId").(int)), date)
+
+	// Return happy JSON
+	c.JSON(200, bva)
+}
+
+//
+// ReportsTaxSummary returns a year of income and expenses totaled by tax form
+// line as json, csv or pdf. Defaults to last year since that is the year taxes
+// get done for.
+//
+func (t *Controller) ReportsTaxSummary(c *gin.Context) {
+	// Set the year and format
+	year, err := strconv.Atoi(c.DefaultQuery("year", strconv.Itoa(time.Now().Year()-1)))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": gin.H{"year": "The year must be a number."}})
+		return
+	}
+
+	format := c.DefaultQuery("format", "json")
+
+	if (format != "json") && (format != "csv") && (format != "pdf") {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": gin.H{"format": "The format must be json, csv or pdf."}})
+		return
+	}
+
+	// Run function
+	accountId := uint(c.MustGet("accountId").(int))
+	ts := reports.GetTaxSummary(t.db, accountId, year)
+
+	// Return happy JSON
+	if format == "json" {
+		c.JSON(200, ts)
+		return
+	}
+
+	// Send the file.
+	c.Header("Content-Type", export.ContentType(format))
+	c.Header("Content-Disposition", "attachment; filename=\"tax-summary-"+strconv.Itoa(year)+"."+format+"\"")
+
+	if format == "csv" {
+		err = export.WriteTaxSummaryCSV(c.Writer, ts)
+	} else {
+		account, _ := t.db.GetAccountById(accountId)
+		err = export.WriteTaxSummaryPDF(c.Writer, ts, account.Name)
+	}
+
+	if err != nil {
+		services.Info(err)
+	}
+}
+
+//
+// ReportsContractors1099 returns the contacts flagged for a 1099 we paid at
+// least the threshold in a year, as json or a csv for e-filing. Defaults to
+// last year and that year's threshold.
+//
+func (t *Controller) ReportsContractors1099(c *gin.Context) {
+	// Set the year, threshold and format
+	year, err := strconv.Atoi(c.DefaultQuery("year", strconv.Itoa(time.Now().Year()-1)))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": gin.H{"year": "The year must be a number."}})
+		return
+	}
+
+	threshold, err := strconv.ParseFloat(c.DefaultQuery("threshold", strconv.FormatFloat(reports.Default1099Threshold(year), 'f', 2, 64)), 64)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": gin.H{"threshold": "The threshold must be a number."}})
+		return
+	}
+
+	format := c.DefaultQuery("format", "json")
+
+	if (format != "json") && (format != "csv") {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": gin.H{"format": "The format must be json or csv."}})
+		return
+	}
+
+	// Run function
+	r := reports.GetContractors1099(t.db, uint(c.MustGet("accountId").(int)), year, threshold)
+
+	// Return happy JSON
+	if format == "json" {
+		c.JSON(200, r)
+		return
+	}
+
+	// Send the file.
+	c.Header("Content-Type", export.ContentType(format))
+	c.Header("Content-Disposition", "attachment; filename=\"1099-nec-"+strconv.Itoa(year)+".csv\"")
+
+	if err := export.WriteContractors1099CSV(c.Writer, r); err != nil {
+		services.Info(err)
+	}
+}
+
+// ----------------- Private Helper Funcs -------------- //
+
+//
+// reportFormat - The format a report was asked for. The P&L reports come as
+// json or pdf.
+//
+func reportFormat(c *gin.Context) (string, bool) {
+	format := c.DefaultQuery("format", "json")
+
+	if (format != "json") && (format != "pdf") {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": gin.H{"format": "The format must be json or pdf."}})
+		return "", false
+	}
+
+	return format, true
+}
+
+//
+// sendReportPDF - Send a report as a PDF download with the account name on it.
+//
+func (t *Controller) sendReportPDF(c *gin.Context, name string, write func(w io.Writer, accountName string) error) {
+	account, _ := t.db.GetAccountById(uint(c.MustGet("accountId").(int)))
+
+	c.Header("Content-Type", export.ContentType("pdf"))
+	c.Header("Content-Disposition", "attachment; filename=\""+name+".pdf\"")
+
+	if err := write(c.Writer, account.Name); err != nil {
+		services.Info(err)
+	}
+}
+
 /* End File */
@@ -10,6 +10,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -660,4 +661,259 @@ func TestReportsCurrentPnl01(t *testing.T) {
 	st.Expect(t, helpers.Round(results.Value, 2), helpers.Round(total, 2))
 }
 
+//
+// TestReportsBalanceSheetCashFlow01 - Comparative columns default to the period before.
+//
+func TestReportsBalanceSheetCashFlow01(t *testing.T) {
+	// Start the db connection.
+	db, dbName, _ := models.NewTestDB("")
+	defer models.TestingTearDown(db, dbName)
+
+	// Create controller
+	c := &Controller{}
+	c.SetDB(db)
+
+	db.LedgerCreate(&models.Ledger{AccountId: 33, Date: helpers.ParseDateNoError("2023-05-01"), Amount: 100, Contact: models.Contact{Name: "Acme"}, Category: models.Category{Name: "Sales", Type: "2"}})
+	db.LedgerCreate(&models.Ledger{AccountId: 33, Date: helpers.ParseDateNoError("2024-05-01"), Amount: 250, Contact: models.Contact{Name: "Acme"}, Category: models.Category{Name: "Sales", Type: "2"}})
+
+	// Setup router
+	gin.SetMode("release")
+	gin.DisableConsoleColor()
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("accountId", 33)
+		c.Set("userId", 1)
+	})
+	r.GET("/api/v3/:account/reports/balance-sheet", c.ReportsBalanceSheet)
+	r.GET("/api/v3/:account/reports/cash-flow", c.ReportsCashFlow)
+
+	// Balance sheet
+	w := doJSONRequest(r, "GET", "/api/v3/33/reports/balance-sheet?date=2024-06-30", ``)
+	st.Expect(t, w.Code, 200)
+
+	bs := reports.BalanceSheet{}
+	json.Unmarshal(w.Body.Bytes(), &bs)
+	st.Expect(t, bs.CompareDate, "2023-06-30")
+	st.Expect(t, bs.Balanced, true)
+	st.Expect(t, bs.Assets.Total, 350.00)
+	st.Expect(t, bs.Assets.Compare, 100.00)
+
+	// Cash flow
+	w = doJSONRequest(r, "GET", "/api/v3/33/reports/cash-flow?start=2024-01-01&end=2024-12-31", ``)
+	st.Expect(t, w.Code, 200)
+
+	cf := reports.CashFlow{}
+	json.Unmarshal(w.Body.Bytes(), &cf)
+	st.Expect(t, cf.CompareStart, "2023-01-01")
+	st.Expect(t, cf.CompareEnd, "2023-12-31")
+	st.Expect(t, cf.Operating.Total, 250.00)
+	st.Expect(t, cf.Operating.Compare, 100.00)
+	st.Expect(t, cf.EndingCash.Amount, 350.00)
+
+	// Pick the compare period.
+	w = doJSONRequest(r, "GET", "/api/v3/33/reports/cash-flow?start=2024-01-01&end=2024-12-31&compare_start=2020-01-01&compare_end=2020-12-31", ``)
+	json.Unmarshal(w.Body.Bytes(), &cf)
+	st.Expect(t, cf.CompareStart, "2020-01-01")
+	st.Expect(t, cf.Operating.Compare, 0.00)
+}
+
+//
+// TestReportsTaxSummary01 - Tax summary as json, csv and pdf.
+//
+func TestReportsTaxSummary01(t *testing.T) {
+	// Start the db connection.
+	db, dbName, _ := models.NewTestDB("")
+	defer models.TestingTearDown(db, dbName)
+
+	// Create controller
+	c := &Controller{}
+	c.SetDB(db)
+
+	db.LedgerCreate(&models.Ledger{AccountId: 33, Date: helpers.ParseDateNoError("2024-05-01"), Amount: 250, Contact: models.Contact{Name: "Acme"}, Category: models.Category{Name: "Sales", Type: "2", Irs: "schedule-c:1"}})
+	db.LedgerCreate(&models.Ledger{AccountId: 33, Date: helpers.ParseDateNoError("2024-05-02"), Amount: -80, Contact: models.Contact{Name: "Acme"}, Category: models.Category{Name: "Gifts", Type: "1"}})
+
+	// Setup router
+	gin.SetMode("release")
+	gin.DisableConsoleColor()
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("accountId", 33)
+		c.Set("userId", 1)
+	})
+	r.GET("/api/v3/:account/reports/tax-summary", c.ReportsTaxSummary)
+
+	// Json
+	w := doJSONRequest(r, "GET", "/api/v3/33/reports/tax-summary?year=2024", ``)
+	st.Expect(t, w.Code, 200)
+
+	ts := reports.TaxSummary{}
+	json.Unmarshal(w.Body.Bytes(), &ts)
+	st.Expect(t, ts.Year, 2024)
+	st.Expect(t, len(ts.Forms), 1)
+	st.Expect(t, ts.Forms[0].Lines[0].Amount, 250.00)
+	st.Expect(t, ts.Unmapped[0].Name, "Gifts")
+	st.Expect(t, ts.Warnings, []string{"The Gifts category ($80.00) is not mapped to a tax form line."})
+
+	// Csv
+	w = doJSONRequest(r, "GET", "/api/v3/33/reports/tax-summary?year=2024&format=csv", ``)
+	st.Expect(t, w.Code, 200)
+	st.Expect(t, w.Header().Get("Content-Type"), "text/csv")
+	st.Expect(t, w.Header().Get("Content-Disposition"), `attachment; filename="tax-summary-2024.csv"`)
+	st.Expect(t, w.Body.String(), "Form,Line,Description,Amount,Categories\n"+
+		"Schedule C - Profit or Loss From Business,1,Gross receipts or sales,250.00,Sales\n"+
+		"Schedule C - Profit or Loss From Business,,Total income,250.00,\n"+
+		"Schedule C - Profit or Loss From Business,,Total expenses,0.00,\n"+
+		"Schedule C - Profit or Loss From Business,,Net profit or loss,250.00,\n"+
+		"Not on a tax form line,,Unmapped expense,80.00,Gifts\n")
+
+	// Pdf
+	w = doJSONRequest(r, "GET", "/api/v3/33/reports/tax-summary?year=2024&format=pdf", ``)
+	st.Expect(t, w.Code, 200)
+	st.Expect(t, w.Header().Get("Content-Type"), "application/pdf")
+	st.Expect(t, w.Body.String()[:8], "%PDF-1.4")
+
+	// Bad requests
+	w = doJSONRequest(r, "GET", "/api/v3/33/reports/tax-summary?year=last", ``)
+	st.Expect(t, w.Code, 400)
+	st.Expect(t, w.Body.String(), `{"errors":{"year":"The year must be a number."}}`)
+
+	w = doJSONRequest(r, "GET", "/api/v3/33/reports/tax-summary?format=xlsx", ``)
+	st.Expect(t, w.Code, 400)
+	st.Expect(t, w.Body.String(), `{"errors":{"format":"The format must be json, csv or pdf."}}`)
+}
+
+//
+// TestReportsPdf01 - The P&L reports as PDFs.
+//
+func TestReportsPdf01(t *testing.T) {
+	// Start the db connection.
+	db, dbName, _ := models.NewTestDB("")
+	defer models.TestingTearDown(db, dbName)
+
+	// Create controller
+	c := &Controller{}
+	c.SetDB(db)
+
+	db.LedgerCreate(&models.Ledger{AccountId: 33, Date: helpers.ParseDateNoError("2024-05-01"), Amount: 250, Contact: models.Contact{Name: "Acme"}, Category: models.Category{Name: "Sales", Type: "2"}, Labels: []models.Label{{Name: "Retail"}}})
+	db.LedgerCreate(&models.Ledger{AccountId: 33, Date: helpers.ParseDateNoError("2024-05-02"), Amount: -80, Contact: models.Contact{Name: "Airline"}, Category: models.Category{Name: "Travel", Type: "1"}})
+
+	// Setup router
+	gin.SetMode("release")
+	gin.DisableConsoleColor()
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("accountId", 33)
+		c.Set("userId", 1)
+	})
+	r.GET("/api/v3/:account/reports/pnl", c.ReportsPnl)
+	r.GET("/api/v3/:account/reports/pnl-label", c.ReportsPnlLabel)
+	r.GET("/api/v3/:account/reports/pnl-category", c.ReportsPnlCategory)
+	r.GET("/api/v3/:account/reports/income-by-contact", c.ReportsIncomeByContact)
+	r.GET("/api/v3/:account/reports/expenses-by-contact", c.ReportsExpensesByContact)
+
+	files := map[string]string{
+		"pnl?group=quarter":   "pnl-quarter.pdf",
+		"pnl?group=x\"y":      "pnl.pdf",
+		"pnl-label":           "pnl-label.pdf",
+		"pnl-category":        "pnl-category.pdf",
+		"income-by-contact":   "income-by-contact.pdf",
+		"expenses-by-contact": "expenses-by-contact.pdf",
+	}
+
+	for route, file := range files {
+		sep := "?"
+
+		if strings.Contains(route, "?") {
+			sep = "&"
+		}
+
+		w := doJSONRequest(r, "GET", "/api/v3/33/reports/"+route+sep+"start=2024-01-01&end=2024-12-31&format=pdf", ``)
+		st.Expect(t, w.Code, 200)
+		st.Expect(t, w.Header().Get("Content-Type"), "application/pdf")
+		st.Expect(t, w.Header().Get("Content-Disposition"), `attachment; filename="`+file+`"`)
+		st.Expect(t, w.Body.String()[:8], "%PDF-1.4")
+		st.Expect(t, strings.Contains(w.Body.String(), "/BaseFont /Roboto-Bold"), true)
+
+		// Json is still the default.
+		w = doJSONRequest(r, "GET", "/api/v3/33/reports/"+route, ``)
+		st.Expect(t, w.Code, 200)
+		st.Expect(t, w.Body.String()[:1], "[")
+
+		w = doJSONRequest(r, "GET", "/api/v3/33/reports/"+route+sep+"format=csv", ``)
+		st.Expect(t, w.Code, 400)
+		st.Expect(t, w.Body.String(), `{"errors":{"format":"The format must be json or pdf."}}`)
+	}
+}
+
+//
+// TestReportsContractors1099 - 1099 report as json and e-file csv.
+//
+func TestReportsContractors1099(t *testing.T) {
+	// Start the db connection.
+	db, dbName, _ := models.NewTestDB("")
+	defer models.TestingTearDown(db, dbName)
+
+	// Create controller
+	c := &Controller{}
+	c.SetDB(db)
+
+	acme := models.Contact{AccountId: 33, Name: "Acme Design", Address: "1 Main St", City: "Portland", State: "or", Zip: "97201", Is1099: true, TaxIdType: "ein", AccountNumber: "A-1"}
+	acme.SetTaxId("123456789")
+	db.Save(&acme)
+
+	db.LedgerCreate(&models.Ledger{AccountId: 33, Date: helpers.ParseDateNoError("2024-05-01"), Amount: -650, Contact: acme, Category: models.Category{Name: "Contract Labor", Type: "1"}})
+	db.LedgerCreate(&models.Ledger{AccountId: 33, Date: helpers.ParseDateNoError("2026-05-01"), Amount: -1500, Contact: acme, Category: models.Category{Name: "Contract Labor", Type: "1"}})
+
+	// Setup router
+	gin.SetMode("release")
+	gin.DisableConsoleColor()
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("accountId", 33)
+		c.Set("userId", 1)
+	})
+	r.GET("/api/v3/:account/reports/1099", c.ReportsContractors1099)
+
+	// Json never has the full tax id.
+	w := doJSONRequest(r, "GET", "/api/v3/33/reports/1099?year=2024", ``)
+	st.Expect(t, w.Code, 200)
+	st.Expect(t, strings.Contains(w.Body.String(), "3456789"), false)
+
+	result := reports.Contractors1099{}
+	json.Unmarshal(w.Body.Bytes(), &result)
+	st.Expect(t, result.Threshold, 600.00)
+	st.Expect(t, result.Total, 650.00)
+	st.Expect(t, result.Contractors[0].TaxIdLast4, "6789")
+
+	// 2026 has a higher threshold unless we pick one.
+	w = doJSONRequest(r, "GET", "/api/v3/33/reports/1099?year=2026", ``)
+	json.Unmarshal(w.Body.Bytes(), &result)
+	st.Expect(t, len(result.Contractors), 0)
+	st.Expect(t, result.BelowThreshold, 1)
+
+	w = doJSONRequest(r, "GET", "/api/v3/33/reports/1099?year=2026&threshold=1000", ``)
+	json.Unmarshal(w.Body.Bytes(), &result)
+	st.Expect(t, len(result.Contractors), 1)
+
+	// Csv
+	w = doJSONRequest(r, "GET", "/api/v3/33/reports/1099?year=2024&format=csv", ``)
+	st.Expect(t, w.Code, 200)
+	st.Expect(t, w.Header().Get("Content-Disposition"), `attachment; filename="1099-nec-2024.csv"`)
+	st.Expect(t, w.Body.String(), "Tax Year,Form Type,Recipient TIN Type,Recipient TIN,Recipient Name,Recipient First Name,Recipient Last Name,Address Line 1,City,State,ZIP,Country,Email,Account Number,Box 1 Nonemployee Compensation\n"+
+		"2024,1099-NEC,EIN,12-3456789,Acme Design,,,1 Main St,Portland,OR,97201,,,A-1,650.00\n")
+
+	// Bad requests
+	w = doJSONRequest(r, "GET", "/api/v3/33/reports/1099?threshold=lots", ``)
+	st.Expect(t, w.Code, 400)
+	st.Expect(t, w.Body.String(), `{"errors":{"threshold":"The threshold must be a number."}}`)
+
+	w = doJSONRequest(r, "GET", "/api/v3/33/reports/1099?format=pdf", ``)
+	st.Expect(t, w.Code, 400)
+	st.Expect(t, w.Body.String(), `{"errors":{"format":"The format must be json or csv."}}`)
+}
+
 /* End File */
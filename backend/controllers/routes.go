@@ -30,6 +30,7 @@ func (t *Controller) DoRoutes(r *gin.Engine) {
 		apiV1.PUT("/:account/account", t.UpdateAccount)
 		apiV1.POST("/:account/account/new", t.NewAccount)
 		apiV1.POST("/:account/account/clear", t.ClearAccount)
+		apiV1.GET("/:account/account/export", t.ExportAccount)
 		apiV1.POST("/:account/account/delete", t.DeleteAccount)
 		apiV1.PUT("/:account/account/subscription", t.ChangeSubscription)
 		apiV1.POST("/:account/account/stripe-token", t.NewStripeToken)
@@ -50,12 +51,76 @@ func (t *Controller) DoRoutes(r *gin.Engine) {
 
 		// Ledger
 		apiV1.GET("/:account/ledger", t.GetLedgers)
-		apiV1.GET("/:account/ledger/:id", t.GetLedger)
+		apiV1.GET("/:account/ledger/:id", t.GetLedger) // Also /ledger/suggest and /ledger/export. See GetLedger.
 		apiV1.GET("/:account/ledger-summary", t.GetLedgerSummary)
 		apiV1.GET("/:account/ledger-pl-summary", t.GetLedgerPlSummary)
 		apiV1.POST("/:account/ledger", t.CreateLedger)
 		apiV1.PUT("/:account/ledger/:id", t.UpdateLedger)
 		apiV1.DELETE("/:account/ledger/:id", t.DeleteLedger)
+		apiV1.GET("/:account/ledger/:id/history", t.GetLedgerHistory)
+		apiV1.POST("/:account/ledger/:id/restore/:revision", t.RestoreLedgerRevision)
+		apiV1.POST("/:account/ledger/:id", t.BulkLedger) // Only /ledger/bulk. See BulkLedger.
+
+		// Recurring
+		apiV1.GET("/:account/recurring", t.GetRecurrings)
+		apiV1.GET("/:account/recurring/:id", t.GetRecurring)
+		apiV1.POST("/:account/recurring", t.CreateRecurring)
+		apiV1.PUT("/:account/recurring/:id", t.UpdateRecurring)
+		apiV1.DELETE("/:account/recurring/:id", t.DeleteRecurring)
+
+		// Rules
+		apiV1.GET("/:account/rules", t.GetRules)
+		apiV1.GET("/:account/rules/:id", t.GetRule)
+		apiV1.POST("/:account/rules", t.CreateRule)
+		apiV1.POST("/:account/rules/apply", t.ApplyRules)
+		apiV1.PUT("/:account/rules/:id", t.UpdateRule)
+		apiV1.DELETE("/:account/rules/:id", t.DeleteRule)
+
+		// Reconciliations
+		apiV1.GET("/:account/reconciliations", t.GetReconciliations)
+		apiV1.GET("/:account/reconciliations/:id", t.GetReconciliation)
+		apiV1.POST("/:account/reconciliations", t.CreateReconciliation)
+		apiV1.POST("/:account/reconciliations/:id/clear", t.ClearReconciliationEntries)
+		apiV1.POST("/:account/reconciliations/:id/finish", t.FinishReconciliation)
+		apiV1.POST("/:account/reconciliations/:id/undo", t.UndoReconciliation)
+		apiV1.PUT("/:account/reconciliations/:id", t.UpdateReconciliation)
+		apiV1.DELETE("/:account/reconciliations/:id", t.DeleteReconciliation)
+
+		// Trash
+		apiV1.GET("/:account/trash", t.GetTrash)
+		apiV1.POST("/:account/trash/:id/restore", t.RestoreTrashItem)
+
+		// Ledger Views
+		apiV1.GET("/:account/views", t.GetLedgerViews)
+		apiV1.GET("/:account/views/:id", t.GetLedgerView)
+		apiV1.POST("/:account/views", t.CreateLedgerView)
+		apiV1.PUT("/:account/views/:id", t.UpdateLedgerView)
+		apiV1.DELETE("/:account/views/:id", t.DeleteLedgerView)
+
+		// Search
+		apiV1.GET("/:account/search", t.GetSearch)
+
+		// Closed Periods
+		apiV1.GET("/:account/periods", t.GetClosedPeriods)
+		apiV1.PUT("/:account/periods/closed-through", t.UpdateBooksClosedThrough)
+		apiV1.POST("/:account/periods", t.CreatePeriodLock)
+		apiV1.DELETE("/:account/periods/:id", t.ReopenPeriodLock)
+
+		// Exports
+		apiV1.GET("/:account/exports", t.GetExportJobs)
+		apiV1.GET("/:account/exports/:id", t.GetExportJob)
+		apiV1.POST("/:account/exports", t.CreateExportJob)
+
+		// Import
+		apiV1.POST("/:account/import/ofx/preview", t.PreviewOfxImport)
+		apiV1.POST("/:account/import/ofx", t.CommitOfxImport)
+		apiV1.POST("/:account/import/csv/preview", t.PreviewCsvImport)
+		apiV1.POST("/:account/import/csv", t.CommitCsvImport)
+		apiV1.GET("/:account/import/profiles", t.GetImportProfiles)
+		apiV1.GET("/:account/import/profiles/:id", t.GetImportProfile)
+		apiV1.POST("/:account/import/profiles", t.CreateImportProfile)
+		apiV1.PUT("/:account/import/profiles/:id", t.UpdateImportProfile)
+		apiV1.DELETE("/:account/import/profiles/:id", t.DeleteImportProfile)
 
 		// Labels
 		apiV1.GET("/:account/labels", t.GetLabels)
@@ -64,12 +129,32 @@ func (t *Controller) DoRoutes(r *gin.Engine) {
 		apiV1.PUT("/:account/labels/:id", t.UpdateLabel)
 		apiV1.DELETE("/:account/labels/:id", t.DeleteLabel)
 
+		// Chart Of Accounts
+		apiV1.GET("/:account/chart-accounts", t.GetChartAccounts)
+		apiV1.GET("/:account/chart-accounts/:id", t.GetChartAccount)
+		apiV1.POST("/:account/chart-accounts", t.CreateChartAccount)
+		apiV1.PUT("/:account/chart-accounts/:id", t.UpdateChartAccount)
+		apiV1.DELETE("/:account/chart-accounts/:id", t.DeleteChartAccount)
+
+		// Budgets
+		apiV1.GET("/:account/budgets", t.GetBudgets)
+		apiV1.GET("/:account/budgets/:id", t.GetBudget)
+		apiV1.POST("/:account/budgets", t.CreateBudget)
+		apiV1.PUT("/:account/budgets/:id", t.UpdateBudget)
+		apiV1.DELETE("/:account/budgets/:id", t.DeleteBudget)
+
+		// Report Subscriptions
+		apiV1.GET("/:account/report-subscriptions", t.GetReportSubscriptions)
+		apiV1.POST("/:account/report-subscriptions", t.CreateReportSubscription)
+		apiV1.DELETE("/:account/report-subscriptions/:id", t.DeleteReportSubscription)
+
 		// Categories
 		apiV1.GET("/:account/categories", t.GetCategories)
 		apiV1.GET("/:account/categories/:id", t.GetCategory)
 		apiV1.POST("/:account/categories", t.CreateCategory)
 		apiV1.PUT("/:account/categories/:id", t.UpdateCategory)
 		apiV1.DELETE("/:account/categories/:id", t.DeleteCategory)
+		apiV1.GET("/:account/tax-forms", t.GetTaxForms)
 
 		// Contacts
 		apiV1.GET("/:account/contacts", t.GetContacts)
@@ -97,6 +182,12 @@ func (t *Controller) DoRoutes(r *gin.Engine) {
 		apiV1.GET("/:account/reports/income-by-contact", t.ReportsIncomeByContact)
 		apiV1.GET("/:account/reports/expenses-by-contact", t.ReportsExpensesByContact)
 		apiV1.GET("/:account/reports/pnl-current-year", t.ReportsCurrentPnl)
+		apiV1.GET("/:account/reports/trial-balance", t.ReportsTrialBalance)
+		apiV1.GET("/:account/reports/balance-sheet", t.ReportsBalanceSheet)
+		apiV1.GET("/:account/reports/cash-flow", t.ReportsCashFlow)
+		apiV1.GET("/:account/reports/budget-vs-actual", t.ReportsBudgetVsActual)
+		apiV1.GET("/:account/reports/tax-summary", t.ReportsTaxSummary)
+		apiV1.GET("/:account/reports/1099", t.ReportsContractors1099)
 
 		// Stripe
 		apiV1.GET("/:account/stripe/authorize", t.StripeAuthorizeURL)
@@ -121,6 +212,10 @@ func (t *Controller) DoRoutes(r *gin.Engine) {
 	// Support
 	r.POST("/support/contact-us", t.ContactUs)
 
+	// Unsubscribe from scheduled report emails. GET confirms, POST unsubscribes.
+	r.GET("/reports/unsubscribe/:id", t.UnsubscribeReport)
+	r.POST("/reports/unsubscribe/:id", t.UnsubscribeReport)
+
 	// Stripe Auth Callback
 	r.GET("/stripe/auth/callback", t.StripeAuthCallback)
 
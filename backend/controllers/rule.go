@@ -0,0 +1,201 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"app.skyclerk.com/backend/library/request"
+	"app.skyclerk.com/backend/library/response"
+	"app.skyclerk.com/backend/models"
+)
+
+//
+// GetRules - Return a list of categorization rules in the order they run.
+//
+func (t *Controller) GetRules(c *gin.Context) {
+	// Place to store the results.
+	var results = []models.Rule{}
+
+	// Get limits and pages
+	page, limit, _ := request.GetSetPagingParms(c)
+
+	// Set the query parms
+	params := models.QueryParam{
+		Order:            c.DefaultQuery("order", "priority"),
+		Sort:             c.DefaultQuery("sort", "ASC"),
+		Limit:            limit,
+		Page:             page,
+		PreLoads:         []string{"Category", "Contact", "Labels"},
+		AllowedOrderCols: []string{"id", "priority", "name"},
+		Wheres: []models.KeyValue{
+			{Key: "account_id", Compare: "=", ValueInt: c.MustGet("accountId").(int)},
+		},
+	}
+
+	// Run the query
+	meta, err := t.db.QueryMeta(&results, params)
+
+	// Return json based on if this was a good result or not.
+	response.ResultsMeta(c, results, err, meta)
+}
+
+//
+// GetRule by id
+//
+func (t *Controller) GetRule(c *gin.Context) {
+	// Set id
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": err})
+		return
+	}
+
+	// Get rule and make sure we have perms to it
+	r, err := t.db.GetRuleByAccountAndId(uint(c.MustGet("accountId").(int)), uint(id))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Rule not found."})
+		return
+	}
+
+	// Return happy.
+	response.Results(c, r, nil)
+}
+
+//
+// CreateRule - Create a rule within the account.
+//
+func (t *Controller) CreateRule(c *gin.Context) {
+	// Setup Rule obj
+	o := models.Rule{}
+
+	// Here we parse the JSON sent in, assign it to a struct, set validation errors if any.
+	if t.ValidateRequest(c, &o, "create") != nil {
+		return
+	}
+
+	// Make sure the AccountId is correct.
+	o.Id = 0
+	o.AccountId = uint(c.MustGet("accountId").(int))
+
+	// Create rule
+	t.db.RuleCreate(&o)
+
+	// Fresh pull
+	r, err := t.db.GetRuleByAccountAndId(o.AccountId, o.Id)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "System error. Please contact help@skyclerk.com."})
+		return
+	}
+
+	// Return happy.
+	response.RespondCreated(c, r, nil)
+}
+
+//
+// UpdateRule - Update a rule within the account.
+//
+func (t *Controller) UpdateRule(c *gin.Context) {
+	// Get ID from URL
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": err})
+		return
+	}
+
+	// Get rule and make sure we have perms to it
+	org, err := t.db.GetRuleByAccountAndId(uint(c.MustGet("accountId").(int)), uint(id))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Rule not found."})
+		return
+	}
+
+	// Setup Rule obj
+	o := models.Rule{}
+
+	// Here we parse the JSON sent in, assign it to a struct, set validation errors if any.
+	if t.ValidateRequest(c, &o, "update") != nil {
+		return
+	}
+
+	// Make sure the Id, AccountId and CreatedAt are correct.
+	o.Id = org.Id
+	o.AccountId = org.AccountId
+	o.CreatedAt = org.CreatedAt
+
+	// Update rule
+	t.db.RuleUpdate(&o)
+
+	// Fresh pull
+	r, err := t.db.GetRuleByAccountAndId(org.AccountId, org.Id)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "System error. Please contact help@skyclerk.com."})
+		return
+	}
+
+	// Return happy.
+	response.RespondUpdated(c, r, nil)
+}
+
+//
+// DeleteRule a rule within the account.
+//
+func (t *Controller) DeleteRule(c *gin.Context) {
+	// Get Id
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": err})
+		return
+	}
+
+	// AccountId.
+	accountId := uint(c.MustGet("accountId").(int))
+
+	// First we make sure this is a rule we have access to.
+	_, err = t.db.GetRuleByAccountAndId(accountId, uint(id))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Rule not found."})
+		return
+	}
+
+	// Delete rule
+	err = t.db.DeleteRuleByAccountAndId(accountId, uint(id))
+
+	if err != nil {
+		response.RespondError(c, err)
+		return
+	}
+
+	// Return happy.
+	response.RespondDeleted(c, nil)
+}
+
+//
+// ApplyRules - Re-apply the account's rules to existing ledger entries. Pass
+// dry_run=true to see what would change without changing anything.
+//
+func (t *Controller) ApplyRules(c *gin.Context) {
+	// Run the rules
+	changes := t.db.ReapplyLedgerRules(uint(c.MustGet("accountId").(int)), uint(c.MustGet("userId").(int)), (c.DefaultQuery("dry_run", "false") == "true"))
+
+	// Return happy.
+	response.Results(c, changes, nil)
+}
+
+/* End File */
@@ -0,0 +1,207 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nbio/st"
+
+	"app.skyclerk.com/backend/library/test"
+	"app.skyclerk.com/backend/models"
+)
+
+//
+// TestCreateRule01 - Create a rule and make sure imports use it.
+//
+func TestCreateRule01(t *testing.T) {
+	// Start the db connection.
+	db, dbName, _ := models.NewTestDB("")
+	defer models.TestingTearDown(db, dbName)
+
+	// Create controller
+	c := &Controller{}
+	c.SetDB(db)
+
+	// Category we want
+	cat := db.GetOrCreateCategory(33, "Supplies", "1")
+
+	// Get JSON
+	postStr := []byte(`{ "name": "Home Depot", "payee_contains": "home depot", "type": "expense", "category_id": 1, "labels": [ { "name": "hardware" } ] }`)
+
+	// Setup request
+	req, _ := http.NewRequest("POST", "/api/v3/33/rules", bytes.NewBuffer(postStr))
+
+	// Setup writer.
+	w := httptest.NewRecorder()
+	gin.SetMode("release")
+	gin.DisableConsoleColor()
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("accountId", 33)
+		c.Set("userId", 1)
+	})
+	r.POST("/api/v3/33/rules", c.CreateRule)
+	r.ServeHTTP(w, req)
+
+	// Grab result and convert to strut
+	result := models.Rule{}
+	err := json.Unmarshal([]byte(w.Body.String()), &result)
+
+	// Test results
+	st.Expect(t, err, nil)
+	st.Expect(t, w.Code, 201)
+	st.Expect(t, result.Id, uint(1))
+	st.Expect(t, result.AccountId, uint(33))
+	st.Expect(t, result.Status, "Active")
+	st.Expect(t, result.Category.Id, cat.Id)
+	st.Expect(t, result.Labels[0].Name, "hardware")
+
+	// Imported entries pick up the rule.
+	l := test.GetRandomLedger(33)
+	l.Amount = -54.23
+	l.Contact = models.Contact{Name: "THE HOME DEPOT #1234"}
+	l.Category = models.Category{Name: "Other Expense", Type: "1"}
+	l.Labels = []models.Label{}
+	l.Source = "import"
+	db.LedgerCreate(&l)
+
+	l1, _ := db.GetLedgerByAccountAndId(33, l.Id)
+	st.Expect(t, l1.Category.Name, "Supplies")
+	st.Expect(t, l1.Labels[0].Name, "hardware")
+	st.Expect(t, l1.Source, "import")
+
+	// Entries people type in are left alone.
+	l2 := test.GetRandomLedger(33)
+	l2.Amount = -10.00
+	l2.Contact = models.Contact{Name: "Home Depot"}
+	l2.Category = models.Category{Name: "Travel", Type: "1"}
+	db.LedgerCreate(&l2)
+
+	l3, _ := db.GetLedgerByAccountAndId(33, l2.Id)
+	st.Expect(t, l3.Category.Name, "Travel")
+
+	// ----------- Test no conditions ---------- //
+
+	postStr = []byte(`{ "name": "Everything", "category_id": 1 }`)
+	req2, _ := http.NewRequest("POST", "/api/v3/33/rules", bytes.NewBuffer(postStr))
+	w2 := httptest.NewRecorder()
+	r.ServeHTTP(w2, req2)
+
+	st.Expect(t, w2.Code, 400)
+	st.Expect(t, w2.Body.String(), `{"errors":{"payee_contains":"A rule needs at least one condition."}}`)
+
+	// ----------- Test category in another account ---------- //
+
+	theirs := db.GetOrCreateCategory(34, "Theirs", "1")
+	postStr = []byte(fmt.Sprintf(`{ "name": "Bad", "payee_contains": "x", "category_id": %d }`, theirs.Id))
+	req3, _ := http.NewRequest("POST", "/api/v3/33/rules", bytes.NewBuffer(postStr))
+	w3 := httptest.NewRecorder()
+	r.ServeHTTP(w3, req3)
+
+	st.Expect(t, w3.Code, 400)
+	st.Expect(t, w3.Body.String(), `{"errors":{"category_id":"Category not found."}}`)
+}
+
+//
+// TestApplyRules01 - Re-apply rules to existing entries with and without a dry run.
+//
+func TestApplyRules01(t *testing.T) {
+	// Start the db connection.
+	db, dbName, _ := models.NewTestDB("")
+	defer models.TestingTearDown(db, dbName)
+
+	// Create controller
+	c := &Controller{}
+	c.SetDB(db)
+
+	// Existing entries
+	for i := 0; i < 3; i++ {
+		l := test.GetRandomLedger(33)
+		l.Amount = -20.00
+		l.Contact = models.Contact{Name: "Shell Gas"}
+		l.Category = models.Category{Name: "Other Expense", Type: "1"}
+		db.LedgerCreate(&l)
+	}
+
+	l := test.GetRandomLedger(33)
+	l.Amount = -500.00
+	l.Contact = models.Contact{Name: "Shell Gas"}
+	l.Category = models.Category{Name: "Other Expense", Type: "1"}
+	db.LedgerCreate(&l)
+
+	// Rule for small gas purchases
+	cat := db.GetOrCreateCategory(33, "Car & Truck Expenses", "1")
+	rule := models.Rule{AccountId: 33, Name: "Gas", PayeeContains: "shell", AmountMax: 100, CategoryId: cat.Id, Note: "Fuel"}
+	db.RuleCreate(&rule)
+
+	// Setup request
+	req, _ := http.NewRequest("POST", "/api/v3/33/rules/apply?dry_run=true", nil)
+
+	// Setup writer.
+	w := httptest.NewRecorder()
+	gin.SetMode("release")
+	gin.DisableConsoleColor()
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("accountId", 33)
+		c.Set("userId", 1)
+	})
+	r.POST("/api/v3/33/rules/apply", c.ApplyRules)
+	r.ServeHTTP(w, req)
+
+	// Grab result and convert to strut
+	results := []models.RuleChange{}
+	err := json.Unmarshal([]byte(w.Body.String()), &results)
+
+	// Test results
+	st.Expect(t, err, nil)
+	st.Expect(t, w.Code, 200)
+	st.Expect(t, len(results), 3)
+	st.Expect(t, results[0].RuleId, rule.Id)
+	st.Expect(t, results[0].Changes[0].Field, "category")
+	st.Expect(t, results[0].Changes[0].From, "Other Expense")
+	st.Expect(t, results[0].Changes[0].To, "Car & Truck Expenses")
+	st.Expect(t, results[0].Changes[1].Field, "note")
+
+	// Dry run changed nothing.
+	var count int
+	db.New().Model(&models.Ledger{}).Where("LedgerCategoryId = ?", cat.Id).Count(&count)
+	st.Expect(t, count, 0)
+
+	// ----------- For real ---------- //
+
+	req2, _ := http.NewRequest("POST", "/api/v3/33/rules/apply", nil)
+	w2 := httptest.NewRecorder()
+	r.ServeHTTP(w2, req2)
+
+	st.Expect(t, w2.Code, 200)
+
+	db.New().Model(&models.Ledger{}).Where("LedgerCategoryId = ? AND LedgerNote = ?", cat.Id, "Fuel").Count(&count)
+	st.Expect(t, count, 3)
+
+	db.New().Model(&models.Activity{}).Where("sub_action = ?", "update").Count(&count)
+	st.Expect(t, count, 3)
+
+	// Nothing left to change.
+	req3, _ := http.NewRequest("POST", "/api/v3/33/rules/apply?dry_run=true", nil)
+	w3 := httptest.NewRecorder()
+	r.ServeHTTP(w3, req3)
+
+	st.Expect(t, w3.Body.String(), `[]`)
+}
+
+/* End File */
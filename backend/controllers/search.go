@@ -0,0 +1,64 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"app.skyclerk.com/backend/library/response"
+)
+
+// Most search results we return at one time.
+const searchMaxLimit = 100
+
+//
+// GetSearch - Search ledger entries, contacts, labels and categories at once.
+// ?search=home depot 42.50 - words match on prefix, "quoted words" as a
+// phrase and amounts match the entry amount. Limit with ?types=ledger,contact
+// and ?limit=25. Best match first.
+//
+func (t *Controller) GetSearch(c *gin.Context) {
+	search := strings.TrimSpace(c.DefaultQuery("search", ""))
+
+	if len(search) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": gin.H{"search": "The search field is required."}})
+		return
+	}
+
+	// Types to search
+	types := []string{}
+
+	if len(c.DefaultQuery("types", "")) > 0 {
+		for _, row := range strings.Split(c.DefaultQuery("types", ""), ",") {
+			switch strings.TrimSpace(row) {
+			case "ledger", "contact", "label", "category":
+				types = append(types, strings.TrimSpace(row))
+			default:
+				c.JSON(http.StatusBadRequest, gin.H{"errors": gin.H{"types": "The types field must be ledger, contact, label or category."}})
+				return
+			}
+		}
+	}
+
+	// How many to return
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "25"))
+
+	if (err != nil) || (limit < 1) || (limit > searchMaxLimit) {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": gin.H{"limit": "The limit field must be between 1 and 100."}})
+		return
+	}
+
+	// Return happy.
+	response.Results(c, t.db.Search(uint(c.MustGet("accountId").(int)), search, types, limit), nil)
+}
+
+/* End File */
@@ -0,0 +1,171 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nbio/st"
+
+	"app.skyclerk.com/backend/library/test"
+	"app.skyclerk.com/backend/models"
+)
+
+//
+// TestSearch01 - Search across ledger entries, contacts, labels and categories.
+//
+func TestSearch01(t *testing.T) {
+	// Start the db connection.
+	db, dbName, _ := models.NewTestDB("")
+	defer models.TestingTearDown(db, dbName)
+
+	// Create controller
+	c := &Controller{}
+	c.SetDB(db)
+
+	l1 := test.GetRandomLedger(33)
+	l1.Amount = -42.50
+	l1.Note = "Lumber for the back deck"
+	l1.Contact = models.Contact{Name: "Home Depot"}
+	l1.Category = models.Category{Name: "Supplies", Type: "1"}
+	l1.Labels = []models.Label{{Name: "Client A"}}
+	db.LedgerCreate(&l1)
+
+	l2 := test.GetRandomLedger(33)
+	l2.Amount = -1200.00
+	l2.Note = "New laptop for the office"
+	l2.Contact = models.Contact{Name: "Apple Inc."}
+	l2.Category = models.Category{Name: "Equipment", Type: "1"}
+	l2.Labels = []models.Label{{Name: "Office"}}
+	db.LedgerCreate(&l2)
+
+	l3 := test.GetRandomLedger(33)
+	l3.Amount = 4250.00
+	l3.Note = "Invoice 1042 paid"
+	l3.Contact = models.Contact{Name: "Acme Corp"}
+	l3.Category = models.Category{Name: "Sales", Type: "2"}
+	l3.Labels = []models.Label{}
+	db.LedgerCreate(&l3)
+
+	// Someone else's laptop
+	other := test.GetRandomLedger(34)
+	other.Note = "Laptop stand"
+	db.LedgerCreate(&other)
+
+	// Setup router
+	gin.SetMode("release")
+	gin.DisableConsoleColor()
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("accountId", 33)
+		c.Set("userId", 1)
+	})
+	r.GET("/api/v3/:account/search", c.GetSearch)
+	r.GET("/api/v3/:account/ledger", c.GetLedgers)
+	r.DELETE("/api/v3/:account/ledger/:id", c.DeleteLedger)
+	r.PUT("/api/v3/:account/labels/:id", c.UpdateLabel)
+
+	search := func(q string) []models.SearchResult {
+		results := []models.SearchResult{}
+		w := doJSONRequest(r, "GET", "/api/v3/33/search?search="+url.QueryEscape(q), ``)
+		st.Expect(t, w.Code, 200)
+		json.Unmarshal([]byte(w.Body.String()), &results)
+		return results
+	}
+
+	// Validation
+	w := doJSONRequest(r, "GET", "/api/v3/33/search", ``)
+	st.Expect(t, w.Code, 400)
+	st.Expect(t, w.Body.String(), `{"errors":{"search":"The search field is required."}}`)
+
+	w = doJSONRequest(r, "GET", "/api/v3/33/search?search=depot&types=files", ``)
+	st.Expect(t, w.Code, 400)
+
+	// Prefix match, highlighted.
+	w = doJSONRequest(r, "GET", "/api/v3/33/search?search=dep&types=contact", ``)
+	results := []models.SearchResult{}
+	err := json.Unmarshal([]byte(w.Body.String()), &results)
+
+	st.Expect(t, err, nil)
+	st.Expect(t, len(results), 1)
+	st.Expect(t, results[0].Type, "contact")
+	st.Expect(t, results[0].Id, l1.Contact.Id)
+	st.Expect(t, results[0].TitleHighlight, "Home <mark>Depot</mark>")
+
+	results = search("lumb")
+	st.Expect(t, len(results), 1)
+	st.Expect(t, results[0].Id, l1.Id)
+	st.Expect(t, results[0].Amount, -42.50)
+	st.Expect(t, results[0].BodyHighlight, "<mark>Lumber</mark> for the back deck · Supplies · Client A")
+
+	// Mixed types, the category name beats a mention in an entry.
+	results = search("supplies")
+	st.Expect(t, len(results), 2)
+	st.Expect(t, results[0].Type, "category")
+	st.Expect(t, results[1].Type, "ledger")
+
+	// Phrases
+	st.Expect(t, len(search(`"back deck"`)), 1)
+	st.Expect(t, len(search(`"deck back"`)), 0)
+
+	// Amounts
+	results = search("42.50")
+	st.Expect(t, len(results), 1)
+	st.Expect(t, results[0].Id, l1.Id)
+
+	st.Expect(t, len(search("$1,200")), 1)
+	st.Expect(t, len(search("42.5 depot")), 1)
+	st.Expect(t, len(search("42.50 apple")), 0)
+
+	// Renaming a label updates the entries it is on.
+	w = doJSONRequest(r, "PUT", fmt.Sprintf("/api/v3/33/labels/%d", l1.Labels[0].Id), `{ "name": "Client B" }`)
+	st.Expect(t, w.Code, 200)
+
+	results = search(`"client b"`)
+	st.Expect(t, len(results), 2)
+	st.Expect(t, len(search(`"client a"`)), 0)
+
+	// The ledger listing uses the same index.
+	w = doJSONRequest(r, "GET", "/api/v3/33/ledger?search=laptop", ``)
+	ledgers := []models.Ledger{}
+	json.Unmarshal([]byte(w.Body.String()), &ledgers)
+
+	st.Expect(t, len(ledgers), 1)
+	st.Expect(t, ledgers[0].Id, l2.Id)
+
+	ledgerSearch := func(q string) []models.Ledger {
+		ledgers := []models.Ledger{}
+		w := doJSONRequest(r, "GET", "/api/v3/33/ledger?search="+url.QueryEscape(q), ``)
+		st.Expect(t, w.Code, 200)
+		json.Unmarshal([]byte(w.Body.String()), &ledgers)
+		return ledgers
+	}
+
+	st.Expect(t, len(ledgerSearch(`"back deck"`)), 1)
+	st.Expect(t, ledgerSearch("42.50")[0].Id, l1.Id)
+	st.Expect(t, len(ledgerSearch("42.50 apple")), 0)
+	st.Expect(t, len(ledgerSearch("supplies")), 1)
+	st.Expect(t, len(ledgerSearch("!!!")), 0)
+
+	// Trashed entries drop out.
+	w = doJSONRequest(r, "DELETE", fmt.Sprintf("/api/v3/33/ledger/%d", l2.Id), ``)
+	st.Expect(t, w.Code, 204)
+	st.Expect(t, len(search("laptop")), 0)
+
+	// Rebuilding gives the same answers.
+	db.ReindexSearch(33)
+	st.Expect(t, len(search("lumber")), 1)
+	st.Expect(t, len(search("laptop")), 0)
+}
+
+/* End File */
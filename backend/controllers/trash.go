@@ -0,0 +1,95 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"app.skyclerk.com/backend/library/realip"
+	"app.skyclerk.com/backend/library/response"
+	"app.skyclerk.com/backend/models"
+)
+
+//
+// GetTrash - Return what is in the trash. Filter with ?type=ledger, contact,
+// category or label.
+//
+func (t *Controller) GetTrash(c *gin.Context) {
+	itemType := c.DefaultQuery("type", "")
+
+	switch itemType {
+	case "", "ledger", "contact", "category", "label":
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"errors": gin.H{"type": "The type field must be ledger, contact, category or label."}})
+		return
+	}
+
+	// Return happy.
+	response.Results(c, t.db.GetTrashByAccount(uint(c.MustGet("accountId").(int)), itemType), nil)
+}
+
+//
+// RestoreTrashItem - Take something out of the trash.
+//
+func (t *Controller) RestoreTrashItem(c *gin.Context) {
+	// Get user and account
+	userId := uint(c.MustGet("userId").(int))
+	accountId := uint(c.MustGet("accountId").(int))
+
+	// Set id
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": err})
+		return
+	}
+
+	// Get the item and make sure we have perms to it
+	item, err := t.db.GetTrashItemByAccountAndId(accountId, uint(id))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Trash item not found."})
+		return
+	}
+
+	// Restore
+	if err := t.db.RestoreTrashItem(item); err != nil {
+		response.RespondError(c, err)
+		return
+	}
+
+	// Restored ledger entries go in the history and the activity log.
+	if item.Type == "ledger" {
+		t.db.CreateLedgerRevision(accountId, item.ObjectId, userId, realip.RealIP(c.Request), "restore")
+
+		// Set the ledger type
+		ledgerType := "expense"
+
+		if item.Amount > 0 {
+			ledgerType = "income"
+		}
+
+		t.db.New().Create(&models.Activity{
+			AccountId: accountId,
+			UserId:    userId,
+			Action:    ledgerType,
+			SubAction: "restore",
+			Name:      item.Name,
+			Amount:    item.Amount,
+			LedgerId:  item.ObjectId,
+		})
+	}
+
+	// Return happy.
+	response.RespondUpdated(c, item, nil)
+}
+
+/* End File */
@@ -0,0 +1,222 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nbio/st"
+
+	"app.skyclerk.com/backend/library/reports"
+	"app.skyclerk.com/backend/library/test"
+	"app.skyclerk.com/backend/models"
+)
+
+//
+// TestTrash01 - Delete, list, restore and purge ledger entries and labels.
+//
+func TestTrash01(t *testing.T) {
+	// Start the db connection.
+	db, dbName, _ := models.NewTestDB("")
+	defer models.TestingTearDown(db, dbName)
+
+	// Create controller
+	c := &Controller{}
+	c.SetDB(db)
+
+	// Two entries in 2024 with a file on the first.
+	file := test.GetRandomFile(33)
+	db.Save(&file)
+
+	l1 := test.GetRandomLedger(33)
+	l1.Date = time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	l1.Amount = -100.00
+	l1.Contact = models.Contact{Name: "Home Depot"}
+	l1.Labels = []models.Label{{Name: "Trip"}, {Name: "Clients"}}
+	l1.Files = []models.File{file}
+	db.LedgerCreate(&l1)
+
+	l2 := test.GetRandomLedger(33)
+	l2.Date = time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)
+	l2.Amount = -50.00
+	l2.Contact = models.Contact{Name: "Apple Inc."}
+	l2.Labels = []models.Label{{Name: "Trip"}, {Name: "Refund"}}
+	db.LedgerCreate(&l2)
+
+	// Setup router
+	gin.SetMode("release")
+	gin.DisableConsoleColor()
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("accountId", 33)
+		c.Set("userId", 1)
+	})
+	r.GET("/api/v3/:account/ledger", c.GetLedgers)
+	r.DELETE("/api/v3/:account/ledger/:id", c.DeleteLedger)
+	r.DELETE("/api/v3/:account/labels/:id", c.DeleteLabel)
+	r.DELETE("/api/v3/:account/contacts/:id", c.DeleteContact)
+	r.GET("/api/v3/:account/trash", c.GetTrash)
+	r.POST("/api/v3/:account/trash/:id/restore", c.RestoreTrashItem)
+
+	// Trash the first entry.
+	w := doJSONRequest(r, "DELETE", fmt.Sprintf("/api/v3/33/ledger/%d", l1.Id), ``)
+	st.Expect(t, w.Code, 204)
+
+	// Gone from the list and the reports.
+	w = doJSONRequest(r, "GET", "/api/v3/33/ledger", ``)
+	ledgers := []models.Ledger{}
+	json.Unmarshal([]byte(w.Body.String()), &ledgers)
+
+	st.Expect(t, len(ledgers), 1)
+	st.Expect(t, ledgers[0].Id, l2.Id)
+
+	pnl := reports.GetCurrentYearPnL(db, 33, 2024)
+	st.Expect(t, pnl.Value, -50.00)
+
+	// The contact is still in use by the trashed entry.
+	w = doJSONRequest(r, "DELETE", fmt.Sprintf("/api/v3/33/contacts/%d", l1.Contact.Id), ``)
+	st.Expect(t, w.Code, 400)
+
+	// Trash a label both entries use.
+	w = doJSONRequest(r, "DELETE", fmt.Sprintf("/api/v3/33/labels/%d", l2.Labels[0].Id), ``)
+	st.Expect(t, w.Code, 204)
+
+	l, _ := db.GetLedgerByAccountAndId(33, l2.Id)
+	st.Expect(t, len(l.Labels), 1)
+
+	// What is in the trash
+	w = doJSONRequest(r, "GET", "/api/v3/33/trash", ``)
+	items := []models.TrashItem{}
+	err := json.Unmarshal([]byte(w.Body.String()), &items)
+
+	st.Expect(t, err, nil)
+	st.Expect(t, w.Code, 200)
+	st.Expect(t, len(items), 2)
+	st.Expect(t, items[0].Type, "label")
+	st.Expect(t, items[0].Name, "Trip")
+	st.Expect(t, items[1].Type, "ledger")
+	st.Expect(t, items[1].ObjectId, l1.Id)
+	st.Expect(t, items[1].Amount, -100.00)
+	st.Expect(t, items[1].PurgeAt, items[1].CreatedAt.AddDate(0, 0, 30))
+
+	w = doJSONRequest(r, "GET", "/api/v3/33/trash?type=ledger", ``)
+	json.Unmarshal([]byte(w.Body.String()), &items)
+	st.Expect(t, len(items), 1)
+
+	w = doJSONRequest(r, "GET", "/api/v3/33/trash?type=files", ``)
+	st.Expect(t, w.Code, 400)
+
+	// Restore both. The label is still in the trash so it waits to be restored too.
+	w = doJSONRequest(r, "POST", "/api/v3/33/trash/1/restore", ``)
+	st.Expect(t, w.Code, 200)
+
+	l, _ = db.GetLedgerByAccountAndId(33, l1.Id)
+	st.Expect(t, l.Id, l1.Id)
+	st.Expect(t, len(l.Labels), 1)
+	st.Expect(t, len(l.Files), 1)
+
+	count := 0
+	db.New().Model(&models.LabelsToLedger{}).Where("LabelsToLedgerLedgerId = ? AND LabelsToLedgerLabelId = ?", l1.Id, l2.Labels[0].Id).Count(&count)
+	st.Expect(t, count, 0)
+
+	w = doJSONRequest(r, "POST", "/api/v3/33/trash/2/restore", ``)
+	st.Expect(t, w.Code, 200)
+
+	l, _ = db.GetLedgerByAccountAndId(33, l1.Id)
+	st.Expect(t, len(l.Labels), 2)
+
+	l, _ = db.GetLedgerByAccountAndId(33, l2.Id)
+	st.Expect(t, len(l.Labels), 2)
+
+	st.Expect(t, reports.GetCurrentYearPnL(db, 33, 2024).Value, -150.00)
+	st.Expect(t, len(db.GetTrashByAccount(33, "")), 0)
+	st.Expect(t, db.GetLedgerRevisions(33, l1.Id)[0].Action, "restore")
+
+	// Can not restore twice.
+	w = doJSONRequest(r, "POST", "/api/v3/33/trash/1/restore", ``)
+	st.Expect(t, w.Code, 400)
+	st.Expect(t, w.Body.String(), `{"error":"Trash item not found."}`)
+
+	// Purge only what is past the retention period.
+	doJSONRequest(r, "DELETE", fmt.Sprintf("/api/v3/33/ledger/%d", l1.Id), ``)
+
+	st.Expect(t, db.PurgeTrash(time.Now().AddDate(0, 0, -30)), 0)
+	st.Expect(t, db.PurgeTrash(time.Now().Add(time.Minute)), 1)
+
+	db.New().Unscoped().Model(&models.Ledger{}).Where("LedgerId = ?", l1.Id).Count(&count)
+	st.Expect(t, count, 0)
+
+	// Now the contact can go.
+	w = doJSONRequest(r, "DELETE", fmt.Sprintf("/api/v3/33/contacts/%d", l1.Contact.Id), ``)
+	st.Expect(t, w.Code, 204)
+}
+
+//
+// TestTrash02 - Restoring a label leaves locked entries alone.
+//
+func TestTrash02(t *testing.T) {
+	// Start the db connection.
+	db, dbName, _ := models.NewTestDB("")
+	defer models.TestingTearDown(db, dbName)
+
+	// Create controller
+	c := &Controller{}
+	c.SetDB(db)
+
+	// One open, one reconciled and one in a closed period.
+	ledgers := []models.Ledger{}
+
+	for _, day := range []int{1, 2, 3} {
+		l := test.GetRandomLedger(33)
+		l.Date = time.Date(2024, 3, day, 0, 0, 0, 0, time.UTC)
+		l.Contact = models.Contact{Name: "Home Depot"}
+		l.Labels = []models.Label{{Name: "Trip"}}
+		db.LedgerCreate(&l)
+		ledgers = append(ledgers, l)
+	}
+
+	label := ledgers[0].Labels[0]
+
+	// Setup router
+	gin.SetMode("release")
+	gin.DisableConsoleColor()
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("accountId", 33)
+		c.Set("userId", 1)
+	})
+	r.DELETE("/api/v3/:account/labels/:id", c.DeleteLabel)
+	r.POST("/api/v3/:account/trash/:id/restore", c.RestoreTrashItem)
+
+	w := doJSONRequest(r, "DELETE", fmt.Sprintf("/api/v3/33/labels/%d", label.Id), ``)
+	st.Expect(t, w.Code, 204)
+
+	db.New().Model(&models.Ledger{}).Where("LedgerId = ?", ledgers[1].Id).UpdateColumn("LedgerStatus", "reconciled")
+	db.Save(&models.PeriodLock{AccountId: 33, UserId: 1, StartDate: time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC)})
+
+	w = doJSONRequest(r, "POST", "/api/v3/33/trash/1/restore", ``)
+	st.Expect(t, w.Code, 200)
+
+	l, _ := db.GetLedgerByAccountAndId(33, ledgers[0].Id)
+	st.Expect(t, len(l.Labels), 1)
+	st.Expect(t, l.Labels[0].Id, label.Id)
+
+	l, _ = db.GetLedgerByAccountAndId(33, ledgers[1].Id)
+	st.Expect(t, len(l.Labels), 0)
+
+	l, _ = db.GetLedgerByAccountAndId(33, ledgers[2].Id)
+	st.Expect(t, len(l.Labels), 0)
+}
+
+/* End File */
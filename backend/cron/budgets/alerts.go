@@ -0,0 +1,112 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package budgets
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"app.skyclerk.com/backend/emails"
+	"app.skyclerk.com/backend/library/email"
+	"app.skyclerk.com/backend/library/reports"
+	"app.skyclerk.com/backend/models"
+	"app.skyclerk.com/backend/services"
+)
+
+//
+// BudgetAlerts will email everyone on an account when one of its spending
+// budgets crosses 80% and again at 100% for the current period. We remember
+// what we sent so each alert goes out once per period.
+//
+func BudgetAlerts(db models.Datastore) {
+	services.InfoMsg("Starting budget alerts.")
+
+	// Track how many we send.
+	count := 0
+	now := time.Now()
+
+	for _, accountId := range db.GetBudgetAccountIds() {
+		account, err := db.GetAccountById(accountId)
+
+		if err != nil {
+			services.Info(fmt.Errorf("BudgetAlerts - Account: %d - %s", accountId, err.Error()))
+			continue
+		}
+
+		for _, row := range db.GetBudgetsByAccount(accountId) {
+			b := row
+			ba := reports.GetBudgetActual(db, b, now)
+			level := alertLevel(b, ba)
+
+			if level == 0 {
+				continue
+			}
+
+			sendBudgetAlertEmail(db, account, ba, level)
+			db.BudgetAlertSent(&b, ba.Start, level)
+			count++
+		}
+	}
+
+	services.InfoMsg(fmt.Sprintf("Sent %d budget alerts.", count))
+}
+
+// ----------------- Private Helper Funcs -------------- //
+
+//
+// alertLevel - The alert we owe for a budget, 80 or 100, or zero if we have
+// already sent it this period. Income targets do not get alerts.
+//
+func alertLevel(b models.Budget, ba reports.BudgetActual) int {
+	if ba.Type != "expense" {
+		return 0
+	}
+
+	level := 0
+
+	if ba.PercentUsed >= 100 {
+		level = 100
+	} else if ba.PercentUsed >= 80 {
+		level = 80
+	}
+
+	// A new period starts over.
+	sent := b.AlertLevel
+
+	if b.AlertStart != ba.Start {
+		sent = 0
+	}
+
+	if level <= sent {
+		return 0
+	}
+
+	return level
+}
+
+//
+// sendBudgetAlertEmail - Let everyone on the account know where the budget is at.
+//
+func sendBudgetAlertEmail(db models.Datastore, account models.Account, ba reports.BudgetActual, level int) {
+	url := os.Getenv("SITE_URL") + "/" + strconv.Itoa(int(account.Id)) + "/budgets"
+	subject := fmt.Sprintf("Your %s Budget Is %d%% Used", ba.Name, level)
+
+	if level >= 100 {
+		subject = fmt.Sprintf("Your %s Budget Is Used Up", ba.Name)
+	}
+
+	for _, user := range db.GetUsersByAccount(account.Id) {
+		if err := email.Send(user.Email, "", subject, emails.GetBudgetAlertHTML(user, account, ba, url), []string{}); err != nil {
+			services.Info(fmt.Errorf("BudgetAlerts - Budget: %d, User: %d - %s", ba.BudgetId, user.Id, err.Error()))
+		}
+	}
+}
+
+/* End File */
@@ -12,7 +12,12 @@ import (
 	"github.com/robfig/cron"
 
 	"app.skyclerk.com/backend/cron/account"
+	"app.skyclerk.com/backend/cron/budgets"
+	"app.skyclerk.com/backend/cron/exports"
+	"app.skyclerk.com/backend/cron/ledger"
+	"app.skyclerk.com/backend/cron/subscriptions"
 	"app.skyclerk.com/backend/cron/sync"
+	"app.skyclerk.com/backend/cron/trash"
 	"app.skyclerk.com/backend/models"
 	"app.skyclerk.com/backend/services"
 )
@@ -27,6 +32,11 @@ func Start(db models.Datastore) {
 	// Stuff we do on start as well
 	sync.StripeSync(db)
 	account.ExpireTrails(db)
+	ledger.RecurringEntries(db)
+	trash.PurgeTrash(db)
+	exports.PurgeExportJobs(db)
+	budgets.BudgetAlerts(db)
+	subscriptions.ScheduledReports(db)
 
 	// New Cron instance
 	c := cron.New()
@@ -34,9 +44,25 @@ func Start(db models.Datastore) {
 	// User clean up stuff
 	c.AddFunc("@every 50m", func() { account.ExpireTrails(db) }) // Some reason 1h does not work.
 
+	// Email the weekly, monthly and quarterly reports people subscribed to.
+	c.AddFunc("@every 50m", func() { subscriptions.ScheduledReports(db) }) // Same as above, 1h does not work.
+
 	// Connected accounts sync.
 	c.AddFunc("@every 30m", func() { sync.StripeSync(db) })
 
+	// Recurring ledger entries.
+	c.AddFunc("@every 50m", func() { ledger.RecurringEntries(db) }) // Same as above, 1h does not work.
+
+	// Let people know when they are close to or over a budget.
+	c.AddFunc("@every 50m", func() { budgets.BudgetAlerts(db) }) // Same as above, 1h does not work.
+
+	// Empty old things out of the trash.
+	c.AddFunc("@every 6h", func() { trash.PurgeTrash(db) })
+
+	// Build exports people asked for and remove old ones.
+	c.AddFunc("@every 1m", func() { exports.RunExportJobs(db) })
+	c.AddFunc("@every 6h", func() { exports.PurgeExportJobs(db) })
+
 	// System stuff.
 	c.AddFunc("@every 10s", func() { DatabasePing(db) })
 
@@ -0,0 +1,97 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package exports
+
+import (
+	"fmt"
+	"time"
+
+	"app.skyclerk.com/backend/emails"
+	"app.skyclerk.com/backend/library/email"
+	"app.skyclerk.com/backend/library/export"
+	"app.skyclerk.com/backend/library/store/object"
+	"app.skyclerk.com/backend/models"
+	"app.skyclerk.com/backend/services"
+)
+
+// A running job that has not moved in this long died with the server.
+const staleAfter = time.Hour
+
+// Swapped out in tests so we do not hit the object store.
+var deleteObject = object.DeleteObject
+
+//
+// RunExportJobs will build every pending export and email the person who
+// asked for it a link to download it.
+//
+func RunExportJobs(db models.Datastore) {
+	db.ResetStaleExportJobs(time.Now().Add(-staleAfter))
+
+	for _, row := range db.GetPendingExportJobs() {
+		job := row
+
+		// Another server got to it first.
+		if !db.ClaimExportJob(&job) {
+			continue
+		}
+
+		services.InfoMsg(fmt.Sprintf("Building %s export %d for account %d.", job.Type, job.Id, job.AccountId))
+
+		if err := export.BuildYearEnd(db, &job); err != nil {
+			services.Info(fmt.Errorf("RunExportJobs - Export: %d, Account: %d - %s", job.Id, job.AccountId, err.Error()))
+			db.ExportJobFailed(&job, err)
+			continue
+		}
+
+		sendExportReadyEmail(db, job)
+	}
+}
+
+//
+// PurgeExportJobs will remove exports from the object store once their
+// download link has expired.
+//
+func PurgeExportJobs(db models.Datastore) {
+	count := 0
+
+	for _, row := range db.GetExpiredExportJobs(time.Now()) {
+		job := row
+
+		if err := deleteObject(job.Path); err != nil {
+			services.Info(fmt.Errorf("PurgeExportJobs - Export: %d, Account: %d - %s", job.Id, job.AccountId, err.Error()))
+			continue
+		}
+
+		db.ExportJobExpired(&job)
+		count++
+	}
+
+	services.InfoMsg(fmt.Sprintf("Purged %d expired exports.", count))
+}
+
+// ----------------- Private Helper Funcs -------------- //
+
+//
+// sendExportReadyEmail - Send the download link to the user that asked for
+// the export.
+//
+func sendExportReadyEmail(db models.Datastore, job models.ExportJob) {
+	user, err := db.GetUserById(job.UserId)
+
+	if err != nil {
+		services.Info(fmt.Errorf("RunExportJobs - Export: %d, User: %d - %s", job.Id, job.UserId, err.Error()))
+		return
+	}
+
+	url := db.GetSignedFileUrlFor(job.Path, time.Until(job.ExpiresAt))
+	subject := fmt.Sprintf("Your %d Year-End Export Is Ready", job.Year)
+
+	email.Send(user.Email, "", subject, emails.GetExportReadyHTML(user, job.Year, url, job.ExpiresAt), []string{})
+}
+
+/* End File */
@@ -0,0 +1,44 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package ledger
+
+import (
+	"fmt"
+	"time"
+
+	"app.skyclerk.com/backend/models"
+	"app.skyclerk.com/backend/services"
+)
+
+//
+// RecurringEntries will create ledger entries for every recurring template that
+// is due. Since we start from the template's next run date we also catch up on
+// anything we missed while the server was down.
+//
+func RecurringEntries(db models.Datastore) {
+	services.InfoMsg("Starting recurring ledger entries.")
+
+	// Track how many we make.
+	count := 0
+	now := time.Now()
+
+	// Loop through all the templates that are due.
+	for _, row := range db.GetRecurringDue(now) {
+		ledgers, err := db.RecurringRun(&row, now)
+
+		if err != nil {
+			services.Info(fmt.Errorf("RecurringEntries - Recurring: %d, Account: %d - %s", row.Id, row.AccountId, err.Error()))
+		}
+
+		count = count + len(ledgers)
+	}
+
+	services.InfoMsg(fmt.Sprintf("Created %d recurring ledger entries.", count))
+}
+
+/* End File */
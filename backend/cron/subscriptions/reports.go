@@ -0,0 +1,97 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package subscriptions
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"app.skyclerk.com/backend/emails"
+	"app.skyclerk.com/backend/library/email"
+	"app.skyclerk.com/backend/library/reports"
+	"app.skyclerk.com/backend/models"
+	"app.skyclerk.com/backend/services"
+)
+
+//
+// ScheduledReports will email each subscribed user their report once the
+// week, month or quarter it covers is over. We remember the last period we
+// sent so each report goes out once.
+//
+func ScheduledReports(db models.Datastore) {
+	services.InfoMsg("Starting scheduled reports.")
+
+	// Track how many we send.
+	count := 0
+	now := time.Now()
+
+	for _, row := range db.GetReportSubscriptions() {
+		s := row
+		start, next := s.LastPeriodBounds(now)
+
+		if s.LastPeriod == start.Format("2006-01-02") {
+			continue
+		}
+
+		if err := sendScheduledReport(db, s, start, next); err != nil {
+			services.Info(fmt.Errorf("ScheduledReports - Subscription: %d - %s", s.Id, err.Error()))
+			continue
+		}
+
+		db.ReportSubscriptionSent(&s, start.Format("2006-01-02"))
+		count++
+	}
+
+	services.InfoMsg(fmt.Sprintf("Sent %d scheduled reports.", count))
+}
+
+// ----------------- Private Helper Funcs -------------- //
+
+//
+// sendScheduledReport - Build the report for the period and email it.
+//
+func sendScheduledReport(db models.Datastore, s models.ReportSubscription, start time.Time, next time.Time) error {
+	account, err := db.GetAccountById(s.AccountId)
+
+	if err != nil {
+		return err
+	}
+
+	user, err := db.GetUserById(s.UserId)
+
+	if err != nil {
+		return err
+	}
+
+	url := os.Getenv("SITE_URL") + "/dashboard/reports"
+	subject := ""
+	body := ""
+
+	switch s.Report {
+	case "monthly-pnl":
+		r := reports.GetPnLComparison(db, account.Id, start, next, start.AddDate(0, -1, 0), start)
+		subject = "Your " + start.Format("January 2006") + " Profit & Loss"
+		body = emails.GetMonthlyPnLHTML(user, account, r, url, s.UnsubscribeUrl())
+
+	case "quarterly-categories":
+		r := reports.GetCategoryTotals(db, account.Id, start, next)
+		subject = fmt.Sprintf("Your Q%d %d Category Totals", (int(start.Month())+2)/3, start.Year())
+		body = emails.GetQuarterlyCategoriesHTML(user, account, r, url, s.UnsubscribeUrl())
+
+	default:
+		r := reports.GetActivityDigest(db, account.Id, start, next)
+		url = os.Getenv("SITE_URL") + "/activity"
+		subject = "Your Weekly Activity for " + account.Name
+		body = emails.GetWeeklyActivityHTML(user, account, r, url, s.UnsubscribeUrl())
+	}
+
+	return email.SendWithHeaders(user.Email, "", subject, body, []string{}, s.UnsubscribeHeaders())
+}
+
+/* End File */
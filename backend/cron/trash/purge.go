@@ -0,0 +1,31 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package trash
+
+import (
+	"fmt"
+	"time"
+
+	"app.skyclerk.com/backend/models"
+	"app.skyclerk.com/backend/services"
+)
+
+//
+// PurgeTrash will delete for good anything that has been in the trash longer
+// than TRASH_RETENTION_DAYS (30 days by default).
+//
+func PurgeTrash(db models.Datastore) {
+	services.InfoMsg("Starting trash purge.")
+
+	days := models.TrashRetentionDays()
+	count := db.PurgeTrash(time.Now().AddDate(0, 0, -days))
+
+	services.InfoMsg(fmt.Sprintf("Purged %d items older than %d days from the trash.", count, days))
+}
+
+/* End File */
@@ -9,105 +9,41 @@
 package airbnb
 
 import (
-	"bufio"
-	"encoding/csv"
-	"io"
+	"fmt"
 	"os"
-	"strconv"
 
+	"app.skyclerk.com/backend/library/csvimport"
 	"app.skyclerk.com/backend/models"
 	"app.skyclerk.com/backend/services"
-	"github.com/araddon/dateparse"
 )
 
 //
-// Import CSV Ledger Entries
+// Import CSV Ledger Entries. This is just the built in AirBnb import profile.
 //
 func CSVImport(db models.Datastore, accountId uint, file string) int {
 
-	var count int = 0
-
 	// Open CSV file and read it.
-	csvFile, _ := os.Open(file)
-	reader := csv.NewReader(bufio.NewReader(csvFile))
-
-	// Lop through each line in this CSV
-	for {
-
-		line, err := reader.Read()
-
-		if err == io.EOF {
-			break
-		} else if err != nil {
-			services.LogFatal(err)
-		}
-
-		// We are only interested in payouts.
-		if line[1] != "Reservation" {
-			continue
-		}
-
-		// Check to see if we already have this confirmation code in our system.
-		l := models.Ledger{}
-		db.New().Where("LedgerAirBnbHash = ?", line[2]).First(&l)
-
-		if l.Id > 0 {
-			continue
-		}
-
-		// Get the amount.
-		amount, err := strconv.ParseFloat(line[10], 64)
+	csvFile, err := os.Open(file)
 
-		if err != nil {
-			services.Error(err)
-		}
-
-		// Get the date
-		date, err := dateparse.ParseAny(line[0])
-
-		if err != nil {
-			services.Error(err)
-		}
-
-		// Build the contact
-		contact := models.Contact{
-			AccountId: accountId,
-			Name:      line[5],
-		}
+	if err != nil {
+		services.Error(err)
+		return 0
+	}
 
-		// Setup the label
-		labels := []models.Label{
-			{AccountId: accountId, Name: "airbnb"},
-		}
+	defer csvFile.Close()
 
-		// Setup the category
-		category := models.Category{
-			Type:      "2",
-			Irs:       "1",
-			Show:      "1",
-			AccountId: accountId,
-			Name:      "Rental Income",
-		}
+	profile, _ := csvimport.GetBuiltinProfile("airbnb")
 
-		// Setup the Ledger entry.
-		ledger := models.Ledger{
-			AccountId:  accountId,
-			Date:       date,
-			Amount:     amount,
-			Contact:    contact,
-			Category:   category,
-			Labels:     labels,
-			Note:       line[6] + " : " + line[2],
-			AirBnbHash: line[2],
-		}
+	// Run the import. Bad rows are skipped not fatal.
+	count, rows, err := csvimport.Import(db, accountId, 0, profile, csvFile, []int{})
 
-		// Store the entry in the database
-		err = db.LedgerCreate(&ledger)
+	if err != nil {
+		services.Error(err)
+	}
 
-		if err != nil {
-			services.Error(err)
-		} else {
-			count++
+	for _, row := range rows {
+		if len(row.Error) > 0 {
+			services.Info(fmt.Errorf("AirBnb import line %d - %s", row.Line, row.Error))
 		}
 	}
 
@@ -0,0 +1,215 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+// Package archive reads and writes a whole account as a zip of JSON files
+// plus the file blobs. We use it to move accounts between servers and so
+// people can leave with all of their data.
+//
+// The zip looks like this:
+//
+//	manifest.json    Format, version and where it came from.
+//	account.json     Account settings.
+//	users.json       Users on the account. No passwords or sessions.
+//	contacts.json
+//	categories.json
+//	labels.json
+//	chart_accounts.json  Balance sheet accounts. Not in archives from before we had them.
+//	files.json       File records. The blob is in files/.
+//	ledger.json      Entries with their label, file and split lines.
+//	snapclerks.json
+//	activities.json
+//	files/<id>/<name>
+//
+// Ids in the archive are the ids on the server it came from. Import gives
+// everything new ids and remembers the mapping so it can run again safely.
+package archive
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"app.skyclerk.com/backend/library/store/object"
+)
+
+// What we write into manifest.json. Bump Version when the format changes.
+const (
+	Format  = "skyclerk-account"
+	Version = 1
+)
+
+// Swapped out in tests so we do not hit the object store.
+var (
+	downloadObject = object.DownloadObject
+	uploadObject   = object.UploadObject
+)
+
+// Manifest - manifest.json
+type Manifest struct {
+	Format       string    `json:"format"`
+	Version      int       `json:"version"`
+	Source       string    `json:"source"` // The server and account this came from.
+	ExportedAt   time.Time `json:"exported_at"`
+	MissingFiles []uint    `json:"missing_files"` // Files we could not get out of the object store.
+}
+
+// Account - account.json
+type Account struct {
+	Name               string    `json:"name"`
+	Address            string    `json:"address"`
+	City               string    `json:"city"`
+	State              string    `json:"state"`
+	Zip                string    `json:"zip"`
+	Country            string    `json:"country"`
+	Locale             string    `json:"locale"`
+	Currency           string    `json:"currency"`
+	BooksClosedThrough time.Time `json:"books_closed_through"`
+}
+
+// User - users.json
+type User struct {
+	Id        uint   `json:"id"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Email     string `json:"email"`
+	Owner     bool   `json:"owner"`
+}
+
+// Contact - contacts.json
+type Contact struct {
+	Id            uint   `json:"id"`
+	Name          string `json:"name"`
+	FirstName     string `json:"first_name"`
+	LastName      string `json:"last_name"`
+	Email         string `json:"email"`
+	Address       string `json:"address"`
+	City          string `json:"city"`
+	State         string `json:"state"`
+	Zip           string `json:"zip"`
+	Country       string `json:"country"`
+	Phone         string `json:"phone"`
+	Fax           string `json:"fax"`
+	Website       string `json:"website"`
+	AccountNumber string `json:"account_number"`
+	Twitter       string `json:"twitter"`
+	Facebook      string `json:"facebook"`
+	Linkedin      string `json:"linkedin"`
+	Is1099        bool   `json:"is_1099"`
+	TaxIdType     string `json:"tax_id_type"` // The tax id is encrypted with our key so it stays behind.
+}
+
+// Category - categories.json
+type Category struct {
+	Id   uint   `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+	Irs  string `json:"irs"`
+	Show string `json:"show"`
+}
+
+// Label - labels.json
+type Label struct {
+	Id   uint   `json:"id"`
+	Name string `json:"name"`
+}
+
+// ChartAccount - chart_accounts.json
+type ChartAccount struct {
+	Id     uint   `json:"id"`
+	Code   string `json:"code"`
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	System string `json:"system"`
+	Cash   bool   `json:"cash"`
+}
+
+// File - files.json
+type File struct {
+	Id        uint      `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	Name      string    `json:"name"`
+	Type      string    `json:"type"`
+	Hash      string    `json:"hash"`
+	Size      int64     `json:"size"`
+	Blob      string    `json:"blob"`  // Path in the zip
+	Thumb     string    `json:"thumb"` // Path in the zip
+}
+
+// Ledger - ledger.json
+type Ledger struct {
+	Id                     uint          `json:"id"`
+	CreatedAt              time.Time     `json:"created_at"`
+	Date                   time.Time     `json:"date"`
+	AddedById              uint          `json:"added_by_id"`
+	ContactId              uint          `json:"contact_id"`
+	CategoryId             uint          `json:"category_id"`
+	ChartAccountId         uint          `json:"chart_account_id"`
+	TransferChartAccountId uint          `json:"transfer_chart_account_id"`
+	Amount                 float64       `json:"amount"`
+	Note                   string        `json:"note"`
+	Lat                    float64       `json:"lat"`
+	Lon                    float64       `json:"lon"`
+	Source                 string        `json:"source"`
+	Status                 string        `json:"status"`
+	FitId                  string        `json:"fit_id"`
+	FitAcct                string        `json:"fit_acct"`
+	ImportKey              string        `json:"import_key"`
+	LabelIds               []uint        `json:"label_ids"`
+	FileIds                []uint        `json:"file_ids"`
+	Splits                 []LedgerSplit `json:"splits"`
+}
+
+// LedgerSplit - A split line inside a ledger entry.
+type LedgerSplit struct {
+	Amount     float64 `json:"amount"`
+	CategoryId uint    `json:"category_id"`
+	Note       string  `json:"note"`
+	LabelIds   []uint  `json:"label_ids"`
+}
+
+// SnapClerk - snapclerks.json
+type SnapClerk struct {
+	Id          uint      `json:"id"`
+	CreatedAt   time.Time `json:"created_at"`
+	AddedById   uint      `json:"added_by_id"`
+	Status      string    `json:"status"`
+	FileId      uint      `json:"file_id"`
+	LedgerId    uint      `json:"ledger_id"`
+	Amount      float64   `json:"amount"`
+	Contact     string    `json:"contact"`
+	Category    string    `json:"category"`
+	Labels      string    `json:"labels"`
+	Note        string    `json:"note"`
+	Lat         string    `json:"lat"`
+	Lon         string    `json:"lon"`
+	ProcessedAt time.Time `json:"processed_at"`
+}
+
+// Activity - activities.json
+type Activity struct {
+	Id          uint      `json:"id"`
+	CreatedAt   time.Time `json:"created_at"`
+	UserId      uint      `json:"user_id"`
+	Action      string    `json:"action"`
+	SubAction   string    `json:"sub_action"`
+	Name        string    `json:"name"`
+	Amount      float64   `json:"amount"`
+	LedgerId    uint      `json:"ledger_id"`
+	ContactId   uint      `json:"contact_id"`
+	LabelId     uint      `json:"label_id"`
+	CategoryId  uint      `json:"category_id"`
+	SnapClerkId uint      `json:"snapclerk_id"`
+}
+
+//
+// Source - How an account on this server is named in an archive.
+//
+func Source(accountId uint) string {
+	return os.Getenv("APP_URL") + "/accounts/" + strconv.Itoa(int(accountId))
+}
+
+/* End File */
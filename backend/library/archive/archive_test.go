@@ -0,0 +1,294 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package archive
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nbio/st"
+
+	"app.skyclerk.com/backend/library/test"
+	"app.skyclerk.com/backend/models"
+)
+
+//
+// TestExportImport01 - Export an account and import it into another one.
+//
+func TestExportImport01(t *testing.T) {
+	// Start the db connection.
+	db, dbName, _ := models.NewTestDB("")
+	defer models.TestingTearDown(db, dbName)
+
+	// Fake object store. Blobs hold their path, "missing" fails.
+	dir, _ := ioutil.TempDir("", "archive-test-")
+	uploaded := map[string]string{}
+	oldDownload, oldUpload := downloadObject, uploadObject
+
+	downloadObject = func(path string) (string, error) {
+		if strings.Contains(path, "missing") {
+			return "", errors.New("The object does not exist.")
+		}
+
+		p := filepath.Join(dir, filepath.Base(path))
+		return p, ioutil.WriteFile(p, []byte(path), 0644)
+	}
+
+	uploadObject = func(filePath string, storePath string) error {
+		b, _ := ioutil.ReadFile(filePath)
+		uploaded[storePath] = string(b)
+		return nil
+	}
+
+	defer func() {
+		downloadObject = oldDownload
+		uploadObject = oldUpload
+	}()
+
+	// The account we export.
+	owner := test.GetRandomUser(33)
+	owner.Password = "secret-hash"
+	db.Save(&owner)
+
+	other := test.GetRandomUser(33)
+	db.Save(&other)
+
+	account := test.GetRandomAccount(33)
+	account.OwnerId = owner.Id
+	account.Name = "Old Books"
+	account.Currency = "EUR"
+	db.Save(&account)
+	db.Save(&models.AcctToUsers{AccountId: 33, UserId: owner.Id})
+	db.Save(&models.AcctToUsers{AccountId: 33, UserId: other.Id})
+
+	file := models.File{AccountId: 33, Name: "receipt.jpg", Path: "accounts/33/7_receipt.jpg", ThumbPath: "accounts/33/thumb_7_receipt.jpg", Type: "image/jpeg"}
+	db.Save(&file)
+
+	gone := models.File{AccountId: 33, Name: "gone.jpg", Path: "accounts/33/missing.jpg", Type: "image/jpeg"}
+	db.Save(&gone)
+
+	checking := models.ChartAccount{AccountId: 33, Code: "1010", Name: "Checking", Type: "asset"}
+	db.ChartAccountCreate(&checking)
+
+	l1 := test.GetRandomLedger(33)
+	l1.Amount = -150.25
+	l1.ChartAccountId = checking.Id
+	l1.AddedById = other.Id
+	l1.Status = "reconciled"
+	l1.Contact = models.Contact{Name: "Home Depot", Phone: "555-1212"}
+	l1.Category = models.Category{Name: "Supplies", Type: "1"}
+	l1.Labels = []models.Label{{Name: "Client A"}, {Name: "Client B"}}
+	l1.Files = []models.File{file, gone}
+	db.LedgerCreate(&l1)
+
+	l2 := test.GetRandomLedger(33)
+	l2.Amount = -300
+	l2.Contact = models.Contact{Name: "Staples"}
+	l2.Category = models.Category{}
+	l2.Labels = []models.Label{}
+	l2.Splits = []models.LedgerSplit{
+		{Amount: -200, Category: models.Category{Name: "Sales", Type: "2"}, Labels: []models.Label{{Name: "Client A"}}},
+		{Amount: -100, Category: models.Category{Name: "Supplies", Type: "1"}},
+	}
+	db.LedgerCreate(&l2)
+
+	sc := test.GetRandomSnapClerk(33)
+	sc.File = models.File{}
+	sc.FileId = file.Id
+	sc.LedgerId = l1.Id
+	sc.AddedById = owner.Id
+	db.Save(&sc)
+
+	db.Save(&models.Activity{AccountId: 33, UserId: owner.Id, Action: "create", SubAction: "expense", Name: "Home Depot", LedgerId: l1.Id, ContactId: l1.ContactId, SnapClerkId: sc.Id})
+
+	// Someone else
+	db.LedgerCreate(&models.Ledger{AccountId: 34, Amount: 1, Contact: models.Contact{Name: "Not Ours"}})
+
+	// Export
+	buf := bytes.Buffer{}
+	err := Export(db, 33, &buf)
+	st.Expect(t, err, nil)
+
+	z, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	st.Expect(t, err, nil)
+
+	entries := map[string]string{}
+
+	for _, row := range z.File {
+		rc, _ := row.Open()
+		b, _ := ioutil.ReadAll(rc)
+		rc.Close()
+		entries[row.Name] = string(b)
+	}
+
+	manifest := Manifest{}
+	json.Unmarshal([]byte(entries["manifest.json"]), &manifest)
+	st.Expect(t, manifest.Format, "skyclerk-account")
+	st.Expect(t, manifest.Version, 1)
+	st.Expect(t, manifest.Source, Source(33))
+	st.Expect(t, manifest.MissingFiles, []uint{gone.Id})
+
+	// No secrets.
+	st.Expect(t, strings.Contains(entries["users.json"], owner.Email), true)
+	st.Expect(t, strings.Contains(entries["users.json"], "secret-hash"), false)
+	st.Expect(t, strings.Contains(entries["users.json"], "salt123"), false)
+	st.Expect(t, strings.Contains(entries["contacts.json"], "Not Ours"), false)
+
+	st.Expect(t, entries["files/1/7_receipt.jpg"], "accounts/33/7_receipt.jpg")
+	st.Expect(t, entries["files/1/thumb/thumb_7_receipt.jpg"], "accounts/33/thumb_7_receipt.jpg")
+
+	ledgers := []Ledger{}
+	st.Expect(t, json.Unmarshal([]byte(entries["ledger.json"]), &ledgers), nil)
+	st.Expect(t, len(ledgers), 2)
+	st.Expect(t, len(ledgers[0].LabelIds), 2)
+	st.Expect(t, ledgers[0].FileIds, []uint{file.Id, gone.Id})
+	st.Expect(t, len(ledgers[1].Splits), 2)
+
+	// The account we import into already has a Sales category.
+	target := test.GetRandomAccount(44)
+	target.OwnerId = owner.Id
+	db.Save(&target)
+	sales := models.Category{AccountId: 44, Name: "Sales", Type: "2"}
+	db.Save(&sales)
+
+	result, err := Import(db, 44, bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	st.Expect(t, err, nil)
+	st.Expect(t, result.Created["user"], 0)
+	st.Expect(t, result.Existing["user"], 2)
+	st.Expect(t, result.Created["contact"], 2)
+	st.Expect(t, result.Created["category"], 1)
+	st.Expect(t, result.Existing["category"], 1)
+	st.Expect(t, result.Created["label"], 2)
+	st.Expect(t, result.Created["file"], 1)
+	st.Expect(t, result.Missing["file"], 1)
+	st.Expect(t, result.Created["chart_account"], 1)
+	st.Expect(t, result.Existing["chart_account"], 4)
+	st.Expect(t, result.Created["ledger"], 2)
+	st.Expect(t, result.Created["snapclerk"], 1)
+	st.Expect(t, result.Created["activity"], 1)
+
+	// Account settings
+	a, _ := db.GetAccountById(44)
+	st.Expect(t, a.Name, "Old Books")
+	st.Expect(t, a.Currency, "EUR")
+
+	count := 0
+	db.New().Model(&models.AcctToUsers{}).Where("account_id = ?", 44).Count(&count)
+	st.Expect(t, count, 2)
+
+	// Ledger entries point at the new records.
+	rows := []models.Ledger{}
+	db.New().Where("LedgerAccountId = ?", 44).Order("LedgerId ASC").Preload("Contact").Preload("Category").Preload("Labels").Preload("Files").Preload("Splits").Preload("Splits.Category").Preload("Splits.Labels").Find(&rows)
+	st.Expect(t, len(rows), 2)
+	st.Expect(t, rows[0].Contact.Name, "Home Depot")
+	st.Expect(t, rows[0].Contact.Phone, "555-1212")
+	st.Expect(t, rows[0].Contact.AccountId, uint(44))
+	st.Expect(t, rows[0].Category.Name, "Supplies")
+	st.Expect(t, rows[0].Category.AccountId, uint(44))
+	st.Expect(t, rows[0].AddedById, other.Id)
+	st.Expect(t, rows[0].Status, "cleared")
+	st.Expect(t, rows[0].Amount, -150.25)
+	st.Expect(t, rows[0].Date.Equal(l1.Date), true)
+	st.Expect(t, len(rows[0].Labels), 2)
+	st.Expect(t, rows[0].Labels[0].AccountId, uint(44))
+	st.Expect(t, len(rows[0].Files), 1)
+	st.Expect(t, rows[0].Files[0].Path, "accounts/44/3_7_receipt.jpg")
+	st.Expect(t, rows[0].Files[0].ThumbPath, "accounts/44/3_thumb_7_receipt.jpg")
+
+	ca, _ := db.GetChartAccountByAccountAndId(44, rows[0].ChartAccountId)
+	st.Expect(t, ca.Name, "Checking")
+	st.Expect(t, uploaded["accounts/44/3_7_receipt.jpg"], "accounts/33/7_receipt.jpg")
+
+	st.Expect(t, rows[1].Contact.Name, "Staples")
+	st.Expect(t, len(rows[1].Splits), 2)
+	st.Expect(t, rows[1].Splits[0].Category.Id, sales.Id)
+	st.Expect(t, rows[1].Splits[0].Labels[0].Name, "Client A")
+	st.Expect(t, rows[1].Splits[0].Labels[0].AccountId, uint(44))
+	st.Expect(t, rows[1].Splits[1].Category.Id, rows[0].Category.Id)
+
+	// SnapClerk and activity
+	s := models.SnapClerk{}
+	db.New().Where("SnapClerkAccountId = ?", 44).First(&s)
+	st.Expect(t, s.LedgerId, rows[0].Id)
+	st.Expect(t, s.FileId, rows[0].Files[0].Id)
+	st.Expect(t, s.Note, sc.Note)
+
+	act := models.Activity{}
+	db.New().Where("account_id = ?", 44).First(&act)
+	st.Expect(t, act.LedgerId, rows[0].Id)
+	st.Expect(t, act.ContactId, rows[0].ContactId)
+	st.Expect(t, act.SnapClerkId, s.Id)
+	st.Expect(t, act.UserId, owner.Id)
+
+	// Search works on the new account.
+	st.Expect(t, len(db.Search(44, "depot", []string{"ledger"}, 10)) > 0, true)
+
+	// Running it again does nothing.
+	result, err = Import(db, 44, bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	st.Expect(t, err, nil)
+	st.Expect(t, result.Created, map[string]int{})
+	st.Expect(t, result.Existing["ledger"], 2)
+	st.Expect(t, result.Existing["activity"], 1)
+
+	db.New().Model(&models.Ledger{}).Where("LedgerAccountId = ?", 44).Count(&count)
+	st.Expect(t, count, 2)
+	db.New().Model(&models.Contact{}).Where("ContactsAccountId = ?", 44).Count(&count)
+	st.Expect(t, count, 2)
+
+	// Not into the account it came from.
+	_, err = Import(db, 33, bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	st.Expect(t, err.Error(), "This archive came from this account.")
+}
+
+//
+// TestImport02 - Archives we can not read.
+//
+func TestImport02(t *testing.T) {
+	// Start the db connection.
+	db, dbName, _ := models.NewTestDB("")
+	defer models.TestingTearDown(db, dbName)
+
+	tests := []struct {
+		manifest Manifest
+		err      string
+	}{
+		{Manifest{Format: "other", Version: 1}, "This is not a Skyclerk account archive."},
+		{Manifest{Format: Format, Version: 2}, "Archive version 2 is not supported."},
+	}
+
+	for _, row := range tests {
+		buf := bytes.Buffer{}
+		z := zip.NewWriter(&buf)
+		writeJSON(z, "manifest.json", row.manifest)
+		z.Close()
+
+		_, err := Import(db, 44, bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		st.Expect(t, err.Error(), row.err)
+	}
+
+	// Not a zip
+	_, err := Import(db, 44, strings.NewReader("hello"), 5)
+	st.Expect(t, err != nil, true)
+
+	// No manifest
+	buf := bytes.Buffer{}
+	z := zip.NewWriter(&buf)
+	writeJSON(z, "account.json", Account{Name: "Books"})
+	z.Close()
+
+	_, err = Import(db, 44, bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	st.Expect(t, err.Error(), "The archive is missing manifest.json.")
+}
+
+/* End File */
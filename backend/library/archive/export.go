@@ -0,0 +1,400 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package archive
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"app.skyclerk.com/backend/models"
+)
+
+// How many ledger entries we load at a time.
+const ledgerBatchSize = 500
+
+//
+// Export - Write the whole account as a zip archive to w. Files we can not
+// get from the object store are listed in the manifest and skipped.
+//
+func Export(db models.Datastore, accountId uint, w io.Writer) error {
+	account, err := db.GetAccountById(accountId)
+
+	if err != nil {
+		return err
+	}
+
+	z := zip.NewWriter(w)
+
+	manifest := Manifest{
+		Format:       Format,
+		Version:      Version,
+		Source:       Source(accountId),
+		ExportedAt:   time.Now().UTC(),
+		MissingFiles: []uint{},
+	}
+
+	// Account
+	err = writeJSON(z, "account.json", Account{
+		Name:               account.Name,
+		Address:            account.Address,
+		City:               account.City,
+		State:              account.State,
+		Zip:                account.Zip,
+		Country:            account.Country,
+		Locale:             account.Locale,
+		Currency:           account.Currency,
+		BooksClosedThrough: account.BooksClosedThrough,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	// Users
+	users := []models.User{}
+	db.New().Where("id IN (SELECT user_id FROM acct_to_users WHERE account_id = ?)", accountId).Order("id ASC").Find(&users)
+
+	aUsers := []User{}
+
+	for _, row := range users {
+		aUsers = append(aUsers, User{Id: row.Id, FirstName: row.FirstName, LastName: row.LastName, Email: row.Email, Owner: row.Id == account.OwnerId})
+	}
+
+	if err := writeJSON(z, "users.json", aUsers); err != nil {
+		return err
+	}
+
+	// Contacts
+	contacts := []models.Contact{}
+	db.New().Where("ContactsAccountId = ?", accountId).Order("ContactsId ASC").Find(&contacts)
+
+	aContacts := []Contact{}
+
+	for _, row := range contacts {
+		aContacts = append(aContacts, Contact{
+			Id:            row.Id,
+			Name:          row.Name,
+			FirstName:     row.FirstName,
+			LastName:      row.LastName,
+			Email:         row.Email,
+			Address:       row.Address,
+			City:          row.City,
+			State:         row.State,
+			Zip:           row.Zip,
+			Country:       row.Country,
+			Phone:         row.Phone,
+			Fax:           row.Fax,
+			Website:       row.Website,
+			AccountNumber: row.AccountNumber,
+			Twitter:       row.Twitter,
+			Facebook:      row.Facebook,
+			Linkedin:      row.Linkedin,
+			Is1099:        row.Is1099,
+			TaxIdType:     row.TaxIdType,
+		})
+	}
+
+	if err := writeJSON(z, "contacts.json", aContacts); err != nil {
+		return err
+	}
+
+	// Categories
+	categories := []models.Category{}
+	db.New().Where("CategoriesAccountId = ?", accountId).Order("CategoriesId ASC").Find(&categories)
+
+	aCategories := []Category{}
+
+	for _, row := range categories {
+		aCategories = append(aCategories, Category{Id: row.Id, Name: row.Name, Type: row.Type, Irs: row.Irs, Show: row.Show})
+	}
+
+	if err := writeJSON(z, "categories.json", aCategories); err != nil {
+		return err
+	}
+
+	// Labels
+	labels := []models.Label{}
+	db.New().Where("LabelsAccountId = ?", accountId).Order("LabelsId ASC").Find(&labels)
+
+	aLabels := []Label{}
+
+	for _, row := range labels {
+		aLabels = append(aLabels, Label{Id: row.Id, Name: row.Name})
+	}
+
+	if err := writeJSON(z, "labels.json", aLabels); err != nil {
+		return err
+	}
+
+	// Chart of accounts. Income and expense accounts follow the categories so
+	// we only need the balance sheet and system accounts.
+	charts := []models.ChartAccount{}
+	db.New().Where("account_id = ? AND category_id = 0", accountId).Order("id ASC").Find(&charts)
+
+	aCharts := []ChartAccount{}
+
+	for _, row := range charts {
+		aCharts = append(aCharts, ChartAccount{Id: row.Id, Code: row.Code, Name: row.Name, Type: row.Type, System: row.System, Cash: row.Cash})
+	}
+
+	if err := writeJSON(z, "chart_accounts.json", aCharts); err != nil {
+		return err
+	}
+
+	// Files and their blobs
+	files := []models.File{}
+	db.New().Where("FilesAccountId = ?", accountId).Order("FilesId ASC").Find(&files)
+
+	aFiles := []File{}
+
+	for _, row := range files {
+		f := File{Id: row.Id, CreatedAt: row.CreatedAt, Name: row.Name, Type: row.Type, Hash: row.Hash, Size: row.Size}
+
+		if len(row.Path) > 0 {
+			f.Blob = fmt.Sprintf("files/%d/%s", row.Id, filepath.Base(row.Path))
+
+			if err := writeBlob(z, f.Blob, row.Path); err != nil {
+				manifest.MissingFiles = append(manifest.MissingFiles, row.Id)
+				f.Blob = ""
+			}
+		}
+
+		// A missing thumbnail we can live without.
+		if len(row.ThumbPath) > 0 {
+			f.Thumb = fmt.Sprintf("files/%d/thumb/%s", row.Id, filepath.Base(row.ThumbPath))
+
+			if err := writeBlob(z, f.Thumb, row.ThumbPath); err != nil {
+				f.Thumb = ""
+			}
+		}
+
+		aFiles = append(aFiles, f)
+	}
+
+	if err := writeJSON(z, "files.json", aFiles); err != nil {
+		return err
+	}
+
+	// Ledger. This can be big so we stream it out a batch at a time.
+	if err := writeLedger(db, z, accountId); err != nil {
+		return err
+	}
+
+	// SnapClerk
+	snapclerks := []models.SnapClerk{}
+	db.New().Where("SnapClerkAccountId = ?", accountId).Order("SnapClerkId ASC").Find(&snapclerks)
+
+	aSnapClerks := []SnapClerk{}
+
+	for _, row := range snapclerks {
+		aSnapClerks = append(aSnapClerks, SnapClerk{
+			Id:          row.Id,
+			CreatedAt:   row.CreatedAt,
+			AddedById:   row.AddedById,
+			Status:      row.Status,
+			FileId:      row.FileId,
+			LedgerId:    row.LedgerId,
+			Amount:      row.Amount,
+			Contact:     row.Contact,
+			Category:    row.Category,
+			Labels:      row.Labels,
+			Note:        row.Note,
+			Lat:         row.Lat,
+			Lon:         row.Lon,
+			ProcessedAt: row.ProcessedAt,
+		})
+	}
+
+	if err := writeJSON(z, "snapclerks.json", aSnapClerks); err != nil {
+		return err
+	}
+
+	// Activities
+	activities := []models.Activity{}
+	db.New().Where("account_id = ?", accountId).Order("id ASC").Find(&activities)
+
+	aActivities := []Activity{}
+
+	for _, row := range activities {
+		aActivities = append(aActivities, Activity{
+			Id:          row.Id,
+			CreatedAt:   row.CreatedAt,
+			UserId:      row.UserId,
+			Action:      row.Action,
+			SubAction:   row.SubAction,
+			Name:        row.Name,
+			Amount:      row.Amount,
+			LedgerId:    row.LedgerId,
+			ContactId:   row.ContactId,
+			LabelId:     row.LabelId,
+			CategoryId:  row.CategoryId,
+			SnapClerkId: row.SnapClerkId,
+		})
+	}
+
+	if err := writeJSON(z, "activities.json", aActivities); err != nil {
+		return err
+	}
+
+	// The manifest goes last so it can list missing files.
+	if err := writeJSON(z, "manifest.json", manifest); err != nil {
+		return err
+	}
+
+	return z.Close()
+}
+
+// ----------------- Private Helper Funcs -------------- //
+
+//
+// writeLedger - Write ledger.json as a JSON array one batch at a time.
+//
+func writeLedger(db models.Datastore, z *zip.Writer, accountId uint) error {
+	w, err := z.Create("ledger.json")
+
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.WriteString(w, "["); err != nil {
+		return err
+	}
+
+	params := models.QueryParam{
+		Order:    "LedgerId",
+		Sort:     "ASC",
+		PreLoads: []string{"Labels", "Files", "Splits", "Splits.Labels"},
+		Wheres: []models.KeyValue{
+			{Key: "LedgerAccountId", Compare: "=", ValueInt: int(accountId)},
+		},
+	}
+
+	first := true
+
+	err = db.EachLedgerBatch(params, ledgerBatchSize, func(rows []models.Ledger) error {
+		for _, row := range rows {
+			l := Ledger{
+				Id:                     row.Id,
+				CreatedAt:              row.CreatedAt,
+				Date:                   row.Date,
+				AddedById:              row.AddedById,
+				ContactId:              row.ContactId,
+				CategoryId:             row.CategoryId,
+				ChartAccountId:         row.ChartAccountId,
+				TransferChartAccountId: row.TransferChartAccountId,
+				Amount:                 row.Amount,
+				Note:                   row.Note,
+				Lat:                    row.Lat,
+				Lon:                    row.Lon,
+				Source:                 row.Source,
+				Status:                 row.Status,
+				FitId:                  row.FitId,
+				FitAcct:                row.FitAcct,
+				ImportKey:              row.ImportKey,
+				LabelIds:               []uint{},
+				FileIds:                []uint{},
+				Splits:                 []LedgerSplit{},
+			}
+
+			for _, label := range row.Labels {
+				l.LabelIds = append(l.LabelIds, label.Id)
+			}
+
+			for _, file := range row.Files {
+				l.FileIds = append(l.FileIds, file.Id)
+			}
+
+			for _, split := range row.Splits {
+				s := LedgerSplit{Amount: split.Amount, CategoryId: split.CategoryId, Note: split.Note, LabelIds: []uint{}}
+
+				for _, label := range split.Labels {
+					s.LabelIds = append(s.LabelIds, label.Id)
+				}
+
+				l.Splits = append(l.Splits, s)
+			}
+
+			b, err := json.Marshal(l)
+
+			if err != nil {
+				return err
+			}
+
+			if !first {
+				if _, err := io.WriteString(w, ","); err != nil {
+					return err
+				}
+			}
+
+			first = false
+
+			if _, err := w.Write(b); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, "]")
+	return err
+}
+
+//
+// writeJSON - Add a JSON file to the zip.
+//
+func writeJSON(z *zip.Writer, name string, v interface{}) error {
+	w, err := z.Create(name)
+
+	if err != nil {
+		return err
+	}
+
+	return json.NewEncoder(w).Encode(v)
+}
+
+//
+// writeBlob - Copy a file from the object store into the zip.
+//
+func writeBlob(z *zip.Writer, name string, path string) error {
+	local, err := downloadObject(path)
+
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(local)
+
+	f, err := os.Open(local)
+
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	w, err := z.Create(name)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(w, f)
+	return err
+}
+
+/* End File */
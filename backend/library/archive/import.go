@@ -0,0 +1,742 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package archive
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"app.skyclerk.com/backend/models"
+)
+
+// Result - What an import did. Existing counts things that were already
+// imported or that we matched to what the account already had.
+type Result struct {
+	Created  map[string]int `json:"created"`
+	Existing map[string]int `json:"existing"`
+	Missing  map[string]int `json:"missing"`
+}
+
+// importer holds what we need while we work through an archive.
+type importer struct {
+	db        models.Datastore
+	zip       *zip.Reader
+	accountId uint
+	source    string
+	result    Result
+	users     map[uint]uint
+	maps      map[string]map[uint]uint
+}
+
+//
+// Import - Load an archive into an account. Ids are remapped to new ones on
+// this server. Running the same archive again only adds what is missing.
+//
+func Import(db models.Datastore, accountId uint, r io.ReaderAt, size int64) (Result, error) {
+	z, err := zip.NewReader(r, size)
+
+	if err != nil {
+		return Result{}, err
+	}
+
+	t := &importer{
+		db:        db,
+		zip:       z,
+		accountId: accountId,
+		result:    Result{Created: map[string]int{}, Existing: map[string]int{}, Missing: map[string]int{}},
+		users:     map[uint]uint{},
+		maps:      map[string]map[uint]uint{},
+	}
+
+	// Make sure this is something we can read.
+	manifest := Manifest{}
+
+	if err := t.readJSON("manifest.json", &manifest); err != nil {
+		return t.result, err
+	}
+
+	if manifest.Format != Format {
+		return t.result, errors.New("This is not a Skyclerk account archive.")
+	}
+
+	if (manifest.Version < 1) || (manifest.Version > Version) {
+		return t.result, fmt.Errorf("Archive version %d is not supported.", manifest.Version)
+	}
+
+	if manifest.Source == Source(accountId) {
+		return t.result, errors.New("This archive came from this account.")
+	}
+
+	t.source = manifest.Source
+
+	// Order matters, later things point at earlier things.
+	steps := []func() error{
+		t.importAccount,
+		t.importUsers,
+		t.importContacts,
+		t.importCategories,
+		t.importLabels,
+		t.importChartAccounts,
+		t.importFiles,
+		t.importLedger,
+		t.importSnapClerks,
+		t.importActivities,
+	}
+
+	for _, step := range steps {
+		if err := step(); err != nil {
+			return t.result, err
+		}
+	}
+
+	// We skipped the model hooks so build the search index and journal in one go.
+	db.ReindexSearch(accountId)
+	db.RebuildJournal(accountId)
+
+	return t.result, nil
+}
+
+// ----------------- Private Helper Funcs -------------- //
+
+//
+// importAccount - Copy over the account settings.
+//
+func (t *importer) importAccount() error {
+	a := Account{}
+
+	if err := t.readJSON("account.json", &a); err != nil {
+		return err
+	}
+
+	account, err := t.db.GetAccountById(t.accountId)
+
+	if err != nil {
+		return err
+	}
+
+	account.Name = a.Name
+	account.Address = a.Address
+	account.City = a.City
+	account.State = a.State
+	account.Zip = a.Zip
+	account.Country = a.Country
+	account.Locale = a.Locale
+	account.Currency = a.Currency
+	account.BooksClosedThrough = a.BooksClosedThrough
+
+	return t.db.New().Save(&account).Error
+}
+
+//
+// importUsers - Users are matched by email. New users have no password and
+// need to use forgot password to log in.
+//
+func (t *importer) importUsers() error {
+	list := []User{}
+
+	if err := t.readJSON("users.json", &list); err != nil {
+		return err
+	}
+
+	for _, row := range list {
+		user, err := t.db.GetUserByEmail(row.Email)
+
+		if err != nil {
+			user = models.User{FirstName: row.FirstName, LastName: row.LastName, Email: row.Email, Status: "Active"}
+
+			if err := t.db.New().Create(&user).Error; err != nil {
+				return err
+			}
+
+			t.result.Created["user"]++
+		} else {
+			t.result.Existing["user"]++
+		}
+
+		t.users[row.Id] = user.Id
+
+		// Give them access to the account.
+		count := 0
+		t.db.New().Model(&models.AcctToUsers{}).Where("account_id = ? AND user_id = ?", t.accountId, user.Id).Count(&count)
+
+		if count == 0 {
+			t.db.New().Create(&models.AcctToUsers{AccountId: t.accountId, UserId: user.Id})
+		}
+	}
+
+	return nil
+}
+
+//
+// importContacts - Contacts.
+//
+func (t *importer) importContacts() error {
+	list := []Contact{}
+
+	if err := t.readJSON("contacts.json", &list); err != nil {
+		return err
+	}
+
+	m := t.loadMap("contact")
+
+	for _, row := range list {
+		if _, ok := m[row.Id]; ok {
+			t.result.Existing["contact"]++
+			continue
+		}
+
+		c := models.Contact{
+			AccountId:     t.accountId,
+			Name:          row.Name,
+			FirstName:     row.FirstName,
+			LastName:      row.LastName,
+			Email:         row.Email,
+			Address:       row.Address,
+			City:          row.City,
+			State:         row.State,
+			Zip:           row.Zip,
+			Country:       row.Country,
+			Phone:         row.Phone,
+			Fax:           row.Fax,
+			Website:       row.Website,
+			AccountNumber: row.AccountNumber,
+			Twitter:       row.Twitter,
+			Facebook:      row.Facebook,
+			Linkedin:      row.Linkedin,
+			Is1099:        row.Is1099,
+			TaxIdType:     row.TaxIdType,
+			AvatarChecked: "No", // The avatar cron builds a new one.
+		}
+
+		if err := t.db.New().Create(&c).Error; err != nil {
+			return err
+		}
+
+		t.result.Created["contact"]++
+
+		if err := t.saveMap("contact", row.Id, c.Id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+//
+// importCategories - Categories with the same name and type as one the
+// account already has are merged into it.
+//
+func (t *importer) importCategories() error {
+	list := []Category{}
+
+	if err := t.readJSON("categories.json", &list); err != nil {
+		return err
+	}
+
+	m := t.loadMap("category")
+
+	for _, row := range list {
+		if _, ok := m[row.Id]; ok {
+			t.result.Existing["category"]++
+			continue
+		}
+
+		c, err := t.db.GetCategoryByNameAndTypeAndAccountID(t.accountId, row.Name, row.Type)
+
+		if err == nil {
+			t.result.Existing["category"]++
+		} else {
+			c = models.Category{AccountId: t.accountId, Name: row.Name, Type: row.Type, Irs: models.LegacyTaxLine(row.Irs, row.Type), Show: row.Show}
+
+			if err := t.db.New().Create(&c).Error; err != nil {
+				return err
+			}
+
+			t.result.Created["category"]++
+		}
+
+		if err := t.saveMap("category", row.Id, c.Id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+//
+// importLabels - Labels with the same name as one the account already has
+// are merged into it.
+//
+func (t *importer) importLabels() error {
+	list := []Label{}
+
+	if err := t.readJSON("labels.json", &list); err != nil {
+		return err
+	}
+
+	m := t.loadMap("label")
+
+	for _, row := range list {
+		if _, ok := m[row.Id]; ok {
+			t.result.Existing["label"]++
+			continue
+		}
+
+		l, err := t.db.GetLabelByAccountAndName(t.accountId, row.Name)
+
+		if err == nil {
+			t.result.Existing["label"]++
+		} else {
+			l = models.Label{AccountId: t.accountId, Name: row.Name}
+
+			if err := t.db.New().Create(&l).Error; err != nil {
+				return err
+			}
+
+			t.result.Created["label"]++
+		}
+
+		if err := t.saveMap("label", row.Id, l.Id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+//
+// importChartAccounts - System accounts go to this account's system accounts.
+// Others with the same name and type as one the account already has are
+// merged into it. Older archives do not have this file.
+//
+func (t *importer) importChartAccounts() error {
+	list := []ChartAccount{}
+
+	f, err := t.open("chart_accounts.json")
+
+	if err != nil {
+		return nil
+	}
+
+	defer f.Close()
+
+	if err := json.NewDecoder(f).Decode(&list); err != nil {
+		return err
+	}
+
+	m := t.loadMap("chart_account")
+
+	for _, row := range list {
+		if _, ok := m[row.Id]; ok {
+			t.result.Existing["chart_account"]++
+			continue
+		}
+
+		a := models.ChartAccount{}
+
+		if len(row.System) > 0 {
+			a = t.db.GetSystemChartAccount(t.accountId, row.System)
+		} else {
+			t.db.New().Where("account_id = ? AND name = ? AND type = ? AND category_id = 0", t.accountId, row.Name, row.Type).First(&a)
+		}
+
+		if a.Id > 0 {
+			t.result.Existing["chart_account"]++
+		} else {
+			a = models.ChartAccount{AccountId: t.accountId, Code: row.Code, Name: row.Name, Type: row.Type, Cash: row.Cash}
+
+			// Codes are unique so a clash needs a new one.
+			if !t.db.New().Where("account_id = ? AND code = ?", t.accountId, row.Code).First(&models.ChartAccount{}).RecordNotFound() {
+				a.Code = ""
+			}
+
+			if err := t.db.ChartAccountCreate(&a); err != nil {
+				return err
+			}
+
+			t.result.Created["chart_account"]++
+		}
+
+		if err := t.saveMap("chart_account", row.Id, a.Id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+//
+// importFiles - Upload each blob to the object store under this account.
+//
+func (t *importer) importFiles() error {
+	list := []File{}
+
+	if err := t.readJSON("files.json", &list); err != nil {
+		return err
+	}
+
+	m := t.loadMap("file")
+
+	for _, row := range list {
+		if _, ok := m[row.Id]; ok {
+			t.result.Existing["file"]++
+			continue
+		}
+
+		// The export could not get this one.
+		if len(row.Blob) == 0 {
+			t.result.Missing["file"]++
+			continue
+		}
+
+		f := models.File{
+			AccountId: t.accountId,
+			CreatedAt: row.CreatedAt,
+			Host:      "amazon-s3",
+			Name:      row.Name,
+			Type:      row.Type,
+			Hash:      row.Hash,
+			Size:      row.Size,
+		}
+
+		if err := t.db.New().Create(&f).Error; err != nil {
+			return err
+		}
+
+		// Same paths StoreFile uses.
+		f.Path = fmt.Sprintf("accounts/%d/%d_%s", t.accountId, f.Id, filepath.Base(row.Blob))
+
+		if err := t.uploadBlob(row.Blob, f.Path); err != nil {
+			t.db.New().Delete(&f)
+			return err
+		}
+
+		if len(row.Thumb) > 0 {
+			thumb := fmt.Sprintf("accounts/%d/%d_%s", t.accountId, f.Id, filepath.Base(row.Thumb))
+
+			if err := t.uploadBlob(row.Thumb, thumb); err == nil {
+				f.ThumbPath = thumb
+			}
+		}
+
+		if err := t.db.New().Save(&f).Error; err != nil {
+			return err
+		}
+
+		t.result.Created["file"]++
+
+		if err := t.saveMap("file", row.Id, f.Id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+//
+// importLedger - Ledger entries with their labels, files and split lines. We
+// read one entry at a time as this file can be big.
+//
+func (t *importer) importLedger() error {
+	f, err := t.open("ledger.json")
+
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	m := t.loadMap("ledger")
+	contacts := t.loadMap("contact")
+	categories := t.loadMap("category")
+	labels := t.loadMap("label")
+	files := t.loadMap("file")
+	charts := t.loadMap("chart_account")
+
+	dec := json.NewDecoder(f)
+
+	// The opening [
+	if _, err := dec.Token(); err != nil {
+		return err
+	}
+
+	for dec.More() {
+		row := Ledger{}
+
+		if err := dec.Decode(&row); err != nil {
+			return err
+		}
+
+		if _, ok := m[row.Id]; ok {
+			t.result.Existing["ledger"]++
+			continue
+		}
+
+		// Reconciliations are not in the archive so these go back to cleared.
+		status := row.Status
+
+		if status == "reconciled" {
+			status = "cleared"
+		}
+
+		l := models.Ledger{
+			AccountId:              t.accountId,
+			CreatedAt:              row.CreatedAt,
+			Date:                   row.Date,
+			AddedById:              t.users[row.AddedById],
+			ContactId:              contacts[row.ContactId],
+			CategoryId:             categories[row.CategoryId],
+			ChartAccountId:         charts[row.ChartAccountId],
+			TransferChartAccountId: charts[row.TransferChartAccountId],
+			Amount:                 row.Amount,
+			Note:                   row.Note,
+			Lat:                    row.Lat,
+			Lon:                    row.Lon,
+			Source:                 row.Source,
+			Status:                 status,
+			FitId:                  row.FitId,
+			FitAcct:                row.FitAcct,
+			ImportKey:              row.ImportKey,
+		}
+
+		// We do not want LedgerCreate here. It would run rules and make contacts.
+		if err := t.db.New().Set("gorm:save_associations", false).Create(&l).Error; err != nil {
+			return err
+		}
+
+		for _, id := range row.LabelIds {
+			if labels[id] > 0 {
+				t.db.New().Create(&models.LabelsToLedger{LabelsToLedgerLabelId: labels[id], LabelsToLedgerLedgerId: l.Id})
+			}
+		}
+
+		for _, id := range row.FileIds {
+			if files[id] > 0 {
+				t.db.New().Create(&models.FilesToLedger{FilesToLedgerFileId: files[id], FilesToLedgerLedgerId: l.Id})
+			}
+		}
+
+		for _, split := range row.Splits {
+			s := models.LedgerSplit{
+				AccountId:  t.accountId,
+				LedgerId:   l.Id,
+				Amount:     split.Amount,
+				CategoryId: categories[split.CategoryId],
+				Note:       split.Note,
+			}
+
+			if err := t.db.New().Set("gorm:save_associations", false).Create(&s).Error; err != nil {
+				return err
+			}
+
+			for _, id := range split.LabelIds {
+				if labels[id] > 0 {
+					t.db.New().Exec("INSERT INTO ledger_split_labels (ledger_split_id, label_id) VALUES (?, ?)", s.Id, labels[id])
+				}
+			}
+		}
+
+		t.result.Created["ledger"]++
+
+		if err := t.saveMap("ledger", row.Id, l.Id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+//
+// importSnapClerks - SnapClerk records.
+//
+func (t *importer) importSnapClerks() error {
+	list := []SnapClerk{}
+
+	if err := t.readJSON("snapclerks.json", &list); err != nil {
+		return err
+	}
+
+	m := t.loadMap("snapclerk")
+	files := t.loadMap("file")
+	ledgers := t.loadMap("ledger")
+
+	for _, row := range list {
+		if _, ok := m[row.Id]; ok {
+			t.result.Existing["snapclerk"]++
+			continue
+		}
+
+		s := models.SnapClerk{
+			AccountId:   t.accountId,
+			CreatedAt:   row.CreatedAt,
+			AddedById:   t.users[row.AddedById],
+			Status:      row.Status,
+			FileId:      files[row.FileId],
+			LedgerId:    ledgers[row.LedgerId],
+			Amount:      row.Amount,
+			Contact:     row.Contact,
+			Category:    row.Category,
+			Labels:      row.Labels,
+			Note:        row.Note,
+			Lat:         row.Lat,
+			Lon:         row.Lon,
+			ProcessedAt: row.ProcessedAt,
+		}
+
+		if err := t.db.New().Set("gorm:save_associations", false).Create(&s).Error; err != nil {
+			return err
+		}
+
+		t.result.Created["snapclerk"]++
+
+		if err := t.saveMap("snapclerk", row.Id, s.Id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+//
+// importActivities - The activity feed.
+//
+func (t *importer) importActivities() error {
+	list := []Activity{}
+
+	if err := t.readJSON("activities.json", &list); err != nil {
+		return err
+	}
+
+	m := t.loadMap("activity")
+	ledgers := t.loadMap("ledger")
+	contacts := t.loadMap("contact")
+	labels := t.loadMap("label")
+	categories := t.loadMap("category")
+	snapclerks := t.loadMap("snapclerk")
+
+	for _, row := range list {
+		if _, ok := m[row.Id]; ok {
+			t.result.Existing["activity"]++
+			continue
+		}
+
+		a := models.Activity{
+			AccountId:   t.accountId,
+			CreatedAt:   row.CreatedAt,
+			UserId:      t.users[row.UserId],
+			Action:      row.Action,
+			SubAction:   row.SubAction,
+			Name:        row.Name,
+			Amount:      row.Amount,
+			LedgerId:    ledgers[row.LedgerId],
+			ContactId:   contacts[row.ContactId],
+			LabelId:     labels[row.LabelId],
+			CategoryId:  categories[row.CategoryId],
+			SnapClerkId: snapclerks[row.SnapClerkId],
+		}
+
+		if err := t.db.New().Set("gorm:save_associations", false).Create(&a).Error; err != nil {
+			return err
+		}
+
+		t.result.Created["activity"]++
+
+		if err := t.saveMap("activity", row.Id, a.Id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+//
+// loadMap - Old id to new id for a type. Cached for the import.
+//
+func (t *importer) loadMap(objType string) map[uint]uint {
+	if _, ok := t.maps[objType]; !ok {
+		t.maps[objType] = t.db.GetArchiveMap(t.accountId, t.source, objType)
+	}
+
+	return t.maps[objType]
+}
+
+//
+// saveMap - Remember what an old id became.
+//
+func (t *importer) saveMap(objType string, oldId uint, newId uint) error {
+	t.loadMap(objType)[oldId] = newId
+	return t.db.CreateArchiveMap(t.accountId, t.source, objType, oldId, newId)
+}
+
+//
+// open - Open a file in the zip.
+//
+func (t *importer) open(name string) (io.ReadCloser, error) {
+	for _, row := range t.zip.File {
+		if row.Name == name {
+			return row.Open()
+		}
+	}
+
+	return nil, fmt.Errorf("The archive is missing %s.", name)
+}
+
+//
+// readJSON - Decode a JSON file in the zip.
+//
+func (t *importer) readJSON(name string, v interface{}) error {
+	f, err := t.open(name)
+
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	return json.NewDecoder(f).Decode(v)
+}
+
+//
+// uploadBlob - Copy a blob out of the zip and up to the object store.
+//
+func (t *importer) uploadBlob(name string, storePath string) error {
+	f, err := t.open(name)
+
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	tmp, err := ioutil.TempFile("", "archive-blob-*"+filepath.Ext(name))
+
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	if _, err := io.Copy(tmp, f); err != nil {
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return uploadObject(tmp.Name(), storePath)
+}
+
+/* End File */
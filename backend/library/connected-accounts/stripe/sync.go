@@ -178,6 +178,7 @@ func processTransaction(
 		contact.Email = custEmail
 		contact.StripeCustID = custID
 		db.New().Save(&contact)
+		db.IndexContact(contact.AccountId, contact.Id)
 	}
 
 	// Setup the new contact for Stripe
@@ -192,6 +193,7 @@ func processTransaction(
 		feeContact.Name = "Stripe"
 		feeContact.Website = "https://stripe.com"
 		db.New().Save(&feeContact)
+		db.IndexContact(feeContact.AccountId, feeContact.Id)
 	}
 
 	// Get income category
@@ -236,27 +238,43 @@ func processTransaction(
 	ledger := models.Ledger{
 		AccountId:  connectedAccount.AccountID,
 		ContactId:  contact.Id,
+		Contact:    contact,
 		Date:       time.Unix(createdAt, 0),
 		Amount:     float64(float64(amount) / float64(100)),
 		CategoryId: incomeCat.Id,
+		Category:   incomeCat,
 		StripeId:   tranID,
+		Source:     "stripe",
 		Note:       "Stripe Import of charge - " + tranID,
 		Labels:     []models.Label{label},
 	}
+	db.ApplyLedgerRules(&ledger)
+	db.MoveLedgerToOpenPeriod(&ledger)
 	db.New().Save(&ledger)
+	db.PostLedgerJournal(ledger.AccountId, ledger.Id)
+	db.CreateLedgerRevision(ledger.AccountId, ledger.Id, 0, "", "create")
+	db.IndexLedger(ledger.AccountId, ledger.Id)
 
 	// Insert the stripe fee.
 	feeObj := models.Ledger{
 		AccountId:  connectedAccount.AccountID,
 		ContactId:  feeContact.Id,
+		Contact:    feeContact,
 		Date:       time.Unix(createdAt+1, 0), // Just so it is created after the entry in our DB.
 		Amount:     float64(float64(fee)/float64(100)) * -1,
 		CategoryId: feeCat.Id,
+		Category:   feeCat,
 		StripeId:   tranID,
+		Source:     "stripe",
 		Note:       "Stripe Fee of charge - " + tranID,
 		Labels:     []models.Label{label},
 	}
+	db.ApplyLedgerRules(&feeObj)
+	db.MoveLedgerToOpenPeriod(&feeObj)
 	db.New().Save(&feeObj)
+	db.PostLedgerJournal(feeObj.AccountId, feeObj.Id)
+	db.CreateLedgerRevision(feeObj.AccountId, feeObj.Id, 0, "", "create")
+	db.IndexLedger(feeObj.AccountId, feeObj.Id)
 
 }
 
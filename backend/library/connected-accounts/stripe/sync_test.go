@@ -10,6 +10,7 @@ package stripe
 import (
 	"os"
 	"testing"
+	"time"
 
 	"app.skyclerk.com/backend/library/test"
 	"app.skyclerk.com/backend/models"
@@ -209,4 +210,38 @@ func TestSync03(t *testing.T) {
 	st.Expect(t, l[1].Labels[0].Name, "stripe")
 }
 
+//
+// TestSync04 - A charge and its fee post to the journal.
+//
+func TestSync04(t *testing.T) {
+	// Start the db connection.
+	db, dbName, _ := models.NewTestDB("")
+	defer models.TestingTearDown(db, dbName)
+
+	ac := models.ConnectedAccounts{AccountID: 33, Connection: "Stripe"}
+	processTransaction(db, ac, "ch_journal", 1500, 74, time.Now().Unix(), "cus_journal", "jane@example.com", "Jane Wells", "")
+
+	l := []models.Ledger{}
+	db.New().Where("LedgerAccountId = ? AND LedgerStripeId = ?", 33, "ch_journal").Order("LedgerId ASC").Find(&l)
+	st.Expect(t, len(l), 2)
+
+	cash := db.GetSystemChartAccount(33, "cash")
+
+	// The charge: cash in, income out.
+	lines := []models.JournalLine{}
+	db.New().Where("ledger_id = ?", l[0].Id).Order("id ASC").Find(&lines)
+	st.Expect(t, len(lines), 2)
+	st.Expect(t, lines[0].ChartAccountId, cash.Id)
+	st.Expect(t, lines[0].Debit, 15.00)
+	st.Expect(t, lines[1].Credit, 15.00)
+
+	// The fee: cash out, expense in.
+	lines = []models.JournalLine{}
+	db.New().Where("ledger_id = ?", l[1].Id).Order("id ASC").Find(&lines)
+	st.Expect(t, len(lines), 2)
+	st.Expect(t, lines[0].ChartAccountId, cash.Id)
+	st.Expect(t, lines[0].Credit, 0.74)
+	st.Expect(t, lines[1].Debit, 0.74)
+}
+
 /* End File */
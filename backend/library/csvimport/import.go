@@ -0,0 +1,424 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package csvimport
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/araddon/dateparse"
+	"github.com/ssor/bom"
+
+	"app.skyclerk.com/backend/library/helpers"
+	"app.skyclerk.com/backend/models"
+	"app.skyclerk.com/backend/services"
+)
+
+// ImportRow struct - One line of the CSV mapped to a ledger entry.
+type ImportRow struct {
+	Line        int                      `json:"line"`
+	Key         string                   `json:"key"`
+	Duplicate   bool                     `json:"duplicate"`
+	Error       string                   `json:"error"`
+	Ledger      models.Ledger            `json:"ledger"`
+	Suggestions models.LedgerSuggestions `json:"suggestions"` // Only set on previews.
+}
+
+// Date formats we let the user pick from.
+var dateFormats = map[string]string{
+	"MM/DD/YYYY": "1/2/2006",
+	"DD/MM/YYYY": "2/1/2006",
+	"YYYY-MM-DD": "2006-01-02",
+}
+
+//
+// Preview maps every line of the CSV to a ledger entry without saving anything.
+// Lines that can not be mapped come back with an error. Lines we already imported
+// are flagged as duplicates.
+//
+func Preview(db models.Datastore, accountId uint, profile models.ImportProfile, r io.Reader) ([]ImportRow, error) {
+	rows := []ImportRow{}
+	seen := map[string]int{}
+
+	// Some exports (AirBnb) start with a BOM.
+	br, err := bom.NewReaderWithoutBom(r)
+
+	if err != nil {
+		return rows, err
+	}
+
+	reader := csv.NewReader(br)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	if len(profile.Delimiter) > 0 {
+		reader.Comma = []rune(profile.Delimiter)[0]
+	}
+
+	line := 0
+
+	for {
+		record, err := reader.Read()
+		line++
+
+		if err == io.EOF {
+			break
+		}
+
+		if line <= profile.SkipRows {
+			continue
+		}
+
+		if err != nil {
+			rows = append(rows, ImportRow{Line: line, Error: "Unable to read this line."})
+			continue
+		}
+
+		// Skip blank lines
+		if len(strings.TrimSpace(strings.Join(record, ""))) == 0 {
+			continue
+		}
+
+		// Only the rows the profile cares about.
+		if (profile.FilterColumn > 0) && (column(record, profile.FilterColumn) != profile.FilterValue) {
+			continue
+		}
+
+		ir := ImportRow{Line: line}
+		ir.Ledger, err = buildLedger(db, accountId, profile, record)
+
+		if err == nil {
+			err = db.ValidateLedgerPeriodOpen(accountId, ir.Ledger.Date)
+		}
+
+		if err != nil {
+			ir.Error = err.Error()
+			rows = append(rows, ir)
+			continue
+		}
+
+		// Build the dedupe key. When we build it from the entry itself the same
+		// entry can show up twice in a file (two coffees in one day) so we count them.
+		base := dedupeBase(profile, record, ir.Ledger)
+		seen[base]++
+
+		if len(strings.TrimSpace(profile.DedupeColumns)) > 0 {
+			ir.Key = helpers.GetMd5(base)
+
+			if seen[base] > 1 {
+				ir.Duplicate = true
+			}
+		} else {
+			ir.Key = helpers.GetMd5(fmt.Sprintf("%s|%d", base, seen[base]))
+		}
+
+		ir.Ledger.ImportKey = ir.Key
+
+		// See if we have already imported this line.
+		if isDuplicate(db, accountId, profile, record, ir.Key) {
+			ir.Duplicate = true
+		}
+
+		rows = append(rows, ir)
+	}
+
+	return rows, nil
+}
+
+//
+// Import stores the CSV lines as ledger entries. Duplicates and lines with errors
+// are skipped. If lines is not empty we only import those lines. We return the
+// number imported along with the preview rows so the caller can show the errors.
+//
+func Import(db models.Datastore, accountId uint, userId uint, profile models.ImportProfile, r io.Reader, lines []int) (int, []ImportRow, error) {
+	var count int = 0
+
+	rows, err := Preview(db, accountId, profile, r)
+
+	if err != nil {
+		return 0, rows, err
+	}
+
+	// Build a lookup of the lines we want.
+	only := map[int]bool{}
+
+	for _, row := range lines {
+		only[row] = true
+	}
+
+	for key, row := range rows {
+		if row.Duplicate || (len(row.Error) > 0) {
+			continue
+		}
+
+		if (len(only) > 0) && !only[row.Line] {
+			continue
+		}
+
+		row.Ledger.AddedById = userId
+
+		// Store the entry in the database
+		err := db.LedgerCreate(&row.Ledger)
+
+		if err != nil {
+			services.Error(err)
+			rows[key].Error = "Unable to save this line."
+			continue
+		}
+
+		db.CreateLedgerRevision(row.Ledger.AccountId, row.Ledger.Id, userId, "", "create")
+
+		rows[key].Ledger = row.Ledger
+		count++
+	}
+
+	// Return the total imported
+	return count, rows, nil
+}
+
+// ----------------- Private Helper Funcs -------------- //
+
+//
+// buildLedger maps one CSV line to a ledger entry.
+//
+func buildLedger(db models.Datastore, accountId uint, profile models.ImportProfile, record []string) (models.Ledger, error) {
+	// Get the date
+	date, err := parseDate(column(record, profile.DateColumn), profile.DateFormat)
+
+	if err != nil {
+		return models.Ledger{}, fmt.Errorf("Unable to read the date \"%s\".", column(record, profile.DateColumn))
+	}
+
+	// Get the amount.
+	amount, err := parseAmount(profile, record)
+
+	if err != nil {
+		return models.Ledger{}, err
+	}
+
+	// Get the contact.
+	name := column(record, profile.ContactColumn)
+
+	if len(name) == 0 {
+		return models.Ledger{}, errors.New("The contact column is empty.")
+	}
+
+	contact := models.Contact{}
+	db.New().Where("ContactsAccountId = ? AND ContactsName = ?", accountId, name).First(&contact)
+
+	if contact.Id == 0 {
+		contact = models.Contact{AccountId: accountId, Name: name}
+	}
+
+	// Figure out the category.
+	catType := "1"
+	catName := "Other Expense"
+
+	if amount > 0 {
+		catType = "2"
+		catName = "Other Income"
+	}
+
+	category := models.Category{AccountId: accountId, Type: catType}
+
+	if len(column(record, profile.CategoryColumn)) > 0 {
+		catName = column(record, profile.CategoryColumn)
+	} else if len(profile.CategoryName) > 0 {
+		catName = profile.CategoryName
+		category.Show = profile.CategoryShow
+		category.Irs = profile.CategoryIrs
+
+		// Only use the tax form line if it is on the same side as the entry.
+		if models.ValidateCategoryTaxLine(models.Category{Irs: category.Irs, Type: catType}) != nil {
+			category.Irs = ""
+		}
+	} else if contact.Id > 0 {
+		if cat, err := db.GetCategoryForContact(accountId, contact.Id, catType); err == nil {
+			catName = cat.Name
+		}
+	}
+
+	category.Name = catName
+
+	// Setup the labels
+	labels := []models.Label{}
+	names := strings.FieldsFunc(column(record, profile.LabelsColumn), func(r rune) bool { return r == ',' || r == ';' || r == '|' })
+
+	if len(profile.LabelName) > 0 {
+		names = append(names, profile.LabelName)
+	}
+
+	for _, row := range names {
+		if len(strings.TrimSpace(row)) > 0 {
+			labels = append(labels, models.Label{AccountId: accountId, Name: strings.TrimSpace(row)})
+		}
+	}
+
+	// Build the note
+	note := []string{}
+
+	for _, col := range profile.Columns(profile.NoteColumns) {
+		if len(column(record, col)) > 0 {
+			note = append(note, column(record, col))
+		}
+	}
+
+	ledger := models.Ledger{
+		AccountId: accountId,
+		Date:      date,
+		Amount:    amount,
+		Contact:   contact,
+		Category:  category,
+		Labels:    labels,
+		Note:      strings.Join(note, " : "),
+		Source:    "import",
+	}
+
+	// Keep the old AirBnb dedupe field up to date.
+	if profile.Slug == "airbnb" {
+		ledger.AirBnbHash = dedupeBase(profile, record, ledger)
+	}
+
+	return ledger, nil
+}
+
+//
+// parseAmount gets the amount from either the amount column or the debit and
+// credit columns and applies the sign convention.
+//
+func parseAmount(profile models.ImportProfile, record []string) (float64, error) {
+	amount := 0.00
+
+	if profile.AmountColumn > 0 {
+		value := column(record, profile.AmountColumn)
+		num, err := parseNumber(value)
+
+		if err != nil {
+			return 0, fmt.Errorf("Unable to read the amount \"%s\".", value)
+		}
+
+		amount = num
+
+		if profile.SignConvention == "inverted" {
+			amount = amount * -1
+		}
+	} else {
+		credit, err := parseNumber(column(record, profile.CreditColumn))
+
+		if err != nil {
+			return 0, fmt.Errorf("Unable to read the credit \"%s\".", column(record, profile.CreditColumn))
+		}
+
+		debit, err := parseNumber(column(record, profile.DebitColumn))
+
+		if err != nil {
+			return 0, fmt.Errorf("Unable to read the debit \"%s\".", column(record, profile.DebitColumn))
+		}
+
+		amount = math.Abs(credit) - math.Abs(debit)
+	}
+
+	if amount == 0 {
+		return 0, errors.New("The amount is zero.")
+	}
+
+	return math.Round(amount*100) / 100, nil
+}
+
+//
+// parseNumber reads a number like "$1,234.56" or "(12.00)". Empty is zero.
+//
+func parseNumber(value string) (float64, error) {
+	value = strings.TrimSpace(value)
+	negative := strings.HasPrefix(value, "(") && strings.HasSuffix(value, ")")
+	value = strings.NewReplacer("$", "", ",", "", "(", "", ")", "", " ", "").Replace(value)
+
+	if len(value) == 0 {
+		return 0, nil
+	}
+
+	num, err := strconv.ParseFloat(value, 64)
+
+	if err != nil {
+		return 0, err
+	}
+
+	if negative {
+		num = num * -1
+	}
+
+	return num, nil
+}
+
+//
+// parseDate reads a date with the profile's format or guesses if there is none.
+//
+func parseDate(value string, format string) (time.Time, error) {
+	if layout, ok := dateFormats[format]; ok {
+		return time.Parse(layout, value)
+	}
+
+	return dateparse.ParseAny(value)
+}
+
+//
+// dedupeBase is what we hash for the dedupe key.
+//
+func dedupeBase(profile models.ImportProfile, record []string, ledger models.Ledger) string {
+	cols := profile.Columns(profile.DedupeColumns)
+
+	if len(cols) == 0 {
+		return fmt.Sprintf("%s|%.2f|%s|%s", ledger.Date.Format("2006-01-02"), ledger.Amount, ledger.Contact.Name, ledger.Note)
+	}
+
+	values := []string{}
+
+	for _, col := range cols {
+		values = append(values, column(record, col))
+	}
+
+	return strings.Join(values, "|")
+}
+
+//
+// isDuplicate checks to see if we already imported this line.
+//
+func isDuplicate(db models.Datastore, accountId uint, profile models.ImportProfile, record []string, key string) bool {
+	l := models.Ledger{}
+	db.New().Where("LedgerAccountId = ? AND LedgerImportKey = ?", accountId, key).First(&l)
+
+	if l.Id > 0 {
+		return true
+	}
+
+	// Entries from the old AirBnb import only have the confirmation code.
+	if profile.Slug == "airbnb" {
+		db.New().Where("LedgerAccountId = ? AND LedgerAirBnbHash = ?", accountId, dedupeBase(profile, record, l)).First(&l)
+	}
+
+	return l.Id > 0
+}
+
+//
+// column returns a trimmed column value. Columns start at 1.
+//
+func column(record []string, col int) string {
+	if (col <= 0) || (col > len(record)) {
+		return ""
+	}
+
+	return strings.TrimSpace(record[col-1])
+}
+
+/* End File */
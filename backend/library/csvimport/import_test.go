@@ -0,0 +1,162 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package csvimport
+
+import (
+	"os"
+	"testing"
+
+	"app.skyclerk.com/backend/models"
+	"github.com/nbio/st"
+)
+
+//
+// TestPreview01 - Preview a bank CSV with debit and credit columns.
+//
+func TestPreview01(t *testing.T) {
+	// Start the db connection.
+	db, dbName, _ := models.NewTestDB("")
+	defer models.TestingTearDown(db, dbName)
+
+	profile := getBankProfile()
+
+	fh, _ := os.Open("./bank_.csv")
+	defer fh.Close()
+
+	rows, err := Preview(db, 33, profile, fh)
+
+	// Test results
+	st.Expect(t, err, nil)
+	st.Expect(t, len(rows), 7)
+	st.Expect(t, rows[0].Line, 2)
+	st.Expect(t, rows[0].Error, "")
+	st.Expect(t, rows[0].Ledger.Date.Format("2006-01-02"), "2024-01-05")
+	st.Expect(t, rows[0].Ledger.Amount, -54.23)
+	st.Expect(t, rows[0].Ledger.Contact.Name, "Home Depot")
+	st.Expect(t, rows[0].Ledger.Category.Name, "Supplies")
+	st.Expect(t, rows[0].Ledger.Category.Type, "1")
+	st.Expect(t, len(rows[0].Ledger.Labels), 3)
+	st.Expect(t, rows[0].Ledger.Labels[0].Name, "store")
+	st.Expect(t, rows[0].Ledger.Labels[2].Name, "bank")
+	st.Expect(t, rows[1].Ledger.Amount, 1250.00)
+	st.Expect(t, rows[1].Ledger.Category.Type, "2")
+
+	// Same coffee twice in one day is not a duplicate.
+	st.Expect(t, rows[2].Duplicate, false)
+	st.Expect(t, rows[3].Duplicate, false)
+	st.Expect(t, rows[2].Key != rows[3].Key, true)
+
+	// Per row errors
+	st.Expect(t, rows[4].Error, "Unable to read the date \"13/45/2024\".")
+	st.Expect(t, rows[5].Error, "The contact column is empty.")
+	st.Expect(t, rows[6].Error, "Unable to read the debit \"abc\".")
+}
+
+//
+// TestImport01 - Importing the same file twice does nothing the second time.
+//
+func TestImport01(t *testing.T) {
+	// Start the db connection.
+	db, dbName, _ := models.NewTestDB("")
+	defer models.TestingTearDown(db, dbName)
+
+	profile := getBankProfile()
+
+	fh, _ := os.Open("./bank_.csv")
+	count, rows, err := Import(db, 33, 5, profile, fh, []int{})
+	fh.Close()
+
+	st.Expect(t, err, nil)
+	st.Expect(t, count, 4)
+	st.Expect(t, len(rows), 7)
+
+	l, err := db.GetLedgerByAccountAndId(33, rows[0].Ledger.Id)
+	st.Expect(t, err, nil)
+	st.Expect(t, l.Amount, -54.23)
+	st.Expect(t, l.AddedById, uint(5))
+	st.Expect(t, l.Category.Name, "Supplies")
+	st.Expect(t, len(l.Labels), 3)
+
+	// Second time around
+	fh, _ = os.Open("./bank_.csv")
+	count, rows, err = Import(db, 33, 5, profile, fh, []int{})
+	fh.Close()
+
+	st.Expect(t, err, nil)
+	st.Expect(t, count, 0)
+	st.Expect(t, rows[0].Duplicate, true)
+	st.Expect(t, rows[3].Duplicate, true)
+}
+
+//
+// TestImport02 - The built in AirBnb profile.
+//
+func TestImport02(t *testing.T) {
+	// Start the db connection.
+	db, dbName, _ := models.NewTestDB("")
+	defer models.TestingTearDown(db, dbName)
+
+	profile, err := GetBuiltinProfile("airbnb")
+	st.Expect(t, err, nil)
+
+	fh, _ := os.Open("../airbnb/airbnb_.csv")
+	count, _, err := Import(db, 33, 0, profile, fh, []int{})
+	fh.Close()
+
+	st.Expect(t, err, nil)
+	st.Expect(t, count, 62)
+
+	// Get the ledger entries we imported.
+	ledgers := []models.Ledger{}
+	db.New().Preload("Category").Preload("Contact").Preload("Labels").Order("LedgerId ASC").Find(&ledgers)
+
+	// Test results
+	st.Expect(t, len(ledgers), 62)
+	st.Expect(t, ledgers[61].AccountId, uint(33))
+	st.Expect(t, ledgers[61].Contact.Name, "Maya Richman")
+	st.Expect(t, ledgers[61].Category.Name, "Rental Income")
+	st.Expect(t, ledgers[61].Category.Type, "2")
+	st.Expect(t, ledgers[61].Category.Irs, "schedule-e:3")
+	st.Expect(t, ledgers[61].Category.Show, "1")
+	st.Expect(t, ledgers[61].Labels[0].Name, "airbnb")
+	st.Expect(t, ledgers[61].Amount, 306.00)
+	st.Expect(t, ledgers[61].Note, "Mt. Bachelor Village Apartment : P32WRZ")
+	st.Expect(t, ledgers[61].AirBnbHash, "P32WRZ")
+
+	// Entries from the old import are not imported again.
+	db.New().Model(&models.Ledger{}).Where("LedgerId = ?", ledgers[61].Id).Update("LedgerImportKey", "")
+
+	fh, _ = os.Open("../airbnb/airbnb_.csv")
+	count, _, err = Import(db, 33, 0, profile, fh, []int{})
+	fh.Close()
+
+	st.Expect(t, err, nil)
+	st.Expect(t, count, 0)
+}
+
+//
+// getBankProfile - Profile for bank_.csv
+//
+func getBankProfile() models.ImportProfile {
+	return models.ImportProfile{
+		Name:           "My Bank",
+		Delimiter:      ",",
+		SkipRows:       1,
+		DateColumn:     1,
+		DateFormat:     "MM/DD/YYYY",
+		DebitColumn:    4,
+		CreditColumn:   5,
+		SignConvention: "normal",
+		ContactColumn:  2,
+		CategoryColumn: 3,
+		LabelsColumn:   6,
+		LabelName:      "bank",
+	}
+}
+
+/* End File */
@@ -0,0 +1,58 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package csvimport
+
+import (
+	"errors"
+
+	"app.skyclerk.com/backend/models"
+)
+
+//
+// BuiltinProfiles returns the profiles every account gets. These are not stored
+// in the database and are looked up by slug.
+//
+func BuiltinProfiles() []models.ImportProfile {
+	return []models.ImportProfile{
+		// AirBnb transaction history export. We only want the reservation payouts.
+		{
+			Slug:           "airbnb",
+			Name:           "AirBnb",
+			Delimiter:      ",",
+			SkipRows:       1,
+			DateColumn:     1,
+			DateFormat:     "MM/DD/YYYY",
+			AmountColumn:   11,
+			SignConvention: "normal",
+			ContactColumn:  6,
+			NoteColumns:    "7,3",
+			CategoryName:   "Rental Income",
+			CategoryIrs:    "schedule-e:3",
+			CategoryShow:   "1",
+			LabelName:      "airbnb",
+			FilterColumn:   2,
+			FilterValue:    "Reservation",
+			DedupeColumns:  "3",
+		},
+	}
+}
+
+//
+// GetBuiltinProfile returns a built in profile by slug.
+//
+func GetBuiltinProfile(slug string) (models.ImportProfile, error) {
+	for _, row := range BuiltinProfiles() {
+		if row.Slug == slug {
+			return row, nil
+		}
+	}
+
+	return models.ImportProfile{}, errors.New("Import profile not found.")
+}
+
+/* End File */
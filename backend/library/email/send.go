@@ -80,6 +80,14 @@ func SetBccEmail() {
 // Mailgun's library for sending mail. Attachments are an array of local file paths.
 //
 func Send(to string, replyTo string, subject string, html string, attachments []string) error {
+	return SendWithHeaders(to, replyTo, subject, html, attachments, nil)
+}
+
+//
+// SendWithHeaders - Same as Send with extra headers added to the email. For
+// example List-Unsubscribe on emails people subscribed to.
+//
+func SendWithHeaders(to string, replyTo string, subject string, html string, attachments []string, headers map[string]string) error {
 	// Skip email sending during tests
 	if flag.Lookup("test.v") != nil {
 		return nil
@@ -100,22 +108,22 @@ func Send(to string, replyTo string, subject string, html string, attachments []
 	// Are we sending as SMTP or via Mailgun? Typically we
 	// send as SMTP for local development so we can use Mailhog
 	if os.Getenv("MAIL_DRIVER") == "smtp" {
-		return SMTPSend(to, replyTo, subject, html, text, attachments)
+		return SMTPSend(to, replyTo, subject, html, text, attachments, headers)
 	}
 
 	// Send via mailgun
 	if os.Getenv("MAIL_DRIVER") == "mailgun" {
-		return MailgunSend(to, replyTo, subject, html, text, attachments)
+		return MailgunSend(to, replyTo, subject, html, text, attachments, headers)
 	}
 
 	// Send via postmark
 	if os.Getenv("MAIL_DRIVER") == "postmark" {
-		return PostmarkSend(to, replyTo, subject, html, text, attachments)
+		return PostmarkSend(to, replyTo, subject, html, text, attachments, headers)
 	}
 
 	// We should never get here if we are configured correctly.
 	err = errors.New("No mail driver found.")
-	services.Info(errors.New(err.Error() + "library/email/Send/Send() - No mail driver found."))
+	services.Info(errors.New(err.Error() + "library/email/Send/SendWithHeaders() - No mail driver found."))
 	return err
 
 }
@@ -123,7 +131,7 @@ func Send(to string, replyTo string, subject string, html string, attachments []
 //
 // PostmarkSend will send via postmark.
 //
-func PostmarkSend(to string, replyTo string, subject string, html string, text string, attachments []string) error {
+func PostmarkSend(to string, replyTo string, subject string, html string, text string, attachments []string, headers map[string]string) error {
 	// Setup postmark
 	client := postmark.NewClient(os.Getenv("POSTMARK_SERVER_KEY"), os.Getenv("POSTMARK_ACCOUNT_KEY"))
 
@@ -142,6 +150,11 @@ func PostmarkSend(to string, replyTo string, subject string, html string, text s
 		email.Bcc = bccEmail
 	}
 
+	// Add any extra headers.
+	for key, value := range headers {
+		email.Headers = append(email.Headers, postmark.Header{Name: key, Value: value})
+	}
+
 	// Include any attachements.
 	for _, row := range attachments {
 		// Open file on disk.
@@ -189,7 +202,7 @@ func PostmarkSend(to string, replyTo string, subject string, html string, text s
 //
 // MailgunSend - Send via Mailgun.
 //
-func MailgunSend(to string, replyTo string, subject string, html string, text string, attachments []string) error {
+func MailgunSend(to string, replyTo string, subject string, html string, text string, attachments []string, headers map[string]string) error {
 	// Setup mailgun
 	mg := mailgun.NewMailgun(os.Getenv("MAILGUN_DOMAIN"), os.Getenv("MAILGUN_API_KEY"), "")
 
@@ -203,6 +216,11 @@ func MailgunSend(to string, replyTo string, subject string, html string, text st
 		message.AddBCC(bccEmail)
 	}
 
+	// Add any extra headers.
+	for key, value := range headers {
+		message.AddHeader(key, value)
+	}
+
 	// Include any attachements.
 	for _, row := range attachments {
 		message.AddAttachment(row)
@@ -223,7 +241,7 @@ func MailgunSend(to string, replyTo string, subject string, html string, text st
 //
 // SMTPSend - Send as SMTP.
 //
-func SMTPSend(to string, replyTo string, subject string, html string, text string, attachments []string) error {
+func SMTPSend(to string, replyTo string, subject string, html string, text string, attachments []string, headers map[string]string) error {
 	// Setup the email to send.
 	m := gomail.NewMessage()
 	m.SetHeader("From", fromEmail)
@@ -238,6 +256,11 @@ func SMTPSend(to string, replyTo string, subject string, html string, text strin
 		m.SetHeader("Bcc", bccEmail)
 	}
 
+	// Add any extra headers.
+	for key, value := range headers {
+		m.SetHeader(key, value)
+	}
+
 	// Include any attachements.
 	for _, row := range attachments {
 		m.Attach(row)
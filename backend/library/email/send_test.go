@@ -19,7 +19,7 @@ package email
 // 	}
 //
 // 	// Send Email
-// 	err := PostmarkSend("spicer@cloudmanic.com", "Unit Test For Postmark", "<p>I will be there soon.</p>", "I will be there soon.", attachments)
+// 	err := PostmarkSend("spicer@cloudmanic.com", "Unit Test For Postmark", "<p>I will be there soon.</p>", "I will be there soon.", attachments, nil)
 //
 // 	// Test results
 // 	st.Expect(t, err, nil)
@@ -0,0 +1,76 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+
+	"app.skyclerk.com/backend/library/reports"
+)
+
+// The columns in a 1099-NEC csv. One row per recipient in the layout e-file
+// services take for a bulk import.
+var Contractors1099Columns = []string{
+	"Tax Year",
+	"Form Type",
+	"Recipient TIN Type",
+	"Recipient TIN",
+	"Recipient Name",
+	"Recipient First Name",
+	"Recipient Last Name",
+	"Address Line 1",
+	"City",
+	"State",
+	"ZIP",
+	"Country",
+	"Email",
+	"Account Number",
+	"Box 1 Nonemployee Compensation",
+}
+
+//
+// WriteContractors1099CSV - Write the 1099 report as a csv for e-filing.
+//
+func WriteContractors1099CSV(w io.Writer, r reports.Contractors1099) error {
+	c := csv.NewWriter(w)
+	c.Write(Contractors1099Columns)
+
+	for _, row := range r.Contractors {
+		name := row.Name
+
+		if len(name) == 0 {
+			name = strings.TrimSpace(row.FirstName + " " + row.LastName)
+		}
+
+		c.Write([]string{
+			strconv.Itoa(r.Year),
+			"1099-NEC",
+			strings.ToUpper(row.TaxIdType),
+			row.TaxId,
+			csvSafe(name),
+			csvSafe(row.FirstName),
+			csvSafe(row.LastName),
+			csvSafe(row.Address),
+			csvSafe(row.City),
+			csvSafe(strings.ToUpper(row.State)),
+			csvSafe(row.Zip),
+			csvSafe(row.Country),
+			csvSafe(row.Email),
+			csvSafe(row.AccountNumber),
+			csvMoney(row.Amount),
+		})
+	}
+
+	c.Flush()
+	return c.Error()
+}
+
+/* End File */
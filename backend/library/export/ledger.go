@@ -0,0 +1,244 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package export
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"app.skyclerk.com/backend/library/xlsx"
+	"app.skyclerk.com/backend/models"
+)
+
+// How long attachment links in an export work.
+const attachmentLinkExpires = 7 * 24 * time.Hour
+
+// The columns in a ledger export.
+var LedgerColumns = []string{"Date", "Contact", "Category", "Labels", "Amount", "Note", "Attachments"}
+
+// What we preload to fill in the columns.
+var LedgerPreloads = []string{"Category", "Contact", "Labels", "Files", "Splits", "Splits.Category", "Splits.Labels"}
+
+// LedgerWriter writes ledger entries as CSV or XLSX as they come in.
+type LedgerWriter struct {
+	db   models.Datastore
+	csv  *csv.Writer
+	xlsx *xlsx.Writer
+
+	// When set the attachments column lists these names instead of links. The
+	// year-end bundle uses this to point at the receipts in the zip.
+	AttachmentName func(l models.Ledger, f models.File) string
+}
+
+//
+// NewLedgerWriter - Start an export in csv or xlsx and write the header row.
+//
+func NewLedgerWriter(db models.Datastore, w io.Writer, format string) (*LedgerWriter, error) {
+	t := &LedgerWriter{db: db}
+
+	switch format {
+	case "csv":
+		t.csv = csv.NewWriter(w)
+		return t, t.csv.Write(LedgerColumns)
+
+	case "xlsx":
+		x, err := xlsx.NewWriter(w, "Ledger")
+
+		if err != nil {
+			return nil, err
+		}
+
+		t.xlsx = x
+		return t, t.xlsx.WriteHeader(LedgerColumns)
+	}
+
+	return nil, errors.New("The format must be csv or xlsx.")
+}
+
+//
+// ContentType - The content type for a format.
+//
+func ContentType(format string) string {
+	if format == "xlsx" {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+
+	if format == "pdf" {
+		return "application/pdf"
+	}
+
+	return "text/csv"
+}
+
+//
+// Write - Write a batch of ledger entries. Entries need LedgerPreloads
+// preloaded.
+//
+func (t *LedgerWriter) Write(rows []models.Ledger) error {
+	for _, row := range rows {
+		if t.xlsx != nil {
+			err := t.xlsx.WriteRow([]interface{}{
+				row.Date,
+				ledgerContactName(row),
+				ledgerCategoryName(row),
+				ledgerLabelNames(row),
+				row.Amount,
+				row.Note,
+				t.ledgerAttachments(row),
+			})
+
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		err := t.csv.Write([]string{
+			row.Date.Format("2006-01-02"),
+			csvSafe(ledgerContactName(row)),
+			csvSafe(ledgerCategoryName(row)),
+			csvSafe(ledgerLabelNames(row)),
+			strconv.FormatFloat(row.Amount, 'f', 2, 64),
+			csvSafe(row.Note),
+			t.ledgerAttachments(row),
+		})
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+//
+// Flush - Push what we have written to the writer.
+//
+func (t *LedgerWriter) Flush() error {
+	if t.xlsx != nil {
+		return t.xlsx.Flush()
+	}
+
+	t.csv.Flush()
+	return t.csv.Error()
+}
+
+//
+// Close - Finish the file.
+//
+func (t *LedgerWriter) Close() error {
+	if t.xlsx != nil {
+		return t.xlsx.Close()
+	}
+
+	return t.Flush()
+}
+
+// ----------------- Private Helper Funcs -------------- //
+
+//
+// ledgerAttachments - Signed links to the files, one per line.
+//
+func (t *LedgerWriter) ledgerAttachments(l models.Ledger) string {
+	links := []string{}
+
+	for _, row := range l.Files {
+		if t.AttachmentName != nil {
+			links = append(links, t.AttachmentName(l, row))
+			continue
+		}
+
+		links = append(links, t.db.GetSignedFileUrlFor(row.Path, attachmentLinkExpires))
+	}
+
+	return strings.Join(links, "\n")
+}
+
+//
+// ledgerContactName - The contact name or first and last.
+//
+func ledgerContactName(l models.Ledger) string {
+	if len(l.Contact.Name) > 0 {
+		return l.Contact.Name
+	}
+
+	return strings.TrimSpace(l.Contact.FirstName + " " + l.Contact.LastName)
+}
+
+//
+// ledgerCategoryName - The category. Split entries list each line's category.
+//
+func ledgerCategoryName(l models.Ledger) string {
+	if len(l.Splits) == 0 {
+		return l.Category.Name
+	}
+
+	names := []string{}
+
+	for _, row := range l.Splits {
+		if !inList(row.Category.Name, names) {
+			names = append(names, row.Category.Name)
+		}
+	}
+
+	return strings.Join(names, ", ")
+}
+
+//
+// ledgerLabelNames - Labels on the entry and its split lines.
+//
+func ledgerLabelNames(l models.Ledger) string {
+	names := []string{}
+
+	for _, row := range l.Labels {
+		if !inList(row.Name, names) {
+			names = append(names, row.Name)
+		}
+	}
+
+	for _, split := range l.Splits {
+		for _, row := range split.Labels {
+			if !inList(row.Name, names) {
+				names = append(names, row.Name)
+			}
+		}
+	}
+
+	return strings.Join(names, ", ")
+}
+
+//
+// csvSafe - Stop spreadsheets from running text that looks like a formula.
+//
+func csvSafe(s string) string {
+	if (len(s) > 0) && strings.ContainsAny(s[:1], "=+-@\t\r") {
+		return "'" + s
+	}
+
+	return s
+}
+
+//
+// inList - Is s in list.
+//
+func inList(s string, list []string) bool {
+	for _, row := range list {
+		if row == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+/* End File */
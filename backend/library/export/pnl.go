@@ -0,0 +1,182 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package export
+
+import (
+	"io"
+	"strings"
+	"time"
+
+	"app.skyclerk.com/backend/library/helpers"
+	"app.skyclerk.com/backend/library/pdf"
+	"app.skyclerk.com/backend/library/reports"
+)
+
+// Titles for each way we group the P&L.
+var PnLTitles = map[string]string{
+	"month":   "Profit & Loss by Month",
+	"quarter": "Profit & Loss by Quarter",
+	"year":    "Profit & Loss by Year",
+}
+
+//
+// WritePnLPDF - Write income, expenses and profit for each month, quarter or
+// year as a PDF with a total at the bottom.
+//
+func WritePnLPDF(w io.Writer, rows []reports.PnL, group string, start time.Time, end time.Time, accountName string) error {
+	title, ok := PnLTitles[group]
+
+	if !ok {
+		title = "Profit & Loss"
+	}
+
+	r := newReportPDF(title, start, end, accountName, []pdf.Column{
+		{Name: "Period", Width: 3},
+		{Name: "Income", Width: 2, Right: true},
+		{Name: "Expenses", Width: 2, Right: true},
+		{Name: "Profit", Width: 2, Right: true},
+	})
+
+	s := pdf.Section{}
+	total := reports.PnL{}
+
+	for _, row := range rows {
+		s.Rows = append(s.Rows, pdf.Row{Cells: []string{pnlPeriodName(row.Date), helpers.FormatMoney(row.Income), helpers.FormatMoney(-row.Expense), helpers.FormatMoney(row.Profit)}})
+
+		total.Income = total.Income + row.Income
+		total.Expense = total.Expense + row.Expense
+		total.Profit = total.Profit + row.Profit
+	}
+
+	s.Rows = append(s.Rows, pdf.Row{Cells: []string{"Total", helpers.FormatMoney(total.Income), helpers.FormatMoney(-total.Expense), helpers.FormatMoney(total.Profit)}, Bold: true})
+	r.Sections = append(r.Sections, s)
+
+	_, err := r.WriteTo(w)
+	return err
+}
+
+//
+// WritePnLBreakdownPDF - Write category or label totals as a PDF with income
+// and expenses in their own sections and the net at the bottom. Expenses are
+// shown as positive amounts.
+//
+func WritePnLBreakdownPDF(w io.Writer, title string, column string, rows []reports.NameValue, start time.Time, end time.Time, accountName string) error {
+	r := newReportPDF(title, start, end, accountName, []pdf.Column{
+		{Name: column, Width: 6},
+		{Name: "Amount", Width: 2, Right: true},
+	})
+
+	income := pdf.Section{Heading: "Income"}
+	expense := pdf.Section{Heading: "Expenses"}
+	totalIncome := 0.00
+	totalExpense := 0.00
+
+	for _, row := range rows {
+		if row.Amount < 0 {
+			expense.Rows = append(expense.Rows, pdf.Row{Cells: []string{row.Name, helpers.FormatMoney(-row.Amount)}})
+			totalExpense = totalExpense - row.Amount
+		} else {
+			income.Rows = append(income.Rows, pdf.Row{Cells: []string{row.Name, helpers.FormatMoney(row.Amount)}})
+			totalIncome = totalIncome + row.Amount
+		}
+	}
+
+	income.Rows = append(income.Rows, pdf.Row{Cells: []string{"Total income", helpers.FormatMoney(totalIncome)}, Bold: true})
+	expense.Rows = append(expense.Rows, pdf.Row{Cells: []string{"Total expenses", helpers.FormatMoney(totalExpense)}, Bold: true})
+
+	r.Sections = append(r.Sections, income, expense, pdf.Section{Rows: []pdf.Row{
+		{Cells: []string{"Net profit or loss", helpers.FormatMoney(totalIncome - totalExpense)}, Bold: true},
+	}})
+
+	_, err := r.WriteTo(w)
+	return err
+}
+
+//
+// WriteContactTotalsPDF - Write income or expenses by contact as a PDF with a
+// total at the bottom. Amounts are shown as positive.
+//
+func WriteContactTotalsPDF(w io.Writer, title string, rows []reports.NameValue, start time.Time, end time.Time, accountName string) error {
+	r := newReportPDF(title, start, end, accountName, []pdf.Column{
+		{Name: "Contact", Width: 6},
+		{Name: "Amount", Width: 2, Right: true},
+	})
+
+	s := pdf.Section{}
+	total := 0.00
+
+	for _, row := range rows {
+		amount := row.Amount
+
+		if amount < 0 {
+			amount = -amount
+		}
+
+		s.Rows = append(s.Rows, pdf.Row{Cells: []string{strings.TrimSpace(row.Name), helpers.FormatMoney(amount)}})
+		total = total + amount
+	}
+
+	s.Rows = append(s.Rows, pdf.Row{Cells: []string{"Total", helpers.FormatMoney(total)}, Bold: true})
+	r.Sections = append(r.Sections, s)
+
+	_, err := r.WriteTo(w)
+	return err
+}
+
+// ----------------- Private Helper Funcs -------------- //
+
+//
+// newReportPDF - A report with the account, period and when we made it under the title.
+//
+func newReportPDF(title string, start time.Time, end time.Time, accountName string, columns []pdf.Column) pdf.Report {
+	return pdf.Report{
+		Title:    title,
+		Subtitle: []string{accountName, periodName(start, end), "Prepared " + time.Now().Format("January 2, 2006")},
+		Columns:  columns,
+		Sections: []pdf.Section{},
+	}
+}
+
+//
+// periodName - The dates a report covers. The report endpoints default to a
+// range so wide it means every entry.
+//
+func periodName(start time.Time, end time.Time) string {
+	from := start.Year() > 1800
+	to := end.Year() < 3000
+
+	switch {
+	case from && to:
+		return start.Format("January 2, 2006") + " - " + end.Format("January 2, 2006")
+
+	case from:
+		return "Since " + start.Format("January 2, 2006")
+
+	case to:
+		return "Through " + end.Format("January 2, 2006")
+	}
+
+	return "All dates"
+}
+
+//
+// pnlPeriodName - Turn 2024-03 into March 2024 and 2024-Q1 into Q1 2024.
+//
+func pnlPeriodName(date string) string {
+	if t, err := time.Parse("2006-01", date); err == nil {
+		return t.Format("January 2006")
+	}
+
+	if parts := strings.Split(date, "-"); len(parts) == 2 {
+		return parts[1] + " " + parts[0]
+	}
+
+	return date
+}
+
+/* End File */
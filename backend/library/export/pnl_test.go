@@ -0,0 +1,125 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package export
+
+import (
+	"bytes"
+	"compress/zlib"
+	"io/ioutil"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/nbio/st"
+
+	"app.skyclerk.com/backend/library/helpers"
+	"app.skyclerk.com/backend/library/reports"
+)
+
+//
+// TestWritePnLPDF01 - P&L by month and quarter with a total row.
+//
+func TestWritePnLPDF01(t *testing.T) {
+	rows := []reports.PnL{
+		{Date: "2024-02", Income: 1500, Expense: -400.50, Profit: 1099.50},
+		{Date: "2024-01", Income: 1000, Expense: -250, Profit: 750},
+	}
+
+	buf := bytes.Buffer{}
+	err := WritePnLPDF(&buf, rows, "month", helpers.ParseDateNoError("2024-01-01"), helpers.ParseDateNoError("2024-12-31"), "Acme, Inc.")
+	st.Expect(t, err, nil)
+
+	text := pdfText(buf.String())
+	st.Expect(t, strings.Contains(text, "(Profit & Loss by Month)"), true)
+	st.Expect(t, strings.Contains(text, "(Acme, Inc.)"), true)
+	st.Expect(t, strings.Contains(text, "(January 1, 2024 - December 31, 2024)"), true)
+	st.Expect(t, strings.Contains(text, "(Skyclerk)"), true)
+	st.Expect(t, strings.Contains(text, "(Page 1 of 1)"), true)
+	st.Expect(t, strings.Contains(text, "(February 2024)"), true)
+	st.Expect(t, strings.Contains(text, "($400.50)"), true)
+	st.Expect(t, strings.Contains(text, "($2,500.00)"), true)
+	st.Expect(t, strings.Contains(text, "($650.50)"), true)
+	st.Expect(t, strings.Contains(text, "($1,849.50)"), true)
+
+	// Quarters and the whole history.
+	buf = bytes.Buffer{}
+	WritePnLPDF(&buf, []reports.PnL{{Date: "2024-Q3", Income: 10, Profit: 10}}, "quarter", helpers.ParseDateNoError("1800-01-01"), helpers.ParseDateNoError("3000-01-01"), "Acme, Inc.")
+
+	text = pdfText(buf.String())
+	st.Expect(t, strings.Contains(text, "(Profit & Loss by Quarter)"), true)
+	st.Expect(t, strings.Contains(text, "(Q3 2024)"), true)
+	st.Expect(t, strings.Contains(text, "(All dates)"), true)
+}
+
+//
+// TestWritePnLBreakdownPDF01 - Categories split into income and expenses.
+//
+func TestWritePnLBreakdownPDF01(t *testing.T) {
+	rows := []reports.NameValue{
+		{Name: "Sales", Amount: 3000},
+		{Name: "Rent", Amount: -1200},
+		{Name: "Travel", Amount: -300.25},
+	}
+
+	buf := bytes.Buffer{}
+	err := WritePnLBreakdownPDF(&buf, "Profit & Loss by Category", "Category", rows, helpers.ParseDateNoError("2024-01-01"), helpers.ParseDateNoError("3000-01-01"), "Acme, Inc.")
+	st.Expect(t, err, nil)
+
+	text := pdfText(buf.String())
+	st.Expect(t, strings.Contains(text, "(Since January 1, 2024)"), true)
+	st.Expect(t, strings.Contains(text, "(Total income)"), true)
+	st.Expect(t, strings.Contains(text, "($3,000.00)"), true)
+	st.Expect(t, strings.Contains(text, "($1,200.00)"), true)
+	st.Expect(t, strings.Contains(text, "($1,500.25)"), true)
+	st.Expect(t, strings.Contains(text, "($1,499.75)"), true)
+	st.Expect(t, strings.Contains(text, "(-$"), false)
+}
+
+//
+// TestWriteContactTotalsPDF01 - Expenses by contact come out positive.
+//
+func TestWriteContactTotalsPDF01(t *testing.T) {
+	rows := []reports.NameValue{
+		{Name: "Airline", Amount: -450},
+		{Name: " Jane Smith", Amount: -50},
+	}
+
+	buf := bytes.Buffer{}
+	err := WriteContactTotalsPDF(&buf, "Expenses by Contact", rows, helpers.ParseDateNoError("1800-01-01"), helpers.ParseDateNoError("2024-06-30"), "Acme, Inc.")
+	st.Expect(t, err, nil)
+
+	text := pdfText(buf.String())
+	st.Expect(t, strings.Contains(text, "(Through June 30, 2024)"), true)
+	st.Expect(t, strings.Contains(text, "(Jane Smith)"), true)
+	st.Expect(t, strings.Contains(text, "($500.00)"), true)
+	st.Expect(t, strings.Contains(text, "(-$"), false)
+}
+
+// ----------------- Private Helper Funcs -------------- //
+
+//
+// pdfText - All the page content in a PDF, uncompressed.
+//
+func pdfText(out string) string {
+	text := ""
+
+	for _, row := range regexp.MustCompile(`(?s)/Filter /FlateDecode >>\nstream\n(.*?)\nendstream`).FindAllStringSubmatch(out, -1) {
+		zr, err := zlib.NewReader(strings.NewReader(row[1]))
+
+		if err != nil {
+			continue
+		}
+
+		b, _ := ioutil.ReadAll(zr)
+		text = text + string(b)
+	}
+
+	return text
+}
+
+/* End File */
@@ -0,0 +1,138 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"app.skyclerk.com/backend/library/helpers"
+	"app.skyclerk.com/backend/library/pdf"
+	"app.skyclerk.com/backend/library/reports"
+)
+
+// The columns in a tax summary csv.
+var TaxSummaryColumns = []string{"Form", "Line", "Description", "Amount", "Categories"}
+
+//
+// WriteTaxSummaryCSV - Write the tax summary as a csv. Each form line is a row
+// followed by the form totals. Unmapped categories and uncategorized entries
+// come last so they are hard to miss.
+//
+func WriteTaxSummaryCSV(w io.Writer, ts reports.TaxSummary) error {
+	c := csv.NewWriter(w)
+	c.Write(TaxSummaryColumns)
+
+	for _, form := range ts.Forms {
+		for _, row := range form.Lines {
+			c.Write([]string{form.Name, row.Line, row.Name, csvMoney(row.Amount), csvSafe(taxLineCategories(row))})
+		}
+
+		c.Write([]string{form.Name, "", "Total income", csvMoney(form.Income), ""})
+		c.Write([]string{form.Name, "", "Total expenses", csvMoney(form.Expense), ""})
+		c.Write([]string{form.Name, "", "Net profit or loss", csvMoney(form.Net), ""})
+	}
+
+	for _, row := range ts.Unmapped {
+		c.Write([]string{"Not on a tax form line", "", "Unmapped " + row.Type, csvMoney(row.Amount), csvSafe(row.Name)})
+	}
+
+	if ts.Uncategorized.Count > 0 {
+		c.Write([]string{"Not on a tax form line", "", "Uncategorized income", csvMoney(ts.Uncategorized.Income), strconv.Itoa(ts.Uncategorized.Count) + " entries"})
+		c.Write([]string{"Not on a tax form line", "", "Uncategorized expense", csvMoney(ts.Uncategorized.Expense), strconv.Itoa(ts.Uncategorized.Count) + " entries"})
+	}
+
+	c.Flush()
+	return c.Error()
+}
+
+//
+// WriteTaxSummaryPDF - Write the tax summary as a PDF to hand to a preparer.
+//
+func WriteTaxSummaryPDF(w io.Writer, ts reports.TaxSummary, accountName string) error {
+	r := pdf.Report{
+		Title: "Tax Summary " + strconv.Itoa(ts.Year),
+		Subtitle: []string{
+			accountName,
+			"January 1, " + strconv.Itoa(ts.Year) + " - December 31, " + strconv.Itoa(ts.Year),
+			"Prepared " + time.Now().Format("January 2, 2006"),
+		},
+		Columns: []pdf.Column{
+			{Name: "Line", Width: 1},
+			{Name: "Description", Width: 5},
+			{Name: "Categories", Width: 4},
+			{Name: "Amount", Width: 2, Right: true},
+		},
+		Sections: []pdf.Section{},
+		Notes:    ts.Warnings,
+	}
+
+	for _, form := range ts.Forms {
+		s := pdf.Section{Heading: form.Name}
+
+		for _, row := range form.Lines {
+			s.Rows = append(s.Rows, pdf.Row{Cells: []string{row.Line, row.Name, taxLineCategories(row), helpers.FormatMoney(row.Amount)}})
+		}
+
+		s.Rows = append(s.Rows,
+			pdf.Row{Cells: []string{"", "Total income", "", helpers.FormatMoney(form.Income)}, Bold: true},
+			pdf.Row{Cells: []string{"", "Total expenses", "", helpers.FormatMoney(form.Expense)}, Bold: true},
+			pdf.Row{Cells: []string{"", "Net profit or loss", "", helpers.FormatMoney(form.Net)}, Bold: true},
+		)
+
+		r.Sections = append(r.Sections, s)
+	}
+
+	// What still needs work.
+	if (len(ts.Unmapped) > 0) || (ts.Uncategorized.Count > 0) {
+		s := pdf.Section{Heading: "Not on a tax form line"}
+
+		for _, row := range ts.Unmapped {
+			s.Rows = append(s.Rows, pdf.Row{Cells: []string{"", "Unmapped " + row.Type, row.Name, helpers.FormatMoney(row.Amount)}})
+		}
+
+		if ts.Uncategorized.Count > 0 {
+			s.Rows = append(s.Rows,
+				pdf.Row{Cells: []string{"", "Uncategorized income", strconv.Itoa(ts.Uncategorized.Count) + " entries", helpers.FormatMoney(ts.Uncategorized.Income)}},
+				pdf.Row{Cells: []string{"", "Uncategorized expense", strconv.Itoa(ts.Uncategorized.Count) + " entries", helpers.FormatMoney(ts.Uncategorized.Expense)}},
+			)
+		}
+
+		r.Sections = append(r.Sections, s)
+	}
+
+	_, err := r.WriteTo(w)
+	return err
+}
+
+// ----------------- Private Helper Funcs -------------- //
+
+//
+// taxLineCategories - The names of the categories on a line.
+//
+func taxLineCategories(l reports.TaxSummaryLine) string {
+	names := []string{}
+
+	for _, row := range l.Categories {
+		names = append(names, row.Name)
+	}
+
+	return strings.Join(names, ", ")
+}
+
+//
+// csvMoney - Amounts in a csv are plain numbers so they add up in a spreadsheet.
+//
+func csvMoney(f float64) string {
+	return strconv.FormatFloat(f, 'f', 2, 64)
+}
+
+/* End File */
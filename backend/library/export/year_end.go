@@ -0,0 +1,323 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"app.skyclerk.com/backend/library/files"
+	"app.skyclerk.com/backend/library/reports"
+	"app.skyclerk.com/backend/library/store/object"
+	"app.skyclerk.com/backend/models"
+)
+
+// How many ledger entries we load at a time.
+const yearEndBatchSize = 500
+
+// Swapped out in tests so we do not hit the object store.
+var (
+	downloadObject = object.DownloadObject
+	uploadObject   = object.UploadObject
+)
+
+var slugRegex = regexp.MustCompile("[^a-z0-9]+")
+
+//
+// BuildYearEnd - Build the year-end zip for an accountant. It has the ledger,
+// a P&L by month, category and label totals and every receipt named
+// date_contact_amount. The zip is uploaded to the object store and the job
+// is marked done.
+//
+func BuildYearEnd(db models.Datastore, job *models.ExportJob) error {
+	os.MkdirAll(os.Getenv("CACHE_DIR"), 0755)
+
+	dir, err := ioutil.TempDir(os.Getenv("CACHE_DIR"), "year-end-")
+
+	if err != nil {
+		return err
+	}
+
+	defer os.RemoveAll(dir)
+
+	// SQLite stores dates as text with a time on the end, so "<= Dec 31" would
+	// miss the last day. We use "< Jan 1" of the next year instead.
+	start := time.Date(job.Year, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(1, 0, 0)
+
+	// The ledger and a list of receipts to pull.
+	receipts, err := yearEndLedger(db, job, dir, start, end)
+
+	if err != nil {
+		return err
+	}
+
+	db.ExportJobProgress(job, 20)
+
+	// Summary reports. These compare "<= end" as text, so Jan 1 still gets us
+	// all of Dec 31 and none of the next year.
+	if err := yearEndPnL(db, job, filepath.Join(dir, "pnl.csv"), start, end); err != nil {
+		return err
+	}
+
+	if err := yearEndNameValues(filepath.Join(dir, "categories.csv"), "Category", reports.GetCategoriesPnL(db, job.AccountId, start, end, "asc")); err != nil {
+		return err
+	}
+
+	if err := yearEndNameValues(filepath.Join(dir, "labels.csv"), "Label", reports.GetLabelsPnL(db, job.AccountId, start, end, "asc")); err != nil {
+		return err
+	}
+
+	db.ExportJobProgress(job, 30)
+
+	// Pull each receipt from the object store.
+	list := []string{
+		filepath.Join(dir, "ledger.csv"),
+		filepath.Join(dir, "pnl.csv"),
+		filepath.Join(dir, "categories.csv"),
+		filepath.Join(dir, "labels.csv"),
+	}
+
+	missing := []string{}
+
+	for key, row := range receipts {
+		dest := filepath.Join(dir, row.name)
+
+		if err := yearEndReceipt(row.path, dest); err != nil {
+			missing = append(missing, row.name+": "+err.Error())
+		} else {
+			list = append(list, dest)
+		}
+
+		// Receipts take us from 30% to 90%.
+		db.ExportJobProgress(job, 30+int(60*float64(key+1)/float64(len(receipts))))
+	}
+
+	if len(missing) > 0 {
+		p := filepath.Join(dir, "missing_receipts.txt")
+
+		if err := ioutil.WriteFile(p, []byte(strings.Join(missing, "\n")+"\n"), 0644); err != nil {
+			return err
+		}
+
+		list = append(list, p)
+	}
+
+	// Zip it up and send it to the object store.
+	name := fmt.Sprintf("skyclerk-%d-year-end.zip", job.Year)
+	zipPath := filepath.Join(dir, name)
+
+	if err := files.ZipFiles(zipPath, list); err != nil {
+		return err
+	}
+
+	info, err := os.Stat(zipPath)
+
+	if err != nil {
+		return err
+	}
+
+	storePath := fmt.Sprintf("accounts/%d/exports/%d/%s", job.AccountId, job.Id, name)
+
+	if err := uploadObject(zipPath, storePath); err != nil {
+		return err
+	}
+
+	return db.ExportJobDone(job, storePath, info.Size())
+}
+
+// ----------------- Private Helper Funcs -------------- //
+
+// A receipt we want in the zip.
+type yearEndFile struct {
+	path string
+	name string
+}
+
+//
+// yearEndLedger - Write ledger.csv for the year. Returns the receipts on
+// the entries with the names we give them in the zip.
+//
+func yearEndLedger(db models.Datastore, job *models.ExportJob, dir string, start time.Time, end time.Time) ([]yearEndFile, error) {
+	f, err := os.Create(filepath.Join(dir, "ledger.csv"))
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	w, err := NewLedgerWriter(db, f, "csv")
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Name each receipt once. An entry and its file can only be seen once but
+	// two receipts can end up with the same name.
+	receipts := []yearEndFile{}
+	names := map[uint]string{}
+	used := map[string]int{}
+
+	w.AttachmentName = func(l models.Ledger, file models.File) string {
+		if name, ok := names[file.Id]; ok {
+			return name
+		}
+
+		name := receiptName(l, file)
+		used[name]++
+
+		if used[name] > 1 {
+			ext := filepath.Ext(name)
+			name = fmt.Sprintf("%s_%d%s", strings.TrimSuffix(name, ext), used[name], ext)
+		}
+
+		names[file.Id] = name
+		receipts = append(receipts, yearEndFile{path: file.Path, name: name})
+
+		return name
+	}
+
+	params := models.QueryParam{
+		Order:    "LedgerDate",
+		Sort:     "ASC",
+		PreLoads: LedgerPreloads,
+		Wheres: []models.KeyValue{
+			{Key: "LedgerAccountId", Compare: "=", ValueInt: int(job.AccountId)},
+			{Key: "LedgerDate", Compare: ">=", Value: start.Format("2006-01-02")},
+			{Key: "LedgerDate", Compare: "<", Value: end.Format("2006-01-02")},
+		},
+	}
+
+	err = db.EachLedgerBatch(params, yearEndBatchSize, func(rows []models.Ledger) error {
+		return w.Write(rows)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return receipts, w.Close()
+}
+
+//
+// yearEndPnL - Income, expense and profit by month with a total row.
+//
+func yearEndPnL(db models.Datastore, job *models.ExportJob, path string, start time.Time, end time.Time) error {
+	rows := [][]string{{"Month", "Income", "Expense", "Profit"}}
+	total := reports.PnL{Date: "Total"}
+
+	for _, row := range reports.GetPnL(db, job.AccountId, start, end, "month", "asc") {
+		rows = append(rows, []string{row.Date, money(row.Income), money(row.Expense), money(row.Profit)})
+		total.Income += row.Income
+		total.Expense += row.Expense
+		total.Profit += row.Profit
+	}
+
+	rows = append(rows, []string{total.Date, money(total.Income), money(total.Expense), money(total.Profit)})
+
+	return writeCSV(path, rows)
+}
+
+//
+// yearEndNameValues - A name and total per row.
+//
+func yearEndNameValues(path string, title string, list []reports.NameValue) error {
+	rows := [][]string{{title, "Amount"}}
+
+	for _, row := range list {
+		rows = append(rows, []string{csvSafe(row.Name), money(row.Amount)})
+	}
+
+	return writeCSV(path, rows)
+}
+
+//
+// yearEndReceipt - Download a receipt and copy it in with its new name.
+//
+func yearEndReceipt(path string, dest string) error {
+	local, err := downloadObject(path)
+
+	if err != nil {
+		return err
+	}
+
+	src, err := os.Open(local)
+
+	if err != nil {
+		return err
+	}
+
+	defer src.Close()
+
+	out, err := os.Create(dest)
+
+	if err != nil {
+		return err
+	}
+
+	defer out.Close()
+
+	_, err = io.Copy(out, src)
+	return err
+}
+
+//
+// receiptName - 2024-01-15_home-depot_150.25.pdf
+//
+func receiptName(l models.Ledger, f models.File) string {
+	contact := strings.Trim(slugRegex.ReplaceAllString(strings.ToLower(ledgerContactName(l)), "-"), "-")
+
+	if len(contact) == 0 {
+		contact = "no-contact"
+	}
+
+	ext := strings.ToLower(filepath.Ext(f.Path))
+
+	if len(ext) == 0 {
+		ext = strings.ToLower(filepath.Ext(f.Name))
+	}
+
+	return l.Date.Format("2006-01-02") + "_" + contact + "_" + money(math.Abs(l.Amount)) + ext
+}
+
+//
+// money - Two decimal places.
+//
+func money(v float64) string {
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
+
+//
+// writeCSV - Write rows to a new csv file.
+//
+func writeCSV(path string, rows [][]string) error {
+	f, err := os.Create(path)
+
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	w.WriteAll(rows)
+
+	return w.Error()
+}
+
+/* End File */
@@ -0,0 +1,167 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package export
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nbio/st"
+
+	"app.skyclerk.com/backend/library/test"
+	"app.skyclerk.com/backend/models"
+)
+
+//
+// TestBuildYearEnd01 - Build a year-end zip with receipts.
+//
+func TestBuildYearEnd01(t *testing.T) {
+	// Start the db connection.
+	db, dbName, _ := models.NewTestDB("")
+	defer models.TestingTearDown(db, dbName)
+
+	dir, _ := ioutil.TempDir("", "year-end-test-")
+	defer os.RemoveAll(dir)
+
+	// Fake object store. Receipts hold their path, "missing" fails.
+	uploaded := ""
+	oldDownload, oldUpload := downloadObject, uploadObject
+
+	downloadObject = func(path string) (string, error) {
+		if strings.Contains(path, "missing") {
+			return "", errors.New("The object does not exist.")
+		}
+
+		p := filepath.Join(dir, filepath.Base(path))
+		return p, ioutil.WriteFile(p, []byte(path), 0644)
+	}
+
+	uploadObject = func(filePath string, storePath string) error {
+		uploaded = storePath
+		b, _ := ioutil.ReadFile(filePath)
+		return ioutil.WriteFile(filepath.Join(dir, "out.zip"), b, 0644)
+	}
+
+	defer func() {
+		downloadObject = oldDownload
+		uploadObject = oldUpload
+	}()
+
+	f1 := models.File{AccountId: 33, Name: "receipt.PDF", Path: "accounts/33/a.pdf"}
+	f2 := models.File{AccountId: 33, Name: "receipt.jpg", Path: "accounts/33/b.jpg"}
+	f3 := models.File{AccountId: 33, Name: "receipt.jpg", Path: "accounts/33/c.jpg"}
+	f4 := models.File{AccountId: 33, Name: "receipt.png", Path: "accounts/33/missing.png"}
+	db.Save(&f1)
+	db.Save(&f2)
+	db.Save(&f3)
+	db.Save(&f4)
+
+	l1 := test.GetRandomLedger(33)
+	l1.Amount = -150.25
+	l1.Date = time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	l1.Contact = models.Contact{Name: "Home Depot #42"}
+	l1.Category = models.Category{Name: "Supplies", Type: "1"}
+	l1.Labels = []models.Label{{Name: "Client A"}}
+	l1.Files = []models.File{f1, f4}
+	db.LedgerCreate(&l1)
+
+	// Same name twice.
+	l2 := test.GetRandomLedger(33)
+	l2.Amount = 20
+	l2.Date = time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)
+	l2.Contact = models.Contact{Name: "Acme"}
+	l2.Category = models.Category{Name: "Sales", Type: "2"}
+	l2.Labels = []models.Label{}
+	l2.Files = []models.File{f2, f3}
+	db.LedgerCreate(&l2)
+
+	// Not in 2024
+	l3 := test.GetRandomLedger(33)
+	l3.Date = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	db.LedgerCreate(&l3)
+
+	job := models.ExportJob{AccountId: 33, UserId: 1, Type: "year-end", Year: 2024}
+	db.CreateExportJob(&job)
+
+	err := BuildYearEnd(db, &job)
+	st.Expect(t, err, nil)
+
+	// Job is done.
+	j, _ := db.GetExportJobByAccountAndId(33, job.Id)
+	st.Expect(t, j.Status, "done")
+	st.Expect(t, j.Progress, 100)
+	st.Expect(t, j.Path, uploaded)
+	st.Expect(t, uploaded, "accounts/33/exports/1/skyclerk-2024-year-end.zip")
+	st.Expect(t, j.Size > 0, true)
+	st.Expect(t, j.ExpiresAt.After(time.Now().Add(6*24*time.Hour)), true)
+
+	// What is in the zip.
+	z, err := zip.OpenReader(filepath.Join(dir, "out.zip"))
+	st.Expect(t, err, nil)
+	defer z.Close()
+
+	files := map[string]string{}
+	names := []string{}
+
+	for _, row := range z.File {
+		rc, _ := row.Open()
+		b, _ := ioutil.ReadAll(rc)
+		rc.Close()
+		files[row.Name] = string(b)
+		names = append(names, row.Name)
+	}
+
+	sort.Strings(names)
+	st.Expect(t, names, []string{
+		"2024-01-15_home-depot-42_150.25.pdf",
+		"2024-12-31_acme_20.00.jpg",
+		"2024-12-31_acme_20.00_2.jpg",
+		"categories.csv",
+		"labels.csv",
+		"ledger.csv",
+		"missing_receipts.txt",
+		"pnl.csv",
+	})
+
+	st.Expect(t, files["2024-01-15_home-depot-42_150.25.pdf"], "accounts/33/a.pdf")
+	st.Expect(t, files["2024-12-31_acme_20.00_2.jpg"], "accounts/33/c.jpg")
+	st.Expect(t, files["missing_receipts.txt"], "2024-01-15_home-depot-42_150.25.png: The object does not exist.\n")
+
+	// Ledger
+	rows, _ := csv.NewReader(strings.NewReader(files["ledger.csv"])).ReadAll()
+	st.Expect(t, len(rows), 3)
+	st.Expect(t, rows[1], []string{"2024-01-15", "Home Depot #42", "Supplies", "Client A", "-150.25", l1.Note, "2024-01-15_home-depot-42_150.25.pdf\n2024-01-15_home-depot-42_150.25.png"})
+	st.Expect(t, rows[2][6], "2024-12-31_acme_20.00.jpg\n2024-12-31_acme_20.00_2.jpg")
+
+	// P&L
+	rows, _ = csv.NewReader(strings.NewReader(files["pnl.csv"])).ReadAll()
+	st.Expect(t, rows, [][]string{
+		{"Month", "Income", "Expense", "Profit"},
+		{"2024-01", "0.00", "-150.25", "-150.25"},
+		{"2024-12", "20.00", "0.00", "20.00"},
+		{"Total", "20.00", "-150.25", "-130.25"},
+	})
+
+	// Categories and labels
+	rows, _ = csv.NewReader(strings.NewReader(files["categories.csv"])).ReadAll()
+	st.Expect(t, len(rows), 3)
+	st.Expect(t, rows[0], []string{"Category", "Amount"})
+
+	rows, _ = csv.NewReader(strings.NewReader(files["labels.csv"])).ReadAll()
+	st.Expect(t, rows, [][]string{{"Label", "Amount"}, {"Client A", "-150.25"}})
+}
+
+/* End File */
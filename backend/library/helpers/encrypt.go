@@ -11,8 +11,10 @@ import (
 	"bytes"
 	"crypto/aes"
 	"crypto/cipher"
+	"crypto/hmac"
 	"crypto/md5"
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/base64"
 	"encoding/hex"
 	"errors"
@@ -26,7 +28,7 @@ import (
 func init() {
 	// Only set defaults during tests
 	if flag.Lookup("test.v") != nil {
-		setDefaultIfEmpty("ENCRYPTION_KEY", "test-encryption-key-32-characters")
+		setDefaultIfEmpty("ENCRYPTION_KEY", "test-encryption-key-32-character")
 	}
 }
 
@@ -46,6 +48,23 @@ func GetMd5(text string) string {
 	return hex.EncodeToString(h.Sum(nil))
 }
 
+//
+// Sign the string with our encryption key so we can tell later that we made
+// it. Safe to use in a url.
+//
+func Sign(text string) string {
+	mac := hmac.New(sha256.New, []byte(os.Getenv("ENCRYPTION_KEY")))
+	mac.Write([]byte(text))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+//
+// CheckSignature returns true if sig is what Sign returns for the string.
+//
+func CheckSignature(text string, sig string) bool {
+	return hmac.Equal([]byte(Sign(text)), []byte(sig))
+}
+
 //
 // Encrypt the string.
 //
@@ -8,7 +8,11 @@
 
 package helpers
 
-import "sort"
+import (
+	"math"
+	"sort"
+	"strconv"
+)
 
 //
 // Round
@@ -21,6 +25,24 @@ func Round(v float64, decimals int) float64 {
 	return float64(int((v*pow)+0.5)) / pow
 }
 
+//
+// FormatMoney - Dollars with commas like $1,234.50 or -$12.00
+//
+func FormatMoney(v float64) string {
+	s := strconv.FormatFloat(math.Abs(v), 'f', 2, 64)
+
+	// Add the commas from the right of the dollars.
+	for i := len(s) - 6; i > 0; i = i - 3 {
+		s = s[:i] + "," + s[i:]
+	}
+
+	if math.Round(v*100) < 0 {
+		return "-$" + s
+	}
+
+	return "$" + s
+}
+
 //
 // Find Min Float64 in a Slice
 //
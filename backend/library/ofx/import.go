@@ -0,0 +1,163 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package ofx
+
+import (
+	"strings"
+
+	"app.skyclerk.com/backend/models"
+	"app.skyclerk.com/backend/services"
+)
+
+// ImportRow struct - One statement transaction mapped to a ledger entry.
+type ImportRow struct {
+	FitId       string                   `json:"fit_id"`
+	Duplicate   bool                     `json:"duplicate"`
+	Error       string                   `json:"error"`
+	Ledger      models.Ledger            `json:"ledger"`
+	Suggestions models.LedgerSuggestions `json:"suggestions"` // Only set on previews.
+}
+
+//
+// Preview maps every transaction in the statement to a ledger entry without
+// saving anything. Rows we already imported (same FITID from the same bank
+// account) are flagged as duplicates.
+//
+func Preview(db models.Datastore, accountId uint, stmt Statement) []ImportRow {
+	rows := []ImportRow{}
+	seen := map[string]bool{}
+	fitAcct := stmt.AccountKey()
+
+	for _, row := range stmt.Transactions {
+		ir := ImportRow{
+			FitId:  row.FitId,
+			Ledger: buildLedger(db, accountId, row),
+		}
+
+		ir.Ledger.FitAcct = fitAcct
+
+		// Zero amounts can not be stored.
+		if row.Amount == 0 {
+			ir.Error = "The transaction amount is zero."
+		} else if err := db.ValidateLedgerPeriodOpen(accountId, row.Date); err != nil {
+			ir.Error = err.Error()
+		}
+
+		// See if we have already imported this transaction. Entries from before
+		// we kept the bank account could be from any statement.
+		l := models.Ledger{}
+		db.New().Where("LedgerAccountId = ? AND LedgerFitId = ? AND (LedgerFitAcct = ? OR LedgerFitAcct = '')", accountId, row.FitId, fitAcct).First(&l)
+
+		if (l.Id > 0) || seen[row.FitId] {
+			ir.Duplicate = true
+		}
+
+		seen[row.FitId] = true
+		rows = append(rows, ir)
+	}
+
+	return rows
+}
+
+//
+// Import stores the statement transactions as ledger entries. Duplicates and rows
+// with errors are skipped. If fitIds is not empty we only import those rows.
+//
+func Import(db models.Datastore, accountId uint, userId uint, stmt Statement, fitIds []string) int {
+	var count int = 0
+
+	// Build a lookup of the rows we want.
+	only := map[string]bool{}
+
+	for _, row := range fitIds {
+		only[strings.TrimSpace(row)] = true
+	}
+
+	for _, row := range Preview(db, accountId, stmt) {
+		if row.Duplicate || (len(row.Error) > 0) {
+			continue
+		}
+
+		if (len(only) > 0) && !only[row.FitId] {
+			continue
+		}
+
+		row.Ledger.AddedById = userId
+
+		// Store the entry in the database
+		err := db.LedgerCreate(&row.Ledger)
+
+		if err != nil {
+			services.Error(err)
+		} else {
+			db.CreateLedgerRevision(row.Ledger.AccountId, row.Ledger.Id, userId, "", "create")
+			count++
+		}
+	}
+
+	// Return the total imported
+	return count
+}
+
+// ----------------- Private Helper Funcs -------------- //
+
+//
+// buildLedger maps a statement transaction to a ledger entry. If we know the contact
+// we use the category from their last entry, if not we use a catch all category.
+//
+func buildLedger(db models.Datastore, accountId uint, trn Transaction) models.Ledger {
+	// Figure out the type of category we want.
+	catType := "1"
+	catName := "Other Expense"
+
+	if trn.Amount > 0 {
+		catType = "2"
+		catName = "Other Income"
+	}
+
+	category := models.Category{AccountId: accountId, Name: catName, Type: catType}
+
+	// See if we have seen this contact before.
+	contact := models.Contact{}
+	db.New().Where("ContactsAccountId = ? AND ContactsName = ?", accountId, trn.Name).First(&contact)
+
+	if contact.Id > 0 {
+		cat, err := db.GetCategoryForContact(accountId, contact.Id, catType)
+
+		if err == nil {
+			category = cat
+		}
+	} else {
+		contact = models.Contact{AccountId: accountId, Name: trn.Name}
+	}
+
+	// Build the note
+	note := ""
+
+	if trn.Memo != trn.Name {
+		note = trn.Memo
+	}
+
+	if len(trn.CheckNum) > 0 {
+		note = strings.TrimSpace("Check #" + trn.CheckNum + " " + note)
+	}
+
+	return models.Ledger{
+		AccountId: accountId,
+		Date:      trn.Date,
+		Amount:    trn.Amount,
+		Contact:   contact,
+		Category:  category,
+		Labels:    []models.Label{},
+		Note:      note,
+		FitId:     trn.FitId,
+		Source:    "import",
+	}
+}
+
+/* End File */
@@ -0,0 +1,162 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package ofx
+
+import (
+	"strings"
+	"testing"
+
+	"app.skyclerk.com/backend/models"
+	"github.com/nbio/st"
+)
+
+//
+// TestParse01 - Parse an SGML (v1) statement.
+//
+func TestParse01(t *testing.T) {
+	stmt, err := ParseFile("./statement_.qfx")
+
+	// Test results
+	st.Expect(t, err, nil)
+	st.Expect(t, stmt.BankId, "123000248")
+	st.Expect(t, stmt.AcctId, "987654321")
+	st.Expect(t, stmt.Currency, "USD")
+	st.Expect(t, stmt.Start.Format("2006-01-02"), "2024-02-01")
+	st.Expect(t, stmt.End.Format("2006-01-02"), "2024-02-29")
+	st.Expect(t, len(stmt.Transactions), 3)
+	st.Expect(t, stmt.Transactions[0].FitId, "202402030001")
+	st.Expect(t, stmt.Transactions[0].Type, "DEBIT")
+	st.Expect(t, stmt.Transactions[0].Date.Format("2006-01-02"), "2024-02-03")
+	st.Expect(t, stmt.Transactions[0].Amount, -54.23)
+	st.Expect(t, stmt.Transactions[0].Name, "Home Depot")
+	st.Expect(t, stmt.Transactions[0].Memo, "Store #1234")
+	st.Expect(t, stmt.Transactions[1].Amount, 1250.00)
+	st.Expect(t, stmt.Transactions[1].Name, "Acme & Sons")
+	st.Expect(t, stmt.Transactions[2].CheckNum, "1042")
+
+	// Not a statement
+	_, err = Parse(strings.NewReader("Date,Amount\n2024-01-01,10.00"))
+	st.Expect(t, err.Error(), "The file does not look like an OFX or QFX statement.")
+}
+
+//
+// TestParse02 - Parse an XML (v2) statement.
+//
+func TestParse02(t *testing.T) {
+	stmt, err := ParseFile("./statement_.ofx")
+
+	// Test results
+	st.Expect(t, err, nil)
+	st.Expect(t, stmt.AcctId, "987654321")
+	st.Expect(t, len(stmt.Transactions), 2)
+	st.Expect(t, stmt.Transactions[0].FitId, "202402150003")
+	st.Expect(t, stmt.Transactions[0].Amount, -300.00)
+	st.Expect(t, stmt.Transactions[1].Date.Format("2006-01-02"), "2024-03-02")
+	st.Expect(t, stmt.Transactions[1].Name, "Home Depot")
+}
+
+//
+// TestImport01 - Import two overlapping statements.
+//
+func TestImport01(t *testing.T) {
+	// Start the db connection.
+	db, dbName, _ := models.NewTestDB("")
+	defer models.TestingTearDown(db, dbName)
+
+	// First statement
+	stmt, _ := ParseFile("./statement_.qfx")
+
+	rows := Preview(db, 33, stmt)
+	st.Expect(t, len(rows), 3)
+	st.Expect(t, rows[0].Duplicate, false)
+	st.Expect(t, rows[0].Ledger.Contact.Name, "Home Depot")
+	st.Expect(t, rows[0].Ledger.Category.Name, "Other Expense")
+	st.Expect(t, rows[1].Ledger.Category.Name, "Other Income")
+	st.Expect(t, rows[2].Ledger.Note, "Check #1042")
+
+	// Preview does not save anything.
+	var count int
+	db.New().Model(&models.Ledger{}).Count(&count)
+	st.Expect(t, count, 0)
+
+	st.Expect(t, Import(db, 33, 5, stmt, []string{}), 3)
+
+	l := models.Ledger{}
+	db.New().Where("LedgerFitId = ?", "202402030001").First(&l)
+	st.Expect(t, l.AccountId, uint(33))
+	st.Expect(t, l.AddedById, uint(5))
+	st.Expect(t, l.Amount, -54.23)
+	st.Expect(t, l.Note, "Store #1234")
+
+	// Recategorize so we can see the next import learn from it.
+	cat := db.GetOrCreateCategory(33, "Supplies", "1")
+	db.New().Model(&l).Update("LedgerCategoryId", cat.Id)
+
+	// Second statement overlaps the first.
+	stmt2, _ := ParseFile("./statement_.ofx")
+
+	rows = Preview(db, 33, stmt2)
+	st.Expect(t, rows[0].Duplicate, true)
+	st.Expect(t, rows[1].Duplicate, false)
+	st.Expect(t, rows[1].Ledger.Contact.Id, l.ContactId)
+	st.Expect(t, rows[1].Ledger.Category.Name, "Supplies")
+
+	st.Expect(t, Import(db, 33, 5, stmt2, []string{}), 1)
+
+	// Importing again does nothing.
+	st.Expect(t, Import(db, 33, 5, stmt, []string{}), 0)
+	st.Expect(t, Import(db, 33, 5, stmt2, []string{}), 0)
+
+	db.New().Model(&models.Ledger{}).Where("LedgerAccountId = ?", 33).Count(&count)
+	st.Expect(t, count, 4)
+
+	// Same statement in a different account is not a duplicate.
+	st.Expect(t, Import(db, 34, 5, stmt, []string{"202402100002"}), 1)
+}
+
+//
+// TestImport02 - Two cards that happen to use the same FITID.
+//
+func TestImport02(t *testing.T) {
+	// Start the db connection.
+	db, dbName, _ := models.NewTestDB("")
+	defer models.TestingTearDown(db, dbName)
+
+	card := func(acctId string, name string) string {
+		return "OFXHEADER:100\n<OFX><CREDITCARDMSGSRSV1><CCSTMTTRNRS><CCSTMTRS><CURDEF>USD<CCACCTFROM><ACCTID>" + acctId + "</CCACCTFROM><BANKTRANLIST>" +
+			"<STMTTRN><TRNTYPE>DEBIT<DTPOSTED>20240305<TRNAMT>-20.00<FITID>0001<NAME>" + name + "</STMTTRN>" +
+			"</BANKTRANLIST></CCSTMTRS></CCSTMTTRNRS></CREDITCARDMSGSRSV1></OFX>"
+	}
+
+	visa, err := Parse(strings.NewReader(card("4111", "Coffee Shop")))
+	st.Expect(t, err, nil)
+	st.Expect(t, visa.AcctId, "4111")
+
+	amex, _ := Parse(strings.NewReader(card("3782", "Gas Station")))
+	st.Expect(t, visa.AccountKey() == amex.AccountKey(), false)
+
+	st.Expect(t, Import(db, 33, 5, visa, []string{}), 1)
+
+	rows := Preview(db, 33, amex)
+	st.Expect(t, rows[0].Duplicate, false)
+	st.Expect(t, Import(db, 33, 5, amex, []string{}), 1)
+
+	// Each card is still only imported once.
+	st.Expect(t, Import(db, 33, 5, visa, []string{}), 0)
+	st.Expect(t, Import(db, 33, 5, amex, []string{}), 0)
+
+	var count int
+	db.New().Model(&models.Ledger{}).Where("LedgerAccountId = ? AND LedgerFitId = ?", 33, "0001").Count(&count)
+	st.Expect(t, count, 2)
+
+	// Entries imported before we kept the bank account still count.
+	db.New().Model(&models.Ledger{}).Where("LedgerAccountId = ?", 33).Update("LedgerFitAcct", "")
+	st.Expect(t, Preview(db, 33, visa)[0].Duplicate, true)
+}
+
+/* End File */
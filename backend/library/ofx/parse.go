@@ -0,0 +1,251 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package ofx
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
+
+	"app.skyclerk.com/backend/library/helpers"
+)
+
+// Transaction struct - One STMTTRN from the statement.
+type Transaction struct {
+	FitId    string    `json:"fit_id"`
+	Type     string    `json:"type"`
+	Date     time.Time `json:"date"`
+	Amount   float64   `json:"amount"`
+	Name     string    `json:"name"`
+	Memo     string    `json:"memo"`
+	CheckNum string    `json:"check_num"`
+}
+
+// Statement struct - What we care about in an OFX / QFX file.
+type Statement struct {
+	BankId       string        `json:"bank_id"`
+	AcctId       string        `json:"acct_id"`
+	Currency     string        `json:"currency"`
+	Start        time.Time     `json:"start"`
+	End          time.Time     `json:"end"`
+	Transactions []Transaction `json:"transactions"`
+}
+
+//
+// ParseFile opens an OFX / QFX file and parses it.
+//
+func ParseFile(file string) (Statement, error) {
+	fh, err := os.Open(file)
+
+	if err != nil {
+		return Statement{}, err
+	}
+
+	defer fh.Close()
+
+	return Parse(fh)
+}
+
+//
+// Parse reads an OFX / QFX statement. Version 1.x files are SGML with no closing
+// tags on the values, version 2.x files are XML. We walk the tags the same way
+// for both so we only care about opening tags and the text that follows them.
+//
+func Parse(r io.Reader) (Statement, error) {
+	stmt := Statement{}
+
+	body, err := ioutil.ReadAll(r)
+
+	if err != nil {
+		return stmt, err
+	}
+
+	data := string(body)
+
+	// Skip the header. Everything we want lives inside <OFX>.
+	start := strings.Index(strings.ToUpper(data), "<OFX>")
+
+	if start < 0 {
+		return stmt, errors.New("The file does not look like an OFX or QFX statement.")
+	}
+
+	data = data[start:]
+
+	var trn *Transaction
+
+	for len(data) > 0 {
+		open := strings.Index(data, "<")
+
+		if open < 0 {
+			break
+		}
+
+		close := strings.Index(data[open:], ">")
+
+		if close < 0 {
+			break
+		}
+
+		tag := strings.ToUpper(strings.TrimSpace(data[open+1 : open+close]))
+		data = data[open+close+1:]
+
+		// The value runs until the next tag.
+		next := strings.Index(data, "<")
+
+		if next < 0 {
+			next = len(data)
+		}
+
+		value := decodeEntities(strings.TrimSpace(data[:next]))
+
+		switch tag {
+		case "STMTTRN":
+			trn = &Transaction{}
+			continue
+
+		case "/STMTTRN":
+			if trn != nil {
+				stmt.Transactions = append(stmt.Transactions, finishTransaction(*trn))
+			}
+			trn = nil
+			continue
+		}
+
+		// Closing tags and aggregates have no value.
+		if strings.HasPrefix(tag, "/") || len(value) == 0 {
+			continue
+		}
+
+		// Statement level values.
+		if trn == nil {
+			switch tag {
+			case "BANKID":
+				stmt.BankId = value
+			case "ACCTID":
+				stmt.AcctId = value
+			case "CURDEF":
+				stmt.Currency = value
+			case "DTSTART":
+				stmt.Start, _ = parseDate(value)
+			case "DTEND":
+				stmt.End, _ = parseDate(value)
+			}
+
+			continue
+		}
+
+		// Transaction level values.
+		switch tag {
+		case "FITID":
+			trn.FitId = value
+
+		case "TRNTYPE":
+			trn.Type = value
+
+		case "DTPOSTED":
+			trn.Date, err = parseDate(value)
+
+			if err != nil {
+				return stmt, fmt.Errorf("Unable to read the transaction date %s.", value)
+			}
+
+		case "TRNAMT":
+			trn.Amount = parseAmount(value)
+
+		case "NAME":
+			trn.Name = value
+
+		case "MEMO":
+			trn.Memo = value
+
+		case "CHECKNUM":
+			trn.CheckNum = value
+		}
+	}
+
+	if len(stmt.Transactions) == 0 {
+		return stmt, errors.New("We could not find any transactions in this statement.")
+	}
+
+	// Return happy.
+	return stmt, nil
+}
+
+//
+// AccountKey returns a hash of the bank and account number so we can tell
+// statements from different accounts apart without storing the account number.
+//
+func (s Statement) AccountKey() string {
+	if (len(s.BankId) == 0) && (len(s.AcctId) == 0) {
+		return ""
+	}
+
+	return helpers.GetMd5(s.BankId + "|" + s.AcctId)
+}
+
+// ----------------- Private Helper Funcs -------------- //
+
+//
+// finishTransaction fills in anything the bank left out.
+//
+func finishTransaction(trn Transaction) Transaction {
+	// Some banks leave the name empty and put everything in the memo.
+	if len(trn.Name) == 0 {
+		trn.Name = trn.Memo
+	}
+
+	if len(trn.Name) == 0 {
+		trn.Name = "Unknown"
+	}
+
+	// FITID is required by the spec but not every bank follows the spec.
+	if len(trn.FitId) == 0 {
+		trn.FitId = helpers.GetMd5(fmt.Sprintf("%s|%.2f|%s|%s", trn.Date.Format("2006-01-02"), trn.Amount, trn.Name, trn.Memo))
+	}
+
+	return trn
+}
+
+//
+// parseDate reads an OFX date. The format is YYYYMMDD[HHMMSS[.XXX]][[gmt offset:tz name]]
+// but we only care about the day.
+//
+func parseDate(value string) (time.Time, error) {
+	if len(value) < 8 {
+		return time.Time{}, errors.New("Invalid OFX date.")
+	}
+
+	return time.Parse("20060102", value[:8])
+}
+
+//
+// parseAmount reads an OFX amount. Some banks use a comma for the decimal point.
+//
+func parseAmount(value string) float64 {
+	if strings.Contains(value, ".") {
+		value = strings.Replace(value, ",", "", -1)
+	} else {
+		value = strings.Replace(value, ",", ".", -1)
+	}
+
+	return helpers.StringToFloat64(value)
+}
+
+//
+// decodeEntities turns the few XML entities OFX uses back into text.
+//
+func decodeEntities(value string) string {
+	r := strings.NewReplacer("&amp;", "&", "&lt;", "<", "&gt;", ">", "&quot;", "\"", "&apos;", "'", "&nbsp;", " ")
+	return r.Replace(value)
+}
+
+/* End File */
@@ -0,0 +1,145 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package pdf
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/golang/freetype/truetype"
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+// The font we use for our name on every page. It lives in FONT_PATH with the
+// avatar font.
+const brandFontFile = "Roboto-Bold.ttf"
+
+// brandFont is a TrueType font we embed in the file.
+type brandFont struct {
+	name      string
+	data      []byte // Compressed font file.
+	length    int    // Size of the font file before we compressed it.
+	widths    [256]int
+	bbox      [4]int
+	ascent    int
+	descent   int
+	capHeight int
+}
+
+var (
+	brand     *brandFont
+	brandOnce sync.Once
+)
+
+// ----------------- Private Helper Funcs -------------- //
+
+//
+// getBrandFont - Load the brand font the first time we need it. Returns nil
+// if we can not, in which case we fall back to Helvetica Bold.
+//
+func getBrandFont() *brandFont {
+	brandOnce.Do(func() {
+		dir := os.Getenv("FONT_PATH")
+
+		// Default to the fonts we ship with, relative to this source file.
+		if dir == "" {
+			_, b, _, _ := runtime.Caller(0)
+			dir = filepath.Join(filepath.Dir(b), "..", "..", "..", "fonts")
+		}
+
+		f, err := loadBrandFont(filepath.Join(dir, brandFontFile))
+
+		if err == nil {
+			brand = f
+		}
+	})
+
+	return brand
+}
+
+//
+// loadBrandFont - Read a TrueType font and work out the metrics a PDF reader
+// needs to lay out text with it. Widths are in 1/1000 of the font size for
+// each WinAnsi character code.
+//
+func loadBrandFont(path string) (*brandFont, error) {
+	data, err := ioutil.ReadFile(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := truetype.Parse(data)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Metrics for a 1000 unit em.
+	scale := fixed.Int26_6(1000)
+	bounds := f.Bounds(scale)
+	metrics := truetype.NewFace(f, &truetype.Options{Size: 1000, DPI: 72, Hinting: font.HintingNone}).Metrics()
+
+	rt := &brandFont{
+		name:    strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)),
+		data:    deflate(data),
+		length:  len(data),
+		bbox:    [4]int{int(bounds.Min.X), int(bounds.Min.Y), int(bounds.Max.X), int(bounds.Max.Y)},
+		ascent:  metrics.Ascent.Ceil(),
+		descent: -metrics.Descent.Ceil(),
+	}
+
+	// The top of a capital H.
+	g := &truetype.GlyphBuf{}
+
+	if err := g.Load(f, scale, f.Index('H'), font.HintingNone); err != nil {
+		return nil, err
+	}
+
+	rt.capHeight = int(g.Bounds.Max.Y)
+
+	for code := 32; code < 256; code++ {
+		r, ok := winAnsiRune(byte(code))
+
+		if !ok {
+			continue
+		}
+
+		rt.widths[code] = int(f.HMetric(scale, f.Index(r)).AdvanceWidth)
+	}
+
+	return rt, nil
+}
+
+//
+// objects - The font, its descriptor and the font file. first is the object
+// number the font will get.
+//
+func (b *brandFont) objects(first int) []string {
+	widths := []string{}
+
+	for code := 32; code < 256; code++ {
+		widths = append(widths, fmt.Sprint(b.widths[code]))
+	}
+
+	return []string{
+		fmt.Sprintf("<< /Type /Font /Subtype /TrueType /BaseFont /%s /FirstChar 32 /LastChar 255 /Widths [%s] /FontDescriptor %d 0 R /Encoding /WinAnsiEncoding >>",
+			b.name, strings.Join(widths, " "), first+1),
+		fmt.Sprintf("<< /Type /FontDescriptor /FontName /%s /Flags 32 /FontBBox [%d %d %d %d] /ItalicAngle 0 /Ascent %d /Descent %d /CapHeight %d /StemV 140 /FontFile2 %d 0 R >>",
+			b.name, b.bbox[0], b.bbox[1], b.bbox[2], b.bbox[3], b.ascent, b.descent, b.capHeight, first+2),
+		fmt.Sprintf("<< /Length %d /Length1 %d /Filter /FlateDecode >>\nstream\n%s\nendstream", len(b.data), b.length, b.data),
+	}
+}
+
+/* End File */
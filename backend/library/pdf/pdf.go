@@ -0,0 +1,427 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+// Package pdf writes simple PDF files: text, lines and shaded boxes on letter
+// size pages. Text uses the Helvetica fonts every PDF reader has built in. The
+// brand font is the only one we embed and only when a document uses it.
+package pdf
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// US letter in points.
+const (
+	PageWidth  = 612.0
+	PageHeight = 792.0
+)
+
+// Fonts we can draw with.
+const (
+	Regular = 0
+	Bold    = 1
+	Brand   = 2 // Falls back to Bold if we can not load it.
+)
+
+// The names the fonts go by in the file.
+var fontNames = []string{"Helvetica", "Helvetica-Bold"}
+
+// Document is a PDF being built in memory one page at a time.
+type Document struct {
+	title string
+	pages []*bytes.Buffer
+	page  *bytes.Buffer
+	brand bool // Did we draw with the brand font.
+}
+
+//
+// New - Start a new empty document. The title shows up in the reader's title bar.
+//
+func New(title string) *Document {
+	return &Document{title: title}
+}
+
+//
+// AddPage - Start a new page. Everything we draw goes on it until the next one.
+//
+func (d *Document) AddPage() {
+	d.page = &bytes.Buffer{}
+	d.pages = append(d.pages, d.page)
+}
+
+//
+// Pages - How many pages we have.
+//
+func (d *Document) Pages() int {
+	return len(d.pages)
+}
+
+//
+// SetPage - Go back to a page (starting at 1) to draw on it. Used for things
+// like page numbers that we only know once everything is laid out.
+//
+func (d *Document) SetPage(n int) {
+	d.page = d.pages[n-1]
+}
+
+//
+// Text - Draw text with its baseline at y. Like the rest of the drawing funcs
+// x and y are in points from the top left corner of the page.
+//
+func (d *Document) Text(x float64, y float64, font int, size float64, s string) {
+	if font == Brand {
+		if getBrandFont() == nil {
+			font = Bold
+		} else {
+			d.brand = true
+		}
+	}
+
+	fmt.Fprintf(d.page, "BT /F%d %s Tf %s %s Td (%s) Tj ET\n", font+1, num(size), num(x), num(PageHeight-y), encode(s))
+}
+
+//
+// TextRight - Draw text so it ends at x.
+//
+func (d *Document) TextRight(x float64, y float64, font int, size float64, s string) {
+	d.Text(x-TextWidth(font, size, s), y, font, size, s)
+}
+
+//
+// Line - Draw a line from x1, y1 to x2, y2.
+//
+func (d *Document) Line(x1 float64, y1 float64, x2 float64, y2 float64, width float64) {
+	fmt.Fprintf(d.page, "%s w %s %s m %s %s l S\n", num(width), num(x1), num(PageHeight-y1), num(x2), num(PageHeight-y2))
+}
+
+//
+// Box - Fill a box with a shade of gray (0 is black, 1 is white).
+//
+func (d *Document) Box(x float64, y float64, w float64, h float64, gray float64) {
+	fmt.Fprintf(d.page, "%s g %s %s %s %s re f 0 g\n", num(gray), num(x), num(PageHeight-y-h), num(w), num(h))
+}
+
+//
+// ColorBox - Fill a box with a color. Red, green and blue go from 0 to 1.
+//
+func (d *Document) ColorBox(x float64, y float64, w float64, h float64, r float64, g float64, b float64) {
+	fmt.Fprintf(d.page, "%s %s %s rg %s %s %s %s re f 0 g\n", num(r), num(g), num(b), num(x), num(PageHeight-y-h), num(w), num(h))
+}
+
+//
+// SetColor - The color text is drawn in until we change it again. Boxes set
+// it back to black.
+//
+func (d *Document) SetColor(r float64, g float64, b float64) {
+	fmt.Fprintf(d.page, "%s %s %s rg\n", num(r), num(g), num(b))
+}
+
+//
+// WriteTo - Write the finished PDF.
+//
+func (d *Document) WriteTo(w io.Writer) (int64, error) {
+	out := &countWriter{w: bufio.NewWriter(w)}
+	offsets := []int64{}
+
+	// Objects are numbered from 1 in the order we write them.
+	object := func(body string) {
+		offsets = append(offsets, out.n)
+		fmt.Fprintf(out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	// 1 catalog, 2 page tree, 3 info, then one font per font, then each page and its content.
+	firstFont := 4
+	firstPage := firstFont + len(fontNames)
+	embed := []string{}
+
+	// The brand font comes with its descriptor and font file.
+	if d.brand {
+		embed = getBrandFont().objects(firstPage)
+		firstPage = firstPage + len(embed)
+	}
+
+	kids := []string{}
+
+	for i := range d.pages {
+		kids = append(kids, strconv.Itoa(firstPage+(i*2))+" 0 R")
+	}
+
+	fonts := []string{}
+
+	for i := range fontNames {
+		fonts = append(fonts, fmt.Sprintf("/F%d %d 0 R", i+1, firstFont+i))
+	}
+
+	if d.brand {
+		fonts = append(fonts, fmt.Sprintf("/F%d %d 0 R", Brand+1, firstFont+len(fontNames)))
+	}
+
+	io.WriteString(out, "%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	object(fmt.Sprintf("<< /Title (%s) /Producer (Skyclerk) >>", encode(d.title)))
+
+	for _, row := range fontNames {
+		object("<< /Type /Font /Subtype /Type1 /BaseFont /" + row + " /Encoding /WinAnsiEncoding >>")
+	}
+
+	for _, row := range embed {
+		object(row)
+	}
+
+	for i, row := range d.pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << /Font << %s >> >> /Contents %d 0 R >>",
+			num(PageWidth), num(PageHeight), strings.Join(fonts, " "), firstPage+(i*2)+1))
+
+		z := deflate(row.Bytes())
+		object(fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream", len(z), z))
+	}
+
+	// Cross reference table so readers can find each object.
+	xref := out.n
+	fmt.Fprintf(out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+
+	for _, row := range offsets {
+		fmt.Fprintf(out, "%010d 00000 n \n", row)
+	}
+
+	fmt.Fprintf(out, "trailer\n<< /Size %d /Root 1 0 R /Info 3 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	if out.err != nil {
+		return out.n, out.err
+	}
+
+	return out.n, out.w.Flush()
+}
+
+//
+// TextWidth - How wide text is in points.
+//
+func TextWidth(font int, size float64, s string) float64 {
+	widths := helveticaWidths
+
+	if (font == Brand) && (getBrandFont() != nil) {
+		total := 0
+
+		for _, r := range s {
+			code, ok := winAnsiCode(r)
+
+			if !ok {
+				code = '?'
+			}
+
+			total = total + getBrandFont().widths[code]
+		}
+
+		return float64(total) * size / 1000
+	}
+
+	if font != Regular {
+		widths = helveticaBoldWidths
+	}
+
+	total := 0
+
+	for _, r := range s {
+		if (r >= 32) && (r <= 126) {
+			total = total + widths[r-32]
+		} else {
+			total = total + 556
+		}
+	}
+
+	return float64(total) * size / 1000
+}
+
+//
+// Fit - Cut text down so it fits in width, ending with "..." if we had to cut.
+//
+func Fit(font int, size float64, width float64, s string) string {
+	if TextWidth(font, size, s) <= width {
+		return s
+	}
+
+	r := []rune(s)
+
+	for len(r) > 0 {
+		r = r[:len(r)-1]
+
+		if TextWidth(font, size, string(r)+"...") <= width {
+			break
+		}
+	}
+
+	return strings.TrimRight(string(r), " ") + "..."
+}
+
+//
+// Wrap - Break text into lines no wider than width.
+//
+func Wrap(font int, size float64, width float64, s string) []string {
+	lines := []string{}
+	line := ""
+
+	for _, word := range strings.Fields(s) {
+		next := word
+
+		if line != "" {
+			next = line + " " + word
+		}
+
+		if (line != "") && (TextWidth(font, size, next) > width) {
+			lines = append(lines, line)
+			next = word
+		}
+
+		line = next
+	}
+
+	if line != "" {
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+// ----------------- Private Helper Funcs -------------- //
+
+// countWriter - Keeps track of how much we have written so we know where each object starts.
+type countWriter struct {
+	w   *bufio.Writer
+	n   int64
+	err error
+}
+
+//
+// Write - io.Writer that remembers the first error.
+//
+func (c *countWriter) Write(p []byte) (int, error) {
+	if c.err != nil {
+		return 0, c.err
+	}
+
+	n, err := c.w.Write(p)
+	c.n = c.n + int64(n)
+	c.err = err
+	return n, err
+}
+
+//
+// deflate - Compress a stream.
+//
+func deflate(b []byte) []byte {
+	var z bytes.Buffer
+	zw := zlib.NewWriter(&z)
+	zw.Write(b)
+	zw.Close()
+	return z.Bytes()
+}
+
+//
+// num - Format a number the way PDF likes it.
+//
+func num(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// Characters outside of Latin-1 that WinAnsiEncoding has a spot for.
+var winAnsi = map[rune]byte{
+	'€': 0x80, '…': 0x85, '‘': 0x91, '’': 0x92, '“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97, '™': 0x99,
+}
+
+//
+// encode - Turn text into a PDF string. Anything the fonts can not show becomes a "?".
+//
+func encode(s string) string {
+	var b strings.Builder
+
+	for _, r := range s {
+		switch {
+		case (r == '(') || (r == ')') || (r == '\\'):
+			b.WriteByte('\\')
+			b.WriteRune(r)
+
+		case r < 32:
+			b.WriteByte(' ')
+
+		case r < 127:
+			b.WriteRune(r)
+
+		default:
+			if code, ok := winAnsiCode(r); ok {
+				b.WriteString(fmt.Sprintf("\\%03o", code))
+			} else {
+				b.WriteByte('?')
+			}
+		}
+	}
+
+	return b.String()
+}
+
+//
+// winAnsiCode - Where a character is in WinAnsiEncoding.
+//
+func winAnsiCode(r rune) (byte, bool) {
+	switch {
+	case (r >= 32) && (r < 127):
+		return byte(r), true
+
+	case (r >= 0xA0) && (r <= 0xFF):
+		return byte(r), true
+
+	case winAnsi[r] != 0:
+		return winAnsi[r], true
+	}
+
+	return 0, false
+}
+
+//
+// winAnsiRune - The character at a spot in WinAnsiEncoding.
+//
+func winAnsiRune(code byte) (rune, bool) {
+	if ((code >= 32) && (code < 127)) || (code >= 0xA0) {
+		return rune(code), true
+	}
+
+	for r, c := range winAnsi {
+		if c == code {
+			return r, true
+		}
+	}
+
+	return 0, false
+}
+
+// Character widths for space through ~ from the Adobe font metrics.
+var helveticaWidths = []int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
+
+var helveticaBoldWidths = []int{
+	278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+	975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+	333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+	611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+}
+
+/* End File */
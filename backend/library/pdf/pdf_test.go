@@ -0,0 +1,118 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"io/ioutil"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/nbio/st"
+)
+
+//
+// TestReport01 - Lay out a report long enough to need a few pages.
+//
+func TestReport01(t *testing.T) {
+	r := Report{
+		Title:    "Tax Summary (2024)",
+		Subtitle: []string{"Acme, Inc.", "January 1, 2024 - December 31, 2024"},
+		Columns:  []Column{{Name: "Line", Width: 1}, {Name: "Description", Width: 4}, {Name: "Amount", Width: 2, Right: true}},
+	}
+
+	rows := []Row{}
+
+	for i := 0; i < 100; i++ {
+		rows = append(rows, Row{Cells: []string{strconv.Itoa(i), "Café supplies", "$1,000.00"}})
+	}
+
+	r.Sections = []Section{{Heading: "Schedule C", Rows: rows}}
+	r.Notes = []string{"1 uncategorized entry (totaling $50.00) needs a category."}
+
+	buf := bytes.Buffer{}
+	n, err := r.WriteTo(&buf)
+	st.Expect(t, err, nil)
+	st.Expect(t, n, int64(buf.Len()))
+
+	out := buf.String()
+	st.Expect(t, strings.HasPrefix(out, "%PDF-1.4\n"), true)
+	st.Expect(t, strings.HasSuffix(out, "%%EOF\n"), true)
+	st.Expect(t, strings.Contains(out, "/Count 3 "), true)
+	st.Expect(t, strings.Contains(out, "/Title (Tax Summary \\(2024\\))"), true)
+
+	// The startxref points at the xref table and each entry points at its object.
+	m := regexp.MustCompile(`startxref\n(\d+)\n`).FindStringSubmatch(out)
+	xref, _ := strconv.Atoi(m[1])
+	st.Expect(t, strings.HasPrefix(out[xref:], "xref\n0 15\n"), true)
+
+	for i, row := range strings.Split(out[xref:], "\n")[3:17] {
+		offset, _ := strconv.Atoi(row[:10])
+		st.Expect(t, strings.HasPrefix(out[offset:], strconv.Itoa(i+1)+" 0 obj\n"), true)
+	}
+
+	// Our font is embedded once, ahead of the pages.
+	st.Expect(t, strings.Count(out, "/FontFile2 "), 1)
+	st.Expect(t, strings.Contains(out, "/BaseFont /Roboto-Bold /FirstChar 32 /LastChar 255 "), true)
+	st.Expect(t, strings.Count(out, "/F3 6 0 R"), 3)
+
+	// The last page has the notes, its page number and our name.
+	streams := regexp.MustCompile(`(?s)stream\n(.*?)\nendstream`).FindAllStringSubmatch(out, -1)
+	st.Expect(t, len(streams), 4)
+
+	zr, err := zlib.NewReader(strings.NewReader(streams[3][1]))
+	st.Expect(t, err, nil)
+	last, _ := ioutil.ReadAll(zr)
+
+	st.Expect(t, strings.Contains(string(last), "(Page 3 of 3)"), true)
+	st.Expect(t, strings.Contains(string(last), "(1 uncategorized entry \\(totaling $50.00\\) needs a category.)"), true)
+	st.Expect(t, strings.Contains(string(last), "(Caf\\351 supplies)"), true)
+	st.Expect(t, strings.Contains(string(last), "/F3 14 Tf 50 772 Td (Skyclerk) Tj"), true)
+	st.Expect(t, strings.Contains(string(last), "(Acme, Inc.) Tj"), true)
+}
+
+//
+// TestDocument01 - The brand font is only embedded when we use it.
+//
+func TestDocument01(t *testing.T) {
+	d := New("Plain")
+	d.AddPage()
+	d.Text(50, 50, Bold, 10, "Hello")
+
+	buf := bytes.Buffer{}
+	d.WriteTo(&buf)
+	st.Expect(t, strings.Contains(buf.String(), "/FontFile2"), false)
+	st.Expect(t, strings.Contains(buf.String(), "/F3"), false)
+
+	d.Text(50, 70, Brand, 10, "Hello")
+
+	buf = bytes.Buffer{}
+	d.WriteTo(&buf)
+	st.Expect(t, strings.Contains(buf.String(), "/FontFile2 8 0 R"), true)
+	st.Expect(t, strings.Contains(buf.String(), "/Resources << /Font << /F1 4 0 R /F2 5 0 R /F3 6 0 R >> >> /Contents 10 0 R"), true)
+}
+
+//
+// TestFit01 - Cut and wrap text to a width.
+//
+func TestFit01(t *testing.T) {
+	st.Expect(t, TextWidth(Regular, 10, "0000"), 22.24)
+	st.Expect(t, TextWidth(Bold, 10, "i"), 2.78)
+	st.Expect(t, TextWidth(Brand, 10, "H"), 7.07)
+
+	st.Expect(t, Fit(Regular, 10, 100, "Short"), "Short")
+	st.Expect(t, Fit(Regular, 10, 40, "Advertising and marketing"), "Adverti...")
+
+	st.Expect(t, Wrap(Regular, 10, 60, "one two three four five"), []string{"one two", "three four", "five"})
+	st.Expect(t, Wrap(Regular, 10, 60, "  "), []string{})
+}
+
+/* End File */
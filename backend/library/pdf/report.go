@@ -0,0 +1,196 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package pdf
+
+import (
+	"io"
+	"strconv"
+)
+
+// Page layout for reports in points.
+const (
+	margin     = 50.0
+	rowHeight  = 14.0
+	fontSize   = 9.0
+	bodyBottom = PageHeight - 60
+	bandHeight = 30.0
+)
+
+// Our name and color (the dark slate from the app) across the top of each page.
+const brandName = "Skyclerk"
+
+var brandColor = []float64{0.153, 0.184, 0.196}
+
+// Report is a table laid out over as many pages as it needs. Each page has our
+// name across the top, the column headers repeat and each page is numbered.
+type Report struct {
+	Title    string
+	Subtitle []string // Lines under the title like the account name and period. The first one is on every page.
+	Columns  []Column
+	Sections []Section
+	Notes    []string // Paragraphs printed after the table.
+}
+
+// Column is one column of a report. Widths are shares of the page, not points.
+type Column struct {
+	Name  string
+	Width float64
+	Right bool
+}
+
+// Section is a group of rows with an optional shaded heading.
+type Section struct {
+	Heading string
+	Rows    []Row
+}
+
+// Row is one line of a report.
+type Row struct {
+	Cells []string
+	Bold  bool
+}
+
+//
+// WriteTo - Lay out the report and write it as a PDF.
+//
+func (r Report) WriteTo(w io.Writer) (int64, error) {
+	d := New(r.Title)
+	d.AddPage()
+
+	// Turn the column shares into points.
+	share := 0.0
+
+	for _, row := range r.Columns {
+		share = share + row.Width
+	}
+
+	widths := []float64{}
+
+	for _, row := range r.Columns {
+		widths = append(widths, row.Width/share*(PageWidth-(margin*2)))
+	}
+
+	// Title block, first page only.
+	y := margin + 18
+	d.Text(margin, y, Bold, 18, r.Title)
+	y = y + 8
+
+	for _, row := range r.Subtitle {
+		y = y + rowHeight
+		d.Text(margin, y, Regular, 10, row)
+	}
+
+	y = y + rowHeight + 6
+
+	// Draws a row of cells at the current spot.
+	cells := func(cells []string, font int) {
+		x := margin
+
+		for i, row := range r.Columns {
+			text := ""
+
+			if i < len(cells) {
+				text = Fit(font, fontSize, widths[i]-6, cells[i])
+			}
+
+			if row.Right {
+				d.TextRight(x+widths[i], y, font, fontSize, text)
+			} else {
+				d.Text(x, y, font, fontSize, text)
+			}
+
+			x = x + widths[i]
+		}
+	}
+
+	header := func() {
+		y = y + rowHeight
+		names := []string{}
+
+		for _, row := range r.Columns {
+			names = append(names, row.Name)
+		}
+
+		cells(names, Bold)
+		d.Line(margin, y+4, PageWidth-margin, y+4, 0.75)
+		y = y + 4
+	}
+
+	// Move down a row, starting a new page when we run out of room.
+	next := func(rows int) {
+		if y+(rowHeight*float64(rows)) > bodyBottom {
+			d.AddPage()
+			y = margin
+			header()
+		}
+
+		y = y + rowHeight
+	}
+
+	header()
+
+	for _, section := range r.Sections {
+		if section.Heading != "" {
+			// Keep the heading with at least its first row.
+			next(2)
+			y = y + 4
+			d.Box(margin, y-rowHeight+3, PageWidth-(margin*2), rowHeight, 0.9)
+			d.Text(margin+3, y, Bold, fontSize, section.Heading)
+		}
+
+		for _, row := range section.Rows {
+			next(1)
+
+			font := Regular
+
+			if row.Bold {
+				font = Bold
+			}
+
+			cells(row.Cells, font)
+		}
+	}
+
+	// Notes go under the table.
+	y = y + 6
+
+	for _, note := range r.Notes {
+		y = y + 6
+
+		for _, line := range Wrap(Regular, fontSize, PageWidth-(margin*2), note) {
+			if y+rowHeight > bodyBottom {
+				d.AddPage()
+				y = margin
+			}
+
+			y = y + rowHeight
+			d.Text(margin, y, Regular, fontSize, line)
+		}
+	}
+
+	// Now that we know how many pages there are brand and number them.
+	for i := 1; i <= d.Pages(); i++ {
+		d.SetPage(i)
+		d.ColorBox(0, 0, PageWidth, bandHeight, brandColor[0], brandColor[1], brandColor[2])
+		d.SetColor(1, 1, 1)
+		d.Text(margin, 20, Brand, 14, brandName)
+
+		if len(r.Subtitle) > 0 {
+			d.TextRight(PageWidth-margin, 19, Regular, 8, Fit(Regular, 8, PageWidth/2, r.Subtitle[0]))
+		}
+
+		d.SetColor(0, 0, 0)
+		d.Line(margin, PageHeight-40, PageWidth-margin, PageHeight-40, 0.5)
+		d.Text(margin, PageHeight-28, Regular, 8, r.Title)
+		d.TextRight(PageWidth-margin, PageHeight-28, Regular, 8, "Page "+strconv.Itoa(i)+" of "+strconv.Itoa(d.Pages()))
+	}
+
+	return d.WriteTo(w)
+}
+
+/* End File */
@@ -0,0 +1,162 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package reports
+
+import (
+	"sort"
+	"time"
+
+	"app.skyclerk.com/backend/models"
+)
+
+// ComparativeRow struct - One line of a report with this period and the
+// period we compare it to.
+type ComparativeRow struct {
+	ChartAccountId uint    `json:"chart_account_id"` // Zero for lines we work out like retained earnings.
+	Code           string  `json:"code"`
+	Name           string  `json:"name"`
+	Amount         float64 `json:"amount"`
+	Compare        float64 `json:"compare"`
+}
+
+// ComparativeSection struct - A group of lines with their totals.
+type ComparativeSection struct {
+	Accounts []ComparativeRow `json:"accounts"`
+	Total    float64          `json:"total"`
+	Compare  float64          `json:"compare"`
+}
+
+// BalanceSheet struct
+type BalanceSheet struct {
+	Date        string             `json:"date"`
+	CompareDate string             `json:"compare_date"`
+	Assets      ComparativeSection `json:"assets"`
+	Liabilities ComparativeSection `json:"liabilities"`
+	Equity      ComparativeSection `json:"equity"`
+	Balanced    bool               `json:"balanced"` // Assets equal liabilities plus equity on both dates.
+}
+
+//
+// GetBalanceSheet returns assets, liabilities and equity as of the end of date
+// next to the same as of the end of compare. Income and expense to date show
+// up in equity as retained earnings.
+//
+func GetBalanceSheet(db models.Datastore, accountId uint, date time.Time, compare time.Time) BalanceSheet {
+	// Struct we return
+	rt := BalanceSheet{
+		Date:        date.Format("2006-01-02"),
+		CompareDate: compare.Format("2006-01-02"),
+		Assets:      ComparativeSection{Accounts: []ComparativeRow{}},
+		Liabilities: ComparativeSection{Accounts: []ComparativeRow{}},
+		Equity:      ComparativeSection{Accounts: []ComparativeRow{}},
+	}
+
+	now := getAccountBalances(db, accountId, time.Time{}, date, "")
+	then := getAccountBalances(db, accountId, time.Time{}, compare, "")
+
+	// Assets are debit balances, the rest are credit balances.
+	earnings := ComparativeRow{Name: "Retained Earnings"}
+
+	for _, row := range mergeBalances(now, then) {
+		switch row.Type {
+		case "asset":
+			rt.Assets.add(row.ComparativeRow)
+
+		case "liability":
+			rt.Liabilities.add(row.negate())
+
+		case "equity":
+			rt.Equity.add(row.negate())
+
+		default:
+			earnings.Amount = cents(earnings.Amount - row.Amount)
+			earnings.Compare = cents(earnings.Compare - row.Compare)
+		}
+	}
+
+	if (earnings.Amount != 0) || (earnings.Compare != 0) {
+		rt.Equity.add(earnings)
+	}
+
+	rt.Balanced = (cents(rt.Assets.Total-rt.Liabilities.Total-rt.Equity.Total) == 0) && (cents(rt.Assets.Compare-rt.Liabilities.Compare-rt.Equity.Compare) == 0)
+
+	// Return happy.
+	return rt
+}
+
+// ----------------- Private Helper Funcs -------------- //
+
+// typedRow - A comparative row with the chart account type we need to sort it.
+type typedRow struct {
+	ComparativeRow
+	Type string
+}
+
+//
+// negate - Flip a debit balance into a credit balance.
+//
+func (r typedRow) negate() ComparativeRow {
+	r.Amount = -r.Amount
+	r.Compare = -r.Compare
+	return r.ComparativeRow
+}
+
+//
+// add - Add a line to a section. Lines with nothing on either side are left out.
+//
+func (s *ComparativeSection) add(row ComparativeRow) {
+	if (row.Amount == 0) && (row.Compare == 0) {
+		return
+	}
+
+	s.Accounts = append(s.Accounts, row)
+	s.Total = cents(s.Total + row.Amount)
+	s.Compare = cents(s.Compare + row.Compare)
+}
+
+//
+// mergeBalances - Line up two sets of balances by chart account. Amounts are
+// debits less credits.
+//
+func mergeBalances(now []accountBalance, then []accountBalance) []typedRow {
+	rows := map[uint]*typedRow{}
+
+	get := func(b accountBalance) *typedRow {
+		if _, ok := rows[b.ChartAccountId]; !ok {
+			rows[b.ChartAccountId] = &typedRow{ComparativeRow: ComparativeRow{ChartAccountId: b.ChartAccountId, Code: b.Code, Name: b.Name}, Type: b.Type}
+		}
+
+		return rows[b.ChartAccountId]
+	}
+
+	for _, b := range now {
+		get(b).Amount = cents(b.Debit - b.Credit)
+	}
+
+	for _, b := range then {
+		get(b).Compare = cents(b.Debit - b.Credit)
+	}
+
+	rt := []typedRow{}
+
+	for _, row := range rows {
+		rt = append(rt, *row)
+	}
+
+	sort.Slice(rt, func(i, j int) bool {
+		if rt[i].Code != rt[j].Code {
+			return rt[i].Code < rt[j].Code
+		}
+
+		return rt[i].Name < rt[j].Name
+	})
+
+	return rt
+}
+
+/* End File */
@@ -0,0 +1,166 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package reports
+
+import (
+	"testing"
+
+	"github.com/nbio/st"
+
+	"app.skyclerk.com/backend/library/helpers"
+	"app.skyclerk.com/backend/models"
+)
+
+//
+// TestGetBalanceSheet01 - Balance sheet this year and last.
+//
+func TestGetBalanceSheet01(t *testing.T) {
+	// Start the db connection.
+	db, dbName, _ := models.NewTestDB("")
+	defer models.TestingTearDown(db, dbName)
+
+	seedBooks(db)
+
+	bs := GetBalanceSheet(db, 33, helpers.ParseDateNoError("2024-12-31"), helpers.ParseDateNoError("2023-12-31"))
+	st.Expect(t, bs.Date, "2024-12-31")
+	st.Expect(t, bs.CompareDate, "2023-12-31")
+	st.Expect(t, bs.Balanced, true)
+
+	st.Expect(t, bs.Assets.Accounts, []ComparativeRow{
+		{ChartAccountId: 1, Code: "1000", Name: "Cash", Amount: 2500, Compare: 0},
+		{ChartAccountId: 5, Code: "1010", Name: "Checking", Amount: 7200, Compare: 6000},
+		{ChartAccountId: 8, Code: "1500", Name: "Truck", Amount: 8000, Compare: 0},
+	})
+	st.Expect(t, bs.Assets.Total, 17700.00)
+	st.Expect(t, bs.Assets.Compare, 6000.00)
+
+	// The card was paid off so it is not here.
+	st.Expect(t, len(bs.Liabilities.Accounts), 1)
+	st.Expect(t, bs.Liabilities.Accounts[0].Name, "Loan")
+	st.Expect(t, bs.Liabilities.Total, 10000.00)
+	st.Expect(t, bs.Liabilities.Compare, 0.00)
+
+	st.Expect(t, bs.Equity.Accounts, []ComparativeRow{
+		{ChartAccountId: 2, Code: "3000", Name: "Owner's Equity", Amount: 5000, Compare: 5000},
+		{Name: "Retained Earnings", Amount: 2700, Compare: 1000},
+	})
+	st.Expect(t, bs.Equity.Total, 7700.00)
+	st.Expect(t, bs.Equity.Compare, 6000.00)
+
+	// Other accounts have their own books.
+	bs = GetBalanceSheet(db, 34, helpers.ParseDateNoError("2024-12-31"), helpers.ParseDateNoError("2023-12-31"))
+	st.Expect(t, len(bs.Assets.Accounts), 1)
+	st.Expect(t, bs.Assets.Total, 50.00)
+	st.Expect(t, bs.Balanced, true)
+}
+
+//
+// TestGetCashFlow01 - Cash flow this year and last.
+//
+func TestGetCashFlow01(t *testing.T) {
+	// Start the db connection.
+	db, dbName, _ := models.NewTestDB("")
+	defer models.TestingTearDown(db, dbName)
+
+	seedBooks(db)
+
+	start := helpers.ParseDateNoError("2024-01-01")
+	end := helpers.ParseDateNoError("2024-12-31")
+	compareStart, compareEnd := PriorPeriod(start, end)
+
+	cf := GetCashFlow(db, 33, start, end, compareStart, compareEnd)
+	st.Expect(t, cf.CompareStart, "2023-01-01")
+	st.Expect(t, cf.CompareEnd, "2023-12-31")
+
+	// The card charge did not move cash, paying the card did.
+	st.Expect(t, len(cf.Operating.Accounts), 1)
+	st.Expect(t, cf.Operating.Accounts[0].Name, "Sales")
+	st.Expect(t, cf.Operating.Total, 2000.00)
+	st.Expect(t, cf.Operating.Compare, 1000.00)
+
+	st.Expect(t, cf.Investing.Total, -8000.00)
+	st.Expect(t, cf.Investing.Compare, 0.00)
+
+	st.Expect(t, len(cf.Financing.Accounts), 3)
+	st.Expect(t, cf.Financing.Total, 9700.00)
+	st.Expect(t, cf.Financing.Compare, 5000.00)
+
+	// Money between cash accounts is not a cash flow.
+	st.Expect(t, cf.NetChange.Amount, 3700.00)
+	st.Expect(t, cf.NetChange.Compare, 6000.00)
+	st.Expect(t, cf.BeginningCash.Amount, 6000.00)
+	st.Expect(t, cf.BeginningCash.Compare, 0.00)
+	st.Expect(t, cf.EndingCash.Amount, 9700.00)
+	st.Expect(t, cf.EndingCash.Compare, 6000.00)
+}
+
+//
+// TestPriorPeriod01 - Whole months go back by months, everything else by days.
+//
+func TestPriorPeriod01(t *testing.T) {
+	tests := []struct {
+		start string
+		end   string
+		want  []string
+	}{
+		{"2024-01-01", "2024-12-31", []string{"2023-01-01", "2023-12-31"}},
+		{"2024-04-01", "2024-06-30", []string{"2024-01-01", "2024-03-31"}},
+		{"2024-03-01", "2024-03-31", []string{"2024-02-01", "2024-02-29"}},
+		{"2024-03-10", "2024-03-16", []string{"2024-03-03", "2024-03-09"}},
+	}
+
+	for _, row := range tests {
+		s, e := PriorPeriod(helpers.ParseDateNoError(row.start), helpers.ParseDateNoError(row.end))
+		st.Expect(t, []string{s.Format("2006-01-02"), e.Format("2006-01-02")}, row.want)
+	}
+}
+
+// ----------------- Private Helper Funcs -------------- //
+
+//
+// seedBooks - Two years of books for account 33 with a bank account, a
+// credit card, a loan and a truck.
+//
+func seedBooks(db *models.DB) {
+	checking := models.ChartAccount{AccountId: 33, Code: "1010", Name: "Checking", Type: "asset", Cash: true}
+	visa := models.ChartAccount{AccountId: 33, Code: "2000", Name: "Visa", Type: "liability"}
+	loan := models.ChartAccount{AccountId: 33, Code: "2100", Name: "Loan", Type: "liability"}
+	truck := models.ChartAccount{AccountId: 33, Code: "1500", Name: "Truck", Type: "asset"}
+
+	cash := db.GetSystemChartAccount(33, "cash")
+	equity := db.GetSystemChartAccount(33, "equity")
+
+	for _, row := range []*models.ChartAccount{&checking, &visa, &loan, &truck} {
+		db.ChartAccountCreate(row)
+	}
+
+	sales := models.Category{Name: "Sales", Type: "2"}
+	supplies := models.Category{Name: "Supplies", Type: "1"}
+
+	entries := []models.Ledger{
+		{Date: helpers.ParseDateNoError("2023-06-01"), Amount: 5000, ChartAccountId: checking.Id, TransferChartAccountId: equity.Id},
+		{Date: helpers.ParseDateNoError("2023-07-01"), Amount: 1000, ChartAccountId: checking.Id, Category: sales},
+		{Date: helpers.ParseDateNoError("2024-01-10"), Amount: 2000, Category: sales},
+		{Date: helpers.ParseDateNoError("2024-02-01"), Amount: -300, ChartAccountId: visa.Id, Category: supplies},
+		{Date: helpers.ParseDateNoError("2024-03-01"), Amount: -300, ChartAccountId: checking.Id, TransferChartAccountId: visa.Id},
+		{Date: helpers.ParseDateNoError("2024-04-01"), Amount: 10000, ChartAccountId: checking.Id, TransferChartAccountId: loan.Id},
+		{Date: helpers.ParseDateNoError("2024-04-02"), Amount: -8000, ChartAccountId: checking.Id, TransferChartAccountId: truck.Id},
+		{Date: helpers.ParseDateNoError("2024-05-01"), Amount: -500, ChartAccountId: checking.Id, TransferChartAccountId: cash.Id},
+	}
+
+	for _, row := range entries {
+		row.AccountId = 33
+		row.Contact = models.Contact{Name: "Acme"}
+		db.LedgerCreate(&row)
+	}
+
+	// Someone else
+	db.LedgerCreate(&models.Ledger{AccountId: 34, Date: helpers.ParseDateNoError("2024-01-10"), Amount: 50, Contact: models.Contact{Name: "Not Ours"}, Category: models.Category{Name: "Sales", Type: "2"}})
+}
+
+/* End File */
@@ -0,0 +1,142 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package reports
+
+import (
+	"time"
+
+	"app.skyclerk.com/backend/models"
+)
+
+// BudgetActual struct - One budget and where it stands for a period.
+type BudgetActual struct {
+	BudgetId     uint    `json:"budget_id"`
+	Name         string  `json:"name"`
+	Type         string  `json:"type"` // expense or income
+	CategoryId   uint    `json:"category_id"`
+	CategoryName string  `json:"category_name"`
+	LabelId      uint    `json:"label_id"`
+	LabelName    string  `json:"label_name"`
+	Period       string  `json:"period"`
+	Start        string  `json:"start"`
+	End          string  `json:"end"` // Last day of the period.
+	Amount       float64 `json:"amount"`
+	Rollover     float64 `json:"rollover"` // Carried from earlier periods this year.
+	Budget       float64 `json:"budget"`   // Amount plus rollover.
+	Actual       float64 `json:"actual"`
+	Variance     float64 `json:"variance"` // Positive is good: under a spending budget or over an income target.
+	PercentUsed  float64 `json:"percent_used"`
+}
+
+// BudgetVsActual struct
+type BudgetVsActual struct {
+	Date    string         `json:"date"`
+	Budgets []BudgetActual `json:"budgets"`
+}
+
+//
+// GetBudgetVsActual returns every budget in the account with what was spent
+// (or brought in) in the period that date falls in.
+//
+func GetBudgetVsActual(db models.Datastore, accountId uint, date time.Time) BudgetVsActual {
+	rt := BudgetVsActual{Date: date.Format("2006-01-02"), Budgets: []BudgetActual{}}
+
+	for _, row := range db.GetBudgetsByAccount(accountId) {
+		rt.Budgets = append(rt.Budgets, GetBudgetActual(db, row, date))
+	}
+
+	// Return happy.
+	return rt
+}
+
+//
+// GetBudgetActual returns where one budget stands for the period that date
+// falls in. With rollover on, what was left from each earlier period in the
+// same year is added to this period's budget (overspending takes away). For an
+// income target a shortfall is added and anything over the target comes off.
+//
+func GetBudgetActual(db models.Datastore, b models.Budget, date time.Time) BudgetActual {
+	start, end := b.PeriodBounds(date)
+
+	rt := BudgetActual{
+		BudgetId:     b.Id,
+		Name:         b.Name,
+		Type:         "expense",
+		CategoryId:   b.CategoryId,
+		CategoryName: b.Category.Name,
+		LabelId:      b.LabelId,
+		LabelName:    b.Label.Name,
+		Period:       b.Period,
+		Start:        start.Format("2006-01-02"),
+		End:          end.AddDate(0, 0, -1).Format("2006-01-02"),
+		Amount:       b.Amount,
+		Actual:       budgetSpent(db, b, start, end),
+	}
+
+	if b.IsIncome() {
+		rt.Type = "income"
+	}
+
+	// Carry over from the start of the year.
+	if b.Rollover {
+		for s := time.Date(start.Year(), 1, 1, 0, 0, 0, 0, time.UTC); s.Before(start); {
+			_, e := b.PeriodBounds(s)
+			rt.Rollover = cents(rt.Rollover + b.Amount - budgetSpent(db, b, s, e))
+			s = e
+		}
+	}
+
+	rt.Budget = cents(rt.Amount + rt.Rollover)
+	rt.Variance = cents(rt.Budget - rt.Actual)
+
+	if rt.Type == "income" {
+		rt.Variance = -rt.Variance
+	}
+
+	if rt.Budget > 0 {
+		rt.PercentUsed = cents(rt.Actual / rt.Budget * 100)
+	} else {
+		rt.PercentUsed = 100
+	}
+
+	// Return happy.
+	return rt
+}
+
+// ----------------- Private Helper Funcs -------------- //
+
+//
+// budgetSpent - What counts against a budget between start and end. For
+// expenses refunds come off what was spent. Income is what came in.
+// Label budgets only count expenses.
+//
+func budgetSpent(db models.Datastore, b models.Budget, start time.Time, end time.Time) float64 {
+	total := struct {
+		Amount float64
+	}{}
+
+	if b.CategoryId > 0 {
+		sql := "SELECT COALESCE(SUM(LedgerAmount), 0) AS amount FROM " + models.LedgerLinesTable + " "
+		sql = sql + "WHERE LedgerAccountId = ? AND LedgerCategoryId = ? AND LedgerDate >= ? AND LedgerDate < ?"
+		db.New().Raw(sql, b.AccountId, b.CategoryId, start.Format("2006-01-02"), end.Format("2006-01-02")).Scan(&total)
+	} else {
+		sql := "SELECT COALESCE(SUM(LedgerAmount), 0) AS amount FROM LabelsToLedger "
+		sql = sql + "JOIN Ledger ON LabelsToLedger.LabelsToLedgerLedgerId = Ledger.LedgerId "
+		sql = sql + "WHERE LedgerAccountId = ? AND LabelsToLedgerLabelId = ? AND LedgerDate >= ? AND LedgerDate < ? "
+		sql = sql + "AND LedgerAmount < 0 AND LedgerDeletedAt IS NULL AND LedgerTransferChartAccountId = 0"
+		db.New().Raw(sql, b.AccountId, b.LabelId, start.Format("2006-01-02"), end.Format("2006-01-02")).Scan(&total)
+	}
+
+	if b.IsIncome() {
+		return cents(total.Amount)
+	}
+
+	return cents(-total.Amount)
+}
+
+/* End File */
@@ -0,0 +1,112 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package reports
+
+import (
+	"testing"
+
+	"github.com/nbio/st"
+
+	"app.skyclerk.com/backend/library/helpers"
+	"app.skyclerk.com/backend/models"
+)
+
+//
+// TestGetBudgetVsActual01 - Category, label and income budgets with rollover.
+//
+func TestGetBudgetVsActual01(t *testing.T) {
+	// Start the db connection.
+	db, dbName, _ := models.NewTestDB("")
+	defer models.TestingTearDown(db, dbName)
+
+	seedBudgets(db)
+
+	r := GetBudgetVsActual(db, 33, helpers.ParseDateNoError("2024-03-15"))
+	st.Expect(t, r.Date, "2024-03-15")
+	st.Expect(t, len(r.Budgets), 3)
+
+	// Income target for the year.
+	st.Expect(t, r.Budgets[0], BudgetActual{BudgetId: 3, Name: "Sales Goal", Type: "income", CategoryId: 2, CategoryName: "Sales", Period: "year", Start: "2024-01-01", End: "2024-12-31", Amount: 1000, Budget: 1000, Actual: 900, Variance: -100, PercentUsed: 90})
+
+	// $60 left in January, $50 over in February, and a split line in March.
+	st.Expect(t, r.Budgets[1], BudgetActual{BudgetId: 1, Name: "Travel", Type: "expense", CategoryId: 1, CategoryName: "Travel", Period: "month", Start: "2024-03-01", End: "2024-03-31", Amount: 100, Rollover: 10, Budget: 110, Actual: 30, Variance: 80, PercentUsed: 27.27})
+
+	// Refunds with the label and deleted entries do not count.
+	st.Expect(t, r.Budgets[2], BudgetActual{BudgetId: 2, Name: "Trip", Type: "expense", LabelId: 1, LabelName: "Trip", Period: "quarter", Start: "2024-01-01", End: "2024-03-31", Amount: 250, Budget: 250, Actual: 260, Variance: -10, PercentUsed: 104})
+
+	// January has nothing to roll over.
+	r = GetBudgetVsActual(db, 33, helpers.ParseDateNoError("2024-01-31"))
+	st.Expect(t, r.Budgets[1].Rollover, 0.00)
+	st.Expect(t, r.Budgets[1].Actual, 40.00)
+	st.Expect(t, r.Budgets[1].PercentUsed, 40.00)
+
+	// A new quarter starts over.
+	r = GetBudgetVsActual(db, 33, helpers.ParseDateNoError("2024-04-01"))
+	st.Expect(t, r.Budgets[1].Rollover, 80.00)
+	st.Expect(t, r.Budgets[1].Budget, 180.00)
+	st.Expect(t, r.Budgets[2].Start, "2024-04-01")
+	st.Expect(t, r.Budgets[2].End, "2024-06-30")
+	st.Expect(t, r.Budgets[2].Actual, 0.00)
+
+	// Other accounts have their own budgets.
+	r = GetBudgetVsActual(db, 34, helpers.ParseDateNoError("2024-03-15"))
+	st.Expect(t, r.Budgets, []BudgetActual{})
+}
+
+// ----------------- Private Helper Funcs -------------- //
+
+//
+// seedBudgets - A few months of entries for account 33 and budgets to go with them.
+//
+func seedBudgets(db *models.DB) {
+	travel := models.Category{AccountId: 33, Name: "Travel", Type: "1"}
+	sales := models.Category{AccountId: 33, Name: "Sales", Type: "2"}
+	meals := models.Category{AccountId: 33, Name: "Meals", Type: "1"}
+	db.Save(&travel)
+	db.Save(&sales)
+	db.Save(&meals)
+
+	trip := []models.Label{{Name: "Trip"}}
+
+	entries := []models.Ledger{
+		{Date: helpers.ParseDateNoError("2024-01-10"), Amount: -40, Category: travel},
+		{Date: helpers.ParseDateNoError("2024-02-10"), Amount: -150, Category: travel, Labels: trip},
+		{Date: helpers.ParseDateNoError("2024-03-05"), Amount: -80, Splits: []models.LedgerSplit{
+			{Amount: -30, Category: travel},
+			{Amount: -50, Category: meals},
+		}, Labels: trip},
+		{Date: helpers.ParseDateNoError("2024-03-06"), Amount: -30, Category: meals, Labels: trip},
+		{Date: helpers.ParseDateNoError("2024-03-07"), Amount: 25, Category: sales, Labels: trip},
+		{Date: helpers.ParseDateNoError("2024-02-01"), Amount: 400, Category: sales},
+		{Date: helpers.ParseDateNoError("2024-03-01"), Amount: 475, Category: sales},
+		{Date: helpers.ParseDateNoError("2023-12-31"), Amount: -500, Category: travel, Labels: trip},
+	}
+
+	for _, row := range entries {
+		row.AccountId = 33
+		row.Contact = models.Contact{Name: "Acme"}
+		db.LedgerCreate(&row)
+	}
+
+	// In the trash
+	l := models.Ledger{AccountId: 33, Date: helpers.ParseDateNoError("2024-03-10"), Amount: -1000, Contact: models.Contact{Name: "Acme"}, Category: travel, Labels: trip}
+	db.LedgerCreate(&l)
+	db.New().Delete(&l)
+
+	budgets := []models.Budget{
+		{AccountId: 33, Name: "Travel", CategoryId: travel.Id, Amount: 100, Period: "month", Rollover: true},
+		{AccountId: 33, Name: "Trip", LabelId: 1, Amount: 250, Period: "quarter", Rollover: true},
+		{AccountId: 33, Name: "Sales Goal", CategoryId: sales.Id, Amount: 1000, Period: "year", Rollover: true},
+	}
+
+	for key := range budgets {
+		db.BudgetCreate(&budgets[key])
+	}
+}
+
+/* End File */
@@ -0,0 +1,114 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package reports
+
+import (
+	"time"
+
+	"app.skyclerk.com/backend/models"
+)
+
+// Journal lines on non-cash accounts from entries that moved cash.
+const cashFlowWhere = "AND chart_accounts.cash = 0 AND EXISTS (SELECT 1 FROM journal_lines AS cl JOIN chart_accounts AS ca ON ca.id = cl.chart_account_id WHERE cl.ledger_id = journal_lines.ledger_id AND ca.cash = 1) "
+
+// CashFlow struct - Statement of cash flows for a period next to the period
+// we compare it to.
+type CashFlow struct {
+	Start         string             `json:"start"`
+	End           string             `json:"end"`
+	CompareStart  string             `json:"compare_start"`
+	CompareEnd    string             `json:"compare_end"`
+	Operating     ComparativeSection `json:"operating"`
+	Investing     ComparativeSection `json:"investing"`
+	Financing     ComparativeSection `json:"financing"`
+	NetChange     ComparativeRow     `json:"net_change"`
+	BeginningCash ComparativeRow     `json:"beginning_cash"`
+	EndingCash    ComparativeRow     `json:"ending_cash"`
+}
+
+//
+// GetCashFlow returns the cash that came in and went out of the cash accounts
+// between start and end. Each line is the account on the other side of the
+// cash: income and expense are operating, other assets are investing, and
+// liabilities and equity are financing. Moves between cash accounts are left out.
+//
+func GetCashFlow(db models.Datastore, accountId uint, start time.Time, end time.Time, compareStart time.Time, compareEnd time.Time) CashFlow {
+	// Struct we return
+	rt := CashFlow{
+		Start:         start.Format("2006-01-02"),
+		End:           end.Format("2006-01-02"),
+		CompareStart:  compareStart.Format("2006-01-02"),
+		CompareEnd:    compareEnd.Format("2006-01-02"),
+		Operating:     ComparativeSection{Accounts: []ComparativeRow{}},
+		Investing:     ComparativeSection{Accounts: []ComparativeRow{}},
+		Financing:     ComparativeSection{Accounts: []ComparativeRow{}},
+		NetChange:     ComparativeRow{Name: "Net Change in Cash"},
+		BeginningCash: ComparativeRow{Name: "Cash at Beginning of Period"},
+		EndingCash:    ComparativeRow{Name: "Cash at End of Period"},
+	}
+
+	now := getAccountBalances(db, accountId, start, end, cashFlowWhere)
+	then := getAccountBalances(db, accountId, compareStart, compareEnd, cashFlowWhere)
+
+	// A credit on the other side is cash in.
+	for _, row := range mergeBalances(now, then) {
+		switch row.Type {
+		case "income", "expense":
+			rt.Operating.add(row.negate())
+
+		case "asset":
+			rt.Investing.add(row.negate())
+
+		default:
+			rt.Financing.add(row.negate())
+		}
+	}
+
+	rt.NetChange.Amount = cents(rt.Operating.Total + rt.Investing.Total + rt.Financing.Total)
+	rt.NetChange.Compare = cents(rt.Operating.Compare + rt.Investing.Compare + rt.Financing.Compare)
+
+	rt.BeginningCash.Amount = cashBalance(db, accountId, start.AddDate(0, 0, -1))
+	rt.BeginningCash.Compare = cashBalance(db, accountId, compareStart.AddDate(0, 0, -1))
+
+	rt.EndingCash.Amount = cashBalance(db, accountId, end)
+	rt.EndingCash.Compare = cashBalance(db, accountId, compareEnd)
+
+	// Return happy.
+	return rt
+}
+
+//
+// PriorPeriod returns the period just before start and end. Whole months go
+// back by the same number of months, anything else by the same number of days.
+//
+func PriorPeriod(start time.Time, end time.Time) (time.Time, time.Time) {
+	if (start.Day() == 1) && (end.AddDate(0, 0, 1).Day() == 1) {
+		months := ((end.Year() - start.Year()) * 12) + int(end.Month()) - int(start.Month()) + 1
+		return start.AddDate(0, -months, 0), start.AddDate(0, 0, -1)
+	}
+
+	days := int(end.Sub(start).Hours()/24) + 1
+	return start.AddDate(0, 0, -days), start.AddDate(0, 0, -1)
+}
+
+// ----------------- Private Helper Funcs -------------- //
+
+//
+// cashBalance - What is in the cash accounts at the end of date.
+//
+func cashBalance(db models.Datastore, accountId uint, date time.Time) float64 {
+	total := 0.0
+
+	for _, row := range getAccountBalances(db, accountId, time.Time{}, date, "AND chart_accounts.cash = 1 ") {
+		total = total + row.Debit - row.Credit
+	}
+
+	return cents(total)
+}
+
+/* End File */
@@ -0,0 +1,236 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package reports
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"app.skyclerk.com/backend/models"
+)
+
+// Contractor1099 struct - A 1099 contact and what we paid them in the year.
+type Contractor1099 struct {
+	ContactId     uint     `json:"contact_id"`
+	Name          string   `json:"name"`
+	FirstName     string   `json:"first_name"`
+	LastName      string   `json:"last_name"`
+	Address       string   `json:"address"`
+	City          string   `json:"city"`
+	State         string   `json:"state"`
+	Zip           string   `json:"zip"`
+	Country       string   `json:"country"`
+	Email         string   `json:"email"`
+	AccountNumber string   `json:"account_number"`
+	TaxIdType     string   `json:"tax_id_type"`
+	TaxIdLast4    string   `json:"tax_id_last4"`
+	TaxId         string   `json:"-"` // Decrypted for the e-file csv, never sent as json.
+	Payments      int      `json:"payments"`
+	Amount        float64  `json:"amount"`
+	Warnings      []string `json:"warnings"` // What is missing before we can file.
+}
+
+// Contractors1099 struct
+type Contractors1099 struct {
+	Year           int              `json:"year"`
+	Threshold      float64          `json:"threshold"`
+	Contractors    []Contractor1099 `json:"contractors"`
+	Total          float64          `json:"total"`
+	BelowThreshold int              `json:"below_threshold"` // 1099 contacts we paid but not enough to file.
+	Incomplete     int              `json:"incomplete"`      // Contractors with warnings.
+}
+
+//
+// Default1099Threshold returns how much a contractor has to be paid in a year
+// before we file a 1099-NEC. It went from $600 to $2,000 for 2026.
+//
+func Default1099Threshold(year int) float64 {
+	if year >= 2026 {
+		return 2000
+	}
+
+	return 600
+}
+
+//
+// GetContractors1099 returns every contact flagged for a 1099 that we paid at
+// least threshold in the year, with what is missing to file for them.
+//
+func GetContractors1099(db models.Datastore, accountId uint, year int, threshold float64) Contractors1099 {
+	// Struct we return
+	rt := Contractors1099{Year: year, Threshold: threshold, Contractors: []Contractor1099{}}
+
+	// Dates are stored with a time so we go up to the start of next year.
+	start := time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC).Format("2006-01-02")
+	end := time.Date(year+1, 1, 1, 0, 0, 0, 0, time.UTC).Format("2006-01-02")
+
+	// Payments are expenses. Transfers between accounts are not payments.
+	sql := "SELECT LedgerContactId AS contact_id, COUNT(LedgerId) AS payments, SUM(-LedgerAmount) AS amount FROM Ledger "
+	sql = sql + "JOIN Contacts ON Contacts.ContactsId = Ledger.LedgerContactId "
+	sql = sql + "WHERE LedgerAccountId = ? AND LedgerDate >= ? AND LedgerDate < ? AND LedgerAmount < 0 AND LedgerDeletedAt IS NULL AND LedgerTransferChartAccountId = 0 "
+	sql = sql + "AND ContactsIs1099 = ? AND ContactsDeletedAt IS NULL GROUP BY LedgerContactId"
+
+	paid := []struct {
+		ContactId uint
+		Payments  int
+		Amount    float64
+	}{}
+
+	db.New().Raw(sql, accountId, start, end, true).Scan(&paid)
+
+	// Load the contacts we paid enough to file for.
+	ids := []uint{}
+	over := map[uint]int{}
+
+	for key, p := range paid {
+		if cents(p.Amount) < threshold {
+			rt.BelowThreshold++
+			continue
+		}
+
+		ids = append(ids, p.ContactId)
+		over[p.ContactId] = key
+	}
+
+	contacts := []models.Contact{}
+
+	if len(ids) > 0 {
+		db.New().Where("ContactsAccountId = ? AND ContactsId IN (?)", accountId, ids).Find(&contacts)
+	}
+
+	for _, c := range contacts {
+		p := paid[over[c.Id]]
+
+		row := Contractor1099{
+			ContactId:     c.Id,
+			Name:          c.Name,
+			FirstName:     c.FirstName,
+			LastName:      c.LastName,
+			Address:       c.Address,
+			City:          c.City,
+			State:         c.State,
+			Zip:           c.Zip,
+			Country:       c.Country,
+			Email:         c.Email,
+			AccountNumber: c.AccountNumber,
+			TaxIdType:     c.TaxIdType,
+			TaxIdLast4:    c.TaxIdLast4,
+			Payments:      p.Payments,
+			Amount:        cents(p.Amount),
+			Warnings:      contractor1099Warnings(c),
+		}
+
+		if taxId, err := c.GetTaxId(); err != nil {
+			row.Warnings = append(row.Warnings, "The tax id on file could not be read. Please enter it again.")
+		} else {
+			row.TaxId = taxId
+		}
+
+		if len(row.Warnings) > 0 {
+			rt.Incomplete++
+		}
+
+		rt.Total = cents(rt.Total + row.Amount)
+		rt.Contractors = append(rt.Contractors, row)
+	}
+
+	// Sorted by name like the contacts list.
+	sort.Slice(rt.Contractors, func(i, j int) bool {
+		return strings.ToLower(contractor1099Name(rt.Contractors[i])) < strings.ToLower(contractor1099Name(rt.Contractors[j]))
+	})
+
+	// Return happy.
+	return rt
+}
+
+// ----------------- Private Helper Funcs -------------- //
+
+//
+// contractor1099Name - The company name or first and last.
+//
+func contractor1099Name(c Contractor1099) string {
+	if len(c.Name) > 0 {
+		return c.Name
+	}
+
+	return strings.TrimSpace(c.FirstName + " " + c.LastName)
+}
+
+//
+// contractor1099Warnings - What is missing from a contact to file a 1099 for
+// them. State and zip are only checked for US addresses.
+//
+func contractor1099Warnings(c models.Contact) []string {
+	warnings := []string{}
+
+	if c.TaxIdLast4 == "" {
+		warnings = append(warnings, "Missing tax id.")
+	} else if c.TaxIdType == "" {
+		warnings = append(warnings, "Missing tax id type (ein or ssn).")
+	}
+
+	if strings.TrimSpace(c.Address) == "" {
+		warnings = append(warnings, "Missing street address.")
+	}
+
+	if strings.TrimSpace(c.City) == "" {
+		warnings = append(warnings, "Missing city.")
+	}
+
+	switch strings.ToUpper(strings.TrimSpace(c.Country)) {
+	case "", "US", "USA", "UNITED STATES", "UNITED STATES OF AMERICA":
+		state := strings.TrimSpace(c.State)
+
+		if state == "" {
+			warnings = append(warnings, "Missing state.")
+		} else if (len(state) != 2) || !isLetters(state) {
+			warnings = append(warnings, fmt.Sprintf("The state %q should be a two letter code.", state))
+		}
+
+		zip := strings.Replace(strings.TrimSpace(c.Zip), "-", "", 1)
+
+		if zip == "" {
+			warnings = append(warnings, "Missing zip code.")
+		} else if ((len(zip) != 5) && (len(zip) != 9)) || !isDigits(zip) {
+			warnings = append(warnings, fmt.Sprintf("The zip code %q should be 5 or 9 digits.", c.Zip))
+		}
+	}
+
+	return warnings
+}
+
+//
+// isLetters - Is s all letters.
+//
+func isLetters(s string) bool {
+	for _, r := range s {
+		if !unicode.IsLetter(r) {
+			return false
+		}
+	}
+
+	return true
+}
+
+//
+// isDigits - Is s all digits.
+//
+func isDigits(s string) bool {
+	for _, r := range s {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+
+	return true
+}
+
+/* End File */
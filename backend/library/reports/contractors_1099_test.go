@@ -0,0 +1,117 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package reports
+
+import (
+	"testing"
+
+	"github.com/nbio/st"
+
+	"app.skyclerk.com/backend/library/helpers"
+	"app.skyclerk.com/backend/models"
+)
+
+//
+// TestGetContractors1099 - Flagged contacts over the threshold with what they are missing.
+//
+func TestGetContractors1099(t *testing.T) {
+	// Start the db connection.
+	db, dbName, _ := models.NewTestDB("")
+	defer models.TestingTearDown(db, dbName)
+
+	seedContractors(db)
+
+	r := GetContractors1099(db, 33, 2024, Default1099Threshold(2024))
+	st.Expect(t, r.Year, 2024)
+	st.Expect(t, r.Threshold, 600.00)
+	st.Expect(t, r.Total, 1700.00)
+	st.Expect(t, r.BelowThreshold, 1)
+	st.Expect(t, r.Incomplete, 1)
+	st.Expect(t, len(r.Contractors), 2)
+
+	// Refunds, deleted entries and other years do not count.
+	st.Expect(t, r.Contractors[0].Name, "Acme Design")
+	st.Expect(t, r.Contractors[0].Payments, 2)
+	st.Expect(t, r.Contractors[0].Amount, 700.00)
+	st.Expect(t, r.Contractors[0].TaxIdLast4, "6789")
+	st.Expect(t, r.Contractors[0].TaxId, "12-3456789")
+	st.Expect(t, r.Contractors[0].Warnings, []string{})
+
+	st.Expect(t, r.Contractors[1].FirstName, "Jane")
+	st.Expect(t, r.Contractors[1].Amount, 1000.00)
+	st.Expect(t, r.Contractors[1].TaxId, "")
+	st.Expect(t, r.Contractors[1].Warnings, []string{
+		"Missing tax id.",
+		"Missing street address.",
+		"The state \"Ohio\" should be a two letter code.",
+		"The zip code \"4321\" should be 5 or 9 digits.",
+	})
+
+	// A higher threshold drops Acme.
+	r = GetContractors1099(db, 33, 2024, 800)
+	st.Expect(t, len(r.Contractors), 1)
+	st.Expect(t, r.BelowThreshold, 2)
+
+	// Other accounts have their own contractors.
+	r = GetContractors1099(db, 34, 2024, 600)
+	st.Expect(t, len(r.Contractors), 1)
+	st.Expect(t, r.Total, 5000.00)
+}
+
+//
+// TestDefault1099Threshold - The threshold went up for 2026.
+//
+func TestDefault1099Threshold(t *testing.T) {
+	st.Expect(t, Default1099Threshold(2025), 600.00)
+	st.Expect(t, Default1099Threshold(2026), 2000.00)
+}
+
+// ----------------- Private Helper Funcs -------------- //
+
+//
+// seedContractors - Contacts for account 33, some flagged for a 1099.
+//
+func seedContractors(db *models.DB) {
+	acme := models.Contact{AccountId: 33, Name: "Acme Design", Address: "1 Main St", City: "Portland", State: "OR", Zip: "97201-1234", Is1099: true, TaxIdType: "ein"}
+	acme.SetTaxId("12-345 6789")
+
+	jane := models.Contact{AccountId: 33, FirstName: "Jane", LastName: "Smith", City: "Columbus", State: "Ohio", Zip: "4321", Is1099: true, TaxIdType: "ssn"}
+	small := models.Contact{AccountId: 33, Name: "Small Jobs LLC", Is1099: true}
+	vendor := models.Contact{AccountId: 33, Name: "Office Store"}
+	other := models.Contact{AccountId: 34, Name: "Not Ours", Is1099: true}
+
+	for _, row := range []*models.Contact{&acme, &jane, &small, &vendor, &other} {
+		db.Save(row)
+	}
+
+	entries := []models.Ledger{
+		{Date: helpers.ParseDateNoError("2024-01-15"), Amount: -400, Contact: acme},
+		{Date: helpers.ParseDateNoError("2024-12-31"), Amount: -300, Contact: acme},
+		{Date: helpers.ParseDateNoError("2024-06-01"), Amount: 50, Contact: acme},
+		{Date: helpers.ParseDateNoError("2023-12-31"), Amount: -900, Contact: acme},
+		{Date: helpers.ParseDateNoError("2024-03-01"), Amount: -1000, Contact: jane},
+		{Date: helpers.ParseDateNoError("2024-03-01"), Amount: -300, Contact: small},
+		{Date: helpers.ParseDateNoError("2024-03-01"), Amount: -5000, Contact: vendor},
+	}
+
+	for _, row := range entries {
+		row.AccountId = 33
+		row.Category = models.Category{Name: "Contract Labor", Type: "1"}
+		db.LedgerCreate(&row)
+	}
+
+	// In the trash
+	l := models.Ledger{AccountId: 33, Date: helpers.ParseDateNoError("2024-05-01"), Amount: -2000, Contact: acme, Category: models.Category{Name: "Contract Labor", Type: "1"}}
+	db.LedgerCreate(&l)
+	db.New().Delete(&l)
+
+	// Someone else
+	db.LedgerCreate(&models.Ledger{AccountId: 34, Date: helpers.ParseDateNoError("2024-01-10"), Amount: -5000, Contact: other, Category: models.Category{Name: "Contract Labor", Type: "1"}})
+}
+
+/* End File */
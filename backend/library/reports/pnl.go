@@ -41,7 +41,7 @@ func GetLabelsPnL(db models.Datastore, accountId uint, start time.Time, end time
 	sql := "SELECT LabelsName as name, SUM(LedgerAmount) as amount FROM LabelsToLedger "
 	sql = sql + "JOIN Ledger ON LabelsToLedger.LabelsToLedgerLedgerId = Ledger.LedgerId "
 	sql = sql + "JOIN Labels ON Labels.LabelsId = LabelsToLedger.LabelsToLedgerLabelId "
-	sql = sql + "WHERE LedgerAccountId = ? AND LedgerDate >= ? AND LedgerDate <= ? "
+	sql = sql + "WHERE LedgerAccountId = ? AND LedgerDate >= ? AND LedgerDate <= ? AND LedgerDeletedAt IS NULL AND LedgerTransferChartAccountId = 0 "
 	sql = sql + "GROUP BY LabelsName ORDER BY name "
 
 	// Struct we return
@@ -68,7 +68,7 @@ func GetLabelsPnL(db models.Datastore, accountId uint, start time.Time, end time
 func GetCategoriesPnL(db models.Datastore, accountId uint, start time.Time, end time.Time, sort string) []NameValue {
 	// SQL String
 	sql := "SELECT CategoriesName as name, SUM(LedgerAmount) as amount "
-	sql = sql + "FROM Ledger JOIN Categories ON Categories.CategoriesId = Ledger.LedgerCategoryId "
+	sql = sql + "FROM " + models.LedgerLinesTable + " JOIN Categories ON Categories.CategoriesId = Ledger.LedgerCategoryId "
 	sql = sql + "WHERE LedgerAccountId = ? AND LedgerDate >= ? AND LedgerDate <= ? "
 	sql = sql + "GROUP BY CategoriesName ORDER BY name "
 
@@ -100,7 +100,7 @@ func GetIncomeByContact(db models.Datastore, accountId uint, start time.Time, en
 	sql = sql + "sum(LedgerAmount) AS amount "
 	sql = sql + "FROM Ledger "
 	sql = sql + "JOIN Contacts ON Contacts.ContactsId = Ledger.LedgerContactId "
-	sql = sql + "WHERE LedgerAccountId = ? AND LedgerDate >= ? AND LedgerDate <= ? "
+	sql = sql + "WHERE LedgerAccountId = ? AND LedgerDate >= ? AND LedgerDate <= ? AND LedgerDeletedAt IS NULL AND LedgerTransferChartAccountId = 0 "
 	sql = sql + "AND LedgerAmount > 0 GROUP BY name ORDER BY name "
 
 	// Struct we return
@@ -131,7 +131,7 @@ func GetExpenseByContact(db models.Datastore, accountId uint, start time.Time, e
 	sql = sql + "sum(LedgerAmount) AS amount "
 	sql = sql + "FROM Ledger "
 	sql = sql + "JOIN Contacts ON Contacts.ContactsId = Ledger.LedgerContactId "
-	sql = sql + "WHERE LedgerAccountId = ? AND LedgerDate >= ? AND LedgerDate <= ? "
+	sql = sql + "WHERE LedgerAccountId = ? AND LedgerDate >= ? AND LedgerDate <= ? AND LedgerDeletedAt IS NULL AND LedgerTransferChartAccountId = 0 "
 	sql = sql + "AND LedgerAmount < 0 GROUP BY name ORDER BY name "
 
 	// Struct we return
@@ -170,14 +170,14 @@ func GetPnL(db models.Datastore, accountId uint, start time.Time, end time.Time,
 	// Build sql based on group type (SQLite syntax)
 	switch group {
 	case "month":
-		sql = "SELECT strftime('%Y-%m', LedgerDate) AS date, SUM(LedgerAmount) AS profit, SUM(CASE WHEN LedgerAmount>0 THEN LedgerAmount ELSE 0 END) AS income, SUM(CASE WHEN LedgerAmount<0 THEN LedgerAmount ELSE 0 END) AS expense FROM Ledger WHERE LedgerAccountId = ? AND LedgerDate >= ? AND LedgerDate <= ? GROUP BY strftime('%Y-%m', LedgerDate) ORDER BY date " + sort
+		sql = "SELECT strftime('%Y-%m', LedgerDate) AS date, SUM(LedgerAmount) AS profit, SUM(CASE WHEN LedgerAmount>0 THEN LedgerAmount ELSE 0 END) AS income, SUM(CASE WHEN LedgerAmount<0 THEN LedgerAmount ELSE 0 END) AS expense FROM " + models.LedgerLinesTable + " WHERE LedgerAccountId = ? AND LedgerDate >= ? AND LedgerDate <= ? GROUP BY strftime('%Y-%m', LedgerDate) ORDER BY date " + sort
 
 	case "quarter":
 		sql = `SELECT strftime('%Y', LedgerDate) || '-Q' || CAST((CAST(strftime('%m', LedgerDate) AS INTEGER) + 2) / 3 AS TEXT) AS date,
 		SUM(LedgerAmount) AS profit,
 		SUM(CASE WHEN LedgerAmount>0 THEN LedgerAmount ELSE 0 END) AS income,
 		SUM(CASE WHEN LedgerAmount<0 THEN LedgerAmount ELSE 0 END) AS expense
-		FROM Ledger
+		FROM ` + models.LedgerLinesTable + `
 		WHERE LedgerAccountId = ? AND LedgerDate >= ? AND LedgerDate <= ?
 		GROUP BY date ORDER BY date ` + sort
 
@@ -186,7 +186,7 @@ func GetPnL(db models.Datastore, accountId uint, start time.Time, end time.Time,
 		SUM(LedgerAmount) AS profit,
 		SUM(CASE WHEN LedgerAmount>0 THEN LedgerAmount ELSE 0 END) AS income,
 		SUM(CASE WHEN LedgerAmount<0 THEN LedgerAmount ELSE 0 END) AS expense
-		FROM Ledger
+		FROM ` + models.LedgerLinesTable + `
 		WHERE LedgerAccountId = ? AND LedgerDate >= ? AND LedgerDate <= ?
 		GROUP BY date ORDER BY date ` + sort
 
@@ -209,7 +209,7 @@ func GetCurrentYearPnL(db models.Datastore, accountId uint, year int) YearPnL {
 	rt := YearPnL{}
 
 	// SQLite SQL
-	sql := "SELECT SUM(LedgerAmount) AS value, CAST(strftime('%Y', LedgerDate) AS INTEGER) AS year FROM Ledger WHERE LedgerAccountId = ? AND strftime('%Y', LedgerDate) = CAST(? AS TEXT)"
+	sql := "SELECT SUM(LedgerAmount) AS value, CAST(strftime('%Y', LedgerDate) AS INTEGER) AS year FROM Ledger WHERE LedgerAccountId = ? AND LedgerDeletedAt IS NULL AND LedgerTransferChartAccountId = 0 AND strftime('%Y', LedgerDate) = CAST(? AS TEXT)"
 
 	// Run query
 	db.New().Raw(sql, accountId, year).Scan(&rt)
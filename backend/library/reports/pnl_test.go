@@ -200,6 +200,54 @@ func TestGetCategoriesPnL01(t *testing.T) {
 	st.Expect(t, result[4].Amount, 500.00)
 }
 
+//
+// TestGetCategoriesPnL02 - Split entries count toward each line's category.
+//
+func TestGetCategoriesPnL02(t *testing.T) {
+	// Start the db connection.
+	db, dbName, _ := models.NewTestDB("")
+	defer models.TestingTearDown(db, dbName)
+
+	// A plain entry
+	l := test.GetRandomLedger(33)
+	l.Amount = -100
+	l.Category.Name = "Category #1"
+	l.Category.Type = "1"
+	l.Date = helpers.ParseDateNoError("2019-03-01")
+	db.LedgerCreate(&l)
+
+	// A split entry
+	l2 := test.GetRandomLedger(33)
+	l2.Amount = -300
+	l2.Category = models.Category{}
+	l2.Date = helpers.ParseDateNoError("2019-03-05")
+	l2.Splits = []models.LedgerSplit{
+		{Amount: -200, Category: models.Category{Name: "Category #2", Type: "1"}},
+		{Amount: -100, Category: models.Category{Name: "Category #1", Type: "1"}},
+	}
+	db.LedgerCreate(&l2)
+
+	// Set start / end
+	start := helpers.ParseDateNoError("2019-03-01")
+	end := helpers.ParseDateNoError("2019-06-30")
+
+	// Run test function
+	result := GetCategoriesPnL(db, 33, start, end, "ASC")
+
+	// Test results
+	st.Expect(t, len(result), 2)
+	st.Expect(t, result[0].Name, "Category #1")
+	st.Expect(t, result[0].Amount, -200.00)
+	st.Expect(t, result[1].Name, "Category #2")
+	st.Expect(t, result[1].Amount, -200.00)
+
+	// Totals do not double count.
+	pnl := GetPnL(db, 33, start, end, "month", "ASC")
+	st.Expect(t, len(pnl), 1)
+	st.Expect(t, pnl[0].Profit, -400.00)
+	st.Expect(t, pnl[0].Expense, -400.00)
+}
+
 //
 // TestGetIncomeByContact01 - Get income by contact
 //
@@ -0,0 +1,162 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package reports
+
+import (
+	"time"
+
+	"app.skyclerk.com/backend/models"
+)
+
+// How many activity messages we put in a digest.
+const digestActivityLimit = 25
+
+// ActivityDigest struct - What happened in an account over a week.
+type ActivityDigest struct {
+	Start      string   `json:"start"`
+	End        string   `json:"end"` // Last day of the period.
+	Income     float64  `json:"income"`
+	Expense    float64  `json:"expense"` // Shown as a positive amount.
+	Profit     float64  `json:"profit"`
+	Activities []string `json:"activities"` // Newest first.
+	More       int      `json:"more"`       // Activity we left out of the list.
+}
+
+// PnLComparison struct - Income, expenses and profit for a period next to the
+// period before it. Expenses are positive amounts.
+type PnLComparison struct {
+	Start        string         `json:"start"`
+	End          string         `json:"end"`
+	CompareStart string         `json:"compare_start"`
+	CompareEnd   string         `json:"compare_end"`
+	Income       ComparativeRow `json:"income"`
+	Expense      ComparativeRow `json:"expense"`
+	Profit       ComparativeRow `json:"profit"`
+}
+
+// CategoryTotals struct - Income and expense categories for a period.
+// Expenses are positive amounts.
+type CategoryTotals struct {
+	Start        string      `json:"start"`
+	End          string      `json:"end"`
+	Income       []NameValue `json:"income"`
+	Expenses     []NameValue `json:"expenses"`
+	TotalIncome  float64     `json:"total_income"`
+	TotalExpense float64     `json:"total_expense"`
+	Profit       float64     `json:"profit"`
+}
+
+//
+// GetActivityDigest returns the totals for entries dated from start up to
+// (not including) next and what people did in the account in that time.
+//
+func GetActivityDigest(db models.Datastore, accountId uint, start time.Time, next time.Time) ActivityDigest {
+	totals := pnlTotals(db, accountId, start, next)
+
+	rt := ActivityDigest{
+		Start:      start.Format("2006-01-02"),
+		End:        next.AddDate(0, 0, -1).Format("2006-01-02"),
+		Income:     totals.Income,
+		Expense:    cents(-totals.Expense),
+		Profit:     totals.Profit,
+		Activities: []string{},
+	}
+
+	list := []models.Activity{}
+	db.New().Preload("User").Preload("Ledger").Where("account_id = ? AND created_at >= ? AND created_at < ?", accountId, start, next).Order("id DESC").Find(&list)
+
+	for key, row := range list {
+		if key >= digestActivityLimit {
+			rt.More = len(list) - digestActivityLimit
+			break
+		}
+
+		// Add in ledger contact, even if it is in the trash.
+		if row.Ledger.ContactId > 0 {
+			db.New().Unscoped().Where("ContactsAccountId = ? AND ContactsId = ?", accountId, row.Ledger.ContactId).First(&row.Ledger.Contact)
+		}
+
+		row.SetMessage()
+
+		if len(row.Message) > 0 {
+			rt.Activities = append(rt.Activities, row.Message)
+		}
+	}
+
+	// Return happy.
+	return rt
+}
+
+//
+// GetPnLComparison returns the P&L from start up to (not including) next
+// next to the same from compareStart up to compareNext.
+//
+func GetPnLComparison(db models.Datastore, accountId uint, start time.Time, next time.Time, compareStart time.Time, compareNext time.Time) PnLComparison {
+	now := pnlTotals(db, accountId, start, next)
+	then := pnlTotals(db, accountId, compareStart, compareNext)
+
+	// Return happy.
+	return PnLComparison{
+		Start:        start.Format("2006-01-02"),
+		End:          next.AddDate(0, 0, -1).Format("2006-01-02"),
+		CompareStart: compareStart.Format("2006-01-02"),
+		CompareEnd:   compareNext.AddDate(0, 0, -1).Format("2006-01-02"),
+		Income:       ComparativeRow{Name: "Income", Amount: now.Income, Compare: then.Income},
+		Expense:      ComparativeRow{Name: "Expenses", Amount: cents(-now.Expense), Compare: cents(-then.Expense)},
+		Profit:       ComparativeRow{Name: "Profit", Amount: now.Profit, Compare: then.Profit},
+	}
+}
+
+//
+// GetCategoryTotals returns the category totals from start up to (not
+// including) next split into income and expenses.
+//
+func GetCategoryTotals(db models.Datastore, accountId uint, start time.Time, next time.Time) CategoryTotals {
+	rt := CategoryTotals{
+		Start:    start.Format("2006-01-02"),
+		End:      next.AddDate(0, 0, -1).Format("2006-01-02"),
+		Income:   []NameValue{},
+		Expenses: []NameValue{},
+	}
+
+	// Dates are stored with a time so the start of next is left out.
+	for _, row := range GetCategoriesPnL(db, accountId, start, next, "ASC") {
+		if row.Amount < 0 {
+			rt.Expenses = append(rt.Expenses, NameValue{Name: row.Name, Amount: cents(-row.Amount)})
+			rt.TotalExpense = cents(rt.TotalExpense - row.Amount)
+		} else {
+			rt.Income = append(rt.Income, NameValue{Name: row.Name, Amount: cents(row.Amount)})
+			rt.TotalIncome = cents(rt.TotalIncome + row.Amount)
+		}
+	}
+
+	rt.Profit = cents(rt.TotalIncome - rt.TotalExpense)
+
+	// Return happy.
+	return rt
+}
+
+// ----------------- Private Helper Funcs -------------- //
+
+//
+// pnlTotals - Income, expenses and profit from start up to (not including)
+// next. Dates are stored with a time so the start of next is left out.
+//
+func pnlTotals(db models.Datastore, accountId uint, start time.Time, next time.Time) PnL {
+	rt := PnL{}
+
+	for _, row := range GetPnL(db, accountId, start, next, "year", "ASC") {
+		rt.Income = cents(rt.Income + row.Income)
+		rt.Expense = cents(rt.Expense + row.Expense)
+		rt.Profit = cents(rt.Profit + row.Profit)
+	}
+
+	return rt
+}
+
+/* End File */
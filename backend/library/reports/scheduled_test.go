@@ -0,0 +1,112 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package reports
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nbio/st"
+
+	"app.skyclerk.com/backend/library/helpers"
+	"app.skyclerk.com/backend/models"
+)
+
+//
+// TestGetPnLComparison01 - March next to February.
+//
+func TestGetPnLComparison01(t *testing.T) {
+	// Start the db connection.
+	db, dbName, _ := models.NewTestDB("")
+	defer models.TestingTearDown(db, dbName)
+
+	seedBudgets(db)
+
+	r := GetPnLComparison(db, 33, helpers.ParseDateNoError("2024-03-01"), helpers.ParseDateNoError("2024-04-01"), helpers.ParseDateNoError("2024-02-01"), helpers.ParseDateNoError("2024-03-01"))
+	st.Expect(t, r.Start, "2024-03-01")
+	st.Expect(t, r.End, "2024-03-31")
+	st.Expect(t, r.CompareEnd, "2024-02-29")
+	st.Expect(t, r.Income, ComparativeRow{Name: "Income", Amount: 500, Compare: 400})
+	st.Expect(t, r.Expense, ComparativeRow{Name: "Expenses", Amount: 110, Compare: 150})
+	st.Expect(t, r.Profit, ComparativeRow{Name: "Profit", Amount: 390, Compare: 250})
+
+	// Across a year end.
+	r = GetPnLComparison(db, 33, helpers.ParseDateNoError("2023-12-01"), helpers.ParseDateNoError("2024-02-01"), helpers.ParseDateNoError("2023-10-01"), helpers.ParseDateNoError("2023-12-01"))
+	st.Expect(t, r.Expense.Amount, 540.00)
+	st.Expect(t, r.Expense.Compare, 0.00)
+}
+
+//
+// TestGetCategoryTotals01 - The first quarter by category.
+//
+func TestGetCategoryTotals01(t *testing.T) {
+	// Start the db connection.
+	db, dbName, _ := models.NewTestDB("")
+	defer models.TestingTearDown(db, dbName)
+
+	seedBudgets(db)
+
+	r := GetCategoryTotals(db, 33, helpers.ParseDateNoError("2024-01-01"), helpers.ParseDateNoError("2024-04-01"))
+	st.Expect(t, r.End, "2024-03-31")
+	st.Expect(t, r.Income, []NameValue{{Name: "Sales", Amount: 900}})
+	st.Expect(t, r.Expenses, []NameValue{{Name: "Meals", Amount: 80}, {Name: "Travel", Amount: 220}})
+	st.Expect(t, r.TotalIncome, 900.00)
+	st.Expect(t, r.TotalExpense, 300.00)
+	st.Expect(t, r.Profit, 600.00)
+
+	// Nothing in the quarter.
+	r = GetCategoryTotals(db, 33, helpers.ParseDateNoError("2024-04-01"), helpers.ParseDateNoError("2024-07-01"))
+	st.Expect(t, r.Income, []NameValue{})
+	st.Expect(t, r.Expenses, []NameValue{})
+}
+
+//
+// TestGetActivityDigest01 - Totals and activity for a week.
+//
+func TestGetActivityDigest01(t *testing.T) {
+	// Start the db connection.
+	db, dbName, _ := models.NewTestDB("")
+	defer models.TestingTearDown(db, dbName)
+
+	seedBudgets(db)
+
+	user := models.User{FirstName: "Jane", Email: "jane@example.com"}
+	db.Save(&user)
+
+	start := helpers.ParseDateNoError("2024-03-04")
+	next := start.AddDate(0, 0, 7)
+	contact := models.Contact{AccountId: 33, Name: "Airline"}
+	db.Save(&contact)
+	l := models.Ledger{AccountId: 33, ContactId: contact.Id}
+	db.Save(&l)
+
+	db.Save(&models.Activity{AccountId: 33, UserId: user.Id, Action: "expense", SubAction: "create", Name: "Airline", Amount: -80, LedgerId: l.Id, CreatedAt: start.Add(time.Hour)})
+	db.Save(&models.Activity{AccountId: 33, UserId: user.Id, Action: "period", SubAction: "close", Name: "February 2024", CreatedAt: start.Add(2 * time.Hour)})
+	db.Save(&models.Activity{AccountId: 33, UserId: user.Id, Action: "period", SubAction: "reopen", Name: "January 2024", CreatedAt: next.Add(time.Hour)})
+	db.Save(&models.Activity{AccountId: 34, UserId: user.Id, Action: "period", SubAction: "reopen", Name: "January 2024", CreatedAt: start.Add(time.Hour)})
+
+	r := GetActivityDigest(db, 33, start, next)
+	st.Expect(t, r.Start, "2024-03-04")
+	st.Expect(t, r.End, "2024-03-10")
+	st.Expect(t, r.Income, 25.00)
+	st.Expect(t, r.Expense, 110.00)
+	st.Expect(t, r.Profit, -85.00)
+	st.Expect(t, r.Activities, []string{"Jane closed the books for February 2024.", "Jane created an expense ledger entry of -80.00 from Airline."})
+	st.Expect(t, r.More, 0)
+
+	// We only list so many.
+	for i := 0; i < digestActivityLimit+2; i++ {
+		db.Save(&models.Activity{AccountId: 33, UserId: user.Id, Action: "period", SubAction: "close", Name: "March 2024", CreatedAt: start.Add(3 * time.Hour)})
+	}
+
+	r = GetActivityDigest(db, 33, start, next)
+	st.Expect(t, len(r.Activities), digestActivityLimit)
+	st.Expect(t, r.More, 4)
+}
+
+/* End File */
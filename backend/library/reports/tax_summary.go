@@ -0,0 +1,172 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package reports
+
+import (
+	"fmt"
+	"time"
+
+	"app.skyclerk.com/backend/library/helpers"
+	"app.skyclerk.com/backend/models"
+)
+
+// TaxSummaryLine struct - The total of one tax form line and the categories on it.
+type TaxSummaryLine struct {
+	Code       string      `json:"code"`
+	Line       string      `json:"line"`
+	Name       string      `json:"name"`
+	Type       string      `json:"type"`
+	Amount     float64     `json:"amount"` // Expenses are positive like they are on the form.
+	Categories []NameValue `json:"categories"`
+}
+
+// TaxSummaryForm struct - The lines of one form we have amounts for.
+type TaxSummaryForm struct {
+	Id      string           `json:"id"`
+	Name    string           `json:"name"`
+	Lines   []TaxSummaryLine `json:"lines"`
+	Income  float64          `json:"income"`
+	Expense float64          `json:"expense"`
+	Net     float64          `json:"net"`
+}
+
+// TaxSummaryCategory struct - A category with activity that is not on a form line.
+type TaxSummaryCategory struct {
+	CategoryId uint    `json:"category_id"`
+	Name       string  `json:"name"`
+	Type       string  `json:"type"` // income or expense
+	Irs        string  `json:"irs"`  // Set when it is mapped to a line we do not know.
+	Amount     float64 `json:"amount"`
+}
+
+// TaxSummaryUncategorized struct - Entries with no category at all.
+type TaxSummaryUncategorized struct {
+	Count   int     `json:"count"`
+	Income  float64 `json:"income"`
+	Expense float64 `json:"expense"`
+}
+
+// TaxSummary struct
+type TaxSummary struct {
+	Year          int                     `json:"year"`
+	Forms         []TaxSummaryForm        `json:"forms"`
+	Unmapped      []TaxSummaryCategory    `json:"unmapped"`
+	Uncategorized TaxSummaryUncategorized `json:"uncategorized"`
+	Warnings      []string                `json:"warnings"`
+}
+
+//
+// GetTaxSummary returns the year's income and expenses totaled by the tax form
+// line each category is mapped to (Categories.Irs). Spending we can not put on
+// a line, uncategorized or unmapped, is called out so it gets fixed before the
+// numbers go to a preparer.
+//
+func GetTaxSummary(db models.Datastore, accountId uint, year int) TaxSummary {
+	// Struct we return
+	rt := TaxSummary{Year: year, Forms: []TaxSummaryForm{}, Unmapped: []TaxSummaryCategory{}, Warnings: []string{}}
+
+	// Dates are stored with a time so we go up to the start of next year.
+	start := time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC).Format("2006-01-02")
+	end := time.Date(year+1, 1, 1, 0, 0, 0, 0, time.UTC).Format("2006-01-02")
+
+	// Totals by category.
+	sql := "SELECT CategoriesId AS category_id, CategoriesName AS name, CategoriesType AS type, CategoriesIrs AS irs, SUM(LedgerAmount) AS amount FROM " + models.LedgerLinesTable + " "
+	sql = sql + "JOIN Categories ON Categories.CategoriesId = Ledger.LedgerCategoryId "
+	sql = sql + "WHERE LedgerAccountId = ? AND LedgerDate >= ? AND LedgerDate < ? "
+	sql = sql + "GROUP BY CategoriesId, CategoriesName, CategoriesType, CategoriesIrs ORDER BY CategoriesName ASC"
+
+	cats := []TaxSummaryCategory{}
+	db.New().Raw(sql, accountId, start, end).Scan(&cats)
+
+	// Put each category on its line.
+	lines := map[string]*TaxSummaryLine{}
+
+	for _, row := range cats {
+		row.Amount = cents(row.Amount)
+
+		if row.Amount == 0 {
+			continue
+		}
+
+		// On the form expenses are positive.
+		if row.Type == "1" {
+			row.Type = "expense"
+			row.Amount = -row.Amount
+		} else {
+			row.Type = "income"
+		}
+
+		line, ok := models.GetTaxLine(row.Irs)
+
+		if !ok {
+			rt.Unmapped = append(rt.Unmapped, row)
+
+			if row.Irs == "" {
+				rt.Warnings = append(rt.Warnings, fmt.Sprintf("The %s category (%s) is not mapped to a tax form line.", row.Name, helpers.FormatMoney(row.Amount)))
+			} else {
+				rt.Warnings = append(rt.Warnings, fmt.Sprintf("The %s category (%s) is mapped to %s which is not a tax form line we know.", row.Name, helpers.FormatMoney(row.Amount), row.Irs))
+			}
+
+			continue
+		}
+
+		if _, ok := lines[line.Code]; !ok {
+			lines[line.Code] = &TaxSummaryLine{Code: line.Code, Line: line.Line, Name: line.Name, Type: line.Type, Categories: []NameValue{}}
+		}
+
+		lines[line.Code].Amount = cents(lines[line.Code].Amount + row.Amount)
+		lines[line.Code].Categories = append(lines[line.Code].Categories, NameValue{Name: row.Name, Amount: row.Amount})
+	}
+
+	// Lay the lines out in form order.
+	for _, form := range models.GetTaxForms() {
+		f := TaxSummaryForm{Id: form.Id, Name: form.Name, Lines: []TaxSummaryLine{}}
+
+		for _, row := range form.Lines {
+			line, ok := lines[row.Code]
+
+			if !ok {
+				continue
+			}
+
+			if line.Type == "income" {
+				f.Income = cents(f.Income + line.Amount)
+			} else {
+				f.Expense = cents(f.Expense + line.Amount)
+			}
+
+			f.Lines = append(f.Lines, *line)
+		}
+
+		if len(f.Lines) > 0 {
+			f.Net = cents(f.Income - f.Expense)
+			rt.Forms = append(rt.Forms, f)
+		}
+	}
+
+	// Entries without a category can not go anywhere.
+	sql = "SELECT COUNT(*) AS count, COALESCE(SUM(CASE WHEN LedgerAmount > 0 THEN LedgerAmount ELSE 0 END), 0) AS income, "
+	sql = sql + "COALESCE(SUM(CASE WHEN LedgerAmount < 0 THEN -LedgerAmount ELSE 0 END), 0) AS expense FROM " + models.LedgerLinesTable + " "
+	sql = sql + "LEFT JOIN Categories ON Categories.CategoriesId = Ledger.LedgerCategoryId "
+	sql = sql + "WHERE LedgerAccountId = ? AND LedgerDate >= ? AND LedgerDate < ? AND Categories.CategoriesId IS NULL"
+
+	db.New().Raw(sql, accountId, start, end).Scan(&rt.Uncategorized)
+	rt.Uncategorized.Income = cents(rt.Uncategorized.Income)
+	rt.Uncategorized.Expense = cents(rt.Uncategorized.Expense)
+
+	if rt.Uncategorized.Count == 1 {
+		rt.Warnings = append(rt.Warnings, fmt.Sprintf("1 uncategorized entry (%s in, %s out) needs a category.", helpers.FormatMoney(rt.Uncategorized.Income), helpers.FormatMoney(rt.Uncategorized.Expense)))
+	} else if rt.Uncategorized.Count > 1 {
+		rt.Warnings = append(rt.Warnings, fmt.Sprintf("%d uncategorized entries (%s in, %s out) need a category.", rt.Uncategorized.Count, helpers.FormatMoney(rt.Uncategorized.Income), helpers.FormatMoney(rt.Uncategorized.Expense)))
+	}
+
+	// Return happy.
+	return rt
+}
+
+/* End File */
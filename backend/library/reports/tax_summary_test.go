@@ -0,0 +1,172 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package reports
+
+import (
+	"testing"
+
+	"github.com/nbio/st"
+
+	"app.skyclerk.com/backend/library/helpers"
+	"app.skyclerk.com/backend/models"
+)
+
+//
+// TestGetTaxSummary01 - Totals by form line with unmapped and uncategorized spending called out.
+//
+func TestGetTaxSummary01(t *testing.T) {
+	// Start the db connection.
+	db, dbName, _ := models.NewTestDB("")
+	defer models.TestingTearDown(db, dbName)
+
+	seedTaxBooks(db)
+
+	ts := GetTaxSummary(db, 33, 2024)
+	st.Expect(t, ts.Year, 2024)
+	st.Expect(t, len(ts.Forms), 2)
+
+	// Lines come back in form order with expenses positive.
+	st.Expect(t, ts.Forms[0].Id, "schedule-c")
+	st.Expect(t, ts.Forms[0].Lines, []TaxSummaryLine{
+		{Code: "schedule-c:1", Line: "1", Name: "Gross receipts or sales", Type: "income", Amount: 5000, Categories: []NameValue{{Name: "Sales", Amount: 5000}}},
+		{Code: "schedule-c:8", Line: "8", Name: "Advertising", Type: "expense", Amount: 450, Categories: []NameValue{{Name: "Ads", Amount: 300}, {Name: "Marketing", Amount: 150}}},
+		{Code: "schedule-c:22", Line: "22", Name: "Supplies", Type: "expense", Amount: 100, Categories: []NameValue{{Name: "Supplies", Amount: 100}}},
+	})
+	st.Expect(t, ts.Forms[0].Income, 5000.00)
+	st.Expect(t, ts.Forms[0].Expense, 550.00)
+	st.Expect(t, ts.Forms[0].Net, 4450.00)
+
+	st.Expect(t, ts.Forms[1].Id, "schedule-e")
+	st.Expect(t, len(ts.Forms[1].Lines), 1)
+	st.Expect(t, ts.Forms[1].Net, 1200.00)
+
+	// Unmapped, and mapped to a line that does not exist.
+	st.Expect(t, ts.Unmapped, []TaxSummaryCategory{
+		{CategoryId: 5, Name: "Gifts", Type: "expense", Irs: "", Amount: 75},
+		{CategoryId: 6, Name: "Old Stuff", Type: "expense", Irs: "1040:99", Amount: 20},
+	})
+
+	st.Expect(t, ts.Uncategorized, TaxSummaryUncategorized{Count: 2, Income: 40, Expense: 60})
+
+	st.Expect(t, ts.Warnings, []string{
+		"The Gifts category ($75.00) is not mapped to a tax form line.",
+		"The Old Stuff category ($20.00) is mapped to 1040:99 which is not a tax form line we know.",
+		"2 uncategorized entries ($40.00 in, $60.00 out) need a category.",
+	})
+
+	// Nothing in 2023 but the one sale.
+	ts = GetTaxSummary(db, 33, 2023)
+	st.Expect(t, len(ts.Forms), 1)
+	st.Expect(t, ts.Forms[0].Income, 999.00)
+	st.Expect(t, ts.Unmapped, []TaxSummaryCategory{})
+	st.Expect(t, ts.Uncategorized.Count, 0)
+	st.Expect(t, ts.Warnings, []string{})
+
+	// Other accounts have their own books.
+	ts = GetTaxSummary(db, 34, 2024)
+	st.Expect(t, len(ts.Forms), 0)
+	st.Expect(t, len(ts.Unmapped), 1)
+}
+
+//
+// TestGetTaxSummary02 - Categories from before tax form lines.
+//
+func TestGetTaxSummary02(t *testing.T) {
+	// Start the db connection.
+	db, dbName, _ := models.NewTestDB("")
+	defer models.TestingTearDown(db, dbName)
+
+	// The old AirBnb import flagged its income category with a "1".
+	rent := models.Category{AccountId: 33, Name: "Rental Income", Type: "2", Irs: "1", Show: "1"}
+	fees := models.Category{AccountId: 33, Name: "Host Fees", Type: "1", Irs: "1"}
+	db.Save(&rent)
+	db.Save(&fees)
+
+	db.LedgerCreate(&models.Ledger{AccountId: 33, Date: helpers.ParseDateNoError("2024-05-01"), Amount: 800, Contact: models.Contact{Name: "AirBnb"}, Category: rent})
+	db.LedgerCreate(&models.Ledger{AccountId: 33, Date: helpers.ParseDateNoError("2024-05-01"), Amount: -24, Contact: models.Contact{Name: "AirBnb"}, Category: fees})
+
+	db.MigrateLegacyTaxLines()
+
+	cat := models.Category{}
+	db.First(&cat, rent.Id)
+	st.Expect(t, cat.Irs, "schedule-e:3")
+	st.Expect(t, models.ValidateCategoryTaxLine(cat), nil)
+
+	cat = models.Category{}
+	db.First(&cat, fees.Id)
+	st.Expect(t, cat.Irs, "")
+
+	ts := GetTaxSummary(db, 33, 2024)
+	st.Expect(t, len(ts.Forms), 1)
+	st.Expect(t, ts.Forms[0].Id, "schedule-e")
+	st.Expect(t, ts.Forms[0].Lines[0].Code, "schedule-e:3")
+	st.Expect(t, ts.Forms[0].Income, 800.00)
+	st.Expect(t, ts.Warnings, []string{"The Host Fees category ($24.00) is not mapped to a tax form line."})
+
+	// Running it again changes nothing.
+	db.MigrateLegacyTaxLines()
+	cat = models.Category{}
+	db.First(&cat, rent.Id)
+	st.Expect(t, cat.Irs, "schedule-e:3")
+}
+
+// ----------------- Private Helper Funcs -------------- //
+
+//
+// seedTaxBooks - A year of entries for account 33 on Schedule C and E.
+//
+func seedTaxBooks(db *models.DB) {
+	cats := []models.Category{
+		{AccountId: 33, Name: "Sales", Type: "2", Irs: "schedule-c:1"},
+		{AccountId: 33, Name: "Ads", Type: "1", Irs: "schedule-c:8"},
+		{AccountId: 33, Name: "Marketing", Type: "1", Irs: "schedule-c:8"},
+		{AccountId: 33, Name: "Supplies", Type: "1", Irs: "schedule-c:22"},
+		{AccountId: 33, Name: "Gifts", Type: "1"},
+		{AccountId: 33, Name: "Old Stuff", Type: "1", Irs: "1040:99"},
+		{AccountId: 33, Name: "Rent", Type: "2", Irs: "schedule-e:3"},
+		{AccountId: 34, Name: "Sales", Type: "2"},
+	}
+
+	for key := range cats {
+		db.Save(&cats[key])
+	}
+
+	entries := []models.Ledger{
+		{Date: helpers.ParseDateNoError("2023-12-31"), Amount: 999, Category: cats[0]},
+		{Date: helpers.ParseDateNoError("2024-01-01"), Amount: 2000, Category: cats[0]},
+		{Date: helpers.ParseDateNoError("2024-12-31"), Amount: 3000, Category: cats[0]},
+		{Date: helpers.ParseDateNoError("2024-02-01"), Amount: -300, Category: cats[1]},
+		{Date: helpers.ParseDateNoError("2024-03-01"), Amount: -175, Splits: []models.LedgerSplit{
+			{Amount: -150, Category: cats[2]},
+			{Amount: -25, Category: cats[3]},
+		}},
+		{Date: helpers.ParseDateNoError("2024-03-02"), Amount: -75, Category: cats[3]},
+		{Date: helpers.ParseDateNoError("2024-04-01"), Amount: -75, Category: cats[4]},
+		{Date: helpers.ParseDateNoError("2024-04-02"), Amount: -20, Category: cats[5]},
+		{Date: helpers.ParseDateNoError("2024-05-01"), Amount: 1200, Category: cats[6]},
+		{Date: helpers.ParseDateNoError("2025-01-01"), Amount: 5000, Category: cats[0]},
+	}
+
+	for _, row := range entries {
+		row.AccountId = 33
+		row.Contact = models.Contact{Name: "Acme"}
+		db.LedgerCreate(&row)
+	}
+
+	// Entries from before we made everything have a category.
+	for _, amount := range []float64{40, -60} {
+		l := models.Ledger{AccountId: 33, Date: helpers.ParseDateNoError("2024-06-01"), Amount: amount, Contact: models.Contact{Name: "Acme"}, Category: cats[0]}
+		db.LedgerCreate(&l)
+		db.New().Exec("UPDATE Ledger SET LedgerCategoryId = 0 WHERE LedgerId = ?", l.Id)
+	}
+
+	// Someone else
+	db.LedgerCreate(&models.Ledger{AccountId: 34, Date: helpers.ParseDateNoError("2024-01-10"), Amount: 50, Contact: models.Contact{Name: "Not Ours"}, Category: cats[7]})
+}
+
+/* End File */
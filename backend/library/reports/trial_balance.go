@@ -0,0 +1,118 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package reports
+
+import (
+	"math"
+	"time"
+
+	"app.skyclerk.com/backend/models"
+)
+
+// TrialBalanceRow struct - The balance of one chart account. Only one of debit
+// or credit is set.
+type TrialBalanceRow struct {
+	ChartAccountId uint    `json:"chart_account_id"`
+	Code           string  `json:"code"`
+	Name           string  `json:"name"`
+	Type           string  `json:"type"`
+	Debit          float64 `json:"debit"`
+	Credit         float64 `json:"credit"`
+}
+
+// TrialBalance struct
+type TrialBalance struct {
+	Date     string            `json:"date"`
+	Accounts []TrialBalanceRow `json:"accounts"`
+	Debit    float64           `json:"debit"`
+	Credit   float64           `json:"credit"`
+	Balanced bool              `json:"balanced"`
+}
+
+//
+// GetTrialBalance returns the balance of every chart account as of the end of
+// date. If the journal is right the debits and credits are the same.
+//
+func GetTrialBalance(db models.Datastore, accountId uint, date time.Time) TrialBalance {
+	// Struct we return
+	rt := TrialBalance{Date: date.Format("2006-01-02"), Accounts: []TrialBalanceRow{}}
+
+	// Work in cents so float math does not bite us.
+	debit := 0.0
+	credit := 0.0
+
+	for _, row := range getAccountBalances(db, accountId, time.Time{}, date, "") {
+		net := math.Round((row.Debit - row.Credit) * 100)
+
+		if net == 0 {
+			continue
+		}
+
+		tb := TrialBalanceRow{ChartAccountId: row.ChartAccountId, Code: row.Code, Name: row.Name, Type: row.Type}
+
+		if net > 0 {
+			tb.Debit = net / 100
+			debit = debit + net
+		} else {
+			tb.Credit = -net / 100
+			credit = credit - net
+		}
+
+		rt.Accounts = append(rt.Accounts, tb)
+	}
+
+	rt.Debit = debit / 100
+	rt.Credit = credit / 100
+	rt.Balanced = (debit == credit)
+
+	// Return happy.
+	return rt
+}
+
+// ----------------- Private Helper Funcs -------------- //
+
+// accountBalance - The debits and credits posted to one chart account.
+type accountBalance struct {
+	ChartAccountId uint
+	Code           string
+	Name           string
+	Type           string
+	Cash           bool
+	Debit          float64
+	Credit         float64
+}
+
+//
+// getAccountBalances - Total the journal by chart account from the start of
+// start to the end of end. The where is added to the query as is.
+//
+func getAccountBalances(db models.Datastore, accountId uint, start time.Time, end time.Time, where string) []accountBalance {
+	// SQL String
+	sql := "SELECT chart_accounts.id AS chart_account_id, chart_accounts.code AS code, chart_accounts.name AS name, chart_accounts.type AS type, chart_accounts.cash AS cash, "
+	sql = sql + "SUM(journal_lines.debit) AS debit, SUM(journal_lines.credit) AS credit FROM journal_lines "
+	sql = sql + "JOIN chart_accounts ON chart_accounts.id = journal_lines.chart_account_id "
+	sql = sql + "JOIN Ledger ON Ledger.LedgerId = journal_lines.ledger_id "
+	sql = sql + "WHERE journal_lines.account_id = ? AND journal_lines.date >= ? AND journal_lines.date < ? AND LedgerDeletedAt IS NULL " + where
+	sql = sql + "GROUP BY chart_accounts.id, chart_accounts.code, chart_accounts.name, chart_accounts.type, chart_accounts.cash ORDER BY code ASC, name ASC"
+
+	// Run query. Dates are stored with a time so we go up to the next day.
+	rt := []accountBalance{}
+	db.New().Raw(sql, accountId, start.Format("2006-01-02"), end.AddDate(0, 0, 1).Format("2006-01-02")).Scan(&rt)
+
+	// Return happy.
+	return rt
+}
+
+//
+// cents - Round to the cent.
+//
+func cents(f float64) float64 {
+	return math.Round(f*100) / 100
+}
+
+/* End File */
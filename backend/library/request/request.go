@@ -48,4 +48,27 @@ func GetSetPagingParms(c *gin.Context) (int, int, int) {
 	return page, limit, offset
 }
 
+//
+// GetCursorParms - ?cursor= pages with keyset cursors. Pass it empty for the
+// first page and then the X-Next-Cursor header. Cursors skip the total count
+// unless ?count=true, ?count=false skips it when paging by page.
+//
+func GetCursorParms(c *gin.Context) (bool, string, bool) {
+	cursor, useCursor := c.GetQuery("cursor")
+
+	// Count by default only when paging by page.
+	noCount := useCursor
+
+	if c.Query("count") == "true" {
+		noCount = false
+	}
+
+	if c.Query("count") == "false" {
+		noCount = true
+	}
+
+	// Return happy.
+	return useCursor, cursor, noCount
+}
+
 /* End File */
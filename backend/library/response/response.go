@@ -25,7 +25,16 @@ func AddPagingInfoToHeaders(c *gin.Context, meta models.QueryMetaData) {
 	c.Writer.Header().Set("X-Last-Page", strconv.FormatBool(meta.LastPage))
 	c.Writer.Header().Set("X-Offset", strconv.Itoa(meta.Offset))
 	c.Writer.Header().Set("X-Limit", strconv.Itoa(meta.Limit))
-	c.Writer.Header().Set("X-No-Limit-Count", strconv.Itoa(meta.NoLimitCount))
+
+	// We skip the count when asked.
+	if meta.NoLimitCount >= 0 {
+		c.Writer.Header().Set("X-No-Limit-Count", strconv.Itoa(meta.NoLimitCount))
+	}
+
+	// Keyset paging
+	if len(meta.NextCursor) > 0 {
+		c.Writer.Header().Set("X-Next-Cursor", meta.NextCursor)
+	}
 }
 
 //
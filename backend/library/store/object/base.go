@@ -99,6 +99,20 @@ func UploadObject(filePath string, storePath string) error {
 	return nil
 }
 
+//
+// DeleteObject - Remove an object from the store.
+//
+func DeleteObject(objectPath string) error {
+	// New returns an Amazon S3 compatible client object.
+	minioClient, err := minio.New(os.Getenv("OBJECT_ENDPOINT"), os.Getenv("OBJECT_ACCESS_KEY_ID"), os.Getenv("OBJECT_SECRET_ACCESS_KEY"), true)
+
+	if err != nil {
+		return err
+	}
+
+	return minioClient.RemoveObject(os.Getenv("OBJECT_BUCKET"), objectPath)
+}
+
 //
 // DownloadObject - Download an object to our cache directory.
 //
@@ -140,6 +140,30 @@ func GetRandomLedger(accountId int64) models.Ledger {
 	return ledger
 }
 
+//
+// GetRandomRecurring returns a random recurring template.
+//
+func GetRandomRecurring(accountId int64) models.Recurring {
+	rand.Seed(time.Now().UnixNano())
+
+	amounts := []float64{1234.56, 33.44, 99.00, -44.34, -1234.53, -10.66}
+
+	r := models.Recurring{
+		AccountId: uint(accountId),
+		AddedById: 1,
+		Amount:    amounts[rand.Intn(len(amounts))],
+		Note:      "Test Note - " + helpers.RandStr(16),
+		Frequency: "month",
+		Interval:  1,
+		StartDate: time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+		Contact:   GetRandomContact(accountId),
+		Category:  GetRandomCategory(accountId),
+		Labels:    []models.Label{GetRandomLabel(accountId)},
+	}
+
+	return r
+}
+
 //
 // GetRandomContact returns a random contact.
 //
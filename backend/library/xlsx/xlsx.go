@@ -0,0 +1,237 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+// Package xlsx writes a one sheet Excel file a row at a time. Rows go
+// straight into the zip stream so big sheets never sit in memory.
+package xlsx
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Cell styles, these match the cellXfs in styles.xml.
+const (
+	styleDate  = 1
+	styleMoney = 2
+	styleBold  = 3
+)
+
+// Writer streams rows into a sheet.
+type Writer struct {
+	zip    *zip.Writer
+	sheet  *bufio.Writer
+	row    int
+	closed bool
+}
+
+//
+// NewWriter - Start a new workbook with one sheet. Close must be called to
+// finish the file.
+//
+func NewWriter(w io.Writer, sheetName string) (*Writer, error) {
+	z := zip.NewWriter(w)
+
+	// Everything but the sheet is small so we write it up front.
+	parts := []struct {
+		name string
+		body string
+	}{
+		{"[Content_Types].xml", contentTypesXml},
+		{"_rels/.rels", relsXml},
+		{"xl/workbook.xml", strings.Replace(workbookXml, "{{name}}", escape(sheetName), 1)},
+		{"xl/_rels/workbook.xml.rels", workbookRelsXml},
+		{"xl/styles.xml", stylesXml},
+	}
+
+	for _, row := range parts {
+		f, err := z.Create(row.name)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := io.WriteString(f, row.body); err != nil {
+			return nil, err
+		}
+	}
+
+	// The sheet stays open until Close.
+	f, err := z.Create("xl/worksheets/sheet1.xml")
+
+	if err != nil {
+		return nil, err
+	}
+
+	t := &Writer{zip: z, sheet: bufio.NewWriter(f)}
+
+	if _, err := t.sheet.WriteString(sheetHeadXml); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+//
+// WriteHeader - Write a row of bold text.
+//
+func (t *Writer) WriteHeader(cols []string) error {
+	cells := []interface{}{}
+
+	for _, row := range cols {
+		cells = append(cells, row)
+	}
+
+	return t.writeRow(cells, true)
+}
+
+//
+// WriteRow - Write a row. Strings are text, float64 is money, int is a number
+// and time.Time is a date. Anything else is written as text.
+//
+func (t *Writer) WriteRow(cells []interface{}) error {
+	return t.writeRow(cells, false)
+}
+
+//
+// Flush - Push the rows written so far to the underlying writer.
+//
+func (t *Writer) Flush() error {
+	if err := t.sheet.Flush(); err != nil {
+		return err
+	}
+
+	return t.zip.Flush()
+}
+
+//
+// Close - Finish the sheet and the zip. Does not close the underlying writer.
+//
+func (t *Writer) Close() error {
+	if t.closed {
+		return nil
+	}
+
+	t.closed = true
+
+	if _, err := t.sheet.WriteString(sheetFootXml); err != nil {
+		return err
+	}
+
+	if err := t.sheet.Flush(); err != nil {
+		return err
+	}
+
+	return t.zip.Close()
+}
+
+// ----------------- Private Helper Funcs -------------- //
+
+//
+// writeRow - Write a row of cells to the sheet.
+//
+func (t *Writer) writeRow(cells []interface{}, bold bool) error {
+	if t.closed {
+		return errors.New("The xlsx writer is closed.")
+	}
+
+	t.row++
+	r := strconv.Itoa(t.row)
+
+	b := strings.Builder{}
+	b.WriteString(`<row r="` + r + `">`)
+
+	for key, row := range cells {
+		ref := ` r="` + columnName(key) + r + `"`
+
+		switch v := row.(type) {
+		case float64:
+			b.WriteString(`<c` + ref + ` s="` + strconv.Itoa(styleMoney) + `"><v>` + strconv.FormatFloat(v, 'f', -1, 64) + `</v></c>`)
+		case int:
+			b.WriteString(`<c` + ref + `><v>` + strconv.Itoa(v) + `</v></c>`)
+		case time.Time:
+			b.WriteString(`<c` + ref + ` s="` + strconv.Itoa(styleDate) + `"><v>` + strconv.FormatFloat(excelDate(v), 'f', -1, 64) + `</v></c>`)
+		default:
+			s, ok := v.(string)
+
+			if !ok {
+				s = ""
+			}
+
+			style := ""
+
+			if bold {
+				style = ` s="` + strconv.Itoa(styleBold) + `"`
+			}
+
+			b.WriteString(`<c` + ref + style + ` t="inlineStr"><is><t xml:space="preserve">` + escape(s) + `</t></is></c>`)
+		}
+	}
+
+	b.WriteString(`</row>`)
+
+	_, err := t.sheet.WriteString(b.String())
+	return err
+}
+
+//
+// columnName - 0 is A, 25 is Z, 26 is AA.
+//
+func columnName(i int) string {
+	name := ""
+
+	for i++; i > 0; i = (i - 1) / 26 {
+		name = string(rune('A'+(i-1)%26)) + name
+	}
+
+	return name
+}
+
+//
+// excelDate - Days since 1899-12-30, which is how Excel stores dates.
+//
+func excelDate(d time.Time) float64 {
+	d = time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, time.UTC)
+	return float64(d.Sub(time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)).Hours() / 24)
+}
+
+//
+// escape - Escape text for XML. Characters XML does not allow are replaced.
+//
+func escape(s string) string {
+	b := strings.Builder{}
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+const contentTypesXml = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/><Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/><Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/></Types>`
+
+const relsXml = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`
+
+const workbookXml = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="{{name}}" sheetId="1" r:id="rId1"/></sheets></workbook>`
+
+const workbookRelsXml = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/><Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/></Relationships>`
+
+const stylesXml = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><numFmts count="1"><numFmt numFmtId="164" formatCode="#,##0.00"/></numFmts><fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts><fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills><borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders><cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs><cellXfs count="4"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/><xf numFmtId="14" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/><xf numFmtId="164" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/><xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/></cellXfs></styleSheet>`
+
+const sheetHeadXml = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+
+const sheetFootXml = `</sheetData></worksheet>`
+
+/* End File */
@@ -0,0 +1,71 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nbio/st"
+)
+
+//
+// TestWriter01 - Write a small sheet and read it back.
+//
+func TestWriter01(t *testing.T) {
+	buf := bytes.Buffer{}
+
+	w, err := NewWriter(&buf, "Ledger & Co")
+	st.Expect(t, err, nil)
+	st.Expect(t, w.WriteHeader([]string{"Date", "Name", "Amount", "Count"}), nil)
+	st.Expect(t, w.WriteRow([]interface{}{time.Date(2024, 1, 15, 13, 0, 0, 0, time.UTC), "A < B", -42.5, 3}), nil)
+	st.Expect(t, w.Close(), nil)
+
+	// Closed is closed.
+	st.Expect(t, w.WriteRow([]interface{}{"late"}) != nil, true)
+
+	z, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	st.Expect(t, err, nil)
+
+	parts := map[string]string{}
+
+	for _, f := range z.File {
+		rc, _ := f.Open()
+		b, _ := ioutil.ReadAll(rc)
+		rc.Close()
+		parts[f.Name] = string(b)
+	}
+
+	st.Expect(t, len(parts), 6)
+	st.Expect(t, strings.Contains(parts["xl/workbook.xml"], `<sheet name="Ledger &amp; Co" sheetId="1" r:id="rId1"/>`), true)
+
+	want := sheetHeadXml +
+		`<row r="1"><c r="A1" s="3" t="inlineStr"><is><t xml:space="preserve">Date</t></is></c><c r="B1" s="3" t="inlineStr"><is><t xml:space="preserve">Name</t></is></c><c r="C1" s="3" t="inlineStr"><is><t xml:space="preserve">Amount</t></is></c><c r="D1" s="3" t="inlineStr"><is><t xml:space="preserve">Count</t></is></c></row>` +
+		`<row r="2"><c r="A2" s="1"><v>45306</v></c><c r="B2" t="inlineStr"><is><t xml:space="preserve">A &lt; B</t></is></c><c r="C2" s="2"><v>-42.5</v></c><c r="D2"><v>3</v></c></row>` +
+		sheetFootXml
+
+	st.Expect(t, parts["xl/worksheets/sheet1.xml"], want)
+}
+
+//
+// TestColumnName01 - Spreadsheet column letters.
+//
+func TestColumnName01(t *testing.T) {
+	st.Expect(t, columnName(0), "A")
+	st.Expect(t, columnName(25), "Z")
+	st.Expect(t, columnName(26), "AA")
+	st.Expect(t, columnName(27), "AB")
+	st.Expect(t, columnName(701), "ZZ")
+	st.Expect(t, columnName(702), "AAA")
+}
+
+/* End File */
@@ -20,20 +20,21 @@ import (
 
 // Account struct
 type Account struct {
-	Id           uint      `gorm:"primary_key" json:"id"`
-	CreatedAt    time.Time `sql:"not null" json:"-"`
-	UpdatedAt    time.Time `sql:"not null" json:"-"`
-	OwnerId      uint      `sql:"not null" json:"owner_id"`
-	BillingId    uint      `sql:"not null" json:"-"`
-	Name         string    `sql:"not null" json:"name"`
-	Address      string    `sql:"not null;type:TEXT" json:"-"`
-	City         string    `sql:"not null" json:"-"`
-	State        string    `sql:"not null" json:"-"`
-	Zip          string    `sql:"not null" json:"-"`
-	Country      string    `sql:"not null" json:"-"`
-	Locale       string    `sql:"not null;default:'en-US'" json:"locale"` // BCP 47 language tag
-	Currency     string    `sql:"not null;default:'USD'" json:"currency"` // The ISO 4217 currency code, such as USD for the US dollar and EUR for the euro.
-	LastActivity time.Time `sql:"not null" json:"-"`
+	Id                 uint      `gorm:"primary_key" json:"id"`
+	CreatedAt          time.Time `sql:"not null" json:"-"`
+	UpdatedAt          time.Time `sql:"not null" json:"-"`
+	OwnerId            uint      `sql:"not null" json:"owner_id"`
+	BillingId          uint      `sql:"not null" json:"-"`
+	Name               string    `sql:"not null" json:"name"`
+	Address            string    `sql:"not null;type:TEXT" json:"-"`
+	City               string    `sql:"not null" json:"-"`
+	State              string    `sql:"not null" json:"-"`
+	Zip                string    `sql:"not null" json:"-"`
+	Country            string    `sql:"not null" json:"-"`
+	Locale             string    `sql:"not null;default:'en-US'" json:"locale"` // BCP 47 language tag
+	Currency           string    `sql:"not null;default:'USD'" json:"currency"` // The ISO 4217 currency code, such as USD for the US dollar and EUR for the euro.
+	LastActivity       time.Time `sql:"not null" json:"-"`
+	BooksClosedThrough time.Time `json:"books_closed_through"` // Zero means the books are open.
 }
 
 //
@@ -126,6 +127,25 @@ func (t *DB) ClearAccount(accountId uint) {
 	t.New().Exec("DELETE FROM Categories WHERE CategoriesAccountId = ?", accountId)
 	t.New().Exec("DELETE FROM SnapClerk WHERE SnapClerkAccountId = ?", accountId)
 	t.New().Exec("DELETE FROM connected_accounts WHERE account_id = ?", accountId)
+	t.New().Exec("DELETE FROM recurring_labels WHERE recurring_id IN (SELECT id FROM recurrings WHERE account_id = ?)", accountId)
+	t.New().Exec("DELETE FROM recurrings WHERE account_id = ?", accountId)
+	t.New().Exec("DELETE FROM ledger_split_labels WHERE ledger_split_id IN (SELECT id FROM ledger_splits WHERE account_id = ?)", accountId)
+	t.New().Exec("DELETE FROM ledger_splits WHERE account_id = ?", accountId)
+	t.New().Exec("DELETE FROM import_profiles WHERE account_id = ?", accountId)
+	t.New().Exec("DELETE FROM rule_labels WHERE rule_id IN (SELECT id FROM rules WHERE account_id = ?)", accountId)
+	t.New().Exec("DELETE FROM rules WHERE account_id = ?", accountId)
+	t.New().Exec("DELETE FROM reconciliations WHERE account_id = ?", accountId)
+	t.New().Exec("DELETE FROM period_locks WHERE account_id = ?", accountId)
+	t.New().Exec("DELETE FROM ledger_revisions WHERE account_id = ?", accountId)
+	t.New().Exec("DELETE FROM trash_items WHERE account_id = ?", accountId)
+	t.New().Exec("DELETE FROM search_docs WHERE account_id = ?", accountId)
+	t.New().Exec("DELETE FROM ledger_views WHERE account_id = ?", accountId)
+	t.New().Exec("DELETE FROM export_jobs WHERE account_id = ?", accountId)
+	t.New().Exec("DELETE FROM archive_maps WHERE account_id = ?", accountId)
+	t.New().Exec("DELETE FROM chart_accounts WHERE account_id = ?", accountId)
+	t.New().Exec("DELETE FROM journal_lines WHERE account_id = ?", accountId)
+	t.New().Exec("DELETE FROM budgets WHERE account_id = ?", accountId)
+	t.New().Exec("DELETE FROM report_subscriptions WHERE account_id = ?", accountId)
 
 	// TODO(spicer): delete files at AWS too.
 }
@@ -72,11 +72,25 @@ func (a *Activity) SetMessage() {
 		subAction = "deleted"
 	}
 
+	if a.SubAction == "restore" {
+		mixWord = "for"
+		subAction = "restored"
+	}
+
 	// See if this is a ledger activity. - Spicer, Added a ledger entry of -2325.20 for Bank of America.
 	if a.LedgerId > 0 {
 		a.Message = fmt.Sprintf("%s %s an %s ledger entry of %.2f %s %s.", userName, subAction, a.Action, a.Amount, mixWord, a.Name)
 	}
 
+	// See if this is a closed period activity.
+	if a.Action == "period" {
+		if a.SubAction == "reopen" {
+			a.Message = fmt.Sprintf("%s reopened the books for %s.", userName, a.Name)
+		} else {
+			a.Message = fmt.Sprintf("%s closed the books for %s.", userName, a.Name)
+		}
+	}
+
 	// See if this is a snapclerk activity.
 	if a.SnapClerkId > 0 {
 		// Create
@@ -0,0 +1,50 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package models
+
+import (
+	"time"
+)
+
+// ArchiveMap struct - When we import an account archive we remember what each
+// old id became. Running the same import again skips anything already here.
+type ArchiveMap struct {
+	Id        uint      `gorm:"primary_key" json:"id"`
+	CreatedAt time.Time `sql:"not null" json:"created_at"`
+	AccountId uint      `sql:"not null;index:account_id" json:"account_id"`
+	Source    string    `sql:"not null" json:"source"` // Where the archive came from. See archive.Manifest.
+	Type      string    `sql:"not null" json:"type"`   // contact, category, label, file, ledger, snapclerk, activity
+	OldId     uint      `sql:"not null" json:"old_id"`
+	NewId     uint      `sql:"not null" json:"new_id"`
+}
+
+//
+// GetArchiveMap - Every old id to new id for a type we have already imported
+// from a source.
+//
+func (db *DB) GetArchiveMap(accountId uint, source string, objType string) map[uint]uint {
+	rows := []ArchiveMap{}
+	db.New().Where("account_id = ? AND source = ? AND type = ?", accountId, source, objType).Find(&rows)
+
+	m := map[uint]uint{}
+
+	for _, row := range rows {
+		m[row.OldId] = row.NewId
+	}
+
+	return m
+}
+
+//
+// CreateArchiveMap - Remember what an old id became.
+//
+func (db *DB) CreateArchiveMap(accountId uint, source string, objType string, oldId uint, newId uint) error {
+	return db.New().Create(&ArchiveMap{AccountId: accountId, Source: source, Type: objType, OldId: oldId, NewId: newId}).Error
+}
+
+/* End File */
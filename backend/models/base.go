@@ -41,7 +41,7 @@ func init() {
 	setDefaultIfEmpty("STRIPE_SECRET_KEY", "sk_test_default")
 	setDefaultIfEmpty("APP_URL", "http://localhost:8080")
 	setDefaultIfEmpty("SITE_URL", "http://localhost:4200")
-	setDefaultIfEmpty("ENCRYPTION_KEY", "test-encryption-key-32-characters")
+	setDefaultIfEmpty("ENCRYPTION_KEY", "test-encryption-key-32-character")
 	setDefaultIfEmpty("POSTMARK_SERVER_KEY", "test-postmark-server-key")
 	setDefaultIfEmpty("POSTMARK_ACCOUNT_KEY", "test-postmark-account-key")
 	setDefaultIfEmpty("MAILGUN_DOMAIN", "test.example.com")
@@ -153,6 +153,31 @@ func doMigrations(db *gorm.DB) {
 	db.AutoMigrate(&ForgotPassword{})
 	db.AutoMigrate(&ConnectedAccounts{})
 	db.AutoMigrate(&Cache{})
+	db.AutoMigrate(&Recurring{})
+	db.AutoMigrate(&LedgerSplit{})
+	db.AutoMigrate(&ImportProfile{})
+	db.AutoMigrate(&Rule{})
+	db.AutoMigrate(&Reconciliation{})
+	db.AutoMigrate(&PeriodLock{})
+	db.AutoMigrate(&LedgerRevision{})
+	db.AutoMigrate(&TrashItem{})
+	db.AutoMigrate(&SearchDoc{})
+	db.AutoMigrate(&LedgerView{})
+	db.AutoMigrate(&ExportJob{})
+	db.AutoMigrate(&ArchiveMap{})
+	db.AutoMigrate(&ChartAccount{})
+	db.AutoMigrate(&JournalLine{})
+	db.AutoMigrate(&Budget{})
+	db.AutoMigrate(&ReportSubscription{})
+
+	// Categories.Irs used to be a flag
+	(&DB{db}).MigrateLegacyTaxLines()
+
+	// Ledger entries from before the journal
+	(&DB{db}).MigrateJournal()
+
+	// Full-text search over search_docs
+	migrateSearchIndex(db)
 }
 
 /* End File */
@@ -0,0 +1,204 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// Budget struct - How much we plan to spend (or bring in) in a category, or
+// on entries with a label, each month, quarter or year.
+type Budget struct {
+	Id         uint      `gorm:"primary_key" json:"id"`
+	CreatedAt  time.Time `sql:"not null" json:"-"`
+	UpdatedAt  time.Time `sql:"not null" json:"-"`
+	AccountId  uint      `sql:"not null;index:account_id" json:"account_id"`
+	Name       string    `sql:"not null" json:"name"`
+	CategoryId uint      `sql:"not null" json:"category_id"`
+	Category   Category  `gorm:"association_autoupdate:false;association_autocreate:false" json:"category"`
+	LabelId    uint      `sql:"not null" json:"label_id"`
+	Label      Label     `gorm:"association_autoupdate:false;association_autocreate:false" json:"label"`
+	Amount     float64   `sql:"not null;type:DECIMAL(12,2)" json:"amount"`
+	Period     string    `sql:"not null;default:'month'" json:"period"` // month, quarter, year
+	Rollover   bool      `sql:"not null" json:"rollover"`               // Carry what is left (or overspent) into the next period.
+	AlertStart string    `sql:"not null" json:"-"`                      // Start of the period we last emailed about.
+	AlertLevel int       `sql:"not null" json:"-"`                      // Percent used we last emailed about (80 or 100).
+}
+
+//
+// Validate for this model.
+//
+func (a Budget) Validate(db Datastore, action string, userId uint, accountId uint, objId uint) error {
+	return validation.ValidateStruct(&a,
+
+		validation.Field(&a.Name,
+			validation.Required.Error("The name field is required."),
+		),
+
+		validation.Field(&a.Amount,
+			validation.By(func(value interface{}) error {
+				if a.Amount <= 0 {
+					return errors.New("The amount field must be more than zero.")
+				}
+				return nil
+			}),
+		),
+
+		validation.Field(&a.Period,
+			validation.In("month", "quarter", "year").Error("The period field must be month, quarter or year."),
+		),
+
+		validation.Field(&a.CategoryId,
+			validation.By(func(value interface{}) error {
+				if (a.CategoryId == 0) && (a.LabelId == 0) {
+					return errors.New("A budget needs a category or a label.")
+				}
+
+				if (a.CategoryId > 0) && (a.LabelId > 0) {
+					return errors.New("A budget can have a category or a label but not both.")
+				}
+
+				if a.CategoryId > 0 {
+					if _, err := db.GetCategoryByAccountAndId(accountId, a.CategoryId); err != nil {
+						return err
+					}
+				}
+
+				return nil
+			}),
+		),
+
+		validation.Field(&a.LabelId,
+			validation.By(func(value interface{}) error {
+				if a.LabelId > 0 {
+					if _, err := db.GetLabelByAccountAndId(accountId, a.LabelId); err != nil {
+						return err
+					}
+				}
+
+				return nil
+			}),
+		),
+	)
+}
+
+//
+// IsIncome returns true if this budget is a target for an income category.
+// Everything else is a spending limit.
+//
+func (a Budget) IsIncome() bool {
+	return (a.CategoryId > 0) && (a.Category.Type == "2")
+}
+
+//
+// PeriodBounds returns the start of the budget period that date falls in and
+// the start of the next one.
+//
+func (a Budget) PeriodBounds(date time.Time) (time.Time, time.Time) {
+	switch a.Period {
+	case "year":
+		start := time.Date(date.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(1, 0, 0)
+
+	case "quarter":
+		start := time.Date(date.Year(), ((date.Month()-1)/3)*3+1, 1, 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(0, 3, 0)
+
+	default:
+		start := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(0, 1, 0)
+	}
+}
+
+//
+// BudgetCreate - Create a new budget.
+//
+func (db *DB) BudgetCreate(b *Budget) error {
+	prepBudgetVars(b)
+	db.New().Create(b)
+	return nil
+}
+
+//
+// BudgetUpdate - Update a budget.
+//
+func (db *DB) BudgetUpdate(b *Budget) error {
+	prepBudgetVars(b)
+	db.New().Save(b)
+	return nil
+}
+
+//
+// GetBudgetsByAccount - All the budgets for an account by name.
+//
+func (db *DB) GetBudgetsByAccount(accountId uint) []Budget {
+	rt := []Budget{}
+	db.New().Preload("Category").Preload("Label").Where("account_id = ?", accountId).Order("name ASC, id ASC").Find(&rt)
+	return rt
+}
+
+//
+// GetBudgetByAccountAndId by account and id.
+//
+func (db *DB) GetBudgetByAccountAndId(accountId uint, id uint) (Budget, error) {
+	b := Budget{}
+
+	// Make query
+	if db.New().Preload("Category").Preload("Label").Where("account_id = ? AND id = ?", accountId, id).First(&b).RecordNotFound() {
+		return Budget{}, errors.New("Budget not found.")
+	}
+
+	// Return result
+	return b, nil
+}
+
+//
+// GetBudgetAccountIds - Every account that has at least one budget.
+//
+func (db *DB) GetBudgetAccountIds() []uint {
+	rt := []uint{}
+	db.New().Model(&Budget{}).Group("account_id").Order("account_id ASC").Pluck("account_id", &rt)
+	return rt
+}
+
+//
+// BudgetAlertSent - Record that we emailed about a budget for a period so we
+// do not send the same alert again.
+//
+func (db *DB) BudgetAlertSent(b *Budget, start string, level int) {
+	b.AlertStart = start
+	b.AlertLevel = level
+	db.New().Model(Budget{}).Where("id = ?", b.Id).Updates(map[string]interface{}{"alert_start": start, "alert_level": level})
+}
+
+//
+// DeleteBudgetByAccountAndId - Delete a budget by account and id.
+//
+func (db *DB) DeleteBudgetByAccountAndId(accountId uint, id uint) error {
+	db.New().Where("account_id = ? AND id = ?", accountId, id).Delete(Budget{})
+	return nil
+}
+
+// ----------------- Private Helper Funcs -------------- //
+
+//
+// prepBudgetVars - Clean up a budget before we save it.
+//
+func prepBudgetVars(b *Budget) {
+	b.Name = strings.TrimSpace(b.Name)
+
+	if len(b.Period) == 0 {
+		b.Period = "month"
+	}
+}
+
+/* End File */
@@ -24,15 +24,16 @@ type CategoryUsage struct {
 
 // Category struct
 type Category struct {
-	Id        uint      `gorm:"primary_key;column:CategoriesId" json:"id"`
-	AccountId uint      `gorm:"column:CategoriesAccountId" sql:"not null" json:"account_id"`
-	UpdatedAt time.Time `gorm:"column:CategoriesUpdatedAt" sql:"not null" json:"-"`
-	CreatedAt time.Time `gorm:"column:CategoriesCreatedAt" sql:"not null" json:"-"`
-	Name      string    `gorm:"column:CategoriesName" sql:"not null;" json:"name"`
-	Type      string    `gorm:"column:CategoriesType" sql:"not null" json:"type"` // 1 = expense, 2 = income
-	Irs       string    `gorm:"column:CategoriesIrs" sql:"not null" json:"-"`
-	Show      string    `gorm:"column:CategoriesShow" sql:"not null" json:"-"`
-	Count     int       `gorm:"-" sql:"not null" json:"count"`
+	Id        uint       `gorm:"primary_key;column:CategoriesId" json:"id"`
+	AccountId uint       `gorm:"column:CategoriesAccountId" sql:"not null" json:"account_id"`
+	UpdatedAt time.Time  `gorm:"column:CategoriesUpdatedAt" sql:"not null" json:"-"`
+	CreatedAt time.Time  `gorm:"column:CategoriesCreatedAt" sql:"not null" json:"-"`
+	DeletedAt *time.Time `gorm:"column:CategoriesDeletedAt;index:CategoriesDeletedAt" json:"-"`
+	Name      string     `gorm:"column:CategoriesName" sql:"not null;" json:"name"`
+	Type      string     `gorm:"column:CategoriesType" sql:"not null" json:"type"` // 1 = expense, 2 = income
+	Irs       string     `gorm:"column:CategoriesIrs" sql:"not null" json:"irs"`   // Tax form line code, see tax_form.go
+	Show      string     `gorm:"column:CategoriesShow" sql:"not null" json:"-"`
+	Count     int        `gorm:"-" sql:"not null" json:"count"`
 }
 
 //
@@ -57,9 +58,35 @@ func (a Category) Validate(db Datastore, action string, userId uint, accountId u
 			validation.Required.Error("The type field is required."),
 			validation.In("1", "2").Error("The type field must be 1, or 2."),
 		),
+
+		validation.Field(&a.Irs,
+			validation.By(func(value interface{}) error { return ValidateCategoryTaxLine(a) }),
+		),
 	)
 }
 
+//
+// ValidateCategoryTaxLine - The tax form line has to be one we know and on the
+// same side (income or expense) as the category.
+//
+func ValidateCategoryTaxLine(cat Category) error {
+	if cat.Irs == "" {
+		return nil
+	}
+
+	line, ok := GetTaxLine(cat.Irs)
+
+	if !ok {
+		return errors.New("The irs field must be a known tax form line.")
+	}
+
+	if (line.Type == "income") != (cat.Type == "2") {
+		return errors.New("The tax form line does not match the category type.")
+	}
+
+	return nil
+}
+
 //
 // ValidateDuplicateCategoryName - Validate Duplicate Name
 //
@@ -131,6 +158,26 @@ func (db *DB) GetCategoryByNameAndTypeAndAccountID(accountID uint, name string,
 	return c, nil
 }
 
+//
+// GetCategoryForContact returns the category from the contact's most recent entry
+// of the same type. Imports use this so we learn from what the user did last time.
+//
+func (db *DB) GetCategoryForContact(accountId uint, contactId uint, catType string) (Category, error) {
+	l := Ledger{}
+
+	// Make query
+	db.New().Preload("Category").Joins("JOIN Categories ON Categories.CategoriesId = Ledger.LedgerCategoryId").
+		Where("LedgerAccountId = ? AND LedgerContactId = ? AND CategoriesType = ?", accountId, contactId, catType).
+		Order("LedgerDate DESC").First(&l)
+
+	if l.Category.Id == 0 {
+		return Category{}, errors.New("Category not found.")
+	}
+
+	// Return result
+	return l.Category, nil
+}
+
 //
 // GetOrCreateCategory will get or create category if we do not already have.
 //
@@ -143,24 +190,40 @@ func (db *DB) GetOrCreateCategory(accountID uint, name string, catType string) C
 		cat.Name = name
 		cat.AccountId = accountID
 		db.New().Save(&cat)
+		db.indexCategoryDoc(cat)
 	}
 
 	return cat
 }
 
 //
-// DeleteCategoryByAccountAndId - Delete a category by account and id.
+// DeleteCategoryByAccountAndId - Move a category to the trash by account and id.
 //
 func (db *DB) DeleteCategoryByAccountAndId(accountId uint, categoryId uint) error {
 
-	// Make query to see if we have ledger entries with this category
-	if !db.New().Where("LedgerAccountId = ? AND LedgerCategoryId = ?", accountId, categoryId).First(&Ledger{}).RecordNotFound() {
+	// Make query to see if we have ledger entries with this category. Entries in the trash count too.
+	if !db.New().Unscoped().Where("LedgerAccountId = ? AND LedgerCategoryId = ?", accountId, categoryId).First(&Ledger{}).RecordNotFound() {
 		return errors.New("Can not delete category. It is in use by a ledger entry.")
 	}
 
+	// Split lines count as in use too.
+	if !db.New().Where("account_id = ? AND category_id = ?", accountId, categoryId).First(&LedgerSplit{}).RecordNotFound() {
+		return errors.New("Can not delete category. It is in use by a ledger entry.")
+	}
+
+	c := Category{}
+
+	if db.New().Where("CategoriesAccountId = ? AND CategoriesId = ?", accountId, categoryId).First(&c).RecordNotFound() {
+		return errors.New("Category not found.")
+	}
+
 	// Make query
 	db.New().Where("CategoriesAccountId = ? AND CategoriesId = ?", accountId, categoryId).Delete(Category{})
 
+	// Add to the trash
+	db.addToTrash(accountId, "category", categoryId, c.Name, 0, TrashLinks{})
+	db.RemoveFromSearch(accountId, "category", categoryId)
+
 	// Return result
 	return nil
 }
@@ -170,7 +233,7 @@ func (db *DB) DeleteCategoryByAccountAndId(accountId uint, categoryId uint) erro
 //
 func (db *DB) GetCategoryUsageByAccount(accountId uint) []CategoryUsage {
 	// SQL String
-	sql := "SELECT CategoriesName AS name, COUNT(LedgerId) AS count FROM Ledger "
+	sql := "SELECT CategoriesName AS name, COUNT(LedgerId) AS count FROM " + LedgerLinesTable + " "
 	sql = sql + "INNER JOIN Categories ON Ledger.LedgerCategoryId=Categories.CategoriesId "
 	sql = sql + "WHERE CategoriesAccountId = ? "
 	sql = sql + "GROUP BY CategoriesName ORDER BY CategoriesName "
@@ -189,39 +252,40 @@ func (db *DB) GetCategoryUsageByAccount(accountId uint) []CategoryUsage {
 // LoadDefaultCategories - install the default categories we get on a new account.
 //
 func (db *DB) LoadDefaultCategories(accountId uint) {
-	// Default cats
+	// Default cats, each on its Schedule C line.
 	cats := []Category{
 		// Expenses
-		{Name: "Advertising", Type: "1", AccountId: accountId},
-		{Name: "Car & Truck Expenses", Type: "1", AccountId: accountId},
-		{Name: "Commissions & Fees", Type: "1", AccountId: accountId},
-		{Name: "Insurance", Type: "1", AccountId: accountId},
-		{Name: "Mortgage", Type: "1", AccountId: accountId},
-		{Name: "Meals & Entertainment", Type: "1", AccountId: accountId},
-		{Name: "Office Expense", Type: "1", AccountId: accountId},
-		{Name: "Professional Services", Type: "1", AccountId: accountId},
-		{Name: "Supplies", Type: "1", AccountId: accountId},
-		{Name: "Travel", Type: "1", AccountId: accountId},
-		{Name: "Maintenance", Type: "1", AccountId: accountId},
-		{Name: "Contractors & Freelancers", Type: "1", AccountId: accountId},
-		{Name: "Cost of Goods Sold'", Type: "1", AccountId: accountId},
-		{Name: "Equipment Rental", Type: "1", AccountId: accountId},
-		{Name: "Utilities", Type: "1", AccountId: accountId},
-		{Name: "Employee Wage", Type: "1", AccountId: accountId},
-		{Name: "Taxes and Licenses", Type: "1", AccountId: accountId},
-		{Name: "Pension & Profit-Sharing Plans", Type: "1", AccountId: accountId},
-		{Name: "Rent Or Lease'", Type: "1", AccountId: accountId},
-		{Name: "Other Expense", Type: "1", AccountId: accountId},
+		{Name: "Advertising", Type: "1", Irs: "schedule-c:8", AccountId: accountId},
+		{Name: "Car & Truck Expenses", Type: "1", Irs: "schedule-c:9", AccountId: accountId},
+		{Name: "Commissions & Fees", Type: "1", Irs: "schedule-c:10", AccountId: accountId},
+		{Name: "Insurance", Type: "1", Irs: "schedule-c:15", AccountId: accountId},
+		{Name: "Mortgage", Type: "1", Irs: "schedule-c:16a", AccountId: accountId},
+		{Name: "Meals & Entertainment", Type: "1", Irs: "schedule-c:24b", AccountId: accountId},
+		{Name: "Office Expense", Type: "1", Irs: "schedule-c:18", AccountId: accountId},
+		{Name: "Professional Services", Type: "1", Irs: "schedule-c:17", AccountId: accountId},
+		{Name: "Supplies", Type: "1", Irs: "schedule-c:22", AccountId: accountId},
+		{Name: "Travel", Type: "1", Irs: "schedule-c:24a", AccountId: accountId},
+		{Name: "Maintenance", Type: "1", Irs: "schedule-c:21", AccountId: accountId},
+		{Name: "Contractors & Freelancers", Type: "1", Irs: "schedule-c:11", AccountId: accountId},
+		{Name: "Cost of Goods Sold'", Type: "1", Irs: "schedule-c:4", AccountId: accountId},
+		{Name: "Equipment Rental", Type: "1", Irs: "schedule-c:20a", AccountId: accountId},
+		{Name: "Utilities", Type: "1", Irs: "schedule-c:25", AccountId: accountId},
+		{Name: "Employee Wage", Type: "1", Irs: "schedule-c:26", AccountId: accountId},
+		{Name: "Taxes and Licenses", Type: "1", Irs: "schedule-c:23", AccountId: accountId},
+		{Name: "Pension & Profit-Sharing Plans", Type: "1", Irs: "schedule-c:19", AccountId: accountId},
+		{Name: "Rent Or Lease'", Type: "1", Irs: "schedule-c:20b", AccountId: accountId},
+		{Name: "Other Expense", Type: "1", Irs: "schedule-c:27a", AccountId: accountId},
 
 		// income
-		{Name: "Sales", Type: "2", AccountId: accountId},
-		{Name: "Returns", Type: "2", AccountId: accountId},
-		{Name: "Other Income", Type: "2", AccountId: accountId},
+		{Name: "Sales", Type: "2", Irs: "schedule-c:1", AccountId: accountId},
+		{Name: "Returns", Type: "2", Irs: "schedule-c:2", AccountId: accountId},
+		{Name: "Other Income", Type: "2", Irs: "schedule-c:6", AccountId: accountId},
 	}
 
 	// Save to database
 	for _, row := range cats {
 		db.New().Create(&row)
+		db.indexCategoryDoc(row)
 	}
 }
 
@@ -0,0 +1,283 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package models
+
+import (
+	"database/sql"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// ChartAccount struct - One account in the chart of accounts. This is the
+// double-entry layer under the ledger. Income and expense categories each get
+// an account the first time they are posted. Balance sheet accounts (bank
+// accounts, credit cards, loans, equity) are set up by the user.
+type ChartAccount struct {
+	Id         uint      `gorm:"primary_key" json:"id"`
+	CreatedAt  time.Time `sql:"not null" json:"-"`
+	UpdatedAt  time.Time `sql:"not null" json:"-"`
+	AccountId  uint      `sql:"not null;index:account_id" json:"account_id"`
+	Code       string    `sql:"not null" json:"code"`
+	Name       string    `sql:"not null" json:"name"`
+	Type       string    `sql:"not null" json:"type"`                          // asset, liability, equity, income, expense
+	System     string    `sql:"not null" json:"system"`                        // cash, equity, uncategorized-income, uncategorized-expense or empty
+	CategoryId uint      `sql:"not null;index:category_id" json:"category_id"` // Set when this account follows a ledger category.
+	Cash       bool      `sql:"not null" json:"cash"`                          // Counts as cash in the cash flow report. Asset accounts only.
+}
+
+// Every account starts with these. Ledger entries without a chart account
+// are in Cash. Entries without a category go to the uncategorized accounts.
+var defaultChartAccounts = []ChartAccount{
+	{Code: "1000", Name: "Cash", Type: "asset", System: "cash", Cash: true},
+	{Code: "3000", Name: "Owner's Equity", Type: "equity", System: "equity"},
+	{Code: "4999", Name: "Uncategorized Income", Type: "income", System: "uncategorized-income"},
+	{Code: "5999", Name: "Uncategorized Expense", Type: "expense", System: "uncategorized-expense"},
+}
+
+//
+// Validate for this model.
+//
+func (a ChartAccount) Validate(db Datastore, action string, userId uint, accountId uint, objId uint) error {
+	return validation.ValidateStruct(&a,
+
+		validation.Field(&a.Code,
+			validation.Required.Error("The code field is required."),
+			validation.By(func(value interface{}) error {
+				if !db.New().Where("account_id = ? AND code = ? AND id != ?", accountId, strings.TrimSpace(a.Code), objId).First(&ChartAccount{}).RecordNotFound() {
+					return errors.New("The code field must be unique.")
+				}
+				return nil
+			}),
+		),
+
+		validation.Field(&a.Name,
+			validation.Required.Error("The name field is required."),
+		),
+
+		validation.Field(&a.Type,
+			validation.Required.Error("The type field is required."),
+			validation.In("asset", "liability", "equity", "income", "expense").Error("The type field must be asset, liability, equity, income, or expense."),
+			validation.By(func(value interface{}) error {
+				if action != "update" {
+					return nil
+				}
+
+				org, err := db.GetChartAccountByAccountAndId(accountId, objId)
+
+				if (err != nil) || (org.Type == a.Type) {
+					return nil
+				}
+
+				if (len(org.System) > 0) || (org.CategoryId > 0) || db.ChartAccountInUse(accountId, objId) {
+					return errors.New("The type of this account can not be changed.")
+				}
+
+				return nil
+			}),
+		),
+
+		validation.Field(&a.Cash,
+			validation.By(func(value interface{}) error {
+				if a.Cash && (a.Type != "asset") {
+					return errors.New("Only asset accounts can be cash.")
+				}
+				return nil
+			}),
+		),
+	)
+}
+
+//
+// BalanceSheet returns true for the accounts money can sit in.
+//
+func (a ChartAccount) BalanceSheet() bool {
+	return (a.Type == "asset") || (a.Type == "liability") || (a.Type == "equity")
+}
+
+//
+// ChartAccountCreate - Create a new chart account.
+//
+func (db *DB) ChartAccountCreate(a *ChartAccount) error {
+	prepChartAccountVars(a)
+	db.New().Create(a)
+	return nil
+}
+
+//
+// ChartAccountUpdate - Update a chart account.
+//
+func (db *DB) ChartAccountUpdate(a *ChartAccount) error {
+	prepChartAccountVars(a)
+	db.New().Save(a)
+	return nil
+}
+
+//
+// GetChartAccountsByAccount - The whole chart of accounts in code order.
+//
+func (db *DB) GetChartAccountsByAccount(accountId uint) []ChartAccount {
+	db.seedChartAccounts(accountId)
+
+	rt := []ChartAccount{}
+	db.New().Where("account_id = ?", accountId).Order("code ASC, name ASC").Find(&rt)
+	return rt
+}
+
+//
+// GetChartAccountByAccountAndId by account and id.
+//
+func (db *DB) GetChartAccountByAccountAndId(accountId uint, id uint) (ChartAccount, error) {
+	a := ChartAccount{}
+
+	// Make query
+	if db.New().Where("account_id = ? AND id = ?", accountId, id).First(&a).RecordNotFound() {
+		return ChartAccount{}, errors.New("Chart account not found.")
+	}
+
+	// Return result
+	return a, nil
+}
+
+//
+// GetSystemChartAccount - One of the accounts every account starts with.
+//
+func (db *DB) GetSystemChartAccount(accountId uint, system string) ChartAccount {
+	db.seedChartAccounts(accountId)
+
+	a := ChartAccount{}
+	db.New().Where("account_id = ? AND system = ?", accountId, system).First(&a)
+	return a
+}
+
+//
+// ChartAccountInUse returns true if any ledger entry posts to this account.
+//
+func (db *DB) ChartAccountInUse(accountId uint, id uint) bool {
+	if !db.New().Where("account_id = ? AND chart_account_id = ?", accountId, id).First(&JournalLine{}).RecordNotFound() {
+		return true
+	}
+
+	return !db.New().Unscoped().Where("LedgerAccountId = ? AND (LedgerChartAccountId = ? OR LedgerTransferChartAccountId = ?)", accountId, id, id).First(&Ledger{}).RecordNotFound()
+}
+
+//
+// DeleteChartAccountByAccountAndId - Delete a chart account by account and id.
+// Accounts we set up and accounts with entries in them stay.
+//
+func (db *DB) DeleteChartAccountByAccountAndId(accountId uint, id uint) error {
+	a, err := db.GetChartAccountByAccountAndId(accountId, id)
+
+	if err != nil {
+		return err
+	}
+
+	if len(a.System) > 0 {
+		return errors.New("This account can not be deleted.")
+	}
+
+	if db.ChartAccountInUse(accountId, id) {
+		return errors.New("This account is in use.")
+	}
+
+	// Make query to delete
+	db.New().Where("account_id = ? AND id = ?", accountId, id).Delete(ChartAccount{})
+
+	// Return result
+	return nil
+}
+
+// ----------------- Private Helper Funcs -------------- //
+
+//
+// prepChartAccountVars for update or create
+//
+func prepChartAccountVars(a *ChartAccount) {
+	a.Code = strings.TrimSpace(a.Code)
+	a.Name = strings.TrimSpace(a.Name)
+}
+
+//
+// seedChartAccounts - Add the default accounts if this account does not have them yet.
+//
+func (db *DB) seedChartAccounts(accountId uint) {
+	count := 0
+	db.New().Model(&ChartAccount{}).Where("account_id = ? AND system != ''", accountId).Count(&count)
+
+	if count >= len(defaultChartAccounts) {
+		return
+	}
+
+	for _, row := range defaultChartAccounts {
+		row.AccountId = accountId
+		db.New().Where("account_id = ? AND system = ?", accountId, row.System).FirstOrCreate(&row)
+	}
+}
+
+//
+// chartAccountForCategory - The income or expense account a category posts to.
+// We make it the first time we need it and keep its name in step with the category.
+//
+func (db *DB) chartAccountForCategory(accountId uint, categoryId uint, amount float64) ChartAccount {
+	cat := Category{}
+
+	if (categoryId == 0) || db.New().Unscoped().Where("CategoriesAccountId = ? AND CategoriesId = ?", accountId, categoryId).First(&cat).RecordNotFound() {
+		if amount > 0 {
+			return db.GetSystemChartAccount(accountId, "uncategorized-income")
+		}
+
+		return db.GetSystemChartAccount(accountId, "uncategorized-expense")
+	}
+
+	a := ChartAccount{}
+
+	if db.New().Where("account_id = ? AND category_id = ?", accountId, categoryId).First(&a).RecordNotFound() {
+		a = ChartAccount{AccountId: accountId, Name: cat.Name, Type: "expense", CategoryId: categoryId}
+
+		if cat.Type == "2" {
+			a.Type = "income"
+		}
+
+		a.Code = db.nextChartAccountCode(accountId, a.Type)
+		db.New().Create(&a)
+		return a
+	}
+
+	if a.Name != cat.Name {
+		a.Name = cat.Name
+		db.New().Model(&a).UpdateColumn("name", cat.Name)
+	}
+
+	return a
+}
+
+//
+// nextChartAccountCode - Income accounts are numbered from 4000 and expense
+// accounts from 5000.
+//
+func (db *DB) nextChartAccountCode(accountId uint, accountType string) string {
+	base := 5000
+
+	if accountType == "income" {
+		base = 4000
+	}
+
+	max := sql.NullInt64{}
+	db.New().Raw("SELECT MAX(CAST(code AS INTEGER)) FROM chart_accounts WHERE account_id = ? AND CAST(code AS INTEGER) >= ? AND CAST(code AS INTEGER) < ?", accountId, base, base+999).Row().Scan(&max)
+
+	if !max.Valid {
+		return strconv.Itoa(base)
+	}
+
+	return strconv.Itoa(int(max.Int64) + 1)
+}
+
+/* End File */
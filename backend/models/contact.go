@@ -18,6 +18,7 @@ import (
 
 	"app.skyclerk.com/backend/library/avatar"
 	"app.skyclerk.com/backend/library/files"
+	"app.skyclerk.com/backend/library/helpers"
 	"app.skyclerk.com/backend/library/store/object"
 	"app.skyclerk.com/backend/services"
 
@@ -27,36 +28,42 @@ import (
 
 // Conact struct
 type Contact struct {
-	Id            uint      `gorm:"primary_key;column:ContactsId" json:"id"`
-	AccountId     uint      `gorm:"column:ContactsAccountId" sql:"not null" json:"account_id"`
-	UpdatedAt     time.Time `gorm:"column:ContactsUpdatedAt" sql:"not null" json:"_"`
-	CreatedAt     time.Time `gorm:"column:ContactsCreatedAt" sql:"not null" json:"_"`
-	Name          string    `gorm:"column:ContactsName" sql:"not null" json:"name"`
-	FirstName     string    `gorm:"column:ContactsFirstName" sql:"not null" json:"first_name"`
-	LastName      string    `gorm:"column:ContactsLastName" sql:"not null" json:"last_name"`
-	Address       string    `gorm:"column:ContactsAddress" sql:"not null" json:"address"`
-	City          string    `gorm:"column:ContactsCity" sql:"not null" json:"city"`
-	State         string    `gorm:"column:ContactsState" sql:"not null" json:"state"`
-	Zip           string    `gorm:"column:ContactsZip" sql:"not null" json:"zip"`
-	Phone         string    `gorm:"column:ContactsPhone" sql:"not null" json:"phone"`
-	Fax           string    `gorm:"column:ContactsFax" sql:"not null" json:"fax"`
-	Website       string    `gorm:"column:ContactsWebsite" sql:"not null" json:"website"`
-	AccountNumber string    `gorm:"column:ContactsAccountNumber" sql:"not null" json:"account_number"`
-	Avatar        string    `gorm:"column:ContactsAvatar" sql:"not null" json:"_"`
-	AvatarChecked string    `gorm:"column:ContactsAvatarChecked" sql:"not null;default:'No'" json:"_"` // This means someone has not uploaded an image we have just generated on. So we can update it if we want.
-	AvatarUrl     string    `gorm:"-" json:"avatar_url"`                                                                      // Not stored in DB.
-	Email         string    `gorm:"column:ContactsEmail" sql:"not null" json:"email"`
-	Twitter       string    `gorm:"column:ContactsTwitter" sql:"not null" json:"twitter"`
-	Facebook      string    `gorm:"column:ContactsFacebook" sql:"not null" json:"facebook"`
-	Linkedin      string    `gorm:"column:ContactsLinkedin" sql:"not null" json:"linkedin"`
-	HrId          uint64    `gorm:"column:ContactsHrId" sql:"not null" json:"_"`
-	PricingPlanId uint      `gorm:"column:ContactsPricingPlanId" sql:"not null" json:"_"`
-	GatewayId     uint      `gorm:"column:ContactsGatewayId" sql:"not null" json:"_"`
-	CardMask      string    `gorm:"column:ContactsCardMask" sql:"not null" json:"_"`
-	CardType      string    `gorm:"column:ContactsCardType" sql:"not null" json:"_"`
-	CardExpire    string    `gorm:"column:ContactsCardExpire" sql:"not null" json:"_"`
-	Country       string    `gorm:"column:ContactsCountry" sql:"not null" json:"country"`
-	StripeCustID  string    `sql:"not null" json:"-"`
+	Id            uint       `gorm:"primary_key;column:ContactsId" json:"id"`
+	AccountId     uint       `gorm:"column:ContactsAccountId" sql:"not null" json:"account_id"`
+	UpdatedAt     time.Time  `gorm:"column:ContactsUpdatedAt" sql:"not null" json:"_"`
+	CreatedAt     time.Time  `gorm:"column:ContactsCreatedAt" sql:"not null" json:"_"`
+	DeletedAt     *time.Time `gorm:"column:ContactsDeletedAt;index:ContactsDeletedAt" json:"-"`
+	Name          string     `gorm:"column:ContactsName" sql:"not null" json:"name"`
+	FirstName     string     `gorm:"column:ContactsFirstName" sql:"not null" json:"first_name"`
+	LastName      string     `gorm:"column:ContactsLastName" sql:"not null" json:"last_name"`
+	Address       string     `gorm:"column:ContactsAddress" sql:"not null" json:"address"`
+	City          string     `gorm:"column:ContactsCity" sql:"not null" json:"city"`
+	State         string     `gorm:"column:ContactsState" sql:"not null" json:"state"`
+	Zip           string     `gorm:"column:ContactsZip" sql:"not null" json:"zip"`
+	Phone         string     `gorm:"column:ContactsPhone" sql:"not null" json:"phone"`
+	Fax           string     `gorm:"column:ContactsFax" sql:"not null" json:"fax"`
+	Website       string     `gorm:"column:ContactsWebsite" sql:"not null" json:"website"`
+	AccountNumber string     `gorm:"column:ContactsAccountNumber" sql:"not null" json:"account_number"`
+	Avatar        string     `gorm:"column:ContactsAvatar" sql:"not null" json:"_"`
+	AvatarChecked string     `gorm:"column:ContactsAvatarChecked" sql:"not null;default:'No'" json:"_"` // This means someone has not uploaded an image we have just generated on. So we can update it if we want.
+	AvatarUrl     string     `gorm:"-" json:"avatar_url"`                                               // Not stored in DB.
+	Email         string     `gorm:"column:ContactsEmail" sql:"not null" json:"email"`
+	Twitter       string     `gorm:"column:ContactsTwitter" sql:"not null" json:"twitter"`
+	Facebook      string     `gorm:"column:ContactsFacebook" sql:"not null" json:"facebook"`
+	Linkedin      string     `gorm:"column:ContactsLinkedin" sql:"not null" json:"linkedin"`
+	HrId          uint64     `gorm:"column:ContactsHrId" sql:"not null" json:"_"`
+	PricingPlanId uint       `gorm:"column:ContactsPricingPlanId" sql:"not null" json:"_"`
+	GatewayId     uint       `gorm:"column:ContactsGatewayId" sql:"not null" json:"_"`
+	CardMask      string     `gorm:"column:ContactsCardMask" sql:"not null" json:"_"`
+	CardType      string     `gorm:"column:ContactsCardType" sql:"not null" json:"_"`
+	CardExpire    string     `gorm:"column:ContactsCardExpire" sql:"not null" json:"_"`
+	Country       string     `gorm:"column:ContactsCountry" sql:"not null" json:"country"`
+	StripeCustID  string     `sql:"not null" json:"-"`
+	Is1099        bool       `gorm:"column:ContactsIs1099" sql:"not null;default:0" json:"is_1099"` // A contractor we send a 1099-NEC to.
+	TaxIdType     string     `gorm:"column:ContactsTaxIdType" sql:"not null" json:"tax_id_type"`    // ein or ssn
+	TaxId         string     `gorm:"column:ContactsTaxId" sql:"not null;type:TEXT" json:"-"`        // Encrypted, see SetTaxId
+	TaxIdLast4    string     `gorm:"column:ContactsTaxIdLast4" sql:"not null" json:"tax_id_last4"`
+	TaxIdPost     *string    `gorm:"-" json:"tax_id,omitempty"` // Only used to set the tax id. Nil leaves it alone, empty clears it.
 }
 
 // generateAvatarsWorkerJob struct
@@ -86,9 +93,94 @@ func (a Contact) Validate(db Datastore, action string, userId uint, accountId ui
 		validation.Field(&a.Name,
 			validation.By(func(value interface{}) error { return db.ValidateContactNameOrFirstLast(a, accountId, objId, action) }),
 		),
+
+		validation.Field(&a.TaxIdType,
+			validation.In("ein", "ssn").Error("The tax id type must be ein or ssn."),
+		),
+
+		validation.Field(&a.TaxIdPost,
+			validation.By(func(value interface{}) error { return ValidateContactTaxId(a.TaxIdPost) }),
+		),
 	)
 }
 
+//
+// ValidateContactTaxId - A tax id is 9 digits. Dashes and spaces are fine.
+//
+func ValidateContactTaxId(taxId *string) error {
+	if (taxId == nil) || (*taxId == "") {
+		return nil
+	}
+
+	clean := cleanTaxId(*taxId)
+
+	if len(clean) != 9 {
+		return errors.New("The tax id must be 9 digits.")
+	}
+
+	for _, row := range clean {
+		if row < '0' || row > '9' {
+			return errors.New("The tax id must be 9 digits.")
+		}
+	}
+
+	return nil
+}
+
+//
+// SetTaxId - Encrypt and store a tax id. We keep the last 4 digits in the
+// clear so we can show which one is on file. An empty id clears it.
+//
+func (c *Contact) SetTaxId(taxId string) error {
+	taxId = cleanTaxId(taxId)
+
+	if taxId == "" {
+		c.TaxId = ""
+		c.TaxIdLast4 = ""
+		return nil
+	}
+
+	enc, err := helpers.Encrypt(taxId)
+
+	if err != nil {
+		return err
+	}
+
+	c.TaxId = enc
+	c.TaxIdLast4 = taxId[len(taxId)-4:]
+	return nil
+}
+
+//
+// GetTaxId - Decrypt the tax id. Formatted like 12-3456789 for an ein and
+// 123-45-6789 for a ssn.
+//
+func (c Contact) GetTaxId() (string, error) {
+	if c.TaxId == "" {
+		return "", nil
+	}
+
+	taxId, err := helpers.Decrypt(c.TaxId)
+
+	if err != nil {
+		return "", err
+	}
+
+	if len(taxId) != 9 {
+		return taxId, nil
+	}
+
+	if c.TaxIdType == "ein" {
+		return taxId[:2] + "-" + taxId[2:], nil
+	}
+
+	if c.TaxIdType == "ssn" {
+		return taxId[:3] + "-" + taxId[3:5] + "-" + taxId[5:], nil
+	}
+
+	return taxId, nil
+}
+
 //
 // CreateContact
 //
@@ -117,6 +209,9 @@ func (db *DB) CreateContact(contact *Contact) error {
 	// Add a signed avatar path
 	contact.AvatarUrl = db.GetSignedFileUrl(contact.Avatar)
 
+	// Add to the search index
+	db.indexContactDoc(*contact)
+
 	// Return happy
 	return nil
 }
@@ -211,17 +306,33 @@ func (db *DB) GetContactByAccountAndId(accountId uint, conId uint) (Contact, err
 }
 
 //
-// DeleteContactByAccountAndId - Delete a contact by account and id.
+// DeleteContactByAccountAndId - Move a contact to the trash by account and id.
 //
 func (db *DB) DeleteContactByAccountAndId(accountId uint, contactId uint) error {
-	// Make query to see if we have ledger entries with this contact
-	if !db.New().Where("LedgerAccountId = ? AND LedgerContactId = ?", accountId, contactId).First(&Ledger{}).RecordNotFound() {
+	// Make query to see if we have ledger entries with this contact. Entries in the trash count too.
+	if !db.New().Unscoped().Where("LedgerAccountId = ? AND LedgerContactId = ?", accountId, contactId).First(&Ledger{}).RecordNotFound() {
 		return errors.New("Can not delete contact. It is in use by a ledger entry.")
 	}
 
+	c := Contact{}
+
+	if db.New().Where("ContactsAccountId = ? AND ContactsId = ?", accountId, contactId).First(&c).RecordNotFound() {
+		return errors.New("Contact not found.")
+	}
+
 	// Make query
 	db.New().Where("ContactsAccountId = ? AND ContactsId = ?", accountId, contactId).Delete(Contact{})
 
+	// Add to the trash
+	name := c.Name
+
+	if len(name) == 0 {
+		name = strings.TrimSpace(c.FirstName + " " + c.LastName)
+	}
+
+	db.addToTrash(accountId, "contact", contactId, name, 0, TrashLinks{})
+	db.RemoveFromSearch(accountId, "contact", contactId)
+
 	// Return result
 	return nil
 }
@@ -446,4 +557,13 @@ func GenerateAndStoreAvatar(accountId uint, contactId uint, name string, email s
 	return up, nil
 }
 
+// ----------------- Private Helper Funcs -------------- //
+
+//
+// cleanTaxId - Drop the dashes and spaces people type in tax ids.
+//
+func cleanTaxId(taxId string) string {
+	return strings.NewReplacer("-", "", " ", "").Replace(strings.TrimSpace(taxId))
+}
+
 /* End File */
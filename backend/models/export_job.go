@@ -0,0 +1,200 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package models
+
+import (
+	"errors"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// How long a finished export can be downloaded.
+const ExportJobExpires = 7 * 24 * time.Hour
+
+// ExportJob struct - A file we build in the background, like the year-end
+// bundle for an accountant. The cron picks up pending jobs.
+type ExportJob struct {
+	Id          uint      `gorm:"primary_key" json:"id"`
+	CreatedAt   time.Time `sql:"not null" json:"created_at"`
+	UpdatedAt   time.Time `sql:"not null" json:"updated_at"`
+	AccountId   uint      `sql:"not null;index:account_id" json:"account_id"`
+	UserId      uint      `sql:"not null" json:"user_id"` // Who asked for it. We email them when it is ready.
+	Type        string    `sql:"not null" json:"type"`    // year-end
+	Year        int       `sql:"not null" json:"year"`
+	Status      string    `sql:"not null;default:'pending';index:status" json:"status"` // pending, running, done, failed or expired
+	Progress    int       `sql:"not null" json:"progress"`                              // 0 - 100
+	Message     string    `sql:"not null;type:TEXT" json:"message"`                     // Why it failed.
+	Path        string    `sql:"not null" json:"-"`                                     // Where the file is in the object store.
+	Size        int64     `sql:"not null" json:"size"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	DownloadUrl string    `gorm:"-" json:"download_url"` // Not stored in DB.
+}
+
+//
+// Validate for this model.
+//
+func (a ExportJob) Validate(db Datastore, action string, userId uint, accountId uint, objId uint) error {
+	return validation.ValidateStruct(&a,
+
+		validation.Field(&a.Type,
+			validation.Required.Error("The type field is required."),
+			validation.In("year-end").Error("The type field must be year-end."),
+		),
+
+		validation.Field(&a.Year,
+			validation.Required.Error("The year field is required."),
+			validation.Min(1900).Error("The year field must be a year."),
+			validation.Max(time.Now().Year()).Error("The year field can not be in the future."),
+			validation.By(func(value interface{}) error {
+				count := 0
+				db.New().Model(&ExportJob{}).Where("account_id = ? AND type = ? AND year = ? AND status IN (?)", accountId, a.Type, a.Year, []string{"pending", "running"}).Count(&count)
+
+				if count > 0 {
+					return errors.New("This export is already being built.")
+				}
+
+				return nil
+			}),
+		),
+	)
+}
+
+//
+// CreateExportJob - Queue up a new export.
+//
+func (db *DB) CreateExportJob(j *ExportJob) error {
+	j.Status = "pending"
+	j.Progress = 0
+	return db.New().Create(j).Error
+}
+
+//
+// GetExportJobsByAccount - Newest first.
+//
+func (db *DB) GetExportJobsByAccount(accountId uint) []ExportJob {
+	jobs := []ExportJob{}
+	db.New().Where("account_id = ?", accountId).Order("id DESC").Find(&jobs)
+
+	for key := range jobs {
+		db.setExportJobDownloadUrl(&jobs[key])
+	}
+
+	return jobs
+}
+
+//
+// GetExportJobByAccountAndId - An export by account and id.
+//
+func (db *DB) GetExportJobByAccountAndId(accountId uint, id uint) (ExportJob, error) {
+	j := ExportJob{}
+
+	if db.New().Where("account_id = ? AND id = ?", accountId, id).First(&j).RecordNotFound() {
+		return ExportJob{}, errors.New("Export not found.")
+	}
+
+	db.setExportJobDownloadUrl(&j)
+
+	return j, nil
+}
+
+//
+// GetPendingExportJobs - Jobs waiting to be built, oldest first.
+//
+func (db *DB) GetPendingExportJobs() []ExportJob {
+	jobs := []ExportJob{}
+	db.New().Where("status = ?", "pending").Order("id ASC").Find(&jobs)
+	return jobs
+}
+
+//
+// ClaimExportJob - Move a job from pending to running. Returns false if
+// someone else got to it first.
+//
+func (db *DB) ClaimExportJob(j *ExportJob) bool {
+	rows := db.New().Model(&ExportJob{}).Where("id = ? AND status = ?", j.Id, "pending").Updates(map[string]interface{}{"status": "running", "progress": 0}).RowsAffected
+
+	if rows != 1 {
+		return false
+	}
+
+	j.Status = "running"
+	j.Progress = 0
+
+	return true
+}
+
+//
+// ExportJobProgress - Save how far along a running job is.
+//
+func (db *DB) ExportJobProgress(j *ExportJob, progress int) {
+	j.Progress = progress
+	db.New().Model(&ExportJob{}).Where("id = ?", j.Id).Update("progress", progress)
+}
+
+//
+// ExportJobDone - The file is built and uploaded.
+//
+func (db *DB) ExportJobDone(j *ExportJob, path string, size int64) error {
+	j.Status = "done"
+	j.Progress = 100
+	j.Path = path
+	j.Size = size
+	j.ExpiresAt = time.Now().Add(ExportJobExpires)
+	return db.New().Save(j).Error
+}
+
+//
+// ExportJobFailed - Something went wrong building the file.
+//
+func (db *DB) ExportJobFailed(j *ExportJob, err error) {
+	j.Status = "failed"
+	j.Message = err.Error()
+	db.New().Save(j)
+}
+
+//
+// ResetStaleExportJobs - Put jobs that have been running since before `since`
+// back in the queue. This happens when the server restarts mid build.
+//
+func (db *DB) ResetStaleExportJobs(since time.Time) {
+	db.New().Model(&ExportJob{}).Where("status = ? AND updated_at < ?", "running", since).Updates(map[string]interface{}{"status": "pending", "progress": 0})
+}
+
+//
+// GetExpiredExportJobs - Finished jobs past their download window.
+//
+func (db *DB) GetExpiredExportJobs(now time.Time) []ExportJob {
+	jobs := []ExportJob{}
+	db.New().Where("status = ? AND expires_at < ?", "done", now).Find(&jobs)
+	return jobs
+}
+
+//
+// ExportJobExpired - The file is gone from the object store.
+//
+func (db *DB) ExportJobExpired(j *ExportJob) {
+	j.Status = "expired"
+	j.Path = ""
+	db.New().Save(j)
+}
+
+// ----------------- Private Helper Funcs -------------- //
+
+//
+// setExportJobDownloadUrl - A signed link that works until the job expires.
+//
+func (db *DB) setExportJobDownloadUrl(j *ExportJob) {
+	if (j.Status != "done") || (len(j.Path) == 0) || time.Now().After(j.ExpiresAt) {
+		return
+	}
+
+	j.DownloadUrl = db.GetSignedFileUrlFor(j.Path, time.Until(j.ExpiresAt))
+}
+
+/* End File */
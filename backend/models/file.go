@@ -146,6 +146,14 @@ func (t *DB) StoreFile(accountId uint, filePath string) (File, error) {
 // This url is good for 5 mins.
 //
 func (t *DB) GetSignedFileUrl(path string) string {
+	return t.GetSignedFileUrlFor(path, 5*time.Minute)
+}
+
+//
+// GetSignedFileUrlFor - Same as GetSignedFileUrl but good for as long as we
+// say. Used for links that leave the app like exports.
+//
+func (t *DB) GetSignedFileUrlFor(path string, expires time.Duration) string {
 	// RUL we need to sign.
 	rawURL := os.Getenv("OBJECT_BASE_URL") + "/" + path
 
@@ -162,9 +170,9 @@ func (t *DB) GetSignedFileUrl(path string) string {
 	block, _ := pem.Decode(sDec)
 	key, _ := x509.ParsePKCS1PrivateKey(block.Bytes)
 
-	// Sign URL to be valid for 5 mins from now.
+	// Sign URL to be valid from now.
 	signer := sign.NewURLSigner(os.Getenv("AWS_CLOUDFRONT_KEY_ID"), key)
-	signedURL, err := signer.Sign(rawURL, time.Now().Add(5*time.Minute))
+	signedURL, err := signer.Sign(rawURL, time.Now().Add(expires))
 
 	if err != nil {
 		services.Info(err)
@@ -0,0 +1,210 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package models
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// ImportProfile struct - How the columns of a CSV file map to a ledger entry.
+// Column numbers start at 1. Zero means the column is not used.
+type ImportProfile struct {
+	Id             uint      `gorm:"primary_key" json:"id"`
+	CreatedAt      time.Time `sql:"not null" json:"-"`
+	UpdatedAt      time.Time `sql:"not null" json:"-"`
+	AccountId      uint      `sql:"not null;index:account_id" json:"account_id"`
+	Slug           string    `gorm:"-" json:"slug"` // Only set on built in profiles.
+	Name           string    `sql:"not null" json:"name"`
+	Delimiter      string    `sql:"not null;default:','" json:"delimiter"`
+	SkipRows       int       `sql:"not null" json:"skip_rows"` // Header rows to skip.
+	DateColumn     int       `sql:"not null" json:"date_column"`
+	DateFormat     string    `sql:"not null" json:"date_format"` // MM/DD/YYYY, DD/MM/YYYY, YYYY-MM-DD or empty to guess.
+	AmountColumn   int       `sql:"not null" json:"amount_column"`
+	DebitColumn    int       `sql:"not null" json:"debit_column"`
+	CreditColumn   int       `sql:"not null" json:"credit_column"`
+	SignConvention string    `sql:"not null;default:'normal'" json:"sign_convention"` // normal (negative is an expense) or inverted.
+	ContactColumn  int       `sql:"not null" json:"contact_column"`
+	NoteColumns    string    `sql:"not null" json:"note_columns"` // Comma separated. Values are joined with " : ".
+	CategoryColumn int       `sql:"not null" json:"category_column"`
+	CategoryName   string    `sql:"not null" json:"category_name"` // Used when there is no category column.
+	CategoryIrs    string    `sql:"not null" json:"category_irs"`  // Tax form line for the category name above when we create it.
+	CategoryShow   string    `sql:"not null" json:"-"`
+	LabelsColumn   int       `sql:"not null" json:"labels_column"`
+	LabelName      string    `sql:"not null" json:"label_name"` // Added to every entry.
+	FilterColumn   int       `sql:"not null" json:"filter_column"`
+	FilterValue    string    `sql:"not null" json:"filter_value"`   // Only import rows where the filter column matches.
+	DedupeColumns  string    `sql:"not null" json:"dedupe_columns"` // Comma separated. Empty means date, amount, contact and note.
+}
+
+//
+// Validate for this model.
+//
+func (a ImportProfile) Validate(db Datastore, action string, userId uint, accountId uint, objId uint) error {
+	return validation.ValidateStruct(&a,
+
+		validation.Field(&a.Name,
+			validation.Required.Error("The name field is required."),
+		),
+
+		validation.Field(&a.Delimiter,
+			validation.In(",", ";", "|", "\t").Error("The delimiter field must be a comma, semicolon, pipe, or tab."),
+		),
+
+		validation.Field(&a.SkipRows,
+			validation.Min(0).Error("The skip_rows field must be a positive number."),
+		),
+
+		validation.Field(&a.DateColumn,
+			validation.Required.Error("The date_column field is required."),
+			validation.Min(1).Error("The date_column field must be 1 or more."),
+		),
+
+		validation.Field(&a.DateFormat,
+			validation.In("MM/DD/YYYY", "DD/MM/YYYY", "YYYY-MM-DD").Error("The date_format field must be MM/DD/YYYY, DD/MM/YYYY, or YYYY-MM-DD."),
+		),
+
+		validation.Field(&a.AmountColumn,
+			validation.By(func(value interface{}) error {
+				if (a.AmountColumn <= 0) && ((a.DebitColumn <= 0) || (a.CreditColumn <= 0)) {
+					return errors.New("An amount column or debit and credit columns are required.")
+				}
+				return nil
+			}),
+		),
+
+		validation.Field(&a.SignConvention,
+			validation.In("normal", "inverted").Error("The sign_convention field must be normal or inverted."),
+		),
+
+		validation.Field(&a.ContactColumn,
+			validation.Required.Error("The contact_column field is required."),
+			validation.Min(1).Error("The contact_column field must be 1 or more."),
+		),
+
+		validation.Field(&a.NoteColumns,
+			validation.By(func(value interface{}) error { return validateColumnList(a.NoteColumns, "note_columns") }),
+		),
+
+		validation.Field(&a.DedupeColumns,
+			validation.By(func(value interface{}) error { return validateColumnList(a.DedupeColumns, "dedupe_columns") }),
+		),
+
+		validation.Field(&a.CategoryIrs,
+			validation.By(func(value interface{}) error {
+				if _, ok := GetTaxLine(a.CategoryIrs); (len(a.CategoryIrs) > 0) && !ok {
+					return errors.New("The category_irs field must be a known tax form line.")
+				}
+				return nil
+			}),
+		),
+	)
+}
+
+//
+// ImportProfileCreate - Create a new import profile.
+//
+func (db *DB) ImportProfileCreate(p *ImportProfile) error {
+	prepImportProfileVars(p)
+	db.New().Create(p)
+	return nil
+}
+
+//
+// ImportProfileUpdate - Update an import profile.
+//
+func (db *DB) ImportProfileUpdate(p *ImportProfile) error {
+	prepImportProfileVars(p)
+	db.New().Save(p)
+	return nil
+}
+
+//
+// GetImportProfileByAccountAndId by account and id.
+//
+func (db *DB) GetImportProfileByAccountAndId(accountId uint, id uint) (ImportProfile, error) {
+	p := ImportProfile{}
+
+	// Make query
+	if db.New().Where("account_id = ? AND id = ?", accountId, id).First(&p).RecordNotFound() {
+		return ImportProfile{}, errors.New("Import profile not found.")
+	}
+
+	// Return result
+	return p, nil
+}
+
+//
+// DeleteImportProfileByAccountAndId - Delete an import profile by account and id.
+//
+func (db *DB) DeleteImportProfileByAccountAndId(accountId uint, id uint) error {
+	db.New().Where("account_id = ? AND id = ?", accountId, id).Delete(ImportProfile{})
+	return nil
+}
+
+//
+// Columns turns a comma separated list of columns into ints.
+//
+func (a ImportProfile) Columns(list string) []int {
+	cols := []int{}
+
+	for _, row := range strings.Split(list, ",") {
+		col, err := strconv.Atoi(strings.TrimSpace(row))
+
+		if (err == nil) && (col > 0) {
+			cols = append(cols, col)
+		}
+	}
+
+	return cols
+}
+
+// ----------------- Private Helper Funcs -------------- //
+
+//
+// prepImportProfileVars - Clean up and set defaults.
+//
+func prepImportProfileVars(p *ImportProfile) {
+	p.Name = strings.TrimSpace(p.Name)
+	p.CategoryName = strings.TrimSpace(p.CategoryName)
+	p.CategoryIrs = strings.TrimSpace(p.CategoryIrs)
+	p.LabelName = strings.TrimSpace(p.LabelName)
+
+	if len(p.Delimiter) == 0 {
+		p.Delimiter = ","
+	}
+
+	if len(p.SignConvention) == 0 {
+		p.SignConvention = "normal"
+	}
+}
+
+//
+// validateColumnList - Make sure a comma separated list of columns is all numbers.
+//
+func validateColumnList(list string, field string) error {
+	if len(strings.TrimSpace(list)) == 0 {
+		return nil
+	}
+
+	for _, row := range strings.Split(list, ",") {
+		col, err := strconv.Atoi(strings.TrimSpace(row))
+
+		if (err != nil) || (col < 1) {
+			return errors.New("The " + field + " field must be a comma separated list of column numbers.")
+		}
+	}
+
+	return nil
+}
+
+/* End File */
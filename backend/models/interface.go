@@ -8,7 +8,11 @@
 
 package models
 
-import "github.com/jinzhu/gorm"
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
 
 // Datastore interface
 type Datastore interface {
@@ -18,6 +22,7 @@ type Datastore interface {
 	// Generic database functions
 	Query(model interface{}, params QueryParam) error
 	QueryMeta(model interface{}, params QueryParam) (QueryMetaData, error)
+	QuerySubQuery(model interface{}, col string, params QueryParam) (interface{}, error)
 	QueryWithNoFilterCount(model interface{}, params QueryParam) (int, error)
 	GetQueryMetaData(noLimitCount int, params QueryParam) QueryMetaData
 
@@ -38,9 +43,135 @@ type Datastore interface {
 	LedgerUpdate(ledger *Ledger) error
 	DeleteLedgerByAccountAndId(accountId uint, id uint) error
 	GetLedgerByAccountAndId(accountId uint, id uint) (Ledger, error)
+	EachLedgerBatch(params QueryParam, size int, fn func([]Ledger) error) error
 	AddFileToLedgerEntry(accountId uint, ledgerId uint, fileId uint) error
 	ValidateLedgerContact(ledger Ledger, accountId uint, objId uint, action string) error
 	ValidateLedgerCategory(ledger Ledger, accountId uint, objId uint, action string) error
+	ValidateLedgerSplits(ledger Ledger, accountId uint, objId uint, action string) error
+	ValidateLedgerChartAccount(accountId uint, chartAccountId uint) error
+	ValidateLedgerTransfer(ledger Ledger, accountId uint) error
+
+	// Recurring
+	RecurringCreate(r *Recurring) error
+	RecurringUpdate(org Recurring, r *Recurring) error
+	RecurringRun(r *Recurring, now time.Time) ([]Ledger, error)
+	GetRecurringDue(now time.Time) []Recurring
+	GetRecurringByAccountAndId(accountId uint, id uint) (Recurring, error)
+	DeleteRecurringByAccountAndId(accountId uint, id uint) error
+
+	// Import Profiles
+	ImportProfileCreate(p *ImportProfile) error
+	ImportProfileUpdate(p *ImportProfile) error
+	GetImportProfileByAccountAndId(accountId uint, id uint) (ImportProfile, error)
+	DeleteImportProfileByAccountAndId(accountId uint, id uint) error
+
+	// Rules
+	RuleCreate(r *Rule) error
+	RuleUpdate(r *Rule) error
+	GetRuleByAccountAndId(accountId uint, id uint) (Rule, error)
+	DeleteRuleByAccountAndId(accountId uint, id uint) error
+	ApplyLedgerRules(ledger *Ledger) uint
+	ReapplyLedgerRules(accountId uint, userId uint, dryRun bool) []RuleChange
+
+	// Reconciliations
+	ReconciliationCreate(r *Reconciliation) error
+	ReconciliationUpdate(r *Reconciliation) error
+	GetReconciliationByAccountAndId(accountId uint, id uint) (Reconciliation, error)
+	GetOpenReconciliation(accountId uint) (Reconciliation, error)
+	DeleteReconciliationByAccountAndId(accountId uint, id uint) error
+	SetLedgersCleared(r Reconciliation, ledgerIds []uint, cleared bool) (Reconciliation, error)
+	FinishReconciliation(r *Reconciliation) error
+	UndoReconciliation(r *Reconciliation) error
+	ValidateReconciliationStatement(r Reconciliation, accountId uint, objId uint, action string) error
+	ValidateLedgerUnlocked(ledger Ledger) error
+
+	// Closed Periods
+	PeriodLockCreate(p *PeriodLock) error
+	GetPeriodLockByAccountAndId(accountId uint, id uint) (PeriodLock, error)
+	ReopenPeriodLock(p PeriodLock, userId uint) error
+	SetBooksClosedThrough(account *Account, userId uint, through time.Time) error
+	ValidateLedgerPeriodOpen(accountId uint, date time.Time) error
+	MoveLedgerToOpenPeriod(ledger *Ledger) bool
+
+	// Ledger Revisions
+	CreateLedgerRevision(accountId uint, ledgerId uint, userId uint, ip string, action string) error
+	GetLedgerRevisions(accountId uint, ledgerId uint) []LedgerRevision
+	GetLedgerRevisionByAccountAndId(accountId uint, id uint) (LedgerRevision, error)
+	RestoreLedgerRevision(ledger *Ledger, rev LedgerRevision) error
+
+	// Ledger Filters
+	GetLedgerFilterWhere(accountId uint, userId uint, f LedgerFilter) (KeyValue, error)
+
+	// Ledger Views
+	LedgerViewCreate(v *LedgerView) error
+	LedgerViewUpdate(v *LedgerView) error
+	GetLedgerViewsByAccountAndUser(accountId uint, userId uint) []LedgerView
+	GetLedgerViewByAccountAndId(accountId uint, userId uint, id uint) (LedgerView, error)
+	DeleteLedgerViewByAccountAndId(accountId uint, id uint) error
+
+	// Ledger Bulk
+	LedgerBulkUpdate(accountId uint, userId uint, ip string, b LedgerBulk) ([]LedgerBulkResult, error)
+
+	// Trash
+	GetTrashByAccount(accountId uint, itemType string) []TrashItem
+	GetTrashItemByAccountAndId(accountId uint, id uint) (TrashItem, error)
+	RestoreTrashItem(t TrashItem) error
+	PurgeTrash(before time.Time) int
+
+	// Export Jobs
+	CreateExportJob(j *ExportJob) error
+	GetExportJobsByAccount(accountId uint) []ExportJob
+	GetExportJobByAccountAndId(accountId uint, id uint) (ExportJob, error)
+	GetPendingExportJobs() []ExportJob
+	ClaimExportJob(j *ExportJob) bool
+	ExportJobProgress(j *ExportJob, progress int)
+	ExportJobDone(j *ExportJob, path string, size int64) error
+	ExportJobFailed(j *ExportJob, err error)
+	ResetStaleExportJobs(since time.Time)
+	GetExpiredExportJobs(now time.Time) []ExportJob
+	ExportJobExpired(j *ExportJob)
+
+	// Archive Maps
+	GetArchiveMap(accountId uint, source string, objType string) map[uint]uint
+	CreateArchiveMap(accountId uint, source string, objType string, oldId uint, newId uint) error
+
+	// Chart Of Accounts
+	ChartAccountCreate(a *ChartAccount) error
+	ChartAccountUpdate(a *ChartAccount) error
+	GetChartAccountsByAccount(accountId uint) []ChartAccount
+	GetChartAccountByAccountAndId(accountId uint, id uint) (ChartAccount, error)
+	GetSystemChartAccount(accountId uint, system string) ChartAccount
+	ChartAccountInUse(accountId uint, id uint) bool
+	DeleteChartAccountByAccountAndId(accountId uint, id uint) error
+	PostLedgerJournal(accountId uint, ledgerId uint) error
+	RebuildJournal(accountId uint) int
+
+	// Budgets
+	BudgetCreate(b *Budget) error
+	BudgetUpdate(b *Budget) error
+	GetBudgetsByAccount(accountId uint) []Budget
+	GetBudgetByAccountAndId(accountId uint, id uint) (Budget, error)
+	GetBudgetAccountIds() []uint
+	BudgetAlertSent(b *Budget, start string, level int)
+	DeleteBudgetByAccountAndId(accountId uint, id uint) error
+
+	// Report Subscriptions
+	ReportSubscriptionCreate(s *ReportSubscription) error
+	GetReportSubscriptionsByAccountAndUser(accountId uint, userId uint) []ReportSubscription
+	GetReportSubscriptionById(id uint) (ReportSubscription, error)
+	GetReportSubscriptions() []ReportSubscription
+	ReportSubscriptionSent(s *ReportSubscription, start string)
+	DeleteReportSubscriptionByAccountUserAndId(accountId uint, userId uint, id uint) error
+
+	// Search
+	Search(accountId uint, query string, types []string, limit int) []SearchResult
+	SearchLedgerWhere(accountId uint, query string) KeyValue
+	IndexLedger(accountId uint, ledgerId uint)
+	IndexContact(accountId uint, contactId uint)
+	IndexLabel(accountId uint, labelId uint)
+	IndexCategory(accountId uint, categoryId uint)
+	RemoveFromSearch(accountId uint, docType string, objectId uint)
+	ReindexSearch(accountId uint) int
 
 	// Category
 	LoadDefaultCategories(accountId uint)
@@ -50,6 +181,8 @@ type Datastore interface {
 	GetCategoryByAccountAndId(accountId uint, categoryId uint) (Category, error)
 	GetCategoryUsageByAccount(accountId uint) []CategoryUsage
 	GetOrCreateCategory(accountID uint, name string, catType string) Category
+	GetCategoryForContact(accountId uint, contactId uint, catType string) (Category, error)
+	GetLedgerSuggestions(accountId uint, contact string, amount float64) LedgerSuggestions
 
 	// Contact
 	GenerateAvatarsForAllMissing() error
@@ -89,6 +222,7 @@ type Datastore interface {
 
 	// File
 	GetSignedFileUrl(path string) string
+	GetSignedFileUrlFor(path string, expires time.Duration) string
 	CleanFileName(fileName string) string
 	StoreFile(accountId uint, filePath string) (File, error)
 	GetFileByAccountAndId(accountId uint, id uint) (File, error)
@@ -0,0 +1,164 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// JournalLine struct - One debit or credit. Every ledger entry posts two or
+// more lines that add up to zero. The ledger is where people work, these are
+// rebuilt from it every time an entry changes.
+type JournalLine struct {
+	Id             uint      `gorm:"primary_key" json:"id"`
+	CreatedAt      time.Time `sql:"not null" json:"-"`
+	AccountId      uint      `sql:"not null;index:account_id" json:"account_id"`
+	LedgerId       uint      `sql:"not null;index:ledger_id" json:"ledger_id"`
+	ChartAccountId uint      `sql:"not null;index:chart_account_id" json:"chart_account_id"`
+	Date           time.Time `sql:"not null" json:"date"`
+	Debit          float64   `sql:"not null;type:DECIMAL(12,2)" json:"debit"`
+	Credit         float64   `sql:"not null;type:DECIMAL(12,2)" json:"credit"`
+	Memo           string    `sql:"not null;type:TEXT" json:"memo"`
+}
+
+//
+// PostLedgerJournal - Replace the journal lines for a ledger entry.
+//
+// Money in debits the entry's chart account (Cash if not set) and money out
+// credits it. The other side goes to the transfer account, or to the income
+// and expense accounts of the entry's category or split lines.
+//
+func (db *DB) PostLedgerJournal(accountId uint, ledgerId uint) error {
+	l := Ledger{}
+
+	// Trashed entries keep their lines so a restore just works. Reports skip them.
+	if db.New().Unscoped().Preload("Splits").Where("LedgerAccountId = ? AND LedgerId = ?", accountId, ledgerId).First(&l).RecordNotFound() {
+		return errors.New("Ledger entry not found.")
+	}
+
+	db.deleteJournalLines(ledgerId)
+
+	// The bank side
+	bank := db.GetSystemChartAccount(accountId, "cash")
+
+	if l.ChartAccountId > 0 {
+		bank = ChartAccount{Id: l.ChartAccountId}
+	}
+
+	lines := []JournalLine{newJournalLine(l, bank.Id, l.Amount, l.Note)}
+
+	// The other side
+	switch {
+	case l.TransferChartAccountId > 0:
+		lines = append(lines, newJournalLine(l, l.TransferChartAccountId, -l.Amount, l.Note))
+
+	case len(l.Splits) > 0:
+		for _, row := range l.Splits {
+			memo := row.Note
+
+			if len(memo) == 0 {
+				memo = l.Note
+			}
+
+			lines = append(lines, newJournalLine(l, db.chartAccountForCategory(accountId, row.CategoryId, row.Amount).Id, -row.Amount, memo))
+		}
+
+	default:
+		lines = append(lines, newJournalLine(l, db.chartAccountForCategory(accountId, l.CategoryId, l.Amount).Id, -l.Amount, l.Note))
+	}
+
+	for key := range lines {
+		if err := db.New().Create(&lines[key]).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+//
+// RebuildJournal - Post every ledger entry again. Pass 0 to rebuild every
+// account. Returns the number of entries posted.
+//
+// go run main.go -cmd=journal-rebuild -account_id=4992
+//
+func (db *DB) RebuildJournal(accountId uint) int {
+	count := 0
+
+	accountIds := []uint{accountId}
+
+	if accountId == 0 {
+		accountIds = []uint{}
+		db.New().Model(&Account{}).Pluck("id", &accountIds)
+	}
+
+	for _, acct := range accountIds {
+		db.New().Where("account_id = ?", acct).Delete(JournalLine{})
+
+		ids := []uint{}
+		db.New().Unscoped().Model(&Ledger{}).Where("LedgerAccountId = ?", acct).Pluck("LedgerId", &ids)
+
+		for _, row := range ids {
+			if db.PostLedgerJournal(acct, row) == nil {
+				count++
+			}
+		}
+	}
+
+	return count
+}
+
+//
+// MigrateJournal - Post the journal for accounts that have ledger entries but
+// no journal lines yet, like every account from before the journal. Accounts
+// already posted are left alone.
+//
+func (db *DB) MigrateJournal() {
+	accountIds := []uint{}
+
+	db.New().Model(&Ledger{}).
+		Where("LedgerAccountId NOT IN (SELECT DISTINCT account_id FROM journal_lines)").
+		Pluck("DISTINCT LedgerAccountId", &accountIds)
+
+	for _, row := range accountIds {
+		db.RebuildJournal(row)
+	}
+}
+
+// ----------------- Private Helper Funcs -------------- //
+
+//
+// newJournalLine - A positive amount is a debit, a negative amount a credit.
+//
+func newJournalLine(l Ledger, chartAccountId uint, amount float64, memo string) JournalLine {
+	line := JournalLine{
+		AccountId:      l.AccountId,
+		LedgerId:       l.Id,
+		ChartAccountId: chartAccountId,
+		Date:           l.Date,
+		Memo:           memo,
+	}
+
+	if amount > 0 {
+		line.Debit = amount
+	} else {
+		line.Credit = -amount
+	}
+
+	return line
+}
+
+//
+// deleteJournalLines removes the journal lines for a ledger entry.
+//
+func (db *DB) deleteJournalLines(ledgerId uint) {
+	db.New().Where("ledger_id = ?", ledgerId).Delete(JournalLine{})
+}
+
+/* End File */
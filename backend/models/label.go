@@ -24,13 +24,14 @@ type LabelUsage struct {
 
 // Label struct
 type Label struct {
-	Id        uint      `gorm:"primary_key;column:LabelsId" json:"id"`
-	AccountId uint      `gorm:"column:LabelsAccountId" sql:"not null" json:"account_id"`
-	UpdatedAt time.Time `gorm:"column:LabelsUpdatedAt" sql:"not null" json:"_"`
-	CreatedAt time.Time `gorm:"column:LabelsCreatedAt" sql:"not null" json:"_"`
-	Name      string    `gorm:"column:LabelsName" sql:"not null;" json:"name"`
-	System    uint      `gorm:"column:LabelsSystem" sql:"not null" json:"_"`
-	Count     int       `gorm:"-" sql:"not null" json:"count"`
+	Id        uint       `gorm:"primary_key;column:LabelsId" json:"id"`
+	AccountId uint       `gorm:"column:LabelsAccountId" sql:"not null" json:"account_id"`
+	UpdatedAt time.Time  `gorm:"column:LabelsUpdatedAt" sql:"not null" json:"_"`
+	CreatedAt time.Time  `gorm:"column:LabelsCreatedAt" sql:"not null" json:"_"`
+	DeletedAt *time.Time `gorm:"column:LabelsDeletedAt;index:LabelsDeletedAt" json:"-"`
+	Name      string     `gorm:"column:LabelsName" sql:"not null;" json:"name"`
+	System    uint       `gorm:"column:LabelsSystem" sql:"not null" json:"_"`
+	Count     int        `gorm:"-" sql:"not null" json:"count"`
 }
 
 //
@@ -105,6 +106,7 @@ func (db *DB) GetOrCreateLabel(accountID uint, name string) Label {
 		label.Name = name
 		label.AccountId = accountID
 		db.New().Save(&label)
+		db.indexLabelDoc(label)
 	}
 
 	return label
@@ -141,15 +143,32 @@ func (db *DB) GetLabelByAccountAndName(accountId uint, name string) (Label, erro
 }
 
 //
-// DeleteLabelByAccountAndId - Delete a label by account and id.
+// DeleteLabelByAccountAndId - Move a label to the trash by account and id.
 //
 func (db *DB) DeleteLabelByAccountAndId(accountId uint, labelId uint) error {
+	lb := Label{}
+
+	if db.New().Where("LabelsAccountId = ? AND LabelsId = ?", accountId, labelId).First(&lb).RecordNotFound() {
+		return errors.New("Label not found.")
+	}
+
+	// Remember the entries it was on so a restore can put it back.
+	links := TrashLinks{}
+	db.New().Model(&LabelsToLedger{}).Where("LabelsToLedgerLabelId = ?", labelId).Pluck("LabelsToLedgerLedgerId", &links.Ledgers)
+
 	// Make query to delete
 	db.New().Where("LabelsAccountId = ? AND LabelsId = ?", accountId, labelId).Delete(Label{})
 
 	// Delete from look up table.
 	db.New().Where("LabelsToLedgerLabelId = ?", labelId).Delete(LabelsToLedger{})
 
+	// Add to the trash
+	db.addToTrash(accountId, "label", labelId, lb.Name, 0, links)
+
+	// Out of search, and off the entries it was on.
+	db.RemoveFromSearch(accountId, "label", labelId)
+	db.indexLedgers(accountId, links.Ledgers)
+
 	// Return result
 	return nil
 }
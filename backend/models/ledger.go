@@ -17,28 +17,39 @@ import (
 )
 
 type Ledger struct {
-	Id               uint      `gorm:"primary_key;column:LedgerId" json:"id"`
-	AccountId        uint      `gorm:"column:LedgerAccountId;index:AccountId" sql:"not null" json:"account_id"`
-	UpdatedAt        time.Time `gorm:"column:LedgerUpdatedAt" sql:"not null" json:"_"`
-	CreatedAt        time.Time `gorm:"column:LedgerCreatedAt" sql:"not null" json:"_"`
-	ContactId        uint      `gorm:"column:LedgerContactId;index:LedgerContactId" sql:"not null" json:"contact_id"`
-	Contact          Contact   `gorm:"foreignkey:LedgerContactId" json:"contact"`
-	Date             time.Time `gorm:"column:LedgerDate" sql:"not null" json:"date"`
-	AddedById        uint      `gorm:"column:LedgerAddedById" sql:"not null" json:"added_by_id"`
-	Amount           float64   `gorm:"column:LedgerAmount" sql:"not null;type:DECIMAL(12,2)" json:"amount"`
-	CategoryId       uint      `gorm:"column:LedgerCategoryId" sql:"not null" json:"category_id"`
-	Category         Category  `gorm:"foreignkey:LedgerCategoryId" json:"category"`
-	Note             string    `gorm:"column:LedgerNote" sql:"not null;type:TEXT" json:"note"`
-	Lat              float64   `gorm:"column:LedgerLat" sql:"not null" json:"lat"`
-	Lon              float64   `gorm:"column:LedgerLon" sql:"not null" json:"lon"`
-	ShoeboxedId      string    `gorm:"column:LedgerShoeboxedId" sql:"not null" json:"_"`
-	ShoeboxedImage   string    `gorm:"column:LedgerShoeboxedImage" sql:"not null" json:"_"`
-	FreshBooksId     string    `gorm:"column:LedgerFreshBooksId" sql:"not null" json:"_"`
-	AirBnbHash       string    `gorm:"column:LedgerAirBnbHash" sql:"not null" json:"_"`
-	AuthGatewayToken string    `gorm:"column:LedgerAuthGatewayToken" sql:"not null" json:"_"`
-	StripeId         string    `gorm:"column:LedgerStripeId" sql:"not null" json:"_"`
-	Labels           []Label   `gorm:"many2many:LabelsToLedger;association_foreignkey:LabelsId;foreignkey:LedgerId;association_jointable_foreignkey:LabelsToLedgerLabelId;jointable_foreignkey:LabelsToLedgerLedgerId" sql:"not null" json:"labels"`
-	Files            []File    `gorm:"many2many:FilesToLedger;association_foreignkey:FilesId;foreignkey:LedgerId;association_jointable_foreignkey:FilesToLedgerFileId;jointable_foreignkey:FilesToLedgerLedgerId" sql:"not null" json:"files"`
+	Id                     uint          `gorm:"primary_key;column:LedgerId" json:"id"`
+	AccountId              uint          `gorm:"column:LedgerAccountId;index:AccountId" sql:"not null" json:"account_id"`
+	UpdatedAt              time.Time     `gorm:"column:LedgerUpdatedAt" sql:"not null" json:"_"`
+	CreatedAt              time.Time     `gorm:"column:LedgerCreatedAt" sql:"not null" json:"_"`
+	DeletedAt              *time.Time    `gorm:"column:LedgerDeletedAt;index:LedgerDeletedAt" json:"-"`
+	ContactId              uint          `gorm:"column:LedgerContactId;index:LedgerContactId" sql:"not null" json:"contact_id"`
+	Contact                Contact       `gorm:"foreignkey:LedgerContactId" json:"contact"`
+	Date                   time.Time     `gorm:"column:LedgerDate" sql:"not null" json:"date"`
+	AddedById              uint          `gorm:"column:LedgerAddedById" sql:"not null" json:"added_by_id"`
+	Amount                 float64       `gorm:"column:LedgerAmount" sql:"not null;type:DECIMAL(12,2)" json:"amount"`
+	CategoryId             uint          `gorm:"column:LedgerCategoryId" sql:"not null" json:"category_id"`
+	Category               Category      `gorm:"foreignkey:LedgerCategoryId" json:"category"`
+	Note                   string        `gorm:"column:LedgerNote" sql:"not null;type:TEXT" json:"note"`
+	Lat                    float64       `gorm:"column:LedgerLat" sql:"not null" json:"lat"`
+	Lon                    float64       `gorm:"column:LedgerLon" sql:"not null" json:"lon"`
+	ShoeboxedId            string        `gorm:"column:LedgerShoeboxedId" sql:"not null" json:"_"`
+	ShoeboxedImage         string        `gorm:"column:LedgerShoeboxedImage" sql:"not null" json:"_"`
+	FreshBooksId           string        `gorm:"column:LedgerFreshBooksId" sql:"not null" json:"_"`
+	AirBnbHash             string        `gorm:"column:LedgerAirBnbHash" sql:"not null" json:"_"`
+	AuthGatewayToken       string        `gorm:"column:LedgerAuthGatewayToken" sql:"not null" json:"_"`
+	StripeId               string        `gorm:"column:LedgerStripeId" sql:"not null" json:"_"`
+	RecurringId            uint          `gorm:"column:LedgerRecurringId;index:LedgerRecurringId" sql:"not null" json:"recurring_id"`
+	FitId                  string        `gorm:"column:LedgerFitId;index:LedgerFitId" sql:"not null" json:"fit_id"`
+	FitAcct                string        `gorm:"column:LedgerFitAcct" sql:"not null" json:"-"` // Hash of the bank and account the FITID came from. FITIDs are only unique within one.
+	ImportKey              string        `gorm:"column:LedgerImportKey;index:LedgerImportKey" sql:"not null" json:"-"`
+	Source                 string        `gorm:"column:LedgerSource" sql:"not null" json:"source"`                     // manual, stripe, import, snapclerk, recurring
+	Status                 string        `gorm:"column:LedgerStatus" sql:"not null;default:'uncleared'" json:"status"` // uncleared, cleared, reconciled
+	ReconciliationId       uint          `gorm:"column:LedgerReconciliationId;index:LedgerReconciliationId" sql:"not null" json:"reconciliation_id"`
+	ChartAccountId         uint          `gorm:"column:LedgerChartAccountId" sql:"not null" json:"chart_account_id"`                  // The balance sheet account the money is in. Zero is Cash.
+	TransferChartAccountId uint          `gorm:"column:LedgerTransferChartAccountId" sql:"not null" json:"transfer_chart_account_id"` // Set when money moves between balance sheet accounts.
+	Labels                 []Label       `gorm:"many2many:LabelsToLedger;association_foreignkey:LabelsId;foreignkey:LedgerId;association_jointable_foreignkey:LabelsToLedgerLabelId;jointable_foreignkey:LabelsToLedgerLedgerId" sql:"not null" json:"labels"`
+	Files                  []File        `gorm:"many2many:FilesToLedger;association_foreignkey:FilesId;foreignkey:LedgerId;association_jointable_foreignkey:FilesToLedgerFileId;jointable_foreignkey:FilesToLedgerLedgerId" sql:"not null" json:"files"`
+	Splits                 []LedgerSplit `gorm:"foreignkey:LedgerId" json:"splits"`
 }
 
 //
@@ -60,6 +71,7 @@ func (a Ledger) Validate(db Datastore, action string, userId uint, accountId uin
 
 		validation.Field(&a.Date,
 			validation.Required.Error("The date field is required."),
+			validation.By(func(value interface{}) error { return db.ValidateLedgerPeriodOpen(accountId, a.Date) }),
 		),
 
 		validation.Field(&a.Category,
@@ -69,6 +81,22 @@ func (a Ledger) Validate(db Datastore, action string, userId uint, accountId uin
 		validation.Field(&a.Contact,
 			validation.By(func(value interface{}) error { return db.ValidateLedgerContact(a, accountId, objId, action) }),
 		),
+
+		validation.Field(&a.Status,
+			validation.In("uncleared", "cleared").Error("The status field must be uncleared or cleared."),
+		),
+
+		validation.Field(&a.Splits,
+			validation.By(func(value interface{}) error { return db.ValidateLedgerSplits(a, accountId, objId, action) }),
+		),
+
+		validation.Field(&a.ChartAccountId,
+			validation.By(func(value interface{}) error { return db.ValidateLedgerChartAccount(accountId, a.ChartAccountId) }),
+		),
+
+		validation.Field(&a.TransferChartAccountId,
+			validation.By(func(value interface{}) error { return db.ValidateLedgerTransfer(a, accountId) }),
+		),
 	)
 }
 
@@ -94,6 +122,16 @@ func (db *DB) ValidateLedgerCategory(ledger Ledger, accountId uint, objId uint,
 	const errMsg1 = "Category name is required."
 	const errMsg2 = "Category type is required."
 
+	// Transfers do not have a category.
+	if ledger.TransferChartAccountId > 0 {
+		return nil
+	}
+
+	// Split entries can get their category from the split lines.
+	if (len(ledger.Splits) > 0) && (len(strings.Trim(ledger.Category.Name, " ")) <= 0) {
+		return nil
+	}
+
 	if len(strings.Trim(ledger.Category.Name, " ")) <= 0 {
 		return errors.New(errMsg1)
 	}
@@ -106,16 +144,79 @@ func (db *DB) ValidateLedgerCategory(ledger Ledger, accountId uint, objId uint,
 	return nil
 }
 
+//
+// ValidateLedgerChartAccount - The account an entry is in must be a balance sheet account.
+//
+func (db *DB) ValidateLedgerChartAccount(accountId uint, chartAccountId uint) error {
+	if chartAccountId == 0 {
+		return nil
+	}
+
+	a, err := db.GetChartAccountByAccountAndId(accountId, chartAccountId)
+
+	if err != nil {
+		return err
+	}
+
+	if !a.BalanceSheet() {
+		return errors.New("The account must be an asset, liability, or equity account.")
+	}
+
+	// All good in the hood
+	return nil
+}
+
+//
+// ValidateLedgerTransfer - A transfer moves money between two different balance sheet accounts.
+//
+func (db *DB) ValidateLedgerTransfer(ledger Ledger, accountId uint) error {
+	if ledger.TransferChartAccountId == 0 {
+		return nil
+	}
+
+	if err := db.ValidateLedgerChartAccount(accountId, ledger.TransferChartAccountId); err != nil {
+		return err
+	}
+
+	if len(ledger.Splits) > 0 {
+		return errors.New("Transfers can not be split.")
+	}
+
+	bank := ledger.ChartAccountId
+
+	if bank == 0 {
+		bank = db.GetSystemChartAccount(accountId, "cash").Id
+	}
+
+	if bank == ledger.TransferChartAccountId {
+		return errors.New("An entry can not transfer to the account it is in.")
+	}
+
+	// All good in the hood
+	return nil
+}
+
 //
 // LedgerCreate - Create a new ledger entry.
 //
 func (db *DB) LedgerCreate(ledger *Ledger) error {
+	// Entries that did not come from a person go through the account's rules.
+	if (ledger.Source == "import") || (ledger.Source == "stripe") || (ledger.Source == "snapclerk") {
+		db.ApplyLedgerRules(ledger)
+	}
+
 	// Prep Vars
 	prepLedgerVars(db, ledger)
 
 	// Store this ledger entry.
 	db.Create(&ledger)
 
+	// Post the debits and credits.
+	db.PostLedgerJournal(ledger.AccountId, ledger.Id)
+
+	// Add to the search index
+	db.indexLedgerLinks(ledger)
+
 	return nil
 }
 
@@ -126,12 +227,19 @@ func (db *DB) LedgerUpdate(ledger *Ledger) error {
 	// Prep Vars
 	prepLedgerVars(db, ledger)
 
-	// Clear out old labels. We start fresh every time.
+	// Clear out old labels and splits. We start fresh every time.
 	db.New().Where("LabelsToLedgerLedgerId = ?", ledger.Id).Delete(LabelsToLedger{})
+	db.deleteLedgerSplits(ledger.Id)
 
 	// Update this ledger entry.
 	db.Save(&ledger)
 
+	// Post the debits and credits again.
+	db.PostLedgerJournal(ledger.AccountId, ledger.Id)
+
+	// Update the search index
+	db.indexLedgerLinks(ledger)
+
 	return nil
 }
 
@@ -143,7 +251,7 @@ func (db *DB) GetLedgerByAccountAndId(accountId uint, id uint) (Ledger, error) {
 	c := Ledger{}
 
 	// Make query
-	if db.New().Preload("Contact").Preload("Category").Preload("Labels").Preload("Files").Where("LedgerAccountId = ? AND LedgerId = ?", accountId, id).First(&c).RecordNotFound() {
+	if db.New().Preload("Contact").Preload("Category").Preload("Labels").Preload("Files").Preload("Splits").Preload("Splits.Category").Preload("Splits.Labels").Where("LedgerAccountId = ? AND LedgerId = ?", accountId, id).First(&c).RecordNotFound() {
 		return Ledger{}, errors.New("Ledger entry not found.")
 	}
 
@@ -158,9 +266,55 @@ func (db *DB) GetLedgerByAccountAndId(accountId uint, id uint) (Ledger, error) {
 }
 
 //
-// DeleteLedgerByAccountAndId - Delete a label by account and id.
+// EachLedgerBatch - Walk every ledger entry that matches params, size at a time,
+// paging with keyset cursors so only one batch is ever in memory.
+//
+func (db *DB) EachLedgerBatch(params QueryParam, size int, fn func([]Ledger) error) error {
+	params.Limit = size
+	params.Page = 0
+	params.UseCursor = true
+	params.Cursor = ""
+	params.CursorCol = "LedgerId"
+	params.NoCount = true
+
+	for {
+		rows := []Ledger{}
+		meta, err := db.QueryMeta(&rows, params)
+
+		if err != nil {
+			return err
+		}
+
+		if len(rows) > 0 {
+			if err := fn(rows); err != nil {
+				return err
+			}
+		}
+
+		if meta.LastPage {
+			return nil
+		}
+
+		params.Cursor = meta.NextCursor
+	}
+}
+
+//
+// DeleteLedgerByAccountAndId - Move a ledger entry to the trash by account and id.
+// Split lines stay until the trash is purged.
 //
 func (db *DB) DeleteLedgerByAccountAndId(accountId uint, id uint) error {
+	l := Ledger{}
+
+	if db.New().Preload("Contact").Where("LedgerAccountId = ? AND LedgerId = ?", accountId, id).First(&l).RecordNotFound() {
+		return errors.New("Ledger entry not found.")
+	}
+
+	// Remember the links so a restore can put them back.
+	links := TrashLinks{}
+	db.New().Model(&LabelsToLedger{}).Where("LabelsToLedgerLedgerId = ?", id).Pluck("LabelsToLedgerLabelId", &links.Labels)
+	db.New().Model(&FilesToLedger{}).Where("FilesToLedgerLedgerId = ?", id).Pluck("FilesToLedgerFileId", &links.Files)
+
 	// Make query to delete
 	db.New().Where("LedgerAccountId = ? AND LedgerId = ?", accountId, id).Delete(Ledger{})
 
@@ -170,6 +324,12 @@ func (db *DB) DeleteLedgerByAccountAndId(accountId uint, id uint) error {
 	// Delete from look up table. - Files
 	db.New().Where("FilesToLedgerLedgerId = ?", id).Delete(FilesToLedger{})
 
+	// Add to the trash
+	db.addToTrash(accountId, "ledger", id, ledgerPayee(db, l), l.Amount, links)
+
+	// Trashed entries do not show up in search.
+	db.RemoveFromSearch(accountId, "ledger", id)
+
 	// Return result
 	return nil
 }
@@ -215,6 +375,11 @@ func prepLedgerVars(db *DB, ledger *Ledger) {
 	// Trim Note
 	ledger.Note = strings.Trim(ledger.Note, " ")
 
+	// New entries have not shown up on a bank statement yet.
+	if len(ledger.Status) == 0 {
+		ledger.Status = "uncleared"
+	}
+
 	// Trim Contact
 	ledger.Contact.Name = strings.Trim(ledger.Contact.Name, " ")
 	ledger.Contact.FirstName = strings.Trim(ledger.Contact.FirstName, " ")
@@ -224,6 +389,16 @@ func prepLedgerVars(db *DB, ledger *Ledger) {
 	ledger.Category.Name = strings.Trim(ledger.Category.Name, " ")
 	ledger.Category.Type = strings.Trim(ledger.Category.Type, " ")
 
+	// Transfers move money between balance sheet accounts. They are not income or expense.
+	if ledger.TransferChartAccountId > 0 {
+		ledger.Category = Category{}
+		ledger.CategoryId = 0
+		ledger.Splits = []LedgerSplit{}
+	}
+
+	// Setup the split lines. This can set the category so do it first.
+	prepLedgerSplits(db, ledger)
+
 	// Setup the contact. If we have a ledger.Contact.Id we assume we are not adding the contact on insert.
 	if ledger.Contact.Id == 0 {
 		if (len(ledger.Contact.FirstName) > 0) || (len(ledger.Contact.LastName) > 0) {
@@ -247,7 +422,7 @@ func prepLedgerVars(db *DB, ledger *Ledger) {
 	}
 
 	// Setup the category. Add the Id if we do not pass one in.
-	if ledger.Category.Id == 0 {
+	if (ledger.Category.Id == 0) && (ledger.TransferChartAccountId == 0) {
 		db.Where("CategoriesAccountId = ? AND CategoriesName = ? AND CategoriesType = ?", ledger.AccountId, ledger.Category.Name, ledger.Category.Type).FirstOrCreate(&ledger.Category)
 	}
 
@@ -0,0 +1,228 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package models
+
+import (
+	"errors"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// LedgerBulk struct - One change applied to many ledger entries at once.
+type LedgerBulk struct {
+	Ids        []uint `json:"ids"`
+	Operation  string `json:"operation"` // set_category, add_labels, remove_labels, set_contact, shift_date, delete
+	CategoryId uint   `json:"category_id"`
+	LabelIds   []uint `json:"label_ids"`
+	ContactId  uint   `json:"contact_id"`
+	Days       int    `json:"days"`
+}
+
+// LedgerBulkResult struct - What happened to one entry.
+type LedgerBulkResult struct {
+	Id      uint   `json:"id"`
+	Success bool   `json:"success"`
+	Error   string `json:"error"`
+}
+
+//
+// Validate for this model.
+//
+func (a LedgerBulk) Validate(db Datastore, action string, userId uint, accountId uint, objId uint) error {
+	return validation.ValidateStruct(&a,
+
+		validation.Field(&a.Operation,
+			validation.Required.Error("The operation field is required."),
+			validation.In("set_category", "add_labels", "remove_labels", "set_contact", "shift_date", "delete").Error("The operation field must be set_category, add_labels, remove_labels, set_contact, shift_date or delete."),
+		),
+
+		validation.Field(&a.CategoryId,
+			validation.By(func(value interface{}) error {
+				if a.Operation != "set_category" {
+					return nil
+				}
+
+				if a.CategoryId == 0 {
+					return errors.New("The category_id field is required.")
+				}
+
+				_, err := db.GetCategoryByAccountAndId(accountId, a.CategoryId)
+				return err
+			}),
+		),
+
+		validation.Field(&a.LabelIds,
+			validation.By(func(value interface{}) error {
+				if (a.Operation != "add_labels") && (a.Operation != "remove_labels") {
+					return nil
+				}
+
+				if len(a.LabelIds) == 0 {
+					return errors.New("The label_ids field is required.")
+				}
+
+				for _, row := range a.LabelIds {
+					if _, err := db.GetLabelByAccountAndId(accountId, row); err != nil {
+						return err
+					}
+				}
+
+				return nil
+			}),
+		),
+
+		validation.Field(&a.ContactId,
+			validation.By(func(value interface{}) error {
+				if a.Operation != "set_contact" {
+					return nil
+				}
+
+				if a.ContactId == 0 {
+					return errors.New("The contact_id field is required.")
+				}
+
+				_, err := db.GetContactByAccountAndId(accountId, a.ContactId)
+				return err
+			}),
+		),
+
+		validation.Field(&a.Days,
+			validation.By(func(value interface{}) error {
+				if (a.Operation == "shift_date") && (a.Days == 0) {
+					return errors.New("The days field is required.")
+				}
+				return nil
+			}),
+		),
+	)
+}
+
+//
+// LedgerBulkUpdate - Apply a bulk change to the ledger entries in b.Ids. It all
+// runs in one transaction. Entries we can not change (not found, reconciled,
+// closed) are skipped and reported in the results. A database error rolls back
+// everything.
+//
+func (db *DB) LedgerBulkUpdate(accountId uint, userId uint, ip string, b LedgerBulk) ([]LedgerBulkResult, error) {
+	results := []LedgerBulkResult{}
+
+	tx := &DB{db.Begin()}
+
+	for _, id := range b.Ids {
+		res, err := tx.ledgerBulkUpdateOne(accountId, userId, ip, id, b)
+
+		if err != nil {
+			tx.Rollback()
+			return []LedgerBulkResult{}, err
+		}
+
+		results = append(results, res)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return []LedgerBulkResult{}, err
+	}
+
+	return results, nil
+}
+
+// ----------------- Private Helper Funcs -------------- //
+
+//
+// ledgerBulkUpdateOne - Apply a bulk change to one entry. Only database
+// errors are returned as an error.
+//
+func (db *DB) ledgerBulkUpdateOne(accountId uint, userId uint, ip string, id uint, b LedgerBulk) (LedgerBulkResult, error) {
+	res := LedgerBulkResult{Id: id}
+
+	l, err := db.GetLedgerByAccountAndId(accountId, id)
+
+	if err != nil {
+		res.Error = err.Error()
+		return res, nil
+	}
+
+	// Reconciled and closed entries are locked.
+	if err := db.ValidateLedgerUnlocked(l); err != nil {
+		res.Error = err.Error()
+		return res, nil
+	}
+
+	query := db.New().Model(&Ledger{}).Where("LedgerAccountId = ? AND LedgerId = ?", accountId, id)
+	subAction := "update"
+
+	switch b.Operation {
+	case "set_category":
+		if len(l.Splits) > 0 {
+			res.Error = "Split entries can not be recategorized in bulk."
+			return res, nil
+		}
+
+		err = query.Updates(map[string]interface{}{"LedgerCategoryId": b.CategoryId}).Error
+
+	case "add_labels":
+		for _, row := range b.LabelIds {
+			db.addLabelLink(row, id)
+		}
+
+	case "remove_labels":
+		err = db.New().Where("LabelsToLedgerLedgerId = ? AND LabelsToLedgerLabelId IN (?)", id, b.LabelIds).Delete(LabelsToLedger{}).Error
+
+	case "set_contact":
+		err = query.Updates(map[string]interface{}{"LedgerContactId": b.ContactId}).Error
+
+	case "shift_date":
+		date := l.Date.AddDate(0, 0, b.Days)
+
+		if err := db.ValidateLedgerPeriodOpen(accountId, date); err != nil {
+			res.Error = err.Error()
+			return res, nil
+		}
+
+		err = query.Updates(map[string]interface{}{"LedgerDate": date}).Error
+
+	case "delete":
+		subAction = "delete"
+		db.CreateLedgerRevision(accountId, id, userId, ip, "delete")
+		err = db.DeleteLedgerByAccountAndId(accountId, id)
+	}
+
+	if err != nil {
+		return res, err
+	}
+
+	// Log it.
+	if subAction == "update" {
+		db.CreateLedgerRevision(accountId, id, userId, ip, "update")
+		db.IndexLedger(accountId, id)
+		db.PostLedgerJournal(accountId, id)
+		l, _ = db.GetLedgerByAccountAndId(accountId, id)
+	}
+
+	ledgerType := "expense"
+
+	if l.Amount > 0 {
+		ledgerType = "income"
+	}
+
+	err = db.New().Create(&Activity{
+		AccountId: accountId,
+		UserId:    userId,
+		Action:    ledgerType,
+		SubAction: subAction,
+		Name:      ledgerPayee(db, l),
+		Amount:    l.Amount,
+		LedgerId:  id,
+	}).Error
+
+	res.Success = (err == nil)
+
+	return res, err
+}
+
+/* End File */
@@ -0,0 +1,539 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Compare operators a filter can use mapped to the SQL we run. Anything not in
+// this map never gets near a query.
+var ledgerFilterCompares = map[string]string{
+	":":  "=",
+	"=":  "=",
+	"<":  "<",
+	"<=": "<=",
+	">":  ">",
+	">=": ">=",
+}
+
+// LedgerFilter struct - A parsed ledger filter query. Op is and, or, not or
+// term. Terms set Field, Compare and Value.
+//
+// amount<-100 AND label:"client a" AND NOT category:Travel AND date>=2024-01-01
+//
+type LedgerFilter struct {
+	Op       string
+	Children []LedgerFilter
+	Field    string
+	Compare  string
+	Value    string
+}
+
+// ledgerFilterToken struct - One piece of a filter query.
+type ledgerFilterToken struct {
+	Kind  string // word, string, op, (, )
+	Value string
+}
+
+//
+// ParseLedgerFilter - Parse a filter query like
+// amount<-100 AND label:"client a" AND NOT category:Travel AND date>=2024-01-01
+//
+// Fields: amount, date, label, category, contact, has (attachment, label,
+// note, split), created_by (user id, email or me), type (income, expense) and
+// status (uncleared, cleared, reconciled). Amounts and dates take : = != < <=
+// > >=, everything else : = !=. Join terms with AND, OR, NOT and parentheses.
+// Terms next to each other are ANDed. End a label, category or contact name
+// with * to match on prefix.
+//
+func ParseLedgerFilter(query string) (LedgerFilter, error) {
+	tokens, err := lexLedgerFilter(query)
+
+	if err != nil {
+		return LedgerFilter{}, err
+	}
+
+	if len(tokens) == 0 {
+		return LedgerFilter{}, errors.New("The filter is empty.")
+	}
+
+	p := &ledgerFilterParser{tokens: tokens}
+
+	f, err := p.parseOr()
+
+	if err != nil {
+		return LedgerFilter{}, err
+	}
+
+	if p.pos < len(p.tokens) {
+		return LedgerFilter{}, fmt.Errorf("Unexpected %s in the filter.", p.tokens[p.pos].Value)
+	}
+
+	return f, nil
+}
+
+//
+// GetLedgerFilterWhere - A where on the Ledger table for the entries that
+// match a parsed filter. It is all SQL with bound values so it works for any
+// number of entries. userId is who "created_by:me" means.
+//
+func (db *DB) GetLedgerFilterWhere(accountId uint, userId uint, f LedgerFilter) (KeyValue, error) {
+	e := &ledgerFilterEval{accountId: accountId, userId: userId}
+
+	sql, args, err := e.eval(f)
+
+	if err != nil {
+		return KeyValue{}, err
+	}
+
+	return KeyValue{Sql: sql, Args: args}, nil
+}
+
+// ----------------- Private Helper Funcs -------------- //
+
+//
+// lexLedgerFilter - Break a filter query into tokens.
+//
+func lexLedgerFilter(query string) ([]ledgerFilterToken, error) {
+	tokens := []ledgerFilterToken{}
+	runes := []rune(query)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+
+		case (r == '(') || (r == ')'):
+			tokens = append(tokens, ledgerFilterToken{Kind: string(r), Value: string(r)})
+			i++
+
+		case r == '"':
+			end := i + 1
+
+			for (end < len(runes)) && (runes[end] != '"') {
+				end++
+			}
+
+			if end == len(runes) {
+				return tokens, errors.New("The filter has a quote that is not closed.")
+			}
+
+			tokens = append(tokens, ledgerFilterToken{Kind: "string", Value: string(runes[i+1 : end])})
+			i = end + 1
+
+		case strings.ContainsRune(":<>=!", r):
+			op := string(r)
+
+			if (i+1 < len(runes)) && (runes[i+1] == '=') && (r != ':') && (r != '=') {
+				op = op + "="
+			}
+
+			if op == "!" {
+				return tokens, errors.New("Use != or NOT in the filter.")
+			}
+
+			tokens = append(tokens, ledgerFilterToken{Kind: "op", Value: op})
+			i = i + len(op)
+
+		default:
+			end := i
+
+			for (end < len(runes)) && !unicode.IsSpace(runes[end]) && !strings.ContainsRune(`():<>=!"`, runes[end]) {
+				end++
+			}
+
+			tokens = append(tokens, ledgerFilterToken{Kind: "word", Value: string(runes[i:end])})
+			i = end
+		}
+	}
+
+	return tokens, nil
+}
+
+// ledgerFilterParser struct - Recursive descent parser for filter tokens.
+type ledgerFilterParser struct {
+	tokens []ledgerFilterToken
+	pos    int
+}
+
+//
+// keyword - Is the next token the AND, OR or NOT keyword.
+//
+func (p *ledgerFilterParser) keyword(word string) bool {
+	if p.pos >= len(p.tokens) {
+		return false
+	}
+
+	t := p.tokens[p.pos]
+
+	return (t.Kind == "word") && strings.EqualFold(t.Value, word)
+}
+
+//
+// parseOr - or := and ( OR and )*
+//
+func (p *ledgerFilterParser) parseOr() (LedgerFilter, error) {
+	left, err := p.parseAnd()
+
+	if err != nil {
+		return left, err
+	}
+
+	children := []LedgerFilter{left}
+
+	for p.keyword("OR") {
+		p.pos++
+
+		right, err := p.parseAnd()
+
+		if err != nil {
+			return right, err
+		}
+
+		children = append(children, right)
+	}
+
+	if len(children) == 1 {
+		return left, nil
+	}
+
+	return LedgerFilter{Op: "or", Children: children}, nil
+}
+
+//
+// parseAnd - and := not ( [AND] not )*
+//
+func (p *ledgerFilterParser) parseAnd() (LedgerFilter, error) {
+	left, err := p.parseNot()
+
+	if err != nil {
+		return left, err
+	}
+
+	children := []LedgerFilter{left}
+
+	for p.pos < len(p.tokens) {
+		if p.keyword("AND") {
+			p.pos++
+		} else if p.keyword("OR") || (p.tokens[p.pos].Kind == ")") {
+			break
+		}
+
+		right, err := p.parseNot()
+
+		if err != nil {
+			return right, err
+		}
+
+		children = append(children, right)
+	}
+
+	if len(children) == 1 {
+		return left, nil
+	}
+
+	return LedgerFilter{Op: "and", Children: children}, nil
+}
+
+//
+// parseNot - not := NOT not | primary
+//
+func (p *ledgerFilterParser) parseNot() (LedgerFilter, error) {
+	if p.keyword("NOT") {
+		p.pos++
+
+		child, err := p.parseNot()
+
+		if err != nil {
+			return child, err
+		}
+
+		return LedgerFilter{Op: "not", Children: []LedgerFilter{child}}, nil
+	}
+
+	return p.parsePrimary()
+}
+
+//
+// parsePrimary - primary := ( or ) | field op value
+//
+func (p *ledgerFilterParser) parsePrimary() (LedgerFilter, error) {
+	if p.pos >= len(p.tokens) {
+		return LedgerFilter{}, errors.New("The filter ends too soon.")
+	}
+
+	t := p.tokens[p.pos]
+
+	// Group
+	if t.Kind == "(" {
+		p.pos++
+
+		f, err := p.parseOr()
+
+		if err != nil {
+			return f, err
+		}
+
+		if (p.pos >= len(p.tokens)) || (p.tokens[p.pos].Kind != ")") {
+			return f, errors.New("The filter is missing a closing parenthesis.")
+		}
+
+		p.pos++
+
+		return f, nil
+	}
+
+	if t.Kind != "word" {
+		return LedgerFilter{}, fmt.Errorf("Unexpected %s in the filter.", t.Value)
+	}
+
+	// field op value
+	if (p.pos+2 >= len(p.tokens)) || (p.tokens[p.pos+1].Kind != "op") || ((p.tokens[p.pos+2].Kind != "word") && (p.tokens[p.pos+2].Kind != "string")) {
+		return LedgerFilter{}, fmt.Errorf("Filter terms look like field:value, %s is missing its value.", t.Value)
+	}
+
+	term := LedgerFilter{
+		Op:      "term",
+		Field:   strings.ToLower(t.Value),
+		Compare: p.tokens[p.pos+1].Value,
+		Value:   p.tokens[p.pos+2].Value,
+	}
+
+	p.pos = p.pos + 3
+
+	if err := validateLedgerFilterTerm(&term); err != nil {
+		return term, err
+	}
+
+	// field!=value is NOT field:value
+	if term.Compare == "!=" {
+		term.Compare = ":"
+		return LedgerFilter{Op: "not", Children: []LedgerFilter{term}}, nil
+	}
+
+	return term, nil
+}
+
+//
+// validateLedgerFilterTerm - Check the field, compare and value of a term.
+//
+func validateLedgerFilterTerm(t *LedgerFilter) error {
+	ranged := false
+
+	switch t.Field {
+	case "added_by":
+		t.Field = "created_by"
+
+	case "amount":
+		ranged = true
+
+		if _, err := strconv.ParseFloat(strings.Replace(strings.Replace(t.Value, "$", "", -1), ",", "", -1), 64); err != nil {
+			return fmt.Errorf("%s is not an amount.", t.Value)
+		}
+
+	case "date":
+		ranged = true
+
+		if _, err := time.Parse("2006-01-02", t.Value); err != nil {
+			return fmt.Errorf("%s is not a date. Use YYYY-MM-DD.", t.Value)
+		}
+
+	case "has":
+		switch strings.ToLower(t.Value) {
+		case "attachment", "file", "label", "note", "split":
+		default:
+			return errors.New("has must be attachment, label, note or split.")
+		}
+
+	case "type":
+		switch strings.ToLower(t.Value) {
+		case "income", "expense":
+		default:
+			return errors.New("type must be income or expense.")
+		}
+
+	case "status":
+		switch strings.ToLower(t.Value) {
+		case "uncleared", "cleared", "reconciled":
+		default:
+			return errors.New("status must be uncleared, cleared or reconciled.")
+		}
+
+	case "label", "category", "contact", "created_by":
+
+	default:
+		return fmt.Errorf("%s is not a filter field. Use amount, date, label, category, contact, has, created_by, type or status.", t.Field)
+	}
+
+	if !ranged && (t.Compare != ":") && (t.Compare != "=") && (t.Compare != "!=") {
+		return fmt.Errorf("%s can only use :, = or !=.", t.Field)
+	}
+
+	return nil
+}
+
+// ledgerFilterEval struct - Turns a filter into a where on the Ledger table.
+type ledgerFilterEval struct {
+	accountId uint
+	userId    uint
+}
+
+//
+// eval - The where clause and its arguments for a filter.
+//
+func (e *ledgerFilterEval) eval(f LedgerFilter) (string, []interface{}, error) {
+	switch f.Op {
+	case "and", "or":
+		parts := []string{}
+		args := []interface{}{}
+
+		for _, row := range f.Children {
+			sql, a, err := e.eval(row)
+
+			if err != nil {
+				return "", nil, err
+			}
+
+			parts = append(parts, sql)
+			args = append(args, a...)
+		}
+
+		return "(" + strings.Join(parts, " "+strings.ToUpper(f.Op)+" ") + ")", args, nil
+
+	case "not":
+		sql, args, err := e.eval(f.Children[0])
+
+		if err != nil {
+			return "", nil, err
+		}
+
+		return "NOT (" + sql + ")", args, nil
+	}
+
+	return e.term(f)
+}
+
+//
+// term - The where clause for a single term. Every value goes in as a query
+// parameter.
+//
+func (e *ledgerFilterEval) term(f LedgerFilter) (string, []interface{}, error) {
+	value := strings.ToLower(f.Value)
+
+	switch f.Field {
+	case "amount":
+		v, _ := strconv.ParseFloat(strings.Replace(strings.Replace(f.Value, "$", "", -1), ",", "", -1), 64)
+		return "(LedgerAmount " + ledgerFilterCompares[f.Compare] + " ?)", []interface{}{v}, nil
+
+	case "date":
+		// Dates are stored with a time so a day is a range.
+		day, _ := time.Parse("2006-01-02", f.Value)
+		start := day.Format("2006-01-02")
+		next := day.AddDate(0, 0, 1).Format("2006-01-02")
+
+		switch f.Compare {
+		case "<":
+			return "(LedgerDate < ?)", []interface{}{start}, nil
+		case "<=":
+			return "(LedgerDate < ?)", []interface{}{next}, nil
+		case ">":
+			return "(LedgerDate >= ?)", []interface{}{next}, nil
+		case ">=":
+			return "(LedgerDate >= ?)", []interface{}{start}, nil
+		}
+
+		return "(LedgerDate >= ? AND LedgerDate < ?)", []interface{}{start, next}, nil
+
+	case "label":
+		name, arg := ledgerFilterName("LabelsName", f.Value)
+
+		// Labels on split lines count too.
+		sql := "(EXISTS (SELECT 1 FROM LabelsToLedger INNER JOIN Labels ON LabelsId = LabelsToLedgerLabelId " +
+			"WHERE LabelsToLedgerLedgerId = Ledger.LedgerId AND LabelsAccountId = ? AND LabelsDeletedAt IS NULL AND " + name + ") " +
+			"OR EXISTS (SELECT 1 FROM ledger_splits INNER JOIN ledger_split_labels ON ledger_split_labels.ledger_split_id = ledger_splits.id " +
+			"INNER JOIN Labels ON LabelsId = ledger_split_labels.label_id " +
+			"WHERE ledger_splits.ledger_id = Ledger.LedgerId AND LabelsAccountId = ? AND LabelsDeletedAt IS NULL AND " + name + "))"
+
+		return sql, []interface{}{e.accountId, arg, e.accountId, arg}, nil
+
+	case "category":
+		name, arg := ledgerFilterName("CategoriesName", f.Value)
+		cats := "SELECT CategoriesId FROM Categories WHERE CategoriesAccountId = ? AND CategoriesDeletedAt IS NULL AND " + name
+
+		sql := "(LedgerCategoryId IN (" + cats + ") " +
+			"OR EXISTS (SELECT 1 FROM ledger_splits WHERE ledger_splits.ledger_id = Ledger.LedgerId AND ledger_splits.category_id IN (" + cats + ")))"
+
+		return sql, []interface{}{e.accountId, arg, e.accountId, arg}, nil
+
+	case "contact":
+		name, arg := ledgerFilterName("ContactsName", f.Value)
+		full, _ := ledgerFilterName("(ContactsFirstName || ' ' || ContactsLastName)", f.Value)
+
+		sql := "(LedgerContactId IN (SELECT ContactsId FROM Contacts WHERE ContactsAccountId = ? AND ContactsDeletedAt IS NULL AND (" + name + " OR " + full + ")))"
+
+		return sql, []interface{}{e.accountId, arg, arg}, nil
+
+	case "has":
+		switch value {
+		case "attachment", "file":
+			return "EXISTS (SELECT 1 FROM FilesToLedger WHERE FilesToLedgerLedgerId = Ledger.LedgerId)", nil, nil
+		case "label":
+			return "EXISTS (SELECT 1 FROM LabelsToLedger WHERE LabelsToLedgerLedgerId = Ledger.LedgerId)", nil, nil
+		case "note":
+			return "(LedgerNote != '')", nil, nil
+		}
+
+		return "EXISTS (SELECT 1 FROM ledger_splits WHERE ledger_splits.ledger_id = Ledger.LedgerId)", nil, nil
+
+	case "created_by":
+		if value == "me" {
+			return "(LedgerAddedById = ?)", []interface{}{e.userId}, nil
+		}
+
+		if id, err := strconv.Atoi(value); err == nil {
+			return "(LedgerAddedById = ?)", []interface{}{id}, nil
+		}
+
+		return "(LedgerAddedById IN (SELECT id FROM users WHERE LOWER(email) = ?))", []interface{}{value}, nil
+
+	case "type":
+		if value == "income" {
+			return "(LedgerAmount > 0)", nil, nil
+		}
+
+		return "(LedgerAmount < 0)", nil, nil
+
+	case "status":
+		return "(LedgerStatus = ?)", []interface{}{value}, nil
+	}
+
+	return "", nil, fmt.Errorf("%s is not a filter field.", f.Field)
+}
+
+//
+// ledgerFilterName - Case insensitive match on a name column. A trailing *
+// matches on prefix. Returns the where clause and its one argument.
+//
+func ledgerFilterName(col string, value string) (string, string) {
+	if strings.HasSuffix(value, "*") {
+		v := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(strings.TrimSuffix(value, "*"))
+		return "LOWER(" + col + ") LIKE LOWER(?) ESCAPE '\\'", v + "%"
+	}
+
+	return "LOWER(" + col + ") = LOWER(?)", value
+}
+
+/* End File */
@@ -0,0 +1,306 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"app.skyclerk.com/backend/services"
+)
+
+// LedgerRevision struct - A snapshot of a ledger entry after every write.
+type LedgerRevision struct {
+	Id        uint           `gorm:"primary_key" json:"id"`
+	CreatedAt time.Time      `sql:"not null" json:"created_at"`
+	AccountId uint           `sql:"not null;index:account_id" json:"account_id"`
+	LedgerId  uint           `sql:"not null;index:ledger_id" json:"ledger_id"`
+	UserId    uint           `sql:"not null" json:"user_id"` // Zero when the system made the change.
+	User      User           `gorm:"association_autoupdate:false;association_autocreate:false" json:"user"`
+	Ip        string         `sql:"not null" json:"ip"`
+	Action    string         `sql:"not null" json:"action"` // create, update, delete, restore
+	Data      string         `sql:"not null;type:TEXT" json:"-"`
+	Snapshot  LedgerSnapshot `gorm:"-" json:"snapshot"`
+	Changes   []FieldChange  `gorm:"-" json:"changes"` // Compared to the revision before this one.
+}
+
+// LedgerSnapshot struct - The parts of a ledger entry we keep history for.
+type LedgerSnapshot struct {
+	Amount       float64         `json:"amount"`
+	Date         time.Time       `json:"date"`
+	ContactId    uint            `json:"contact_id"`
+	Contact      string          `json:"contact"`
+	CategoryId   uint            `json:"category_id"`
+	Category     string          `json:"category"`
+	CategoryType string          `json:"category_type"`
+	LabelIds     []uint          `json:"label_ids"`
+	Labels       []string        `json:"labels"`
+	FileIds      []uint          `json:"file_ids"`
+	Files        []string        `json:"files"`
+	Note         string          `json:"note"`
+	Splits       []SplitSnapshot `json:"splits"`
+}
+
+// SplitSnapshot struct - One split line in a snapshot.
+type SplitSnapshot struct {
+	Amount     float64 `json:"amount"`
+	CategoryId uint    `json:"category_id"`
+	Category   string  `json:"category"`
+	LabelIds   []uint  `json:"label_ids"`
+	Note       string  `json:"note"`
+}
+
+//
+// CreateLedgerRevision - Snapshot a ledger entry as it is in the database right
+// now. Call this after every write (and before a delete).
+//
+func (db *DB) CreateLedgerRevision(accountId uint, ledgerId uint, userId uint, ip string, action string) error {
+	l, err := db.GetLedgerByAccountAndId(accountId, ledgerId)
+
+	if err != nil {
+		return err
+	}
+
+	snap := NewLedgerSnapshot(l)
+	data, err := json.Marshal(snap)
+
+	if err != nil {
+		services.Info(err)
+		return err
+	}
+
+	db.New().Create(&LedgerRevision{
+		AccountId: accountId,
+		LedgerId:  ledgerId,
+		UserId:    userId,
+		Ip:        ip,
+		Action:    action,
+		Data:      string(data),
+	})
+
+	return nil
+}
+
+//
+// GetLedgerRevisions - All revisions for a ledger entry, newest first, with the
+// field changes from the revision before each one.
+//
+func (db *DB) GetLedgerRevisions(accountId uint, ledgerId uint) []LedgerRevision {
+	revs := []LedgerRevision{}
+	db.New().Preload("User").Where("account_id = ? AND ledger_id = ?", accountId, ledgerId).Order("id ASC").Find(&revs)
+
+	prev := LedgerSnapshot{}
+
+	for key := range revs {
+		json.Unmarshal([]byte(revs[key].Data), &revs[key].Snapshot)
+		revs[key].Changes = DiffLedgerSnapshots(prev, revs[key].Snapshot)
+		prev = revs[key].Snapshot
+	}
+
+	// Newest first
+	sort.SliceStable(revs, func(i, j int) bool { return revs[i].Id > revs[j].Id })
+
+	return revs
+}
+
+//
+// GetLedgerRevisionByAccountAndId by account and id.
+//
+func (db *DB) GetLedgerRevisionByAccountAndId(accountId uint, id uint) (LedgerRevision, error) {
+	r := LedgerRevision{}
+
+	// Make query
+	if db.New().Where("account_id = ? AND id = ?", accountId, id).First(&r).RecordNotFound() {
+		return LedgerRevision{}, errors.New("Revision not found.")
+	}
+
+	json.Unmarshal([]byte(r.Data), &r.Snapshot)
+
+	// Return result
+	return r, nil
+}
+
+//
+// RestoreLedgerRevision - Put a ledger entry back the way it was at a revision.
+// Contacts, categories, labels and files that have since been deleted are skipped.
+//
+func (db *DB) RestoreLedgerRevision(ledger *Ledger, rev LedgerRevision) error {
+	if rev.LedgerId != ledger.Id {
+		return errors.New("Revision not found.")
+	}
+
+	snap := rev.Snapshot
+
+	// The restored date has to be open too.
+	if err := db.ValidateLedgerPeriodOpen(ledger.AccountId, snap.Date); err != nil {
+		return err
+	}
+
+	ledger.Amount = snap.Amount
+	ledger.Date = snap.Date
+	ledger.Note = snap.Note
+
+	// Contact
+	if contact, err := db.GetContactByAccountAndId(ledger.AccountId, snap.ContactId); err == nil {
+		ledger.Contact = contact
+	} else {
+		ledger.Contact = Contact{Name: snap.Contact}
+	}
+
+	// Category
+	if cat, err := db.GetCategoryByAccountAndId(ledger.AccountId, snap.CategoryId); err == nil {
+		ledger.Category = cat
+	} else {
+		ledger.Category = Category{Name: snap.Category, Type: snap.CategoryType}
+	}
+
+	// Labels
+	ledger.Labels = []Label{}
+
+	if len(snap.LabelIds) > 0 {
+		db.New().Where("LabelsAccountId = ? AND LabelsId IN (?)", ledger.AccountId, snap.LabelIds).Find(&ledger.Labels)
+	}
+
+	// Files
+	ledger.Files = []File{}
+
+	if len(snap.FileIds) > 0 {
+		db.New().Where("FilesAccountId = ? AND FilesId IN (?)", ledger.AccountId, snap.FileIds).Find(&ledger.Files)
+	}
+
+	// Splits
+	ledger.Splits = []LedgerSplit{}
+
+	for _, row := range snap.Splits {
+		split := LedgerSplit{Amount: row.Amount, Category: Category{Id: row.CategoryId, Name: row.Category, Type: snap.CategoryType}, Note: row.Note, Labels: []Label{}}
+
+		if len(row.LabelIds) > 0 {
+			db.New().Where("LabelsAccountId = ? AND LabelsId IN (?)", ledger.AccountId, row.LabelIds).Find(&split.Labels)
+		}
+
+		ledger.Splits = append(ledger.Splits, split)
+	}
+
+	return db.LedgerUpdate(ledger)
+}
+
+//
+// NewLedgerSnapshot - Build a snapshot from a ledger entry.
+//
+func NewLedgerSnapshot(l Ledger) LedgerSnapshot {
+	contact := l.Contact.Name
+
+	if len(contact) == 0 {
+		contact = strings.TrimSpace(l.Contact.FirstName + " " + l.Contact.LastName)
+	}
+
+	snap := LedgerSnapshot{
+		Amount:       l.Amount,
+		Date:         l.Date.UTC(),
+		ContactId:    l.Contact.Id,
+		Contact:      contact,
+		CategoryId:   l.Category.Id,
+		Category:     l.Category.Name,
+		CategoryType: l.Category.Type,
+		LabelIds:     []uint{},
+		Labels:       []string{},
+		FileIds:      []uint{},
+		Files:        []string{},
+		Note:         l.Note,
+		Splits:       []SplitSnapshot{},
+	}
+
+	for _, row := range l.Labels {
+		snap.LabelIds = append(snap.LabelIds, row.Id)
+		snap.Labels = append(snap.Labels, row.Name)
+	}
+
+	for _, row := range l.Files {
+		snap.FileIds = append(snap.FileIds, row.Id)
+		snap.Files = append(snap.Files, row.Name)
+	}
+
+	for _, row := range l.Splits {
+		split := SplitSnapshot{Amount: row.Amount, CategoryId: row.Category.Id, Category: row.Category.Name, LabelIds: []uint{}, Note: row.Note}
+
+		for _, lb := range row.Labels {
+			split.LabelIds = append(split.LabelIds, lb.Id)
+		}
+
+		snap.Splits = append(snap.Splits, split)
+	}
+
+	return snap
+}
+
+//
+// DiffLedgerSnapshots - The fields that changed between two snapshots.
+//
+func DiffLedgerSnapshots(from LedgerSnapshot, to LedgerSnapshot) []FieldChange {
+	changes := []FieldChange{}
+
+	add := func(field string, a string, b string) {
+		if a != b {
+			changes = append(changes, FieldChange{Field: field, From: a, To: b})
+		}
+	}
+
+	add("amount", formatSnapshotAmount(from), formatSnapshotAmount(to))
+	add("date", formatSnapshotDate(from.Date), formatSnapshotDate(to.Date))
+	add("contact", from.Contact, to.Contact)
+	add("category", from.Category, to.Category)
+	add("labels", strings.Join(from.Labels, ", "), strings.Join(to.Labels, ", "))
+	add("files", strings.Join(from.Files, ", "), strings.Join(to.Files, ", "))
+	add("note", from.Note, to.Note)
+	add("splits", formatSnapshotSplits(from.Splits), formatSnapshotSplits(to.Splits))
+
+	return changes
+}
+
+// ----------------- Private Helper Funcs -------------- //
+
+//
+// formatSnapshotAmount - Empty snapshots have no amount.
+//
+func formatSnapshotAmount(snap LedgerSnapshot) string {
+	if snap.Date.IsZero() {
+		return ""
+	}
+
+	return fmt.Sprintf("%.2f", snap.Amount)
+}
+
+//
+// formatSnapshotDate - Dates in diffs are just the day.
+//
+func formatSnapshotDate(date time.Time) string {
+	if date.IsZero() {
+		return ""
+	}
+
+	return date.Format("2006-01-02")
+}
+
+//
+// formatSnapshotSplits - One line per split.
+//
+func formatSnapshotSplits(splits []SplitSnapshot) string {
+	lines := []string{}
+
+	for _, row := range splits {
+		lines = append(lines, fmt.Sprintf("%.2f %s", row.Amount, row.Category))
+	}
+
+	return strings.Join(lines, ", ")
+}
+
+/* End File */
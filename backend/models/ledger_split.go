@@ -0,0 +1,146 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package models
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"time"
+)
+
+// LedgerLinesTable can be used in place of the Ledger table in reporting SQL.
+// Entries without splits come back as is. Entries with splits come back once per
+// split line with the line's category and amount. The column names match the
+// Ledger table so existing queries only need to swap the FROM. Transfers between
+// balance sheet accounts are not income or expense so they are left out.
+const LedgerLinesTable = "(SELECT LedgerId, LedgerAccountId, LedgerContactId, LedgerDate, LedgerCategoryId, LedgerAmount FROM Ledger " +
+	"WHERE LedgerDeletedAt IS NULL AND LedgerTransferChartAccountId = 0 AND NOT EXISTS (SELECT 1 FROM ledger_splits WHERE ledger_splits.ledger_id = Ledger.LedgerId) " +
+	"UNION ALL " +
+	"SELECT LedgerId, LedgerAccountId, LedgerContactId, LedgerDate, ledger_splits.category_id AS LedgerCategoryId, ledger_splits.amount AS LedgerAmount " +
+	"FROM ledger_splits JOIN Ledger ON Ledger.LedgerId = ledger_splits.ledger_id WHERE LedgerDeletedAt IS NULL AND LedgerTransferChartAccountId = 0) AS Ledger"
+
+// LedgerSplit struct - One line of a ledger entry that is spread across categories.
+type LedgerSplit struct {
+	Id         uint      `gorm:"primary_key" json:"id"`
+	CreatedAt  time.Time `sql:"not null" json:"-"`
+	UpdatedAt  time.Time `sql:"not null" json:"-"`
+	AccountId  uint      `sql:"not null;index:account_id" json:"account_id"`
+	LedgerId   uint      `sql:"not null;index:ledger_id" json:"ledger_id"`
+	Amount     float64   `sql:"not null;type:DECIMAL(12,2)" json:"amount"`
+	CategoryId uint      `sql:"not null;index:category_id" json:"category_id"`
+	Category   Category  `json:"category"`
+	Labels     []Label   `gorm:"many2many:ledger_split_labels;association_jointable_foreignkey:label_id;jointable_foreignkey:ledger_split_id" json:"labels"`
+	Note       string    `sql:"not null;type:TEXT" json:"note"`
+}
+
+//
+// ValidateLedgerSplits - Each line needs an amount and a category and the lines
+// must add up to the entry amount.
+//
+func (db *DB) ValidateLedgerSplits(ledger Ledger, accountId uint, objId uint, action string) error {
+	// Splits are optional.
+	if len(ledger.Splits) == 0 {
+		return nil
+	}
+
+	if len(ledger.Splits) == 1 {
+		return errors.New("A split entry needs at least two lines.")
+	}
+
+	total := 0.00
+
+	for _, row := range ledger.Splits {
+		if row.Amount == 0 {
+			return errors.New("Each split line needs an amount.")
+		}
+
+		if (row.Category.Id == 0) && (len(strings.Trim(row.Category.Name, " ")) == 0 || len(strings.Trim(row.Category.Type, " ")) == 0) {
+			return errors.New("Each split line needs a category.")
+		}
+
+		total = total + row.Amount
+	}
+
+	// Compare in cents so float math does not bite us.
+	if math.Round(total*100) != math.Round(ledger.Amount*100) {
+		return errors.New("The split amounts must add up to the entry amount.")
+	}
+
+	// All good in the hood
+	return nil
+}
+
+//
+// deleteLedgerSplits removes all the split lines (and their labels) for a ledger entry.
+//
+func (db *DB) deleteLedgerSplits(ledgerId uint) {
+	db.New().Exec("DELETE FROM ledger_split_labels WHERE ledger_split_id IN (SELECT id FROM ledger_splits WHERE ledger_id = ?)", ledgerId)
+	db.New().Where("ledger_id = ?", ledgerId).Delete(LedgerSplit{})
+}
+
+//
+// prepLedgerSplits sets up the split lines before we save the ledger entry.
+//
+func prepLedgerSplits(db *DB, ledger *Ledger) {
+	largest := -1.00
+	pickCategory := (ledger.Category.Id == 0) && (len(strings.Trim(ledger.Category.Name, " ")) == 0)
+
+	for key := range ledger.Splits {
+		row := &ledger.Splits[key]
+
+		// We always start fresh.
+		row.Id = 0
+		row.LedgerId = ledger.Id
+		row.AccountId = ledger.AccountId
+		row.Note = strings.Trim(row.Note, " ")
+
+		// Setup the category. If we have an id it must be in this account.
+		if row.Category.Id > 0 {
+			cat, err := db.GetCategoryByAccountAndId(ledger.AccountId, row.Category.Id)
+
+			if err == nil {
+				row.Category = cat
+			} else {
+				row.Category.Id = 0
+			}
+		}
+
+		if row.Category.Id == 0 {
+			row.Category.AccountId = ledger.AccountId
+			row.Category.Name = strings.Trim(row.Category.Name, " ")
+			row.Category.Type = strings.Trim(row.Category.Type, " ")
+			db.Where("CategoriesAccountId = ? AND CategoriesName = ? AND CategoriesType = ?", ledger.AccountId, row.Category.Name, row.Category.Type).FirstOrCreate(&row.Category)
+		}
+
+		row.CategoryId = row.Category.Id
+
+		// Setup the labels
+		labels := []Label{}
+
+		for _, lb := range row.Labels {
+			name := strings.Trim(lb.Name, " ")
+
+			if len(name) == 0 {
+				continue
+			}
+
+			labels = append(labels, db.GetOrCreateLabel(ledger.AccountId, name))
+		}
+
+		row.Labels = labels
+
+		// The entry's own category is the biggest line if we did not get one.
+		if pickCategory && math.Abs(row.Amount) > largest {
+			largest = math.Abs(row.Amount)
+			ledger.Category = row.Category
+		}
+	}
+}
+
+/* End File */
@@ -0,0 +1,233 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package models
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// The GET /ledger parameters a view can save.
+var LedgerViewFilterKeys = []string{"type", "category_id", "year", "start_date", "end_date", "status", "label_ids", "search", "q"}
+
+// The ledger columns a view can show.
+var LedgerViewColumns = []string{"date", "contact", "category", "labels", "note", "amount", "status", "files", "source"}
+
+// LedgerView struct - A named set of ledger filters with a sort order and the
+// columns to show. Views are private to the user that made them unless shared
+// with the account.
+type LedgerView struct {
+	Id        uint              `gorm:"primary_key" json:"id"`
+	CreatedAt time.Time         `sql:"not null" json:"-"`
+	UpdatedAt time.Time         `sql:"not null" json:"-"`
+	AccountId uint              `sql:"not null;index:account_id" json:"account_id"`
+	UserId    uint              `sql:"not null" json:"user_id"` // Who made it
+	Shared    bool              `sql:"not null" json:"shared"`  // Everyone in the account can see and change it.
+	Name      string            `sql:"not null" json:"name"`
+	Filters   map[string]string `gorm:"-" json:"filters"`
+	Query     string            `sql:"not null;type:TEXT" json:"-"` // Filters stored as a query string.
+	Order     string            `sql:"not null" json:"order"`       // LedgerDate or LedgerId
+	Sort      string            `sql:"not null" json:"sort"`        // ASC or DESC
+	Columns   string            `sql:"not null" json:"columns"`     // Comma separated.
+}
+
+//
+// Validate for this model.
+//
+func (a LedgerView) Validate(db Datastore, action string, userId uint, accountId uint, objId uint) error {
+	return validation.ValidateStruct(&a,
+
+		validation.Field(&a.Name,
+			validation.Required.Error("The name field is required."),
+			validation.By(func(value interface{}) error {
+				// Names only need to be unique within what this user can see.
+				count := 0
+				db.New().Model(&LedgerView{}).Where("account_id = ? AND id != ? AND LOWER(name) = LOWER(?) AND (shared = ? OR user_id = ?)", accountId, objId, strings.TrimSpace(a.Name), true, userId).Count(&count)
+
+				if count > 0 {
+					return errors.New("A view with this name already exists.")
+				}
+
+				return nil
+			}),
+		),
+
+		validation.Field(&a.Filters,
+			validation.By(func(value interface{}) error {
+				for key, row := range a.Filters {
+					if !inStringList(key, LedgerViewFilterKeys) {
+						return fmt.Errorf("%s is not a ledger filter. Use %s.", key, strings.Join(LedgerViewFilterKeys, ", "))
+					}
+
+					if (key == "q") && (len(row) > 0) {
+						if _, err := ParseLedgerFilter(row); err != nil {
+							return err
+						}
+					}
+				}
+
+				return nil
+			}),
+		),
+
+		validation.Field(&a.Order,
+			validation.In("LedgerDate", "LedgerId").Error("The order field must be LedgerDate or LedgerId."),
+		),
+
+		validation.Field(&a.Sort,
+			validation.In("ASC", "DESC", "asc", "desc").Error("The sort field must be ASC or DESC."),
+		),
+
+		validation.Field(&a.Columns,
+			validation.By(func(value interface{}) error {
+				for _, row := range a.ColumnList() {
+					if !inStringList(row, LedgerViewColumns) {
+						return fmt.Errorf("%s is not a ledger column. Use %s.", row, strings.Join(LedgerViewColumns, ", "))
+					}
+				}
+
+				return nil
+			}),
+		),
+	)
+}
+
+//
+// LedgerViewCreate - Create a new view.
+//
+func (db *DB) LedgerViewCreate(v *LedgerView) error {
+	prepLedgerViewVars(v)
+	return db.New().Create(v).Error
+}
+
+//
+// LedgerViewUpdate - Update a view.
+//
+func (db *DB) LedgerViewUpdate(v *LedgerView) error {
+	prepLedgerViewVars(v)
+	return db.New().Save(v).Error
+}
+
+//
+// GetLedgerViewsByAccountAndUser - The views a user can see, shared views first.
+//
+func (db *DB) GetLedgerViewsByAccountAndUser(accountId uint, userId uint) []LedgerView {
+	views := []LedgerView{}
+	db.New().Where("account_id = ? AND (shared = ? OR user_id = ?)", accountId, true, userId).Order("shared DESC, name ASC").Find(&views)
+
+	for key := range views {
+		views[key].Filters = views[key].FilterMap()
+	}
+
+	return views
+}
+
+//
+// GetLedgerViewByAccountAndId - A view by account and id. Private views only
+// come back for the user that made them.
+//
+func (db *DB) GetLedgerViewByAccountAndId(accountId uint, userId uint, id uint) (LedgerView, error) {
+	v := LedgerView{}
+
+	// Make query
+	if db.New().Where("account_id = ? AND id = ? AND (shared = ? OR user_id = ?)", accountId, id, true, userId).First(&v).RecordNotFound() {
+		return LedgerView{}, errors.New("View not found.")
+	}
+
+	v.Filters = v.FilterMap()
+
+	// Return result
+	return v, nil
+}
+
+//
+// DeleteLedgerViewByAccountAndId - Delete a view by account and id.
+//
+func (db *DB) DeleteLedgerViewByAccountAndId(accountId uint, id uint) error {
+	return db.New().Where("account_id = ? AND id = ?", accountId, id).Delete(LedgerView{}).Error
+}
+
+//
+// FilterMap - The stored filters as a map.
+//
+func (a LedgerView) FilterMap() map[string]string {
+	filters := map[string]string{}
+	values, _ := url.ParseQuery(a.Query)
+
+	for key := range values {
+		filters[key] = values.Get(key)
+	}
+
+	return filters
+}
+
+//
+// ColumnList - The columns as a list.
+//
+func (a LedgerView) ColumnList() []string {
+	cols := []string{}
+
+	for _, row := range strings.Split(a.Columns, ",") {
+		if len(strings.TrimSpace(row)) > 0 {
+			cols = append(cols, strings.TrimSpace(row))
+		}
+	}
+
+	return cols
+}
+
+// ----------------- Private Helper Funcs -------------- //
+
+//
+// prepLedgerViewVars - Clean up and set defaults.
+//
+func prepLedgerViewVars(v *LedgerView) {
+	v.Name = strings.TrimSpace(v.Name)
+	v.Sort = strings.ToUpper(v.Sort)
+	v.Columns = strings.Join(v.ColumnList(), ",")
+
+	if len(v.Order) == 0 {
+		v.Order = "LedgerDate"
+	}
+
+	if len(v.Sort) == 0 {
+		v.Sort = "DESC"
+	}
+
+	// Empty filters are the same as not set.
+	values := url.Values{}
+
+	for key, row := range v.Filters {
+		if len(strings.TrimSpace(row)) > 0 {
+			values.Set(key, strings.TrimSpace(row))
+		}
+	}
+
+	v.Query = values.Encode()
+	v.Filters = v.FilterMap()
+}
+
+//
+// inStringList - Is s in list.
+//
+func inStringList(s string, list []string) bool {
+	for _, row := range list {
+		if row == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+/* End File */
@@ -0,0 +1,223 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// PeriodLock struct - A range of dates where ledger entries can not be added,
+// changed or deleted. This is on top of the account's books closed through date.
+type PeriodLock struct {
+	Id        uint      `gorm:"primary_key" json:"id"`
+	CreatedAt time.Time `sql:"not null" json:"-"`
+	UpdatedAt time.Time `sql:"not null" json:"-"`
+	AccountId uint      `sql:"not null;index:account_id" json:"account_id"`
+	UserId    uint      `sql:"not null" json:"user_id"`
+	StartDate time.Time `sql:"not null" json:"start_date"`
+	EndDate   time.Time `sql:"not null" json:"end_date"`
+	Note      string    `sql:"not null;type:TEXT" json:"note"`
+}
+
+//
+// Validate for this model.
+//
+func (a PeriodLock) Validate(db Datastore, action string, userId uint, accountId uint, objId uint) error {
+	return validation.ValidateStruct(&a,
+
+		validation.Field(&a.StartDate,
+			validation.Required.Error("The start_date field is required."),
+		),
+
+		validation.Field(&a.EndDate,
+			validation.Required.Error("The end_date field is required."),
+			validation.By(func(value interface{}) error {
+				if a.EndDate.Before(a.StartDate) {
+					return errors.New("The end_date must be on or after the start_date.")
+				}
+				return nil
+			}),
+		),
+	)
+}
+
+//
+// PeriodLockCreate - Lock a period and log it.
+//
+func (db *DB) PeriodLockCreate(p *PeriodLock) error {
+	p.StartDate = dateOnly(p.StartDate)
+	p.EndDate = dateOnly(p.EndDate)
+	p.Note = strings.TrimSpace(p.Note)
+
+	db.New().Create(p)
+
+	db.logPeriodActivity(p.AccountId, p.UserId, "create", p.StartDate.Format("2006-01-02")+" to "+p.EndDate.Format("2006-01-02"))
+
+	return nil
+}
+
+//
+// GetPeriodLockByAccountAndId by account and id.
+//
+func (db *DB) GetPeriodLockByAccountAndId(accountId uint, id uint) (PeriodLock, error) {
+	p := PeriodLock{}
+
+	// Make query
+	if db.New().Where("account_id = ? AND id = ?", accountId, id).First(&p).RecordNotFound() {
+		return PeriodLock{}, errors.New("Period not found.")
+	}
+
+	// Return result
+	return p, nil
+}
+
+//
+// ReopenPeriodLock - Remove a period lock and log who did it. Only the account
+// owner should be able to get here.
+//
+func (db *DB) ReopenPeriodLock(p PeriodLock, userId uint) error {
+	db.New().Delete(&p)
+
+	db.logPeriodActivity(p.AccountId, userId, "reopen", p.StartDate.Format("2006-01-02")+" to "+p.EndDate.Format("2006-01-02"))
+
+	return nil
+}
+
+//
+// SetBooksClosedThrough - Close the books through a date. A zero date opens
+// everything. Moving the date back is a reopen and should only be done by the
+// account owner.
+//
+func (db *DB) SetBooksClosedThrough(account *Account, userId uint, through time.Time) error {
+	org := account.BooksClosedThrough
+	account.BooksClosedThrough = dateOnly(through)
+
+	db.New().Model(account).UpdateColumn("books_closed_through", account.BooksClosedThrough)
+
+	// Log it.
+	if account.BooksClosedThrough.Before(org) {
+		name := "all dates"
+
+		if !account.BooksClosedThrough.IsZero() {
+			name = "dates after " + account.BooksClosedThrough.Format("2006-01-02")
+		}
+
+		db.logPeriodActivity(account.Id, userId, "reopen", name)
+	} else if account.BooksClosedThrough.After(org) {
+		db.logPeriodActivity(account.Id, userId, "create", "dates through "+account.BooksClosedThrough.Format("2006-01-02"))
+	}
+
+	return nil
+}
+
+//
+// ValidateLedgerPeriodOpen - Returns an error if the date is in a closed period.
+//
+func (db *DB) ValidateLedgerPeriodOpen(accountId uint, date time.Time) error {
+	day := dateOnly(date)
+
+	// Books closed through
+	account := Account{}
+	db.New().Select("id, books_closed_through").Where("id = ?", accountId).First(&account)
+
+	if !account.BooksClosedThrough.IsZero() && !day.After(dateOnly(account.BooksClosedThrough)) {
+		return fmt.Errorf("The books are closed through %s. Entries dated on or before then can not be added, changed or deleted.", account.BooksClosedThrough.Format("January 2, 2006"))
+	}
+
+	// Period locks
+	lock := PeriodLock{}
+	db.New().Where("account_id = ? AND start_date <= ? AND end_date >= ?", accountId, day, day).First(&lock)
+
+	if lock.Id > 0 {
+		return fmt.Errorf("The period %s to %s is closed. Entries in it can not be added, changed or deleted.", lock.StartDate.Format("January 2, 2006"), lock.EndDate.Format("January 2, 2006"))
+	}
+
+	return nil
+}
+
+//
+// MoveLedgerToOpenPeriod - Entries we create without a person (Stripe, Snap!Clerk)
+// can not be rejected so if they land in a closed period we move them to the
+// first open day and say so in the note. Returns true if we moved it.
+//
+func (db *DB) MoveLedgerToOpenPeriod(ledger *Ledger) bool {
+	org := ledger.Date
+
+	// Every pass moves past one closed period. Cap it in case something is off.
+	for i := 0; i < 100; i++ {
+		if db.ValidateLedgerPeriodOpen(ledger.AccountId, ledger.Date) == nil {
+			break
+		}
+
+		ledger.Date = db.getPeriodEnd(ledger.AccountId, ledger.Date).AddDate(0, 0, 1)
+	}
+
+	if ledger.Date.Equal(org) {
+		return false
+	}
+
+	ledger.Note = strings.TrimSpace(ledger.Note + "\n\nOriginally dated " + org.Format("January 2, 2006") + ". Moved because the books are closed.")
+
+	return true
+}
+
+// ----------------- Private Helper Funcs -------------- //
+
+//
+// getPeriodEnd - The last closed day of the period this date is in.
+//
+func (db *DB) getPeriodEnd(accountId uint, date time.Time) time.Time {
+	day := dateOnly(date)
+
+	account := Account{}
+	db.New().Select("id, books_closed_through").Where("id = ?", accountId).First(&account)
+
+	if !account.BooksClosedThrough.IsZero() && !day.After(dateOnly(account.BooksClosedThrough)) {
+		return dateOnly(account.BooksClosedThrough)
+	}
+
+	lock := PeriodLock{}
+	db.New().Where("account_id = ? AND start_date <= ? AND end_date >= ?", accountId, day, day).Order("end_date DESC").First(&lock)
+
+	if lock.Id > 0 {
+		return dateOnly(lock.EndDate)
+	}
+
+	return day
+}
+
+//
+// logPeriodActivity - Closing and reopening periods goes in the activity log.
+//
+func (db *DB) logPeriodActivity(accountId uint, userId uint, subAction string, name string) {
+	db.New().Create(&Activity{
+		AccountId: accountId,
+		UserId:    userId,
+		Action:    "period",
+		SubAction: subAction,
+		Name:      name,
+	})
+}
+
+//
+// dateOnly - Drop the time so we compare days.
+//
+func dateOnly(t time.Time) time.Time {
+	if t.IsZero() {
+		return t
+	}
+
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+/* End File */
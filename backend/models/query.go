@@ -9,14 +9,22 @@
 package models
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"math"
 	"os"
+	"reflect"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/jinzhu/gorm"
 )
 
+// ErrInvalidCursor is returned when a cursor can't be decoded or was made for a different order.
+var ErrInvalidCursor = errors.New("The cursor is not valid.")
+
 type QueryParam struct {
 	Limit            int
 	Offset           int
@@ -29,6 +37,10 @@ type QueryParam struct {
 	Wheres           []KeyValue
 	PreLoads         []string
 	AllowedOrderCols []string
+	UseCursor        bool   // Page with keyset cursors instead of OFFSET.
+	Cursor           string // Cursor from the last page. Empty for the first page.
+	CursorCol        string // Unique column that breaks ties in the order. Ex. LedgerId
+	NoCount          bool   // Skip the COUNT(*) of the whole result.
 }
 
 type KeyValue struct {
@@ -38,6 +50,8 @@ type KeyValue struct {
 	ValueFloat   float64
 	ValueIntList []int
 	Compare      string
+	Sql          string        // A where of its own with ? for each of Args. Key and Compare are not used.
+	Args         []interface{} // Bound to the ? in Sql.
 }
 
 type QueryMetaData struct {
@@ -46,7 +60,18 @@ type QueryMetaData struct {
 	Offset       int
 	PageCount    int
 	LastPage     bool
-	NoLimitCount int
+	NoLimitCount int    // -1 when we did not count.
+	NextCursor   string // Empty on the last page.
+}
+
+// queryCursor is what we encode into the cursor we hand out. The value of
+// the order column and the tie breaker from the last row on the page.
+type queryCursor struct {
+	Order string `json:"o"`
+	Sort  string `json:"s"`
+	Kind  string `json:"k"`
+	Value string `json:"v"`
+	Id    uint64 `json:"i"`
 }
 
 //
@@ -54,6 +79,11 @@ type QueryMetaData struct {
 //
 func (t *DB) QueryMeta(model interface{}, params QueryParam) (QueryMetaData, error) {
 
+	// Cursors and skipping the count look one row ahead instead.
+	if params.UseCursor || params.NoCount {
+		return t.queryMetaLookAhead(model, params)
+	}
+
 	// Get no filter count.
 	noFilterCount, err := t.QueryWithNoFilterCount(model, params)
 
@@ -96,6 +126,29 @@ func (t *DB) Query(model interface{}, params QueryParam) error {
 	return nil
 }
 
+//
+// QuerySubQuery - The wheres in params as "SELECT col FROM model WHERE ...".
+// Order and paging are left off. Pass it as the value of a ? in a Where or
+// Raw. Ex. LedgerId IN (?)
+//
+func (t *DB) QuerySubQuery(model interface{}, col string, params QueryParam) (interface{}, error) {
+	params.Order = ""
+	params.Page = 0
+	params.Limit = 0
+	params.Offset = 0
+	params.PreLoads = []string{}
+	params.UseCursor = false
+
+	// Build the query.
+	query, err := t.buildGenericQuery(params)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return query.Model(model).Select(col).QueryExpr(), nil
+}
+
 //
 // A generic way to query any model we want with meta data.
 //
@@ -187,32 +240,8 @@ func (t *DB) buildGenericQuery(params QueryParam) (*gorm.DB, error) {
 	}
 
 	// Set order and get query object
-	if (len(params.Order) > 0) && (len(params.Sort) > 0) {
-		// Check database driver for case-insensitive sorting
-		driver := os.Getenv("DB_DRIVER")
-		if driver == "" {
-			driver = "sqlite3"
-		}
-		
-		// For SQLite, add COLLATE NOCASE for text columns to ensure case-insensitive sorting
-		if driver == "sqlite3" && strings.Contains(params.Order, "Name") {
-			query = t.Order(params.Order + " COLLATE NOCASE " + params.Sort)
-		} else {
-			query = t.Order(params.Order + " " + params.Sort)
-		}
-	} else if len(params.Order) > 0 {
-		// Check database driver for case-insensitive sorting
-		driver := os.Getenv("DB_DRIVER")
-		if driver == "" {
-			driver = "sqlite3"
-		}
-		
-		// For SQLite, add COLLATE NOCASE for text columns to ensure case-insensitive sorting
-		if driver == "sqlite3" && strings.Contains(params.Order, "Name") {
-			query = t.Order(params.Order + " COLLATE NOCASE ASC")
-		} else {
-			query = t.Order(params.Order + " ASC")
-		}
+	if len(params.Order) > 0 {
+		query = t.Order(orderColumn(params.Order) + " " + querySort(params))
 	}
 
 	// Are we debugging this?
@@ -220,8 +249,23 @@ func (t *DB) buildGenericQuery(params QueryParam) (*gorm.DB, error) {
 		query = query.Debug()
 	}
 
+	// Keyset paging. Rows after the cursor in the same order, ties broken by CursorCol.
+	if params.UseCursor {
+		query = query.Order(params.CursorCol + " " + querySort(params))
+
+		if len(params.Cursor) > 0 {
+			where, args, err := cursorWhere(params)
+
+			if err != nil {
+				return query, err
+			}
+
+			query = query.Where(where, args...)
+		}
+	}
+
 	// If we passed in a page we figure out the offset from the page.
-	if params.Page > 0 {
+	if (params.Page > 0) && !params.UseCursor {
 		if (params.Page > 0) && (params.Limit > 0) {
 			params.Offset = (params.Page * params.Limit) - params.Limit
 		}
@@ -259,6 +303,10 @@ func (t *DB) buildGenericQuery(params QueryParam) (*gorm.DB, error) {
 		if len(row.ValueIntList) > 0 {
 			query = query.Where(row.Key+" "+row.Compare+" (?)", row.ValueIntList)
 		}
+
+		if len(row.Sql) > 0 {
+			query = query.Where(row.Sql, row.Args...)
+		}
 	}
 
 	// Search a particular column
@@ -280,4 +328,221 @@ func (t *DB) buildGenericQuery(params QueryParam) (*gorm.DB, error) {
 	return query, nil
 }
 
+// ----------------- Private Helper Funcs -------------- //
+
+//
+// queryMetaLookAhead - Query one more row than the limit so we know if there
+// is another page without counting. Builds the next cursor when paging with cursors.
+//
+func (t *DB) queryMetaLookAhead(model interface{}, params QueryParam) (QueryMetaData, error) {
+	meta := QueryMetaData{Limit: params.Limit, NoLimitCount: -1}
+
+	// Count only if asked. The count ignores the cursor.
+	if !params.NoCount {
+		countParams := params
+		countParams.UseCursor = false
+		countParams.Cursor = ""
+
+		count, err := t.QueryWithNoFilterCount(model, countParams)
+
+		if err != nil {
+			return QueryMetaData{}, err
+		}
+
+		meta.NoLimitCount = count
+	}
+
+	// Ask for one extra row.
+	q := params
+
+	if q.Limit > 0 {
+		if !q.UseCursor && (q.Page > 0) {
+			meta.Page = q.Page
+			meta.Offset = (q.Page * q.Limit) - q.Limit
+			q.Offset = meta.Offset
+		}
+
+		q.Page = 0
+		q.Limit = q.Limit + 1
+	}
+
+	if err := t.Query(model, q); err != nil {
+		return QueryMetaData{}, err
+	}
+
+	// Drop the extra row.
+	rows := reflect.ValueOf(model).Elem()
+	meta.LastPage = true
+
+	if (params.Limit > 0) && (rows.Len() > params.Limit) {
+		meta.LastPage = false
+		rows.Set(rows.Slice(0, params.Limit))
+	}
+
+	if (params.Limit > 0) && (meta.NoLimitCount >= 0) {
+		meta.PageCount = int(math.Ceil(float64(meta.NoLimitCount) / float64(params.Limit)))
+	}
+
+	// Cursor for the next page from the last row.
+	if params.UseCursor && !meta.LastPage {
+		cursor, err := t.encodeQueryCursor(rows.Index(rows.Len()-1).Addr().Interface(), params)
+
+		if err != nil {
+			return QueryMetaData{}, err
+		}
+
+		meta.NextCursor = cursor
+	}
+
+	return meta, nil
+}
+
+//
+// encodeQueryCursor - Build an opaque cursor from a row.
+//
+func (t *DB) encodeQueryCursor(row interface{}, params QueryParam) (string, error) {
+	scope := t.NewScope(row)
+
+	order, ok := scope.FieldByName(params.Order)
+
+	if !ok {
+		return "", errors.New("Unable to make a cursor for " + params.Order)
+	}
+
+	id, ok := scope.FieldByName(params.CursorCol)
+
+	if !ok {
+		return "", errors.New("Unable to make a cursor for " + params.CursorCol)
+	}
+
+	qc := queryCursor{Order: params.Order, Sort: querySort(params)}
+
+	// Store the value as text with its kind so we can bind it back with the same type.
+	switch v := order.Field.Interface().(type) {
+	case time.Time:
+		qc.Kind = "time"
+		qc.Value = v.Format(time.RFC3339Nano)
+	case string:
+		qc.Kind = "string"
+		qc.Value = v
+	default:
+		switch order.Field.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			qc.Kind = "int"
+			qc.Value = strconv.FormatInt(order.Field.Int(), 10)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			qc.Kind = "int"
+			qc.Value = strconv.FormatUint(order.Field.Uint(), 10)
+		case reflect.Float32, reflect.Float64:
+			qc.Kind = "float"
+			qc.Value = strconv.FormatFloat(order.Field.Float(), 'f', -1, 64)
+		default:
+			return "", errors.New("Unable to make a cursor for " + params.Order)
+		}
+	}
+
+	switch id.Field.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		qc.Id = id.Field.Uint()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		qc.Id = uint64(id.Field.Int())
+	default:
+		return "", errors.New("Unable to make a cursor for " + params.CursorCol)
+	}
+
+	js, err := json.Marshal(qc)
+
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(js), nil
+}
+
+//
+// cursorWhere - The where clause for the rows after a cursor.
+//
+func cursorWhere(params QueryParam) (string, []interface{}, error) {
+	js, err := base64.RawURLEncoding.DecodeString(params.Cursor)
+
+	if err != nil {
+		return "", nil, ErrInvalidCursor
+	}
+
+	qc := queryCursor{}
+
+	if err := json.Unmarshal(js, &qc); err != nil {
+		return "", nil, ErrInvalidCursor
+	}
+
+	// A cursor only works with the order it was made for.
+	if (qc.Order != params.Order) || (qc.Sort != querySort(params)) {
+		return "", nil, ErrInvalidCursor
+	}
+
+	// Bind the value back with its type.
+	var value interface{}
+
+	switch qc.Kind {
+	case "time":
+		value, err = time.Parse(time.RFC3339Nano, qc.Value)
+	case "int":
+		value, err = strconv.ParseInt(qc.Value, 10, 64)
+	case "float":
+		value, err = strconv.ParseFloat(qc.Value, 64)
+	case "string":
+		value = qc.Value
+	default:
+		err = ErrInvalidCursor
+	}
+
+	if err != nil {
+		return "", nil, ErrInvalidCursor
+	}
+
+	compare := ">"
+
+	if querySort(params) == "DESC" {
+		compare = "<"
+	}
+
+	// Ordering by the tie breaker itself.
+	if params.Order == params.CursorCol {
+		return params.CursorCol + " " + compare + " ?", []interface{}{qc.Id}, nil
+	}
+
+	col := orderColumn(params.Order)
+
+	return "(" + col + " " + compare + " ?) OR (" + col + " = ? AND " + params.CursorCol + " " + compare + " ?)", []interface{}{value, value, qc.Id}, nil
+}
+
+//
+// querySort - The sort direction, ASC when not set.
+//
+func querySort(params QueryParam) string {
+	if len(params.Sort) == 0 {
+		return "ASC"
+	}
+
+	return strings.ToUpper(params.Sort)
+}
+
+//
+// orderColumn - The order column as we sort by it. For SQLite, add COLLATE
+// NOCASE for text columns to ensure case-insensitive sorting.
+//
+func orderColumn(order string) string {
+	driver := os.Getenv("DB_DRIVER")
+
+	if driver == "" {
+		driver = "sqlite3"
+	}
+
+	if driver == "sqlite3" && strings.Contains(order, "Name") {
+		return order + " COLLATE NOCASE"
+	}
+
+	return order
+}
+
 /* End File */
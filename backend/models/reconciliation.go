@@ -0,0 +1,295 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package models
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// Reconciliation struct - Matching the books to a bank statement. While a session
+// is open entries get ticked (cleared). When the cleared balance matches the
+// statement the session is finished and the cleared entries are reconciled and locked.
+type Reconciliation struct {
+	Id              uint      `gorm:"primary_key" json:"id"`
+	CreatedAt       time.Time `sql:"not null" json:"-"`
+	UpdatedAt       time.Time `sql:"not null" json:"-"`
+	AccountId       uint      `sql:"not null;index:account_id" json:"account_id"`
+	UserId          uint      `sql:"not null" json:"user_id"`
+	StatementDate   time.Time `sql:"not null" json:"statement_date"` // End date on the bank statement.
+	StartingBalance float64   `sql:"not null;type:DECIMAL(12,2)" json:"starting_balance"`
+	EndingBalance   float64   `sql:"not null;type:DECIMAL(12,2)" json:"ending_balance"`
+	Status          string    `sql:"not null;default:'open'" json:"status"` // open, finished
+	FinishedAt      time.Time `json:"finished_at"`
+	ClearedBalance  float64   `gorm:"-" json:"cleared_balance"`
+	ClearedCount    int       `gorm:"-" json:"cleared_count"`
+	Difference      float64   `gorm:"-" json:"difference"` // Ending balance less the cleared balance. Zero means we can finish.
+}
+
+//
+// Validate for this model.
+//
+func (a Reconciliation) Validate(db Datastore, action string, userId uint, accountId uint, objId uint) error {
+	return validation.ValidateStruct(&a,
+
+		validation.Field(&a.StatementDate,
+			validation.Required.Error("The statement_date field is required."),
+			validation.By(func(value interface{}) error { return db.ValidateReconciliationStatement(a, accountId, objId, action) }),
+		),
+	)
+}
+
+//
+// ValidateReconciliationStatement - Only one reconciliation can be open and the
+// statement has to be after the last one we finished.
+//
+func (db *DB) ValidateReconciliationStatement(r Reconciliation, accountId uint, objId uint, action string) error {
+	if open, err := db.GetOpenReconciliation(accountId); (err == nil) && (open.Id != objId) {
+		return errors.New("Finish or delete the open reconciliation first.")
+	}
+
+	if last, err := db.getLastFinishedReconciliation(accountId); (err == nil) && (last.Id != objId) && !r.StatementDate.After(last.StatementDate) {
+		return errors.New("The statement_date must be after the last reconciled statement.")
+	}
+
+	return nil
+}
+
+//
+// ReconciliationCreate - Start a new reconciliation. The starting balance is only
+// used on the first one. After that we start where the last one ended.
+//
+func (db *DB) ReconciliationCreate(r *Reconciliation) error {
+	r.Status = "open"
+	r.FinishedAt = time.Time{}
+
+	if last, err := db.getLastFinishedReconciliation(r.AccountId); err == nil {
+		r.StartingBalance = last.EndingBalance
+	}
+
+	db.New().Create(r)
+	return nil
+}
+
+//
+// ReconciliationUpdate - Update the statement on an open reconciliation.
+//
+func (db *DB) ReconciliationUpdate(r *Reconciliation) error {
+	if r.Status != "open" {
+		return errors.New("Only an open reconciliation can be changed. Undo it first.")
+	}
+
+	if last, err := db.getLastFinishedReconciliation(r.AccountId); (err == nil) && (last.Id != r.Id) {
+		r.StartingBalance = last.EndingBalance
+	}
+
+	db.New().Save(r)
+	db.setReconciliationTotals(r)
+
+	return nil
+}
+
+//
+// GetReconciliationByAccountAndId by account and id. Totals are filled in.
+//
+func (db *DB) GetReconciliationByAccountAndId(accountId uint, id uint) (Reconciliation, error) {
+	r := Reconciliation{}
+
+	// Make query
+	if db.New().Where("account_id = ? AND id = ?", accountId, id).First(&r).RecordNotFound() {
+		return Reconciliation{}, errors.New("Reconciliation not found.")
+	}
+
+	db.setReconciliationTotals(&r)
+
+	// Return result
+	return r, nil
+}
+
+//
+// GetOpenReconciliation - Get the reconciliation we are working on. There is
+// only ever one open at a time.
+//
+func (db *DB) GetOpenReconciliation(accountId uint) (Reconciliation, error) {
+	r := Reconciliation{}
+
+	// Make query
+	if db.New().Where("account_id = ? AND status = ?", accountId, "open").First(&r).RecordNotFound() {
+		return Reconciliation{}, errors.New("Reconciliation not found.")
+	}
+
+	db.setReconciliationTotals(&r)
+
+	// Return result
+	return r, nil
+}
+
+//
+// DeleteReconciliationByAccountAndId - Delete an open reconciliation. Ticked
+// entries stay cleared.
+//
+func (db *DB) DeleteReconciliationByAccountAndId(accountId uint, id uint) error {
+	r, err := db.GetReconciliationByAccountAndId(accountId, id)
+
+	if err != nil {
+		return err
+	}
+
+	if r.Status != "open" {
+		return errors.New("Only an open reconciliation can be deleted. Undo it first.")
+	}
+
+	db.New().Delete(&r)
+	return nil
+}
+
+//
+// SetLedgersCleared - Tick (or untick) entries on an open reconciliation.
+// Reconciled entries and entries after the statement date are skipped. Returns
+// the reconciliation with fresh totals.
+//
+func (db *DB) SetLedgersCleared(r Reconciliation, ledgerIds []uint, cleared bool) (Reconciliation, error) {
+	if r.Status != "open" {
+		return r, errors.New("This reconciliation is finished.")
+	}
+
+	status := "uncleared"
+
+	if cleared {
+		status = "cleared"
+	}
+
+	if len(ledgerIds) > 0 {
+		db.New().Model(&Ledger{}).
+			Where("LedgerAccountId = ? AND LedgerId IN (?) AND LedgerStatus != ? AND LedgerDate < ?", r.AccountId, ledgerIds, "reconciled", r.StatementDate.AddDate(0, 0, 1).Format("2006-01-02")).
+			UpdateColumn("LedgerStatus", status)
+	}
+
+	db.setReconciliationTotals(&r)
+
+	return r, nil
+}
+
+//
+// FinishReconciliation - Lock in the cleared entries once the cleared balance
+// matches the statement.
+//
+func (db *DB) FinishReconciliation(r *Reconciliation) error {
+	if r.Status != "open" {
+		return errors.New("This reconciliation is already finished.")
+	}
+
+	db.setReconciliationTotals(r)
+
+	if r.Difference != 0 {
+		return errors.New("The cleared balance does not match the statement ending balance.")
+	}
+
+	db.New().Model(&Ledger{}).
+		Where("LedgerAccountId = ? AND LedgerStatus = ? AND LedgerDate < ?", r.AccountId, "cleared", r.StatementDate.AddDate(0, 0, 1).Format("2006-01-02")).
+		UpdateColumns(map[string]interface{}{"LedgerStatus": "reconciled", "LedgerReconciliationId": r.Id})
+
+	r.Status = "finished"
+	r.FinishedAt = time.Now()
+	db.New().Save(r)
+
+	db.setReconciliationTotals(r)
+
+	return nil
+}
+
+//
+// UndoReconciliation - Unlock the entries of the last finished reconciliation
+// and open it back up. Only the last one can be undone so starting balances
+// stay correct.
+//
+func (db *DB) UndoReconciliation(r *Reconciliation) error {
+	if r.Status != "finished" {
+		return errors.New("Only a finished reconciliation can be undone.")
+	}
+
+	if _, err := db.GetOpenReconciliation(r.AccountId); err == nil {
+		return errors.New("Finish or delete the open reconciliation first.")
+	}
+
+	last, _ := db.getLastFinishedReconciliation(r.AccountId)
+
+	if last.Id != r.Id {
+		return errors.New("Only the most recent reconciliation can be undone.")
+	}
+
+	db.New().Model(&Ledger{}).
+		Where("LedgerAccountId = ? AND LedgerReconciliationId = ?", r.AccountId, r.Id).
+		UpdateColumns(map[string]interface{}{"LedgerStatus": "cleared", "LedgerReconciliationId": 0})
+
+	r.Status = "open"
+	r.FinishedAt = time.Time{}
+	db.New().Save(r)
+
+	db.setReconciliationTotals(r)
+
+	return nil
+}
+
+//
+// ValidateLedgerUnlocked - Returns an error if this entry can not be changed.
+//
+func (db *DB) ValidateLedgerUnlocked(ledger Ledger) error {
+	if ledger.Status == "reconciled" {
+		return errors.New("This entry has been reconciled. Undo the reconciliation to change it.")
+	}
+
+	return db.ValidateLedgerPeriodOpen(ledger.AccountId, ledger.Date)
+}
+
+// ----------------- Private Helper Funcs -------------- //
+
+//
+// getLastFinishedReconciliation - The reconciliation with the latest statement.
+//
+func (db *DB) getLastFinishedReconciliation(accountId uint) (Reconciliation, error) {
+	r := Reconciliation{}
+
+	if db.New().Where("account_id = ? AND status = ?", accountId, "finished").Order("statement_date DESC, id DESC").First(&r).RecordNotFound() {
+		return Reconciliation{}, errors.New("Reconciliation not found.")
+	}
+
+	return r, nil
+}
+
+//
+// setReconciliationTotals - Work out the cleared balance and difference. Open
+// reconciliations count everything cleared up to the statement date. Finished
+// ones count the entries they locked.
+//
+func (db *DB) setReconciliationTotals(r *Reconciliation) {
+	type total struct {
+		Count  int
+		Amount float64
+	}
+
+	rt := total{}
+	query := db.New().Model(&Ledger{}).Select("COUNT(LedgerId) AS count, COALESCE(SUM(LedgerAmount), 0) AS amount")
+
+	if r.Status == "finished" {
+		query = query.Where("LedgerAccountId = ? AND LedgerReconciliationId = ?", r.AccountId, r.Id)
+	} else {
+		query = query.Where("LedgerAccountId = ? AND LedgerStatus = ? AND LedgerDate < ?", r.AccountId, "cleared", r.StatementDate.AddDate(0, 0, 1).Format("2006-01-02"))
+	}
+
+	query.Scan(&rt)
+
+	r.ClearedCount = rt.Count
+	r.ClearedBalance = math.Round((r.StartingBalance+rt.Amount)*100) / 100
+	r.Difference = math.Round((r.EndingBalance-r.ClearedBalance)*100) / 100
+}
+
+/* End File */
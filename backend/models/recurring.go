@@ -0,0 +1,470 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// maxRecurringCatchUp is the most entries we will create for one template in a
+// single run. It keeps a bad start date from flooding the ledger.
+const maxRecurringCatchUp = 500
+
+// Recurring struct - A template we use to create ledger entries on a schedule.
+type Recurring struct {
+	Id          uint       `gorm:"primary_key" json:"id"`
+	CreatedAt   time.Time  `sql:"not null" json:"-"`
+	UpdatedAt   time.Time  `sql:"not null" json:"-"`
+	AccountId   uint       `sql:"not null;index:account_id" json:"account_id"`
+	AddedById   uint       `sql:"not null" json:"added_by_id"`
+	ContactId   uint       `sql:"not null" json:"contact_id"`
+	Contact     Contact    `json:"contact"`
+	CategoryId  uint       `sql:"not null" json:"category_id"`
+	Category    Category   `json:"category"`
+	Labels      []Label    `gorm:"many2many:recurring_labels;association_jointable_foreignkey:label_id;jointable_foreignkey:recurring_id" json:"labels"`
+	Amount      float64    `sql:"not null;type:DECIMAL(12,2)" json:"amount"`
+	Note        string     `sql:"not null;type:TEXT" json:"note"`
+	Frequency   string     `sql:"not null;default:'month'" json:"frequency"` // day, week, month, year
+	Interval    int        `sql:"not null;default:1" json:"interval"`        // Every X frequency. 2 + week = every other week.
+	StartDate   time.Time  `sql:"not null" json:"start_date"`
+	EndDate     *time.Time `json:"end_date"`
+	NextRunDate time.Time  `sql:"not null;index:next_run_date" json:"next_run_date"`
+	LastRunDate *time.Time `json:"last_run_date"`
+	Occurrence  int        `sql:"not null" json:"-"`                       // Index of the next occurrence counting from StartDate.
+	Status      string     `sql:"not null;default:'Active'" json:"status"` // Active, Paused, Completed
+}
+
+//
+// Validate for this model.
+//
+func (a Recurring) Validate(db Datastore, action string, userId uint, accountId uint, objId uint) error {
+	return validation.ValidateStruct(&a,
+
+		validation.Field(&a.Amount,
+			validation.Required.Error("The amount field is required."),
+		),
+
+		validation.Field(&a.StartDate,
+			validation.Required.Error("The start_date field is required."),
+		),
+
+		validation.Field(&a.Frequency,
+			validation.Required.Error("The frequency field is required."),
+			validation.In("day", "week", "month", "year").Error("The frequency field must be day, week, month, or year."),
+		),
+
+		validation.Field(&a.Interval,
+			validation.Min(1).Error("The interval field must be a positive number."),
+		),
+
+		validation.Field(&a.Status,
+			validation.In("Active", "Paused").Error("The status field must be Active or Paused."),
+		),
+
+		validation.Field(&a.EndDate,
+			validation.By(func(value interface{}) error {
+				if a.EndDate != nil && !a.EndDate.IsZero() && a.EndDate.Before(a.StartDate) {
+					return errors.New("The end_date must be after the start_date.")
+				}
+				return nil
+			}),
+		),
+
+		validation.Field(&a.Category,
+			validation.By(func(value interface{}) error {
+				return db.ValidateLedgerCategory(Ledger{Category: a.Category}, accountId, objId, action)
+			}),
+		),
+
+		validation.Field(&a.Contact,
+			validation.By(func(value interface{}) error {
+				return db.ValidateLedgerContact(Ledger{Contact: a.Contact}, accountId, objId, action)
+			}),
+		),
+	)
+}
+
+//
+// OccurrenceDate returns the date of the nth occurrence of this template. We always
+// count from the start date so monthly entries on the 31st land on the last day of
+// shorter months instead of drifting.
+//
+func (a Recurring) OccurrenceDate(n int) time.Time {
+	interval := a.Interval
+
+	if interval < 1 {
+		interval = 1
+	}
+
+	switch a.Frequency {
+	case "day":
+		return a.StartDate.AddDate(0, 0, n*interval)
+
+	case "week":
+		return a.StartDate.AddDate(0, 0, n*interval*7)
+
+	case "year":
+		return addMonthsClamped(a.StartDate, n*interval*12)
+
+	default:
+		return addMonthsClamped(a.StartDate, n*interval)
+	}
+}
+
+//
+// RecurringCreate - Create a new recurring template.
+//
+func (db *DB) RecurringCreate(r *Recurring) error {
+	// Prep Vars
+	prepRecurringVars(db, r)
+
+	// First run is the start date.
+	r.Occurrence = 0
+	r.NextRunDate = r.StartDate
+
+	// Store this template.
+	db.New().Create(r)
+
+	return nil
+}
+
+//
+// RecurringUpdate - Update a recurring template. If the schedule changed we
+// start counting again from the new start date but never re-create an entry
+// we already made.
+//
+func (db *DB) RecurringUpdate(org Recurring, r *Recurring) error {
+	// Keep the status if we were not sent one. Before prep so it is not
+	// defaulted to Active.
+	if len(r.Status) == 0 {
+		r.Status = org.Status
+	}
+
+	// Prep Vars
+	prepRecurringVars(db, r)
+
+	// Keep the run state from the original.
+	r.Id = org.Id
+	r.CreatedAt = org.CreatedAt
+	r.AddedById = org.AddedById
+	r.LastRunDate = org.LastRunDate
+	r.Occurrence = org.Occurrence
+
+	// Schedule changed. Find the first occurrence after the last run.
+	if !r.StartDate.Equal(org.StartDate) || r.Frequency != org.Frequency || r.Interval != org.Interval {
+		r.Occurrence = 0
+
+		if r.LastRunDate != nil {
+			r.advancePast(r.LastRunDate.AddDate(0, 0, 1))
+		}
+	}
+
+	// Coming back from a pause we skip what we missed.
+	if org.Status == "Paused" && r.Status == "Active" {
+		now := time.Now().UTC()
+		r.advancePast(time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC))
+	}
+
+	r.NextRunDate = r.OccurrenceDate(r.Occurrence)
+
+	// Done once the next run is past the end date. Moving the end date out
+	// brings a completed template back.
+	if r.EndDate != nil && r.NextRunDate.After(*r.EndDate) {
+		r.Status = "Completed"
+	} else if r.Status == "Completed" {
+		r.Status = "Active"
+	}
+
+	// Clear out old labels. We start fresh every time.
+	db.New().Exec("DELETE FROM recurring_labels WHERE recurring_id = ?", r.Id)
+
+	// Update this template.
+	db.New().Save(r)
+
+	return nil
+}
+
+//
+// GetRecurringByAccountAndId by account and id.
+//
+func (db *DB) GetRecurringByAccountAndId(accountId uint, id uint) (Recurring, error) {
+	r := Recurring{}
+
+	// Make query
+	if db.New().Preload("Contact").Preload("Category").Preload("Labels").Where("account_id = ? AND id = ?", accountId, id).First(&r).RecordNotFound() {
+		return Recurring{}, errors.New("Recurring entry not found.")
+	}
+
+	// Return result
+	return r, nil
+}
+
+//
+// DeleteRecurringByAccountAndId - Delete a recurring template by account and id.
+// Ledger entries already created from it are left alone.
+//
+func (db *DB) DeleteRecurringByAccountAndId(accountId uint, id uint) error {
+	// Make query to delete
+	db.New().Where("account_id = ? AND id = ?", accountId, id).Delete(Recurring{})
+
+	// Delete from look up table.
+	db.New().Exec("DELETE FROM recurring_labels WHERE recurring_id = ?", id)
+
+	// Return result
+	return nil
+}
+
+//
+// GetRecurringDue returns every active template that has an entry due on or before now.
+//
+func (db *DB) GetRecurringDue(now time.Time) []Recurring {
+	rt := []Recurring{}
+
+	db.New().Preload("Contact").Preload("Category").Preload("Labels").Where("status = ? AND next_run_date <= ?", "Active", now).Order("id ASC").Find(&rt)
+
+	return rt
+}
+
+//
+// RecurringRun creates every ledger entry that is due for this template up to now.
+// If the server was down we catch up on all the runs we missed. Each entry gets
+// an activity row just like it was entered by hand.
+//
+func (db *DB) RecurringRun(r *Recurring, now time.Time) ([]Ledger, error) {
+	created := []Ledger{}
+
+	if r.Status != "Active" {
+		return created, errors.New("Recurring entry is not active.")
+	}
+
+	for i := 0; i < maxRecurringCatchUp; i++ {
+		date := r.OccurrenceDate(r.Occurrence)
+
+		// Nothing more to do yet.
+		if date.After(now) {
+			break
+		}
+
+		// Past the end date we are done for good.
+		if r.EndDate != nil && !r.EndDate.IsZero() && date.After(*r.EndDate) {
+			r.Status = "Completed"
+			break
+		}
+
+		// Make sure we did not already create this one (say we crashed before saving the template).
+		dup := Ledger{}
+		db.New().Where("LedgerAccountId = ? AND LedgerRecurringId = ? AND LedgerDate = ?", r.AccountId, r.Id, date).First(&dup)
+
+		if dup.Id == 0 {
+			ledger, err := db.createRecurringLedger(*r, date)
+
+			if err != nil {
+				return created, err
+			}
+
+			created = append(created, ledger)
+		}
+
+		// Move on to the next one.
+		r.LastRunDate = &date
+		r.Occurrence++
+	}
+
+	r.NextRunDate = r.OccurrenceDate(r.Occurrence)
+
+	if r.EndDate != nil && !r.EndDate.IsZero() && r.NextRunDate.After(*r.EndDate) {
+		r.Status = "Completed"
+	}
+
+	// Save the run state only. We do not want to touch the associations here.
+	db.New().Model(r).UpdateColumns(map[string]interface{}{
+		"next_run_date": r.NextRunDate,
+		"last_run_date": r.LastRunDate,
+		"occurrence":    r.Occurrence,
+		"status":        r.Status,
+	})
+
+	return created, nil
+}
+
+// ----------------- Private Helper Funcs -------------- //
+
+//
+// createRecurringLedger builds a ledger entry from a template and logs it.
+//
+func (db *DB) createRecurringLedger(r Recurring, date time.Time) (Ledger, error) {
+	// Copy the labels so the ledger gets its own slice.
+	labels := make([]Label, len(r.Labels))
+	copy(labels, r.Labels)
+
+	ledger := Ledger{
+		AccountId:   r.AccountId,
+		AddedById:   r.AddedById,
+		RecurringId: r.Id,
+		Date:        date,
+		Amount:      r.Amount,
+		Note:        r.Note,
+		Contact:     r.Contact,
+		Category:    r.Category,
+		Labels:      labels,
+		Source:      "recurring",
+	}
+
+	// Save ledger entry.
+	err := db.LedgerCreate(&ledger)
+
+	if err != nil {
+		return ledger, err
+	}
+
+	db.CreateLedgerRevision(ledger.AccountId, ledger.Id, ledger.AddedById, "", "create")
+
+	// Set the ledger type
+	ledgerType := "expense"
+
+	if ledger.Amount > 0 {
+		ledgerType = "income"
+	}
+
+	// Get the contact name.
+	contactName := ledger.Contact.Name
+
+	if len(contactName) == 0 {
+		contactName = ledger.Contact.FirstName + " " + ledger.Contact.LastName
+	}
+
+	// Add to the activity log
+	db.New().Create(&Activity{
+		AccountId: ledger.AccountId,
+		UserId:    ledger.AddedById,
+		Action:    ledgerType,
+		SubAction: "create",
+		Name:      contactName,
+		Amount:    ledger.Amount,
+		LedgerId:  ledger.Id,
+	})
+
+	return ledger, nil
+}
+
+//
+// advancePast moves the occurrence counter forward until the next occurrence is on or after t.
+//
+func (a *Recurring) advancePast(t time.Time) {
+	for i := 0; i < 100000 && a.OccurrenceDate(a.Occurrence).Before(t); i++ {
+		a.Occurrence++
+	}
+}
+
+//
+// prepRecurringVars for update or create
+//
+func prepRecurringVars(db *DB, r *Recurring) {
+	// Make sure there is no funny biz with account ids.
+	r.Contact.AccountId = r.AccountId
+	r.Category.AccountId = r.AccountId
+
+	// Defaults
+	if r.Interval < 1 {
+		r.Interval = 1
+	}
+
+	if len(r.Status) == 0 {
+		r.Status = "Active"
+	}
+
+	if r.EndDate != nil && r.EndDate.IsZero() {
+		r.EndDate = nil
+	}
+
+	// Trim
+	r.Note = strings.Trim(r.Note, " ")
+	r.Contact.Name = strings.Trim(r.Contact.Name, " ")
+	r.Contact.FirstName = strings.Trim(r.Contact.FirstName, " ")
+	r.Contact.LastName = strings.Trim(r.Contact.LastName, " ")
+	r.Category.Name = strings.Trim(r.Category.Name, " ")
+	r.Category.Type = strings.Trim(r.Category.Type, " ")
+
+	// Setup the contact. If we have an id it must be in this account.
+	if r.Contact.Id > 0 {
+		contact, err := db.GetContactByAccountAndId(r.AccountId, r.Contact.Id)
+
+		if err == nil {
+			r.Contact = contact
+		} else {
+			r.Contact.Id = 0
+		}
+	}
+
+	if r.Contact.Id == 0 {
+		if (len(r.Contact.FirstName) > 0) || (len(r.Contact.LastName) > 0) {
+			db.Where("ContactsAccountId = ? AND ContactsName = ?", r.AccountId, r.Contact.Name).Or("ContactsAccountId = ? AND ContactsFirstName = ? AND ContactsLastName = ?", r.AccountId, r.Contact.FirstName, r.Contact.LastName).FirstOrCreate(&r.Contact)
+		} else {
+			db.Where("ContactsAccountId = ? AND ContactsName = ?", r.AccountId, r.Contact.Name).FirstOrCreate(&r.Contact)
+		}
+	}
+
+	// Setup the category. If we have an id it must be in this account.
+	if r.Category.Id > 0 {
+		cat, err := db.GetCategoryByAccountAndId(r.AccountId, r.Category.Id)
+
+		if err == nil {
+			r.Category = cat
+		} else {
+			r.Category.Id = 0
+		}
+	}
+
+	if r.Category.Id == 0 {
+		db.Where("CategoriesAccountId = ? AND CategoriesName = ? AND CategoriesType = ?", r.AccountId, r.Category.Name, r.Category.Type).FirstOrCreate(&r.Category)
+	}
+
+	r.ContactId = r.Contact.Id
+	r.CategoryId = r.Category.Id
+
+	// Setup the labels
+	labels := []Label{}
+
+	for _, row := range r.Labels {
+		name := strings.Trim(row.Name, " ")
+
+		if len(name) == 0 {
+			continue
+		}
+
+		labels = append(labels, db.GetOrCreateLabel(r.AccountId, name))
+	}
+
+	r.Labels = labels
+}
+
+//
+// addMonthsClamped adds months to a date. If the day does not exist in the new
+// month (Jan 31 + 1 month) we use the last day of that month.
+//
+func addMonthsClamped(t time.Time, months int) time.Time {
+	// First day of the target month.
+	first := time.Date(t.Year(), t.Month(), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location()).AddDate(0, months, 0)
+
+	// Last day of the target month.
+	last := first.AddDate(0, 1, -1).Day()
+
+	day := t.Day()
+
+	if day > last {
+		day = last
+	}
+
+	return time.Date(first.Year(), first.Month(), day, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+}
+
+/* End File */
@@ -0,0 +1,186 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package models
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"app.skyclerk.com/backend/library/helpers"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// ReportSubscription struct - A user on an account who gets one of the
+// scheduled reports emailed to them.
+type ReportSubscription struct {
+	Id         uint      `gorm:"primary_key" json:"id"`
+	CreatedAt  time.Time `sql:"not null" json:"-"`
+	UpdatedAt  time.Time `sql:"not null" json:"-"`
+	AccountId  uint      `sql:"not null;index:account_id" json:"account_id"`
+	UserId     uint      `sql:"not null;index:user_id" json:"user_id"`
+	Report     string    `sql:"not null" json:"report"` // weekly-activity, monthly-pnl, quarterly-categories
+	LastPeriod string    `sql:"not null" json:"-"`      // Start of the last period we emailed.
+	LastSentAt time.Time `json:"last_sent_at"`
+}
+
+//
+// Validate for this model.
+//
+func (a ReportSubscription) Validate(db Datastore, action string, userId uint, accountId uint, objId uint) error {
+	return validation.ValidateStruct(&a,
+
+		validation.Field(&a.Report,
+			validation.Required.Error("The report field is required."),
+			validation.In("weekly-activity", "monthly-pnl", "quarterly-categories").Error("The report field must be weekly-activity, monthly-pnl or quarterly-categories."),
+			validation.By(func(value interface{}) error {
+				for _, row := range db.GetReportSubscriptionsByAccountAndUser(accountId, userId) {
+					if row.Report == a.Report {
+						return errors.New("You are already subscribed to this report.")
+					}
+				}
+
+				return nil
+			}),
+		),
+	)
+}
+
+//
+// LastPeriodBounds returns the start of the last whole period before now and
+// the start of the one after it. Weeks start on Monday.
+//
+func (a ReportSubscription) LastPeriodBounds(now time.Time) (time.Time, time.Time) {
+	switch a.Report {
+	case "quarterly-categories":
+		end := time.Date(now.Year(), ((now.Month()-1)/3)*3+1, 1, 0, 0, 0, 0, time.UTC)
+		return end.AddDate(0, -3, 0), end
+
+	case "monthly-pnl":
+		end := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+		return end.AddDate(0, -1, 0), end
+
+	default:
+		end := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, -((int(now.Weekday()) + 6) % 7))
+		return end.AddDate(0, 0, -7), end
+	}
+}
+
+//
+// UnsubscribeUrl returns a signed link that removes this subscription without
+// logging in.
+//
+func (a ReportSubscription) UnsubscribeUrl() string {
+	return fmt.Sprintf("%s/reports/unsubscribe/%d?token=%s", os.Getenv("APP_URL"), a.Id, a.UnsubscribeToken())
+}
+
+//
+// UnsubscribeHeaders returns the List-Unsubscribe headers for the email so
+// mail apps can offer their own unsubscribe button (RFC 8058). They POST to
+// the same link.
+//
+func (a ReportSubscription) UnsubscribeHeaders() map[string]string {
+	return map[string]string{
+		"List-Unsubscribe":      "<" + a.UnsubscribeUrl() + ">",
+		"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+	}
+}
+
+//
+// UnsubscribeToken returns the signature for the unsubscribe link.
+//
+func (a ReportSubscription) UnsubscribeToken() string {
+	return helpers.Sign(a.unsubscribeText())
+}
+
+//
+// CheckUnsubscribeToken returns true if the token came from UnsubscribeToken.
+//
+func (a ReportSubscription) CheckUnsubscribeToken(token string) bool {
+	return helpers.CheckSignature(a.unsubscribeText(), token)
+}
+
+//
+// ReportSubscriptionCreate - Subscribe a user to a report. The period that
+// just ended counts as sent so the first email goes out when the next one ends.
+//
+func (db *DB) ReportSubscriptionCreate(s *ReportSubscription) error {
+	s.Report = strings.TrimSpace(s.Report)
+	start, _ := s.LastPeriodBounds(time.Now())
+	s.LastPeriod = start.Format("2006-01-02")
+	db.New().Create(s)
+	return nil
+}
+
+//
+// GetReportSubscriptionsByAccountAndUser - The reports a user gets for an account.
+//
+func (db *DB) GetReportSubscriptionsByAccountAndUser(accountId uint, userId uint) []ReportSubscription {
+	rt := []ReportSubscription{}
+	db.New().Where("account_id = ? AND user_id = ?", accountId, userId).Order("id ASC").Find(&rt)
+	return rt
+}
+
+//
+// GetReportSubscriptionById by id.
+//
+func (db *DB) GetReportSubscriptionById(id uint) (ReportSubscription, error) {
+	s := ReportSubscription{}
+
+	// Make query
+	if db.New().Where("id = ?", id).First(&s).RecordNotFound() {
+		return ReportSubscription{}, errors.New("Report subscription not found.")
+	}
+
+	// Return result
+	return s, nil
+}
+
+//
+// GetReportSubscriptions - Every subscription whose user is still on the account.
+//
+func (db *DB) GetReportSubscriptions() []ReportSubscription {
+	rt := []ReportSubscription{}
+	db.New().Joins("JOIN acct_to_users ON acct_to_users.account_id = report_subscriptions.account_id AND acct_to_users.user_id = report_subscriptions.user_id").Order("report_subscriptions.account_id ASC, report_subscriptions.id ASC").Find(&rt)
+	return rt
+}
+
+//
+// ReportSubscriptionSent - Record that we emailed the report for the period
+// starting at start so we do not send it again.
+//
+func (db *DB) ReportSubscriptionSent(s *ReportSubscription, start string) {
+	s.LastPeriod = start
+	s.LastSentAt = time.Now()
+	db.New().Model(ReportSubscription{}).Where("id = ?", s.Id).Updates(map[string]interface{}{"last_period": s.LastPeriod, "last_sent_at": s.LastSentAt})
+}
+
+//
+// DeleteReportSubscriptionByAccountUserAndId - Unsubscribe a user from a report.
+//
+func (db *DB) DeleteReportSubscriptionByAccountUserAndId(accountId uint, userId uint, id uint) error {
+	if db.New().Where("account_id = ? AND user_id = ? AND id = ?", accountId, userId, id).Delete(ReportSubscription{}).RowsAffected == 0 {
+		return errors.New("Report subscription not found.")
+	}
+
+	return nil
+}
+
+// ----------------- Private Helper Funcs -------------- //
+
+//
+// unsubscribeText - What we sign for the unsubscribe link.
+//
+func (a ReportSubscription) unsubscribeText() string {
+	return fmt.Sprintf("report-subscription:%d:%d:%d", a.Id, a.AccountId, a.UserId)
+}
+
+/* End File */
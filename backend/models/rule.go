@@ -0,0 +1,461 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package models
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// Rule struct - Categorize incoming ledger entries. The first active rule (by
+// priority) that matches wins. Empty conditions match everything.
+type Rule struct {
+	Id            uint      `gorm:"primary_key" json:"id"`
+	CreatedAt     time.Time `sql:"not null" json:"-"`
+	UpdatedAt     time.Time `sql:"not null" json:"-"`
+	AccountId     uint      `sql:"not null;index:account_id" json:"account_id"`
+	Name          string    `sql:"not null" json:"name"`
+	Priority      int       `sql:"not null" json:"priority"`                // Lower runs first.
+	Status        string    `sql:"not null;default:'Active'" json:"status"` // Active, Paused
+	PayeeContains string    `sql:"not null" json:"payee_contains"`
+	NoteContains  string    `sql:"not null" json:"note_contains"`
+	AmountMin     float64   `sql:"not null;type:DECIMAL(12,2)" json:"amount_min"` // Compared to the absolute amount.
+	AmountMax     float64   `sql:"not null;type:DECIMAL(12,2)" json:"amount_max"` // Zero means no max.
+	Type          string    `sql:"not null" json:"type"`                          // income, expense or empty for both.
+	Source        string    `sql:"not null" json:"source"`                        // manual, stripe, import, snapclerk, recurring or empty for all.
+	CategoryId    uint      `sql:"not null" json:"category_id"`
+	Category      Category  `gorm:"association_autoupdate:false;association_autocreate:false" json:"category"`
+	ContactId     uint      `sql:"not null" json:"contact_id"`
+	Contact       Contact   `gorm:"association_autoupdate:false;association_autocreate:false" json:"contact"`
+	Labels        []Label   `gorm:"many2many:rule_labels;association_jointable_foreignkey:label_id;jointable_foreignkey:rule_id" json:"labels"`
+	Note          string    `sql:"not null;type:TEXT" json:"note"`
+}
+
+// FieldChange struct - One field that was (or would be) changed on a ledger entry.
+type FieldChange struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+// RuleChange struct - What re-applying the rules did to one ledger entry.
+type RuleChange struct {
+	LedgerId uint          `json:"ledger_id"`
+	RuleId   uint          `json:"rule_id"`
+	RuleName string        `json:"rule_name"`
+	Changes  []FieldChange `json:"changes"`
+}
+
+//
+// Validate for this model.
+//
+func (a Rule) Validate(db Datastore, action string, userId uint, accountId uint, objId uint) error {
+	return validation.ValidateStruct(&a,
+
+		validation.Field(&a.Name,
+			validation.Required.Error("The name field is required."),
+		),
+
+		validation.Field(&a.Status,
+			validation.In("Active", "Paused").Error("The status field must be Active or Paused."),
+		),
+
+		validation.Field(&a.Type,
+			validation.In("income", "expense").Error("The type field must be income or expense."),
+		),
+
+		validation.Field(&a.Source,
+			validation.In("manual", "stripe", "import", "snapclerk", "recurring").Error("The source field must be manual, stripe, import, snapclerk, or recurring."),
+		),
+
+		validation.Field(&a.AmountMax,
+			validation.By(func(value interface{}) error {
+				if (a.AmountMax > 0) && (a.AmountMax < a.AmountMin) {
+					return errors.New("The amount_max must be more than the amount_min.")
+				}
+				return nil
+			}),
+		),
+
+		validation.Field(&a.PayeeContains,
+			validation.By(func(value interface{}) error {
+				if (len(strings.TrimSpace(a.PayeeContains)) == 0) && (len(strings.TrimSpace(a.NoteContains)) == 0) && (a.AmountMin == 0) && (a.AmountMax == 0) && (len(a.Type) == 0) && (len(a.Source) == 0) {
+					return errors.New("A rule needs at least one condition.")
+				}
+				return nil
+			}),
+		),
+
+		validation.Field(&a.CategoryId,
+			validation.By(func(value interface{}) error {
+				if (a.CategoryId == 0) && (a.ContactId == 0) && (len(a.Labels) == 0) && (len(strings.TrimSpace(a.Note)) == 0) {
+					return errors.New("A rule needs to set a category, contact, labels, or note.")
+				}
+
+				if a.CategoryId > 0 {
+					if _, err := db.GetCategoryByAccountAndId(accountId, a.CategoryId); err != nil {
+						return err
+					}
+				}
+
+				return nil
+			}),
+		),
+
+		validation.Field(&a.ContactId,
+			validation.By(func(value interface{}) error {
+				if a.ContactId > 0 {
+					if _, err := db.GetContactByAccountAndId(accountId, a.ContactId); err != nil {
+						return err
+					}
+				}
+
+				return nil
+			}),
+		),
+	)
+}
+
+//
+// Matches returns true if this rule applies to the entry.
+//
+func (a Rule) Matches(payee string, note string, amount float64, source string) bool {
+	if a.Status != "Active" {
+		return false
+	}
+
+	if (len(a.PayeeContains) > 0) && !strings.Contains(strings.ToLower(payee), strings.ToLower(strings.TrimSpace(a.PayeeContains))) {
+		return false
+	}
+
+	if (len(a.NoteContains) > 0) && !strings.Contains(strings.ToLower(note), strings.ToLower(strings.TrimSpace(a.NoteContains))) {
+		return false
+	}
+
+	if (a.AmountMin > 0) && (math.Abs(amount) < a.AmountMin) {
+		return false
+	}
+
+	if (a.AmountMax > 0) && (math.Abs(amount) > a.AmountMax) {
+		return false
+	}
+
+	if (a.Type == "income") && (amount <= 0) {
+		return false
+	}
+
+	if (a.Type == "expense") && (amount >= 0) {
+		return false
+	}
+
+	if (len(a.Source) > 0) && (a.Source != source) {
+		return false
+	}
+
+	return true
+}
+
+//
+// RuleCreate - Create a new rule.
+//
+func (db *DB) RuleCreate(r *Rule) error {
+	prepRuleVars(db, r)
+	db.New().Create(r)
+	return nil
+}
+
+//
+// RuleUpdate - Update a rule.
+//
+func (db *DB) RuleUpdate(r *Rule) error {
+	prepRuleVars(db, r)
+
+	// Clear out old labels. We start fresh every time.
+	db.New().Exec("DELETE FROM rule_labels WHERE rule_id = ?", r.Id)
+
+	db.New().Save(r)
+	return nil
+}
+
+//
+// GetRuleByAccountAndId by account and id.
+//
+func (db *DB) GetRuleByAccountAndId(accountId uint, id uint) (Rule, error) {
+	r := Rule{}
+
+	// Make query
+	if db.New().Preload("Category").Preload("Contact").Preload("Labels").Where("account_id = ? AND id = ?", accountId, id).First(&r).RecordNotFound() {
+		return Rule{}, errors.New("Rule not found.")
+	}
+
+	// Return result
+	return r, nil
+}
+
+//
+// DeleteRuleByAccountAndId - Delete a rule by account and id.
+//
+func (db *DB) DeleteRuleByAccountAndId(accountId uint, id uint) error {
+	// Make query to delete
+	db.New().Where("account_id = ? AND id = ?", accountId, id).Delete(Rule{})
+
+	// Delete from look up table.
+	db.New().Exec("DELETE FROM rule_labels WHERE rule_id = ?", id)
+
+	// Return result
+	return nil
+}
+
+//
+// ApplyLedgerRules - Run the account's rules on a ledger entry before it is saved.
+// Returns the id of the rule that matched or zero.
+//
+func (db *DB) ApplyLedgerRules(ledger *Ledger) uint {
+	for _, row := range db.getActiveRules(ledger.AccountId) {
+		if row.Matches(ledgerPayee(db, *ledger), ledger.Note, ledger.Amount, ledger.SourceName()) {
+			applyRule(row, ledger)
+			return row.Id
+		}
+	}
+
+	return 0
+}
+
+//
+// ReapplyLedgerRules - Run the account's rules against existing entries. With
+// dryRun we only report what would change.
+//
+func (db *DB) ReapplyLedgerRules(accountId uint, userId uint, dryRun bool) []RuleChange {
+	changes := []RuleChange{}
+	rules := db.getActiveRules(accountId)
+
+	if len(rules) == 0 {
+		return changes
+	}
+
+	ledgers := []Ledger{}
+	db.New().Preload("Contact").Preload("Category").Preload("Labels").Where("LedgerAccountId = ? AND LedgerStatus != ?", accountId, "reconciled").Order("LedgerDate ASC, LedgerId ASC").Find(&ledgers)
+
+	for _, row := range ledgers {
+		// Closed periods are left alone.
+		if db.ValidateLedgerUnlocked(row) != nil {
+			continue
+		}
+
+		for _, rule := range rules {
+			if !rule.Matches(ledgerPayee(db, row), row.Note, row.Amount, row.SourceName()) {
+				continue
+			}
+
+			// First match wins.
+			l := row
+			fields := applyRule(rule, &l)
+
+			if len(fields) > 0 {
+				changes = append(changes, RuleChange{LedgerId: row.Id, RuleId: rule.Id, RuleName: rule.Name, Changes: fields})
+
+				if !dryRun {
+					db.saveRuleChanges(l, userId)
+				}
+			}
+
+			break
+		}
+	}
+
+	return changes
+}
+
+//
+// SourceName - Where this entry came from. Older entries do not have a source so
+// we work it out from the ids the integrations set.
+//
+func (l Ledger) SourceName() string {
+	switch {
+	case len(l.Source) > 0:
+		return l.Source
+	case len(l.StripeId) > 0:
+		return "stripe"
+	case (len(l.FitId) > 0) || (len(l.ImportKey) > 0) || (len(l.AirBnbHash) > 0):
+		return "import"
+	case l.RecurringId > 0:
+		return "recurring"
+	}
+
+	return "manual"
+}
+
+// ----------------- Private Helper Funcs -------------- //
+
+//
+// getActiveRules - Rules for an account in the order we run them.
+//
+func (db *DB) getActiveRules(accountId uint) []Rule {
+	rules := []Rule{}
+	db.New().Preload("Category").Preload("Contact").Preload("Labels").Where("account_id = ? AND status = ?", accountId, "Active").Order("priority ASC, id ASC").Find(&rules)
+	return rules
+}
+
+//
+// saveRuleChanges - Store the fields a rule changes on an existing entry.
+//
+func (db *DB) saveRuleChanges(l Ledger, userId uint) {
+	db.New().Model(&Ledger{}).Where("LedgerId = ?", l.Id).UpdateColumns(map[string]interface{}{
+		"LedgerCategoryId": l.Category.Id,
+		"LedgerContactId":  l.Contact.Id,
+		"LedgerNote":       l.Note,
+	})
+
+	// Labels the rule added.
+	for _, row := range l.Labels {
+		lb := LabelsToLedger{}
+		db.New().Where("LabelsToLedgerLedgerId = ? AND LabelsToLedgerLabelId = ?", l.Id, row.Id).First(&lb)
+
+		if lb.LabelsToLedgerLedgerId == 0 {
+			db.New().Create(&LabelsToLedger{LabelsToLedgerLedgerId: l.Id, LabelsToLedgerLabelId: row.Id})
+		}
+	}
+
+	db.CreateLedgerRevision(l.AccountId, l.Id, userId, "", "update")
+	db.IndexLedger(l.AccountId, l.Id)
+	db.PostLedgerJournal(l.AccountId, l.Id)
+
+	// Set the ledger type
+	ledgerType := "expense"
+
+	if l.Amount > 0 {
+		ledgerType = "income"
+	}
+
+	// Add to the activity log
+	db.New().Create(&Activity{
+		AccountId: l.AccountId,
+		UserId:    userId,
+		Action:    ledgerType,
+		SubAction: "update",
+		Name:      ledgerPayee(db, l),
+		Amount:    l.Amount,
+		LedgerId:  l.Id,
+	})
+}
+
+//
+// applyRule - Set the rule's fields on the entry. Returns what changed.
+//
+func applyRule(r Rule, ledger *Ledger) []FieldChange {
+	changes := []FieldChange{}
+
+	if (r.CategoryId > 0) && (r.Category.Id != ledger.Category.Id) {
+		changes = append(changes, FieldChange{Field: "category", From: ledger.Category.Name, To: r.Category.Name})
+		ledger.Category = r.Category
+		ledger.CategoryId = r.Category.Id
+	}
+
+	if (r.ContactId > 0) && (r.Contact.Id != ledger.Contact.Id) {
+		changes = append(changes, FieldChange{Field: "contact", From: ledger.Contact.Name, To: r.Contact.Name})
+		ledger.Contact = r.Contact
+		ledger.ContactId = r.Contact.Id
+	}
+
+	for _, row := range r.Labels {
+		found := false
+
+		for _, row2 := range ledger.Labels {
+			if strings.ToLower(strings.TrimSpace(row2.Name)) == strings.ToLower(row.Name) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			changes = append(changes, FieldChange{Field: "labels", To: row.Name})
+			ledger.Labels = append(ledger.Labels, row)
+		}
+	}
+
+	if (len(r.Note) > 0) && (r.Note != ledger.Note) {
+		changes = append(changes, FieldChange{Field: "note", From: ledger.Note, To: r.Note})
+		ledger.Note = r.Note
+	}
+
+	return changes
+}
+
+//
+// ledgerPayee - The contact name we match rules against.
+//
+func ledgerPayee(db *DB, ledger Ledger) string {
+	contact := ledger.Contact
+
+	if (len(contact.Name) == 0) && (len(contact.FirstName) == 0) && (ledger.ContactId > 0) {
+		db.New().Where("ContactsId = ?", ledger.ContactId).First(&contact)
+	}
+
+	if len(contact.Name) > 0 {
+		return contact.Name
+	}
+
+	return strings.TrimSpace(fmt.Sprintf("%s %s", contact.FirstName, contact.LastName))
+}
+
+//
+// prepRuleVars - Clean up and set defaults.
+//
+func prepRuleVars(db *DB, r *Rule) {
+	r.Name = strings.TrimSpace(r.Name)
+	r.PayeeContains = strings.TrimSpace(r.PayeeContains)
+	r.NoteContains = strings.TrimSpace(r.NoteContains)
+	r.Note = strings.TrimSpace(r.Note)
+
+	if len(r.Status) == 0 {
+		r.Status = "Active"
+	}
+
+	// We only take ids for the category and contact.
+	r.Category = Category{}
+	r.Contact = Contact{}
+
+	if cat, err := db.GetCategoryByAccountAndId(r.AccountId, r.CategoryId); err == nil {
+		r.Category = cat
+	} else {
+		r.CategoryId = 0
+	}
+
+	if con, err := db.GetContactByAccountAndId(r.AccountId, r.ContactId); err == nil {
+		r.Contact = con
+	} else {
+		r.ContactId = 0
+	}
+
+	// Setup the labels
+	labels := []Label{}
+
+	for _, row := range r.Labels {
+		name := strings.TrimSpace(row.Name)
+
+		if row.Id > 0 {
+			if lb, err := db.GetLabelByAccountAndId(r.AccountId, row.Id); err == nil {
+				labels = append(labels, lb)
+			}
+			continue
+		}
+
+		if len(name) > 0 {
+			labels = append(labels, db.GetOrCreateLabel(r.AccountId, name))
+		}
+	}
+
+	r.Labels = labels
+}
+
+/* End File */
@@ -0,0 +1,704 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package models
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"app.skyclerk.com/backend/services"
+	"github.com/jinzhu/gorm"
+)
+
+// Set when the sqlite driver was built with FTS5 (go build -tags sqlite_fts5).
+// Without it we fall back to LIKE queries against search_docs.
+var searchFTSEnabled = false
+
+// We only need to say FTS5 is missing once.
+var searchFTSWarning sync.Once
+
+// Quoted phrases in a search query.
+var searchPhraseRegex = regexp.MustCompile(`"([^"]*)"`)
+
+// Search terms that look like money. $42, 42.50, -1,200.00
+var searchAmountRegex = regexp.MustCompile(`^-?\$?-?(\d[\d,]*(\.\d{1,2})?)$`)
+
+// Keep the FTS5 table in step with search_docs.
+var searchTriggers = []string{
+	`CREATE TRIGGER IF NOT EXISTS search_docs_ai AFTER INSERT ON search_docs BEGIN
+		INSERT INTO search_fts(rowid, title, body, amount) VALUES (new.id, new.title, new.body, new.amount);
+	END;`,
+	`CREATE TRIGGER IF NOT EXISTS search_docs_ad AFTER DELETE ON search_docs BEGIN
+		INSERT INTO search_fts(search_fts, rowid, title, body, amount) VALUES ('delete', old.id, old.title, old.body, old.amount);
+	END;`,
+	`CREATE TRIGGER IF NOT EXISTS search_docs_au AFTER UPDATE ON search_docs BEGIN
+		INSERT INTO search_fts(search_fts, rowid, title, body, amount) VALUES ('delete', old.id, old.title, old.body, old.amount);
+		INSERT INTO search_fts(rowid, title, body, amount) VALUES (new.id, new.title, new.body, new.amount);
+	END;`,
+}
+
+// SearchDoc struct - One row in the search index. Every ledger entry,
+// contact, label and category has one. Amount is the unsigned amount as text
+// ("42.50") so it can be matched, Value is the signed amount we return.
+type SearchDoc struct {
+	Id        uint      `gorm:"primary_key" json:"-"`
+	UpdatedAt time.Time `sql:"not null" json:"-"`
+	AccountId uint      `sql:"not null;index:account_id" json:"-"`
+	Type      string    `sql:"not null" json:"-"` // ledger, contact, label, category
+	ObjectId  uint      `sql:"not null;index:object_id" json:"-"`
+	Title     string    `sql:"not null" json:"-"`
+	Body      string    `sql:"not null;type:TEXT" json:"-"`
+	Amount    string    `sql:"not null" json:"-"`
+	Value     float64   `sql:"not null;type:DECIMAL(12,2)" json:"-"`
+}
+
+// SearchResult struct - One hit from a search. The highlight fields are HTML
+// escaped with the matched terms wrapped in <mark></mark>.
+type SearchResult struct {
+	Type           string  `json:"type"` // ledger, contact, label, category
+	Id             uint    `json:"id"`
+	Title          string  `json:"title"`
+	Body           string  `json:"body"`
+	Amount         float64 `json:"amount"`
+	TitleHighlight string  `json:"title_highlight"`
+	BodyHighlight  string  `json:"body_highlight"`
+	Rank           float64 `json:"rank"`
+}
+
+// searchTerm struct - One part of a search query.
+type searchTerm struct {
+	Words  []string
+	Prefix bool
+	Match  *regexp.Regexp
+}
+
+// searchHit struct - A search_docs row and its score.
+type searchHit struct {
+	Type     string
+	ObjectId uint
+	Title    string
+	Body     string
+	Amount   string
+	Value    float64
+	Score    float64
+}
+
+//
+// Search - Search an account's ledger entries, contacts, labels and
+// categories. Words match on prefix, "quoted words" match as a phrase and
+// amounts like 42.50 or $42.50 match the entry amount. Pass types to limit
+// what comes back. Best match first.
+//
+func (db *DB) Search(accountId uint, query string, types []string, limit int) []SearchResult {
+	results := []SearchResult{}
+	terms := parseSearchQuery(query)
+
+	if len(terms) == 0 {
+		return results
+	}
+
+	var hits []searchHit
+
+	if searchFTSEnabled {
+		hits = db.searchWithFTS(accountId, terms, types, limit)
+	} else {
+		hits = db.searchWithLike(accountId, terms, types, limit)
+	}
+
+	for _, row := range hits {
+		results = append(results, SearchResult{
+			Type:           row.Type,
+			Id:             row.ObjectId,
+			Title:          row.Title,
+			Body:           row.Body,
+			Amount:         row.Value,
+			TitleHighlight: highlightSearchTerms(row.Title, terms, false),
+			BodyHighlight:  highlightSearchTerms(row.Body, terms, true),
+			Rank:           math.Round(row.Score*1000) / 1000,
+		})
+	}
+
+	return results
+}
+
+//
+// SearchLedgerWhere - A where for the ledger entries that match a search
+// query. It selects the ids from the index in a subquery so there is no limit
+// on how many entries match and we skip the highlighting Search does. Without
+// FTS5 each term is a LIKE, a little looser than the FTS5 match.
+//
+func (db *DB) SearchLedgerWhere(accountId uint, query string) KeyValue {
+	terms := parseSearchQuery(query)
+
+	if len(terms) == 0 {
+		return KeyValue{Sql: "1 = 0"}
+	}
+
+	if searchFTSEnabled {
+		sql := "SELECT search_docs.object_id FROM search_fts INNER JOIN search_docs ON search_docs.id = search_fts.rowid "
+		sql = sql + "WHERE search_fts MATCH ? AND search_docs.account_id = ? AND search_docs.type = 'ledger'"
+
+		return KeyValue{Sql: "LedgerId IN (" + sql + ")", Args: []interface{}{ftsMatchExpression(terms), accountId}}
+	}
+
+	sql := "SELECT object_id FROM search_docs WHERE account_id = ? AND type = 'ledger'"
+	args := []interface{}{accountId}
+
+	// Words in a phrase have one space or dot between them. "42 50" is 42.50.
+	for _, row := range terms {
+		like := "%" + strings.Join(row.Words, "_") + "%"
+		sql = sql + " AND (title LIKE ? OR body LIKE ? OR amount LIKE ?)"
+		args = append(args, like, like, like)
+	}
+
+	return KeyValue{Sql: "LedgerId IN (" + sql + ")", Args: args}
+}
+
+//
+// IndexLedger - Add or update a ledger entry in the search index. Entries
+// that are gone (or in the trash) are taken out.
+//
+func (db *DB) IndexLedger(accountId uint, ledgerId uint) {
+	l := Ledger{}
+
+	if db.New().Preload("Contact").Preload("Category").Preload("Labels").Preload("Splits").Preload("Splits.Category").Where("LedgerAccountId = ? AND LedgerId = ?", accountId, ledgerId).First(&l).RecordNotFound() {
+		db.RemoveFromSearch(accountId, "ledger", ledgerId)
+		return
+	}
+
+	parts := []string{l.Note, l.Category.Name}
+
+	for _, row := range l.Labels {
+		parts = append(parts, row.Name)
+	}
+
+	for _, row := range l.Splits {
+		parts = append(parts, row.Category.Name, row.Note)
+	}
+
+	db.saveSearchDoc(SearchDoc{
+		AccountId: accountId,
+		Type:      "ledger",
+		ObjectId:  l.Id,
+		Title:     ledgerPayee(db, l),
+		Body:      joinSearchParts(parts),
+		Amount:    fmt.Sprintf("%.2f", math.Abs(l.Amount)),
+		Value:     l.Amount,
+	})
+}
+
+//
+// IndexContact - Add or update a contact in the search index along with the
+// ledger entries that use it.
+//
+func (db *DB) IndexContact(accountId uint, contactId uint) {
+	c := Contact{}
+
+	if db.New().Where("ContactsAccountId = ? AND ContactsId = ?", accountId, contactId).First(&c).RecordNotFound() {
+		db.RemoveFromSearch(accountId, "contact", contactId)
+		return
+	}
+
+	db.indexContactDoc(c)
+
+	ids := []uint{}
+	db.New().Model(&Ledger{}).Where("LedgerAccountId = ? AND LedgerContactId = ?", accountId, contactId).Pluck("LedgerId", &ids)
+	db.indexLedgers(accountId, ids)
+}
+
+//
+// IndexLabel - Add or update a label in the search index along with the
+// ledger entries it is on.
+//
+func (db *DB) IndexLabel(accountId uint, labelId uint) {
+	lb := Label{}
+
+	if db.New().Where("LabelsAccountId = ? AND LabelsId = ?", accountId, labelId).First(&lb).RecordNotFound() {
+		db.RemoveFromSearch(accountId, "label", labelId)
+		return
+	}
+
+	db.indexLabelDoc(lb)
+
+	ids := []uint{}
+	db.New().Model(&LabelsToLedger{}).Where("LabelsToLedgerLabelId = ?", labelId).Pluck("LabelsToLedgerLedgerId", &ids)
+	db.indexLedgers(accountId, ids)
+}
+
+//
+// IndexCategory - Add or update a category in the search index along with
+// the ledger entries that use it.
+//
+func (db *DB) IndexCategory(accountId uint, categoryId uint) {
+	cat := Category{}
+
+	if db.New().Where("CategoriesAccountId = ? AND CategoriesId = ?", accountId, categoryId).First(&cat).RecordNotFound() {
+		db.RemoveFromSearch(accountId, "category", categoryId)
+		return
+	}
+
+	db.indexCategoryDoc(cat)
+
+	ids := []uint{}
+	db.New().Model(&Ledger{}).Where("LedgerAccountId = ? AND LedgerCategoryId = ?", accountId, categoryId).Pluck("LedgerId", &ids)
+
+	splitIds := []uint{}
+	db.New().Model(&LedgerSplit{}).Where("account_id = ? AND category_id = ?", accountId, categoryId).Pluck("ledger_id", &splitIds)
+
+	db.indexLedgers(accountId, append(ids, splitIds...))
+}
+
+//
+// RemoveFromSearch - Take something out of the search index.
+//
+func (db *DB) RemoveFromSearch(accountId uint, docType string, objectId uint) {
+	db.New().Where("account_id = ? AND type = ? AND object_id = ?", accountId, docType, objectId).Delete(SearchDoc{})
+}
+
+//
+// ReindexSearch - Rebuild the search index for an account. Pass 0 to rebuild
+// every account. Returns the number of documents indexed.
+//
+// go run main.go -cmd=search-reindex -account_id=4992
+//
+func (db *DB) ReindexSearch(accountId uint) int {
+	count := 0
+
+	accountIds := []uint{accountId}
+
+	if accountId == 0 {
+		accountIds = []uint{}
+		db.New().Model(&Account{}).Pluck("id", &accountIds)
+	}
+
+	for _, acct := range accountIds {
+		db.New().Where("account_id = ?", acct).Delete(SearchDoc{})
+
+		contacts := []Contact{}
+		db.New().Where("ContactsAccountId = ?", acct).Find(&contacts)
+
+		for _, row := range contacts {
+			db.indexContactDoc(row)
+		}
+
+		labels := []Label{}
+		db.New().Where("LabelsAccountId = ?", acct).Find(&labels)
+
+		for _, row := range labels {
+			db.indexLabelDoc(row)
+		}
+
+		cats := []Category{}
+		db.New().Where("CategoriesAccountId = ?", acct).Find(&cats)
+
+		for _, row := range cats {
+			db.indexCategoryDoc(row)
+		}
+
+		ids := []uint{}
+		db.New().Model(&Ledger{}).Where("LedgerAccountId = ?", acct).Pluck("LedgerId", &ids)
+		db.indexLedgers(acct, ids)
+
+		count = count + len(contacts) + len(labels) + len(cats) + len(ids)
+	}
+
+	return count
+}
+
+// ----------------- Private Helper Funcs -------------- //
+
+//
+// migrateSearchIndex - Set up the FTS5 table that mirrors search_docs. If the
+// driver was built without FTS5 we log it and search with LIKE instead.
+//
+func migrateSearchIndex(db *gorm.DB) {
+	found := 0
+	db.Raw("SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'search_fts'").Row().Scan(&found)
+
+	_, err := db.DB().Exec("CREATE VIRTUAL TABLE IF NOT EXISTS search_fts USING fts5(title, body, amount, content='search_docs', content_rowid='id')")
+
+	if err != nil {
+		searchFTSEnabled = false
+		searchFTSWarning.Do(func() {
+			services.InfoMsg("Full-text search is off, build with -tags sqlite_fts5 to turn it on: " + err.Error())
+		})
+		return
+	}
+
+	for _, row := range searchTriggers {
+		if err := db.Exec(row).Error; err != nil {
+			services.Info(err)
+		}
+	}
+
+	// A new FTS table picks up whatever is already in search_docs.
+	if found == 0 {
+		db.Exec("INSERT INTO search_fts(search_fts) VALUES ('rebuild')")
+	}
+
+	searchFTSEnabled = true
+}
+
+//
+// indexLedgerLinks - Index a ledger entry we just stored along with the
+// contact, category and labels it may have created.
+//
+func (db *DB) indexLedgerLinks(ledger *Ledger) {
+	if ledger.Contact.Id > 0 {
+		db.indexContactDoc(ledger.Contact)
+	}
+
+	if ledger.Category.Id > 0 {
+		db.indexCategoryDoc(ledger.Category)
+	}
+
+	for _, row := range ledger.Labels {
+		db.indexLabelDoc(row)
+	}
+
+	db.IndexLedger(ledger.AccountId, ledger.Id)
+}
+
+//
+// indexLedgers - Reindex a list of ledger entries.
+//
+func (db *DB) indexLedgers(accountId uint, ids []uint) {
+	done := map[uint]bool{}
+
+	for _, row := range ids {
+		if done[row] {
+			continue
+		}
+
+		done[row] = true
+		db.IndexLedger(accountId, row)
+	}
+}
+
+//
+// indexContactDoc - Store the search doc for a contact.
+//
+func (db *DB) indexContactDoc(c Contact) {
+	fullName := strings.TrimSpace(c.FirstName + " " + c.LastName)
+	title := c.Name
+	body := []string{c.Email}
+
+	if len(title) == 0 {
+		title = fullName
+	} else if fullName != title {
+		body = append([]string{fullName}, body...)
+	}
+
+	db.saveSearchDoc(SearchDoc{AccountId: c.AccountId, Type: "contact", ObjectId: c.Id, Title: title, Body: joinSearchParts(body)})
+}
+
+//
+// indexLabelDoc - Store the search doc for a label.
+//
+func (db *DB) indexLabelDoc(lb Label) {
+	db.saveSearchDoc(SearchDoc{AccountId: lb.AccountId, Type: "label", ObjectId: lb.Id, Title: lb.Name})
+}
+
+//
+// indexCategoryDoc - Store the search doc for a category.
+//
+func (db *DB) indexCategoryDoc(cat Category) {
+	body := "Expense"
+
+	if cat.Type == "2" {
+		body = "Income"
+	}
+
+	db.saveSearchDoc(SearchDoc{AccountId: cat.AccountId, Type: "category", ObjectId: cat.Id, Title: cat.Name, Body: body})
+}
+
+//
+// saveSearchDoc - Insert or update a search doc.
+//
+func (db *DB) saveSearchDoc(doc SearchDoc) {
+	if (doc.AccountId == 0) || (doc.ObjectId == 0) {
+		return
+	}
+
+	old := SearchDoc{}
+	db.New().Where("account_id = ? AND type = ? AND object_id = ?", doc.AccountId, doc.Type, doc.ObjectId).First(&old)
+
+	// Nothing changed, skip the write.
+	if (old.Id > 0) && (old.Title == doc.Title) && (old.Body == doc.Body) && (old.Amount == doc.Amount) && (old.Value == doc.Value) {
+		return
+	}
+
+	doc.Id = old.Id
+
+	if err := db.New().Save(&doc).Error; err != nil {
+		services.Info(err)
+	}
+}
+
+//
+// searchWithFTS - Run a search with the FTS5 index. bm25 weights title hits over
+// amount hits over body hits.
+//
+func (db *DB) searchWithFTS(accountId uint, terms []searchTerm, types []string, limit int) []searchHit {
+	hits := []searchHit{}
+
+	sql := "SELECT search_docs.type, search_docs.object_id, search_docs.title, search_docs.body, search_docs.amount, search_docs.value, "
+	sql = sql + "-bm25(search_fts, 10.0, 1.0, 5.0) AS score FROM search_fts "
+	sql = sql + "INNER JOIN search_docs ON search_docs.id = search_fts.rowid "
+	sql = sql + "WHERE search_fts MATCH ? AND search_docs.account_id = ? "
+
+	args := []interface{}{ftsMatchExpression(terms), accountId}
+
+	if len(types) > 0 {
+		sql = sql + "AND search_docs.type IN (?) "
+		args = append(args, types)
+	}
+
+	sql = sql + "ORDER BY score DESC, search_docs.id DESC"
+
+	if limit > 0 {
+		sql = sql + " LIMIT " + strconv.Itoa(limit)
+	}
+
+	if err := db.New().Raw(sql, args...).Scan(&hits).Error; err != nil {
+		services.Info(err)
+		return []searchHit{}
+	}
+
+	return hits
+}
+
+//
+// searchWithLike - Run a search without FTS5. LIKE narrows it down, then we
+// match and score each doc the way the FTS5 query would.
+//
+func (db *DB) searchWithLike(accountId uint, terms []searchTerm, types []string, limit int) []searchHit {
+	docs := []SearchDoc{}
+	hits := []searchHit{}
+
+	query := db.New().Where("account_id = ?", accountId)
+
+	if len(types) > 0 {
+		query = query.Where("type IN (?)", types)
+	}
+
+	for _, row := range terms {
+		like := "%" + row.Words[0] + "%"
+		query = query.Where("(title LIKE ? OR body LIKE ? OR amount LIKE ?)", like, like, like)
+	}
+
+	query.Order("id DESC").Find(&docs)
+
+	for _, row := range docs {
+		score := 0.0
+
+		for _, term := range terms {
+			s := 10.0*float64(len(term.Match.FindAllStringIndex(row.Title, -1))) +
+				5.0*float64(len(term.Match.FindAllStringIndex(row.Amount, -1))) +
+				float64(len(term.Match.FindAllStringIndex(row.Body, -1)))
+
+			// Every term has to match.
+			if s == 0 {
+				score = 0
+				break
+			}
+
+			score = score + s
+		}
+
+		if score == 0 {
+			continue
+		}
+
+		hits = append(hits, searchHit{Type: row.Type, ObjectId: row.ObjectId, Title: row.Title, Body: row.Body, Amount: row.Amount, Value: row.Value, Score: score})
+	}
+
+	sort.SliceStable(hits, func(i, j int) bool { return hits[i].Score > hits[j].Score })
+
+	if (limit > 0) && (len(hits) > limit) {
+		hits = hits[:limit]
+	}
+
+	return hits
+}
+
+//
+// parseSearchQuery - Break a query into terms. "quoted words" are phrases,
+// money is matched as the phrase of its digits (42.50 -> "42 50") and
+// everything else is a prefix match.
+//
+func parseSearchQuery(query string) []searchTerm {
+	terms := []searchTerm{}
+
+	for _, row := range searchPhraseRegex.FindAllStringSubmatch(query, -1) {
+		terms = appendSearchTerm(terms, searchWords(row[1]), false)
+	}
+
+	for _, row := range strings.Fields(searchPhraseRegex.ReplaceAllString(query, " ")) {
+		row = strings.Trim(row, `"`)
+
+		if m := searchAmountRegex.FindStringSubmatch(row); (m != nil) && (strings.Contains(row, ".") || strings.Contains(row, "$")) {
+			v, err := strconv.ParseFloat(strings.Replace(m[1], ",", "", -1), 64)
+
+			if err == nil {
+				terms = appendSearchTerm(terms, searchWords(fmt.Sprintf("%.2f", math.Abs(v))), false)
+				continue
+			}
+		}
+
+		terms = appendSearchTerm(terms, searchWords(row), true)
+	}
+
+	return terms
+}
+
+//
+// appendSearchTerm - Add a term and build the regex we highlight with.
+//
+func appendSearchTerm(terms []searchTerm, words []string, prefix bool) []searchTerm {
+	if len(words) == 0 {
+		return terms
+	}
+
+	quoted := []string{}
+
+	for _, row := range words {
+		quoted = append(quoted, regexp.QuoteMeta(row))
+	}
+
+	expr := `(?i)(?:^|[^\pL\pN])(` + strings.Join(quoted, `[^\pL\pN]+`)
+
+	if prefix {
+		expr = expr + `[\pL\pN]*)`
+	} else {
+		expr = expr + `)(?:$|[^\pL\pN])`
+	}
+
+	return append(terms, searchTerm{Words: words, Prefix: prefix, Match: regexp.MustCompile(expr)})
+}
+
+//
+// searchWords - Split text into lower case words the way the FTS5 unicode61
+// tokenizer does.
+//
+func searchWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+//
+// ftsMatchExpression - Turn our terms into an FTS5 MATCH expression.
+//
+func ftsMatchExpression(terms []searchTerm) string {
+	parts := []string{}
+
+	for _, row := range terms {
+		p := `"` + strings.Join(row.Words, " ") + `"`
+
+		if row.Prefix {
+			p = p + "*"
+		}
+
+		parts = append(parts, p)
+	}
+
+	return strings.Join(parts, " ")
+}
+
+//
+// highlightSearchTerms - HTML escape text and wrap the matched terms in
+// <mark></mark>. Long text is cut down to the part around the first match.
+//
+func highlightSearchTerms(text string, terms []searchTerm, snippet bool) string {
+	spans := [][]int{}
+
+	for _, row := range terms {
+		for _, m := range row.Match.FindAllStringSubmatchIndex(text, -1) {
+			spans = append(spans, []int{m[2], m[3]})
+		}
+	}
+
+	sort.Slice(spans, func(i, j int) bool { return spans[i][0] < spans[j][0] })
+
+	start := 0
+	end := len(text)
+
+	if snippet && (len(text) > 200) {
+		if (len(spans) > 0) && (spans[0][0] > 60) {
+			start = snapToRune(text, spans[0][0]-60)
+		}
+
+		if (start + 200) < len(text) {
+			end = snapToRune(text, start+200)
+		}
+	}
+
+	out := ""
+	pos := start
+
+	for _, row := range spans {
+		// Skip overlaps and anything outside the snippet.
+		if (row[0] < pos) || (row[1] > end) {
+			continue
+		}
+
+		out = out + html.EscapeString(text[pos:row[0]]) + "<mark>" + html.EscapeString(text[row[0]:row[1]]) + "</mark>"
+		pos = row[1]
+	}
+
+	out = out + html.EscapeString(text[pos:end])
+
+	if start > 0 {
+		out = "…" + out
+	}
+
+	if end < len(text) {
+		out = out + "…"
+	}
+
+	return out
+}
+
+//
+// snapToRune - Move a byte offset back to the start of a character.
+//
+func snapToRune(text string, i int) int {
+	for (i > 0) && (i < len(text)) && ((text[i] & 0xC0) == 0x80) {
+		i--
+	}
+
+	return i
+}
+
+//
+// joinSearchParts - Join the non empty parts of a doc body.
+//
+func joinSearchParts(parts []string) string {
+	out := []string{}
+
+	for _, row := range parts {
+		row = strings.TrimSpace(row)
+
+		if len(row) > 0 {
+			out = append(out, row)
+		}
+	}
+
+	return strings.Join(out, " · ")
+}
+
+/* End File */
@@ -179,6 +179,10 @@ func (db *DB) ConvertSnapclerkToLedger(sc SnapClerk) (Ledger, error) {
 	ledger.Amount = sc.Amount
 	ledger.Note = sc.Note
 	ledger.Labels = lbArray
+	ledger.Source = "snapclerk"
+
+	// Receipts for a closed period go in the first open day.
+	db.MoveLedgerToOpenPeriod(&ledger)
 
 	// Save ledger entry.
 	err := db.LedgerCreate(&ledger)
@@ -187,6 +191,8 @@ func (db *DB) ConvertSnapclerkToLedger(sc SnapClerk) (Ledger, error) {
 		return ledger, err
 	}
 
+	db.CreateLedgerRevision(ledger.AccountId, ledger.Id, ledger.AddedById, "", "create")
+
 	// Get the contact name.
 	contactName := ledger.Contact.Name
 
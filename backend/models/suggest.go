@@ -0,0 +1,252 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package models
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// How many of the contact's past entries we learn from.
+const suggestHistoryLimit = 250
+
+// After this many days an old entry counts half as much as one from today.
+const suggestHalfLifeDays = 180.0
+
+// How many suggestions of each kind we return.
+const suggestMax = 5
+
+// LedgerSuggestions struct - Ranked categories and labels for a new entry.
+type LedgerSuggestions struct {
+	Categories []CategorySuggestion `json:"categories"`
+	Labels     []LabelSuggestion    `json:"labels"`
+}
+
+// CategorySuggestion struct - A category and how sure we are about it.
+type CategorySuggestion struct {
+	Category Category  `json:"category"`
+	Score    float64   `json:"score"` // 0 - 1
+	Count    int       `json:"count"`
+	LastUsed time.Time `json:"last_used"`
+}
+
+// LabelSuggestion struct - A label and how sure we are about it.
+type LabelSuggestion struct {
+	Label Label   `json:"label"`
+	Score float64 `json:"score"` // 0 - 1
+	Count int     `json:"count"`
+}
+
+// suggestLine struct - One line of history we score.
+type suggestLine struct {
+	LedgerId   uint    `gorm:"column:LedgerId"`
+	Date       string  `gorm:"column:date"`
+	CategoryId uint    `gorm:"column:LedgerCategoryId"`
+	Amount     float64 `gorm:"column:LedgerAmount"`
+	weight     float64
+}
+
+//
+// GetLedgerSuggestions returns ranked category and label suggestions for an entry
+// with this contact (id or name) and amount. Every past entry for the contact
+// votes for its category and labels. Recent entries and entries with a close
+// amount get a bigger vote. An amount of zero skips the amount part and also
+// does not filter by income / expense.
+//
+func (db *DB) GetLedgerSuggestions(accountId uint, contact string, amount float64) LedgerSuggestions {
+	rt := LedgerSuggestions{Categories: []CategorySuggestion{}, Labels: []LabelSuggestion{}}
+
+	// Find the contact.
+	contactIds := []uint{}
+	contact = strings.TrimSpace(contact)
+
+	if len(contact) == 0 {
+		return rt
+	}
+
+	query := db.New().Model(&Contact{}).Where("ContactsAccountId = ?", accountId)
+
+	if id, err := strconv.Atoi(contact); err == nil {
+		query = query.Where("ContactsId = ?", id)
+	} else {
+		query = query.Where("LOWER(ContactsName) = LOWER(?)", contact)
+	}
+
+	query.Pluck("ContactsId", &contactIds)
+
+	if len(contactIds) == 0 {
+		return rt
+	}
+
+	// Get the history. Split entries come back once per line.
+	sql := "SELECT LedgerId, strftime('%Y-%m-%d', LedgerDate) AS date, LedgerCategoryId, LedgerAmount FROM " + LedgerLinesTable + " "
+	sql = sql + "JOIN Categories ON Categories.CategoriesId = Ledger.LedgerCategoryId "
+	sql = sql + "WHERE LedgerAccountId = ? AND LedgerContactId IN (?) "
+	args := []interface{}{accountId, contactIds}
+
+	if amount < 0 {
+		sql = sql + "AND CategoriesType = '1' "
+	} else if amount > 0 {
+		sql = sql + "AND CategoriesType = '2' "
+	}
+
+	sql = sql + "ORDER BY LedgerDate DESC, LedgerId DESC LIMIT " + strconv.Itoa(suggestHistoryLimit)
+
+	lines := []suggestLine{}
+	db.New().Raw(sql, args...).Scan(&lines)
+
+	if len(lines) == 0 {
+		return rt
+	}
+
+	// Score the categories.
+	total := 0.00
+	ledgerWeights := map[uint]float64{}
+	cats := map[uint]*CategorySuggestion{}
+
+	for key, row := range lines {
+		lines[key].weight = suggestWeight(row, amount)
+		total += lines[key].weight
+
+		if _, ok := cats[row.CategoryId]; !ok {
+			cats[row.CategoryId] = &CategorySuggestion{Category: Category{Id: row.CategoryId}}
+			cats[row.CategoryId].LastUsed, _ = time.Parse("2006-01-02", row.Date)
+		}
+
+		cats[row.CategoryId].Score += lines[key].weight
+		cats[row.CategoryId].Count++
+
+		// Labels are on the entry not the line.
+		ledgerWeights[row.LedgerId] = math.Max(ledgerWeights[row.LedgerId], lines[key].weight)
+	}
+
+	// Load the categories.
+	catIds := []uint{}
+
+	for id := range cats {
+		catIds = append(catIds, id)
+	}
+
+	categories := []Category{}
+	db.New().Where("CategoriesAccountId = ? AND CategoriesId IN (?)", accountId, catIds).Find(&categories)
+
+	for _, row := range categories {
+		cats[row.Id].Category = row
+		cats[row.Id].Score = math.Round(cats[row.Id].Score/total*10000) / 10000
+		rt.Categories = append(rt.Categories, *cats[row.Id])
+	}
+
+	sort.SliceStable(rt.Categories, func(i, j int) bool {
+		if rt.Categories[i].Score == rt.Categories[j].Score {
+			return rt.Categories[i].Count > rt.Categories[j].Count
+		}
+
+		return rt.Categories[i].Score > rt.Categories[j].Score
+	})
+
+	if len(rt.Categories) > suggestMax {
+		rt.Categories = rt.Categories[:suggestMax]
+	}
+
+	rt.Labels = db.getLabelSuggestions(accountId, ledgerWeights)
+
+	return rt
+}
+
+// ----------------- Private Helper Funcs -------------- //
+
+//
+// getLabelSuggestions - Score the labels on the entries we learned from.
+//
+func (db *DB) getLabelSuggestions(accountId uint, ledgerWeights map[uint]float64) []LabelSuggestion {
+	rt := []LabelSuggestion{}
+
+	total := 0.00
+	ledgerIds := []uint{}
+
+	for id, weight := range ledgerWeights {
+		total += weight
+		ledgerIds = append(ledgerIds, id)
+	}
+
+	// Get the labels on these entries.
+	type labelLink struct {
+		LedgerId uint `gorm:"column:LabelsToLedgerLedgerId"`
+		LabelId  uint `gorm:"column:LabelsToLedgerLabelId"`
+	}
+
+	links := []labelLink{}
+	db.New().Table("LabelsToLedger").Select("LabelsToLedgerLedgerId, LabelsToLedgerLabelId").Where("LabelsToLedgerLedgerId IN (?)", ledgerIds).Scan(&links)
+
+	if len(links) == 0 {
+		return rt
+	}
+
+	scores := map[uint]*LabelSuggestion{}
+	labelIds := []uint{}
+
+	for _, row := range links {
+		if _, ok := scores[row.LabelId]; !ok {
+			scores[row.LabelId] = &LabelSuggestion{}
+			labelIds = append(labelIds, row.LabelId)
+		}
+
+		scores[row.LabelId].Score += ledgerWeights[row.LedgerId]
+		scores[row.LabelId].Count++
+	}
+
+	labels := []Label{}
+	db.New().Where("LabelsAccountId = ? AND LabelsId IN (?)", accountId, labelIds).Find(&labels)
+
+	for _, row := range labels {
+		scores[row.Id].Label = row
+		scores[row.Id].Score = math.Round(scores[row.Id].Score/total*10000) / 10000
+		rt = append(rt, *scores[row.Id])
+	}
+
+	sort.SliceStable(rt, func(i, j int) bool {
+		if rt[i].Score == rt[j].Score {
+			return rt[i].Count > rt[j].Count
+		}
+
+		return rt[i].Score > rt[j].Score
+	})
+
+	if len(rt) > suggestMax {
+		rt = rt[:suggestMax]
+	}
+
+	return rt
+}
+
+//
+// suggestWeight - How much one line of history counts. Recent lines count more
+// and so do lines with an amount close to the one we are looking at.
+//
+func suggestWeight(line suggestLine, amount float64) float64 {
+	weight := 1.00
+
+	// Recency
+	if date, err := time.Parse("2006-01-02", line.Date); err == nil {
+		days := math.Max(time.Since(date).Hours()/24, 0)
+		weight = weight * math.Pow(0.5, days/suggestHalfLifeDays)
+	}
+
+	// Amount
+	if amount != 0 {
+		diff := math.Abs(math.Abs(line.Amount) - math.Abs(amount))
+		weight = weight / (1 + (diff / math.Max(math.Abs(amount), 1)))
+	}
+
+	return weight
+}
+
+/* End File */
@@ -0,0 +1,191 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package models
+
+import "strings"
+
+// TaxForm struct - A tax form with the lines categories can be mapped to.
+type TaxForm struct {
+	Id    string    `json:"id"`
+	Name  string    `json:"name"`
+	Lines []TaxLine `json:"lines"`
+}
+
+// TaxLine struct - One line of a tax form. The code is what we store in
+// Categories.Irs, the form id and line number like "schedule-c:8".
+type TaxLine struct {
+	Code string `json:"code"`
+	Form string `json:"form"`
+	Line string `json:"line"`
+	Name string `json:"name"`
+	Type string `json:"type"` // income or expense
+}
+
+// The forms we know about in the order we show them.
+var taxForms = []TaxForm{}
+
+// Schedule C (Form 1040) Profit or Loss From Business
+var scheduleC = TaxForm{
+	Id:   "schedule-c",
+	Name: "Schedule C - Profit or Loss From Business",
+	Lines: []TaxLine{
+		{Line: "1", Name: "Gross receipts or sales", Type: "income"},
+		{Line: "2", Name: "Returns and allowances", Type: "income"},
+		{Line: "4", Name: "Cost of goods sold", Type: "expense"},
+		{Line: "6", Name: "Other income", Type: "income"},
+		{Line: "8", Name: "Advertising", Type: "expense"},
+		{Line: "9", Name: "Car and truck expenses", Type: "expense"},
+		{Line: "10", Name: "Commissions and fees", Type: "expense"},
+		{Line: "11", Name: "Contract labor", Type: "expense"},
+		{Line: "12", Name: "Depletion", Type: "expense"},
+		{Line: "13", Name: "Depreciation and section 179 expense", Type: "expense"},
+		{Line: "14", Name: "Employee benefit programs", Type: "expense"},
+		{Line: "15", Name: "Insurance (other than health)", Type: "expense"},
+		{Line: "16a", Name: "Mortgage interest", Type: "expense"},
+		{Line: "16b", Name: "Other interest", Type: "expense"},
+		{Line: "17", Name: "Legal and professional services", Type: "expense"},
+		{Line: "18", Name: "Office expense", Type: "expense"},
+		{Line: "19", Name: "Pension and profit-sharing plans", Type: "expense"},
+		{Line: "20a", Name: "Rent or lease: vehicles, machinery, and equipment", Type: "expense"},
+		{Line: "20b", Name: "Rent or lease: other business property", Type: "expense"},
+		{Line: "21", Name: "Repairs and maintenance", Type: "expense"},
+		{Line: "22", Name: "Supplies", Type: "expense"},
+		{Line: "23", Name: "Taxes and licenses", Type: "expense"},
+		{Line: "24a", Name: "Travel", Type: "expense"},
+		{Line: "24b", Name: "Deductible meals", Type: "expense"},
+		{Line: "25", Name: "Utilities", Type: "expense"},
+		{Line: "26", Name: "Wages", Type: "expense"},
+		{Line: "27a", Name: "Other expenses", Type: "expense"},
+		{Line: "30", Name: "Business use of your home", Type: "expense"},
+	},
+}
+
+// Schedule E (Form 1040) Part I, income or loss from rental real estate and royalties
+var scheduleE = TaxForm{
+	Id:   "schedule-e",
+	Name: "Schedule E - Supplemental Income and Loss",
+	Lines: []TaxLine{
+		{Line: "3", Name: "Rents received", Type: "income"},
+		{Line: "4", Name: "Royalties received", Type: "income"},
+		{Line: "5", Name: "Advertising", Type: "expense"},
+		{Line: "6", Name: "Auto and travel", Type: "expense"},
+		{Line: "7", Name: "Cleaning and maintenance", Type: "expense"},
+		{Line: "8", Name: "Commissions", Type: "expense"},
+		{Line: "9", Name: "Insurance", Type: "expense"},
+		{Line: "10", Name: "Legal and other professional fees", Type: "expense"},
+		{Line: "11", Name: "Management fees", Type: "expense"},
+		{Line: "12", Name: "Mortgage interest paid to banks, etc.", Type: "expense"},
+		{Line: "13", Name: "Other interest", Type: "expense"},
+		{Line: "14", Name: "Repairs", Type: "expense"},
+		{Line: "15", Name: "Supplies", Type: "expense"},
+		{Line: "16", Name: "Taxes", Type: "expense"},
+		{Line: "17", Name: "Utilities", Type: "expense"},
+		{Line: "18", Name: "Depreciation expense or depletion", Type: "expense"},
+		{Line: "19", Name: "Other", Type: "expense"},
+	},
+}
+
+//
+// init - Load the forms we ship with.
+//
+func init() {
+	RegisterTaxForm(scheduleC)
+	RegisterTaxForm(scheduleE)
+}
+
+//
+// RegisterTaxForm - Add a form categories can be mapped to. A form with the
+// same id is replaced. The form and code of each line are filled in for us.
+//
+func RegisterTaxForm(form TaxForm) {
+	lines := []TaxLine{}
+
+	for _, row := range form.Lines {
+		row.Form = form.Id
+		row.Code = form.Id + ":" + row.Line
+		lines = append(lines, row)
+	}
+
+	form.Lines = lines
+
+	for key, row := range taxForms {
+		if row.Id == form.Id {
+			taxForms[key] = form
+			return
+		}
+	}
+
+	taxForms = append(taxForms, form)
+}
+
+//
+// GetTaxForms - The forms we know about.
+//
+func GetTaxForms() []TaxForm {
+	return taxForms
+}
+
+//
+// GetTaxForm - Return a form by id.
+//
+func GetTaxForm(id string) (TaxForm, bool) {
+	for _, row := range taxForms {
+		if row.Id == id {
+			return row, true
+		}
+	}
+
+	return TaxForm{}, false
+}
+
+//
+// GetTaxLine - Return a form line by code.
+//
+func GetTaxLine(code string) (TaxLine, bool) {
+	form, ok := GetTaxForm(strings.Split(code, ":")[0])
+
+	if !ok {
+		return TaxLine{}, false
+	}
+
+	for _, row := range form.Lines {
+		if row.Code == code {
+			return row, true
+		}
+	}
+
+	return TaxLine{}, false
+}
+
+//
+// LegacyTaxLine - Before tax form lines Categories.Irs was a "1" flag and only
+// the AirBnb import set it, on its Rental Income category. Those are rents
+// received on Schedule E. Anything else with the flag gets cleared.
+//
+func LegacyTaxLine(irs string, catType string) string {
+	if irs != "1" {
+		return irs
+	}
+
+	if catType == "2" {
+		return "schedule-e:3"
+	}
+
+	return ""
+}
+
+//
+// MigrateLegacyTaxLines - Move categories still flagged the old way to a tax
+// form line, see LegacyTaxLine.
+//
+func (db *DB) MigrateLegacyTaxLines() {
+	db.New().Exec("UPDATE Categories SET CategoriesIrs = 'schedule-e:3' WHERE CategoriesIrs = '1' AND CategoriesType = '2'")
+	db.New().Exec("UPDATE Categories SET CategoriesIrs = '' WHERE CategoriesIrs = '1'")
+}
+
+/* End File */
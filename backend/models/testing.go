@@ -124,6 +124,25 @@ func truncateAllTables(db *gorm.DB) {
 	db.Exec("DELETE FROM billings;")
 	db.Exec("DELETE FROM forgot_passwords;")
 	db.Exec("DELETE FROM connected_accounts;")
+	db.Exec("DELETE FROM recurring_labels;")
+	db.Exec("DELETE FROM recurrings;")
+	db.Exec("DELETE FROM ledger_split_labels;")
+	db.Exec("DELETE FROM ledger_splits;")
+	db.Exec("DELETE FROM import_profiles;")
+	db.Exec("DELETE FROM rule_labels;")
+	db.Exec("DELETE FROM rules;")
+	db.Exec("DELETE FROM reconciliations;")
+	db.Exec("DELETE FROM period_locks;")
+	db.Exec("DELETE FROM ledger_revisions;")
+	db.Exec("DELETE FROM trash_items;")
+	db.Exec("DELETE FROM search_docs;")
+	db.Exec("DELETE FROM ledger_views;")
+	db.Exec("DELETE FROM export_jobs;")
+	db.Exec("DELETE FROM archive_maps;")
+	db.Exec("DELETE FROM chart_accounts;")
+	db.Exec("DELETE FROM journal_lines;")
+	db.Exec("DELETE FROM budgets;")
+	db.Exec("DELETE FROM report_subscriptions;")
 	db.Exec("DELETE FROM users;")
 	db.Exec("DELETE FROM accounts;")
 	
@@ -0,0 +1,338 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Default number of days we keep things in the trash.
+const defaultTrashRetentionDays = 30
+
+// TrashItem struct - A ledger entry, contact, category or label that was
+// deleted. The row itself is soft deleted, this remembers the links we removed
+// so a restore can put them back.
+type TrashItem struct {
+	Id        uint      `gorm:"primary_key" json:"id"`
+	CreatedAt time.Time `sql:"not null" json:"deleted_at"`
+	AccountId uint      `sql:"not null;index:account_id" json:"account_id"`
+	Type      string    `sql:"not null" json:"type"` // ledger, contact, category, label
+	ObjectId  uint      `sql:"not null" json:"object_id"`
+	Name      string    `sql:"not null" json:"name"`
+	Amount    float64   `sql:"not null;type:DECIMAL(12,2)" json:"amount"`
+	Links     string    `sql:"not null;type:TEXT" json:"-"`
+	PurgeAt   time.Time `gorm:"-" json:"purge_at"`
+}
+
+// TrashLinks struct - The join rows we removed when something went in the trash.
+type TrashLinks struct {
+	Labels  []uint `json:"labels"`
+	Files   []uint `json:"files"`
+	Ledgers []uint `json:"ledgers"`
+}
+
+//
+// GetTrashByAccount - What is in the trash, newest first. Pass a type to only
+// get ledger entries, contacts, categories or labels.
+//
+func (db *DB) GetTrashByAccount(accountId uint, itemType string) []TrashItem {
+	items := []TrashItem{}
+
+	query := db.New().Where("account_id = ?", accountId)
+
+	if len(itemType) > 0 {
+		query = query.Where("type = ?", itemType)
+	}
+
+	query.Order("id DESC").Find(&items)
+
+	for key := range items {
+		items[key].PurgeAt = items[key].CreatedAt.AddDate(0, 0, TrashRetentionDays())
+	}
+
+	return items
+}
+
+//
+// GetTrashItemByAccountAndId by account and id.
+//
+func (db *DB) GetTrashItemByAccountAndId(accountId uint, id uint) (TrashItem, error) {
+	t := TrashItem{}
+
+	// Make query
+	if db.New().Where("account_id = ? AND id = ?", accountId, id).First(&t).RecordNotFound() {
+		return TrashItem{}, errors.New("Trash item not found.")
+	}
+
+	t.PurgeAt = t.CreatedAt.AddDate(0, 0, TrashRetentionDays())
+
+	// Return result
+	return t, nil
+}
+
+//
+// RestoreTrashItem - Take something out of the trash and put back the links we
+// removed when it was deleted.
+//
+func (db *DB) RestoreTrashItem(t TrashItem) error {
+	var err error
+
+	links := TrashLinks{}
+	json.Unmarshal([]byte(t.Links), &links)
+
+	switch t.Type {
+	case "ledger":
+		err = db.restoreLedger(t, links)
+	case "contact":
+		err = db.restoreContact(t)
+	case "category":
+		err = db.restoreCategory(t)
+	case "label":
+		err = db.restoreLabel(t, links)
+	default:
+		err = errors.New("Trash item not found.")
+	}
+
+	if err != nil {
+		return err
+	}
+
+	// Back in search.
+	switch t.Type {
+	case "ledger":
+		db.IndexLedger(t.AccountId, t.ObjectId)
+	case "contact":
+		db.IndexContact(t.AccountId, t.ObjectId)
+	case "category":
+		db.IndexCategory(t.AccountId, t.ObjectId)
+	case "label":
+		db.IndexLabel(t.AccountId, t.ObjectId)
+	}
+
+	db.New().Delete(&t)
+
+	return nil
+}
+
+//
+// PurgeTrash - Delete for good everything that went in the trash before a date.
+// Returns how many items we purged.
+//
+func (db *DB) PurgeTrash(before time.Time) int {
+	items := []TrashItem{}
+	db.New().Where("created_at < ?", before).Find(&items)
+
+	for _, row := range items {
+		switch row.Type {
+		case "ledger":
+			db.New().Unscoped().Where("LedgerAccountId = ? AND LedgerId = ?", row.AccountId, row.ObjectId).Delete(Ledger{})
+			db.New().Where("LabelsToLedgerLedgerId = ?", row.ObjectId).Delete(LabelsToLedger{})
+			db.New().Where("FilesToLedgerLedgerId = ?", row.ObjectId).Delete(FilesToLedger{})
+			db.deleteLedgerSplits(row.ObjectId)
+			db.deleteJournalLines(row.ObjectId)
+		case "contact":
+			db.New().Unscoped().Where("ContactsAccountId = ? AND ContactsId = ?", row.AccountId, row.ObjectId).Delete(Contact{})
+		case "category":
+			db.New().Unscoped().Where("CategoriesAccountId = ? AND CategoriesId = ?", row.AccountId, row.ObjectId).Delete(Category{})
+		case "label":
+			db.New().Unscoped().Where("LabelsAccountId = ? AND LabelsId = ?", row.AccountId, row.ObjectId).Delete(Label{})
+			db.New().Where("LabelsToLedgerLabelId = ?", row.ObjectId).Delete(LabelsToLedger{})
+		}
+
+		db.New().Delete(&row)
+	}
+
+	return len(items)
+}
+
+//
+// TrashRetentionDays - How long we keep things in the trash. Set with
+// TRASH_RETENTION_DAYS.
+//
+func TrashRetentionDays() int {
+	days, err := strconv.Atoi(os.Getenv("TRASH_RETENTION_DAYS"))
+
+	if (err != nil) || (days <= 0) {
+		return defaultTrashRetentionDays
+	}
+
+	return days
+}
+
+// ----------------- Private Helper Funcs -------------- //
+
+//
+// addToTrash - Record something we just soft deleted.
+//
+func (db *DB) addToTrash(accountId uint, itemType string, objectId uint, name string, amount float64, links TrashLinks) {
+	data, _ := json.Marshal(links)
+
+	db.New().Create(&TrashItem{
+		AccountId: accountId,
+		Type:      itemType,
+		ObjectId:  objectId,
+		Name:      name,
+		Amount:    amount,
+		Links:     string(data),
+	})
+}
+
+//
+// restoreLedger - Bring back a ledger entry with its labels and files.
+//
+func (db *DB) restoreLedger(t TrashItem, links TrashLinks) error {
+	l := Ledger{}
+
+	if db.New().Unscoped().Where("LedgerAccountId = ? AND LedgerId = ?", t.AccountId, t.ObjectId).First(&l).RecordNotFound() {
+		return errors.New("Ledger entry not found.")
+	}
+
+	// Entries can not come back into a closed period.
+	if err := db.ValidateLedgerPeriodOpen(l.AccountId, l.Date); err != nil {
+		return err
+	}
+
+	db.New().Unscoped().Model(&Ledger{}).Where("LedgerId = ?", l.Id).UpdateColumn("LedgerDeletedAt", nil)
+
+	// Labels that have since been purged stay gone. Ones still in the trash
+	// come back to this entry when they are restored.
+	for _, row := range links.Labels {
+		if !db.New().Where("LabelsAccountId = ? AND LabelsId = ?", t.AccountId, row).First(&Label{}).RecordNotFound() {
+			db.addLabelLink(row, l.Id)
+			continue
+		}
+
+		db.addTrashLedgerLink(t.AccountId, "label", row, l.Id)
+	}
+
+	for _, row := range links.Files {
+		if !db.New().Where("FilesAccountId = ? AND FilesId = ?", t.AccountId, row).First(&File{}).RecordNotFound() {
+			db.New().Create(&FilesToLedger{FilesToLedgerFileId: row, FilesToLedgerLedgerId: l.Id})
+		}
+	}
+
+	return nil
+}
+
+//
+// restoreContact - Bring back a contact unless the name is taken again.
+//
+func (db *DB) restoreContact(t TrashItem) error {
+	c := Contact{}
+
+	if db.New().Unscoped().Where("ContactsAccountId = ? AND ContactsId = ?", t.AccountId, t.ObjectId).First(&c).RecordNotFound() {
+		return errors.New("Contact not found.")
+	}
+
+	if (len(c.Name) > 0) && !db.New().Where("ContactsAccountId = ? AND ContactsName = ?", t.AccountId, c.Name).First(&Contact{}).RecordNotFound() {
+		return fmt.Errorf("A contact named %s already exists.", c.Name)
+	}
+
+	db.New().Unscoped().Model(&Contact{}).Where("ContactsId = ?", c.Id).UpdateColumn("ContactsDeletedAt", nil)
+
+	return nil
+}
+
+//
+// restoreCategory - Bring back a category unless the name is taken again.
+//
+func (db *DB) restoreCategory(t TrashItem) error {
+	c := Category{}
+
+	if db.New().Unscoped().Where("CategoriesAccountId = ? AND CategoriesId = ?", t.AccountId, t.ObjectId).First(&c).RecordNotFound() {
+		return errors.New("Category not found.")
+	}
+
+	if !db.New().Where("CategoriesAccountId = ? AND CategoriesName = ? AND CategoriesType = ?", t.AccountId, c.Name, c.Type).First(&Category{}).RecordNotFound() {
+		return fmt.Errorf("A category named %s already exists.", c.Name)
+	}
+
+	db.New().Unscoped().Model(&Category{}).Where("CategoriesId = ?", c.Id).UpdateColumn("CategoriesDeletedAt", nil)
+
+	return nil
+}
+
+//
+// restoreLabel - Bring back a label and put it back on its ledger entries.
+//
+func (db *DB) restoreLabel(t TrashItem, links TrashLinks) error {
+	lb := Label{}
+
+	if db.New().Unscoped().Where("LabelsAccountId = ? AND LabelsId = ?", t.AccountId, t.ObjectId).First(&lb).RecordNotFound() {
+		return errors.New("Label not found.")
+	}
+
+	if !db.New().Where("LabelsAccountId = ? AND LabelsName = ?", t.AccountId, lb.Name).First(&Label{}).RecordNotFound() {
+		return fmt.Errorf("A label named %s already exists.", lb.Name)
+	}
+
+	db.New().Unscoped().Model(&Label{}).Where("LabelsId = ?", lb.Id).UpdateColumn("LabelsDeletedAt", nil)
+
+	// Ledger entries that have since been purged stay gone. Reconciled entries
+	// and ones in a closed period can not change so they stay without it.
+	for _, row := range links.Ledgers {
+		l := Ledger{}
+
+		if db.New().Unscoped().Where("LedgerAccountId = ? AND LedgerId = ?", t.AccountId, row).First(&l).RecordNotFound() {
+			continue
+		}
+
+		if db.ValidateLedgerUnlocked(l) != nil {
+			continue
+		}
+
+		db.addLabelLink(lb.Id, row)
+	}
+
+	return nil
+}
+
+//
+// addTrashLedgerLink - Remember a ledger entry on something still in the trash
+// so restoring it links the entry too.
+//
+func (db *DB) addTrashLedgerLink(accountId uint, itemType string, objectId uint, ledgerId uint) {
+	t := TrashItem{}
+
+	if db.New().Where("account_id = ? AND type = ? AND object_id = ?", accountId, itemType, objectId).First(&t).RecordNotFound() {
+		return
+	}
+
+	links := TrashLinks{}
+	json.Unmarshal([]byte(t.Links), &links)
+
+	for _, row := range links.Ledgers {
+		if row == ledgerId {
+			return
+		}
+	}
+
+	links.Ledgers = append(links.Ledgers, ledgerId)
+	data, _ := json.Marshal(links)
+
+	db.New().Model(&TrashItem{}).Where("id = ?", t.Id).UpdateColumn("links", string(data))
+}
+
+//
+// addLabelLink - Add a label to a ledger entry if it is not already there.
+//
+func (db *DB) addLabelLink(labelId uint, ledgerId uint) {
+	lb := LabelsToLedger{}
+	db.New().Where("LabelsToLedgerLedgerId = ? AND LabelsToLedgerLabelId = ?", ledgerId, labelId).First(&lb)
+
+	if lb.LabelsToLedgerLedgerId == 0 {
+		db.New().Create(&LabelsToLedger{LabelsToLedgerLedgerId: ledgerId, LabelsToLedgerLabelId: labelId})
+	}
+}
+
+/* End File */
@@ -0,0 +1,102 @@
+//
+// Date: 2026-10-18
+// Author: Spicer Matthews (spicer@skyclerk.com)
+// Last Modified by: Spicer Matthews
+// Copyright: 2026 Cloudmanic Labs, LLC. All rights reserved.
+//
+
+import { Serializable } from './serializable.model';
+import { Category } from './category.model';
+import { Label } from './label.model';
+
+export class Suggestion implements Serializable {
+	Categories: CategorySuggestion[] = [];
+	Labels: LabelSuggestion[] = [];
+
+	//
+	// Json to Object.
+	//
+	deserialize(json: Object): this {
+		this.Categories = [];
+		this.Labels = [];
+
+		for (let i = 0; i < json["categories"].length; i++) {
+			this.Categories.push(new CategorySuggestion().deserialize(json["categories"][i]));
+		}
+
+		for (let i = 0; i < json["labels"].length; i++) {
+			this.Labels.push(new LabelSuggestion().deserialize(json["labels"][i]));
+		}
+
+		return this;
+	}
+
+	//
+	// Model to JS Object.
+	//
+	serialize(obj: Suggestion): Object {
+		let rt = {
+			categories: obj.Categories.map(row => new CategorySuggestion().serialize(row)),
+			labels: obj.Labels.map(row => new LabelSuggestion().serialize(row))
+		}
+		return rt;
+	}
+}
+
+export class CategorySuggestion implements Serializable {
+	Category: Category = new Category();
+	Score: number = 0;
+	Count: number = 0;
+
+	//
+	// Json to Object.
+	//
+	deserialize(json: Object): this {
+		this.Category = new Category().deserialize(json["category"]);
+		this.Score = json["score"];
+		this.Count = json["count"];
+		return this;
+	}
+
+	//
+	// Model to JS Object.
+	//
+	serialize(obj: CategorySuggestion): Object {
+		let rt = {
+			category: new Category().serialize(obj.Category),
+			score: obj.Score,
+			count: obj.Count
+		}
+		return rt;
+	}
+}
+
+export class LabelSuggestion implements Serializable {
+	Label: Label = new Label();
+	Score: number = 0;
+	Count: number = 0;
+
+	//
+	// Json to Object.
+	//
+	deserialize(json: Object): this {
+		this.Label = new Label().deserialize(json["label"]);
+		this.Score = json["score"];
+		this.Count = json["count"];
+		return this;
+	}
+
+	//
+	// Model to JS Object.
+	//
+	serialize(obj: LabelSuggestion): Object {
+		let rt = {
+			label: new Label().serialize(obj.Label),
+			score: obj.Score,
+			count: obj.Count
+		}
+		return rt;
+	}
+}
+
+/* End File */
@@ -16,6 +16,7 @@ import { Account } from 'src/app/models/account.model';
 import { SnapClerk } from 'src/app/models/snapclerk.model';
 import { Category } from 'src/app/models/category.model';
 import { Ledger } from 'src/app/models/ledger.model';
+import { Suggestion, CategorySuggestion } from 'src/app/models/suggestion.model';
 
 @Component({
 	selector: 'app-view',
@@ -35,6 +36,7 @@ export class ViewComponent {
 	category: Category = new Category();
 	categoriesInput: string = "";
 	categoriesResults: Category[] = [];
+	suggestion: Suggestion = new Suggestion();
 
 	//
 	// Constructor
@@ -67,6 +69,7 @@ export class ViewComponent {
 				this.category = new Category();
 				this.categoriesInput = "";
 				this.categoriesResults = [];
+				this.suggestion = new Suggestion();
 
 				// Load next SC
 				this.loadSnapClerks();
@@ -92,6 +95,7 @@ export class ViewComponent {
 	onAccountChange() {
 		this.contactsResults = [];
 		this.loadContacts();
+		this.loadSuggestions();
 	}
 
 	//
@@ -135,6 +139,7 @@ export class ViewComponent {
 			if (this.snapclerk.AccountId == this.user.Accounts[i].Id) {
 				this.account = this.user.Accounts[i];
 				this.setActiveCategory();
+				this.loadSuggestions();
 				return;
 			}
 		}
@@ -212,6 +217,39 @@ export class ViewComponent {
 		this.contact = result;
 		this.contactInput = this.displayContact(result);
 		this.contactsResults = [];
+		this.loadSuggestions();
+	}
+
+	//
+	// Load category and label suggestions for this contact.
+	//
+	loadSuggestions() {
+		// Nothing to learn from without a contact.
+		if ((! this.contactInput) || (this.contactInput.length == 0)) {
+			this.suggestion = new Suggestion();
+			return;
+		}
+
+		this.requestSuggestions().subscribe(res => {
+			this.suggestion = res;
+		});
+	}
+
+	//
+	// Request suggestions
+	//
+	requestSuggestions(): Observable<Suggestion> {
+		let url = `${environment.app_server}/api/admin/snapclerk/suggest?account_id=${this.account.Id}&contact=${encodeURIComponent(this.contactInput)}&amount=${this.snapclerk.Amount}`;
+
+		return this.http.get<Suggestion>(url)
+			.pipe(map(res => { return new Suggestion().deserialize(res); }));
+	}
+
+	//
+	// onSuggestionSelect
+	//
+	onSuggestionSelect(result: CategorySuggestion) {
+		this.onCategorySelect(result.Category);
 	}
 
 	//